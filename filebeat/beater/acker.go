@@ -19,11 +19,14 @@ package beater
 
 import (
 	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/util"
 	"github.com/elastic/beats/libbeat/logp"
 )
 
 // eventAcker handles publisher pipeline ACKs and forwards
 // them to the registrar or directly to the stateless logger.
+// Stateless events with private data implementing util.ACKer
+// are also acknowledged to their input.
 type eventACKer struct {
 	stateful  statefulLogger
 	stateless statelessLogger
@@ -53,6 +56,9 @@ func (a *eventACKer) ackEvents(data []interface{}) {
 
 		st, ok := datum.(file.State)
 		if !ok {
+			if acker, ok := datum.(util.ACKer); ok {
+				acker.ACK()
+			}
 			stateless++
 			continue
 		}
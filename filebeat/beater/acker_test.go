@@ -86,3 +86,24 @@ func TestACKer(t *testing.T) {
 		})
 	}
 }
+
+type mockInputACKer struct {
+	acked int
+}
+
+func (a *mockInputACKer) ACK() {
+	a.acked++
+}
+
+func TestACKerInputACKs(t *testing.T) {
+	sl := &mockStatelessLogger{}
+	sf := &mockStatefulLogger{}
+	input := &mockInputACKer{}
+
+	h := newEventACKer(sl, sf)
+	h.ackEvents([]interface{}{input, file.State{Source: "-"}, nil, input})
+
+	assert.Equal(t, 2, input.acked)
+	assert.Equal(t, 3, sl.count)
+	assert.Equal(t, []file.State{file.State{Source: "-"}}, sf.states)
+}
@@ -40,6 +40,7 @@ import (
 	cfg "github.com/elastic/beats/filebeat/config"
 	"github.com/elastic/beats/filebeat/crawler"
 	"github.com/elastic/beats/filebeat/fileset"
+	"github.com/elastic/beats/filebeat/ingest"
 	"github.com/elastic/beats/filebeat/registrar"
 
 	// Add filebeat level processors
@@ -171,8 +172,13 @@ func (fb *Filebeat) setupPipelineLoaderCallback(b *beat.Beat) error {
 }
 
 // loadModulesPipelines is called when modules are configured to do the initial
-// setup.
-func (fb *Filebeat) loadModulesPipelines(b *beat.Beat) error {
+// setup. If pipelines is not nil, the pipelines are executed by Filebeat and
+// are loaded into the local registry instead of Elasticsearch.
+func (fb *Filebeat) loadModulesPipelines(b *beat.Beat, pipelines *ingest.Registry) error {
+	if pipelines != nil {
+		return fb.moduleRegistry.LoadPipelines(pipelines, true)
+	}
+
 	if b.Config.Output.Name() != "elasticsearch" {
 		logp.Warn(pipelinesWarning)
 		return nil
@@ -291,8 +297,15 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 	var err error
 	config := fb.config
 
+	var pipelines *ingest.Registry
+	if config.LocalPipelines.Enabled {
+		logp.Info("Ingest pipelines of the modules are executed by Filebeat")
+		pipelines = ingest.NewRegistry(config.LocalPipelines)
+		defer pipelines.Close()
+	}
+
 	if !fb.moduleRegistry.Empty() {
-		err = fb.loadModulesPipelines(b)
+		err = fb.loadModulesPipelines(b, pipelines)
 		if err != nil {
 			return err
 		}
@@ -310,7 +323,11 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 	finishedLogger := newFinishedLogger(wgEvents)
 
 	// Setup registrar to persist state
-	registrar, err := registrar.New(config.RegistryFile, config.RegistryFilePermissions, config.RegistryFlush, finishedLogger)
+	storeConfig := registrar.StoreConfig{
+		Backend:        config.RegistryBackend,
+		CheckpointSize: int64(config.RegistryCheckpointSize),
+	}
+	registrar, err := registrar.New(config.RegistryFile, config.RegistryFilePermissions, config.RegistryFlush, storeConfig, finishedLogger)
 	if err != nil {
 		logp.Err("Could not init registrar: %v", err)
 		return err
@@ -329,7 +346,7 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 
 	outDone := make(chan struct{}) // outDone closes down all active pipeline connections
 	crawler, err := crawler.New(
-		channel.NewOutletFactory(outDone, wgEvents).Create,
+		channel.NewOutletFactory(outDone, wgEvents, pipelines).Create,
 		config.Inputs,
 		b.Info.Version,
 		fb.done,
@@ -365,7 +382,9 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 
 	// Create a ES connection factory for dynamic modules pipeline loading
 	var pipelineLoaderFactory fileset.PipelineLoaderFactory
-	if b.Config.Output.Name() == "elasticsearch" {
+	if pipelines != nil {
+		pipelineLoaderFactory = newLocalPipelineLoaderFactory(pipelines)
+	} else if b.Config.Output.Name() == "elasticsearch" {
 		pipelineLoaderFactory = newPipelineLoaderFactory(b.Config.Output.Config())
 	} else {
 		logp.Warn(pipelinesWarning)
@@ -451,3 +470,10 @@ func newPipelineLoaderFactory(esConfig *common.Config) fileset.PipelineLoaderFac
 	}
 	return pipelineLoaderFactory
 }
+
+// Create a pipeline loader factory loading the pipelines into the local registry
+func newLocalPipelineLoaderFactory(pipelines *ingest.Registry) fileset.PipelineLoaderFactory {
+	return func() (fileset.PipelineLoader, error) {
+		return pipelines, nil
+	}
+}
@@ -18,6 +18,7 @@
 package channel
 
 import (
+	"github.com/elastic/beats/filebeat/ingest"
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/processors"
@@ -26,8 +27,9 @@ import (
 type OutletFactory struct {
 	done <-chan struct{}
 
-	eventer  beat.ClientEventer
-	wgEvents eventCounter
+	eventer   beat.ClientEventer
+	wgEvents  eventCounter
+	pipelines *ingest.Registry
 }
 
 type eventCounter interface {
@@ -61,13 +63,17 @@ type inputOutletConfig struct {
 
 // NewOutletFactory creates a new outlet factory for
 // connecting an input to the publisher pipeline.
+// If pipelines is not nil, the ingest pipelines configured for the inputs
+// are executed by Filebeat instead of Elasticsearch.
 func NewOutletFactory(
 	done <-chan struct{},
 	wgEvents eventCounter,
+	pipelines *ingest.Registry,
 ) *OutletFactory {
 	o := &OutletFactory{
-		done:     done,
-		wgEvents: wgEvents,
+		done:      done,
+		wgEvents:  wgEvents,
+		pipelines: pipelines,
 	}
 
 	if wgEvents != nil {
@@ -99,7 +105,13 @@ func (f *OutletFactory) Create(p beat.Pipeline, cfg *common.Config, dynFields *c
 	}
 
 	meta := common.MapStr{}
-	setMeta(meta, "pipeline", config.Pipeline)
+	if f.pipelines != nil && config.Pipeline != "" {
+		// Run the pipeline after the input processors, as it would run in
+		// Elasticsearch after the event was published.
+		processors.List = append(processors.List, f.pipelines.Processor(config.Pipeline))
+	} else {
+		setMeta(meta, "pipeline", config.Pipeline)
+	}
 
 	fields := common.MapStr{}
 	setMeta(fields, "module", config.Module)
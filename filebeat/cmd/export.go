@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/elastic/beats/filebeat/config"
+	"github.com/elastic/beats/filebeat/registrar"
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/paths"
+)
+
+// registrySettings locates the registry as configured for filebeat.
+type registrySettings struct {
+	file     string
+	fileMode os.FileMode
+	store    registrar.StoreConfig
+}
+
+func genExportRegistryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "registry",
+		Short: "Export the states of the registry as JSON to stdout",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			settings, err := loadRegistrySettings()
+			if err != nil {
+				return err
+			}
+			return exportRegistry(os.Stdout, settings)
+		}),
+	}
+}
+
+// exportRegistry writes the registry states in the format of the JSON registry
+// file, independent of the configured registry backend.
+func exportRegistry(w io.Writer, settings registrySettings) error {
+	states, err := registrar.LoadStates(settings.file, settings.store)
+	if err != nil {
+		return fmt.Errorf("failed to read registry %s: %v", settings.file, err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(states)
+}
+
+// loadRegistrySettings reads the registry settings from the filebeat configuration.
+func loadRegistrySettings() (registrySettings, error) {
+	b, err := instance.NewBeat(Name, "", "")
+	if err != nil {
+		return registrySettings{}, fmt.Errorf("error initializing beat: %s", err)
+	}
+
+	if err = b.Init(); err != nil {
+		return registrySettings{}, fmt.Errorf("error initializing beat: %s", err)
+	}
+
+	rawConfig, err := b.BeatConfig()
+	if err != nil {
+		return registrySettings{}, err
+	}
+
+	config := cfg.DefaultConfig
+	if err := rawConfig.Unpack(&config); err != nil {
+		return registrySettings{}, fmt.Errorf("error reading configuration file: %v", err)
+	}
+
+	return registrySettings{
+		file:     paths.Resolve(paths.Data, config.RegistryFile),
+		fileMode: config.RegistryFilePermissions,
+		store: registrar.StoreConfig{
+			Backend:        config.RegistryBackend,
+			CheckpointSize: int64(config.RegistryCheckpointSize),
+		},
+	}, nil
+}
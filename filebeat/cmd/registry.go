@@ -0,0 +1,306 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/registrar"
+	"github.com/elastic/beats/libbeat/common/cli"
+)
+
+func genRegistryCmd() *cobra.Command {
+	registryCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Inspect and edit the registry while filebeat is stopped",
+	}
+
+	registryCmd.AddCommand(genRegistryListCmd())
+	registryCmd.AddCommand(genRegistryShowCmd())
+	registryCmd.AddCommand(genRegistryResetCmd())
+	registryCmd.AddCommand(genRegistryRemoveCmd())
+	registryCmd.AddCommand(genRegistryCompactCmd())
+
+	return registryCmd
+}
+
+// registrySelection selects registry entries by source path or ID.
+type registrySelection struct {
+	path string
+	id   string
+}
+
+func (s *registrySelection) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&s.path, "path", "", "Select entries whose source path matches the glob pattern")
+	cmd.Flags().StringVar(&s.id, "id", "", "Select the entry with the given ID")
+}
+
+func (s *registrySelection) isEmpty() bool {
+	return s.path == "" && s.id == ""
+}
+
+func (s *registrySelection) matches(state *file.State) (bool, error) {
+	if s.id != "" && state.ID() != s.id {
+		return false, nil
+	}
+	if s.path != "" {
+		return filepath.Match(s.path, state.Source)
+	}
+	return true, nil
+}
+
+// filter splits the states into the selected and the other states.
+func (s *registrySelection) filter(states []file.State) (selected, others []file.State, err error) {
+	for i := range states {
+		match, err := s.matches(&states[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid path pattern '%s': %v", s.path, err)
+		}
+		if match {
+			selected = append(selected, states[i])
+		} else {
+			others = append(others, states[i])
+		}
+	}
+	return selected, others, nil
+}
+
+func genRegistryListCmd() *cobra.Command {
+	var selection registrySelection
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the entries of the registry",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			_, states, err := readRegistry()
+			if err != nil {
+				return err
+			}
+
+			selected, _, err := selection.filter(states)
+			if err != nil {
+				return err
+			}
+			return listStates(os.Stdout, selected)
+		}),
+	}
+	selection.register(command)
+	return command
+}
+
+func listStates(w io.Writer, states []file.State) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSOURCE\tOFFSET\tTTL\tTIMESTAMP")
+	for i := range states {
+		state := &states[i]
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+			state.ID(), state.Source, state.Offset, formatTTL(state.TTL), state.Timestamp.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// formatTTL prints "-" for states that never expire.
+func formatTTL(ttl time.Duration) string {
+	if ttl < 0 {
+		return "-"
+	}
+	return ttl.String()
+}
+
+func genRegistryShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show ID|PATH",
+		Short: "Show a single entry of the registry as JSON",
+		Args:  cobra.ExactArgs(1),
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			_, states, err := readRegistry()
+			if err != nil {
+				return err
+			}
+
+			state, err := findState(states, args[0])
+			if err != nil {
+				return err
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(state)
+		}),
+	}
+}
+
+// findState returns the state with the given ID, or the only state with the
+// given source path.
+func findState(states []file.State, key string) (file.State, error) {
+	var found []file.State
+	for i := range states {
+		state := &states[i]
+		if state.ID() == key {
+			return *state, nil
+		}
+		if state.Source == key {
+			found = append(found, *state)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return file.State{}, fmt.Errorf("no registry entry found for '%s'", key)
+	case 1:
+		return found[0], nil
+	default:
+		ids := make([]string, len(found))
+		for i := range found {
+			ids[i] = found[i].ID()
+		}
+		return file.State{}, fmt.Errorf("%d registry entries found for '%s', select one by ID: %v", len(found), key, ids)
+	}
+}
+
+func genRegistryResetCmd() *cobra.Command {
+	var (
+		selection registrySelection
+		offset    int64
+	)
+	command := &cobra.Command{
+		Use:   "reset",
+		Short: "Reset the offset of the selected entries, so the files are read again from this offset",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			if selection.isEmpty() {
+				return fmt.Errorf("select the entries to reset with --path or --id")
+			}
+			if offset < 0 {
+				return fmt.Errorf("invalid offset %d", offset)
+			}
+
+			settings, states, err := readRegistry()
+			if err != nil {
+				return err
+			}
+
+			selected, others, err := selection.filter(states)
+			if err != nil {
+				return err
+			}
+			if len(selected) == 0 {
+				fmt.Println("No registry entries selected")
+				return nil
+			}
+
+			for i := range selected {
+				state := &selected[i]
+				fmt.Printf("Reset offset of %s from %d to %d\n", state.Source, state.Offset, offset)
+				state.Offset = offset
+				state.Completed = false
+			}
+			return writeRegistry(settings, append(others, selected...))
+		}),
+	}
+	selection.register(command)
+	command.Flags().Int64Var(&offset, "offset", 0, "New offset of the selected entries")
+	return command
+}
+
+func genRegistryRemoveCmd() *cobra.Command {
+	var selection registrySelection
+	command := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove the selected entries, so the files are read from the beginning",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			if selection.isEmpty() {
+				return fmt.Errorf("select the entries to remove with --path or --id")
+			}
+
+			settings, states, err := readRegistry()
+			if err != nil {
+				return err
+			}
+
+			selected, others, err := selection.filter(states)
+			if err != nil {
+				return err
+			}
+			if len(selected) == 0 {
+				fmt.Println("No registry entries selected")
+				return nil
+			}
+
+			for i := range selected {
+				fmt.Printf("Remove entry %s of %s\n", selected[i].ID(), selected[i].Source)
+			}
+			return writeRegistry(settings, others)
+		}),
+	}
+	selection.register(command)
+	return command
+}
+
+func genRegistryCompactCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "compact",
+		Short: "Remove entries with expired TTL and merge duplicate entries",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			settings, states, err := readRegistry()
+			if err != nil {
+				return err
+			}
+
+			compacted := registrar.CompactStates(states, time.Now())
+			if len(compacted) == len(states) {
+				fmt.Println("No registry entries to compact")
+				return nil
+			}
+
+			fmt.Printf("Compacted registry from %d to %d entries\n", len(states), len(compacted))
+			return writeRegistry(settings, compacted)
+		}),
+	}
+}
+
+func readRegistry() (registrySettings, []file.State, error) {
+	settings, err := loadRegistrySettings()
+	if err != nil {
+		return settings, nil, err
+	}
+
+	states, err := registrar.LoadStates(settings.file, settings.store)
+	if err != nil {
+		return settings, nil, fmt.Errorf("failed to read registry %s: %v", settings.file, err)
+	}
+	return settings, states, nil
+}
+
+func writeRegistry(settings registrySettings, states []file.State) error {
+	backups, err := registrar.WriteStates(settings.file, settings.fileMode, settings.store, states)
+	for _, backup := range backups {
+		fmt.Printf("Backup written to %s\n", backup)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write registry %s: %v", settings.file, err)
+	}
+	return nil
+}
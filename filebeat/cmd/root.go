@@ -43,4 +43,6 @@ func init() {
 	RootCmd.TestCmd.Flags().AddGoFlag(flag.CommandLine.Lookup("modules"))
 	RootCmd.SetupCmd.Flags().AddGoFlag(flag.CommandLine.Lookup("modules"))
 	RootCmd.AddCommand(cmd.GenModulesCmd(Name, "", buildModulesManager))
+	RootCmd.ExportCmd.AddCommand(genExportRegistryCmd())
+	RootCmd.AddCommand(genRegistryCmd())
 }
@@ -25,9 +25,12 @@ import (
 	"sort"
 	"time"
 
+	"github.com/elastic/beats/filebeat/ingest"
+	"github.com/elastic/beats/filebeat/registrar"
 	"github.com/elastic/beats/libbeat/autodiscover"
 	"github.com/elastic/beats/libbeat/cfgfile"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgtype"
 	"github.com/elastic/beats/libbeat/common/cfgwarn"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/paths"
@@ -44,6 +47,8 @@ type Config struct {
 	RegistryFile            string               `config:"registry_file"`
 	RegistryFilePermissions os.FileMode          `config:"registry_file_permissions"`
 	RegistryFlush           time.Duration        `config:"registry_flush"`
+	RegistryBackend         string               `config:"registry_backend"`
+	RegistryCheckpointSize  cfgtype.ByteSize     `config:"registry_checkpoint_size" validate:"nonzero,positive"`
 	ConfigDir               string               `config:"config_dir"`
 	ShutdownTimeout         time.Duration        `config:"shutdown_timeout"`
 	Modules                 []*common.Config     `config:"modules"`
@@ -52,14 +57,18 @@ type Config struct {
 	ConfigModules           *common.Config       `config:"config.modules"`
 	Autodiscover            *autodiscover.Config `config:"autodiscover"`
 	OverwritePipelines      bool                 `config:"overwrite_pipelines"`
+	LocalPipelines          ingest.Config        `config:"local_pipelines"`
 }
 
 var (
 	DefaultConfig = Config{
 		RegistryFile:            "registry",
 		RegistryFilePermissions: 0600,
+		RegistryBackend:         registrar.BackendJSON,
+		RegistryCheckpointSize:  registrar.DefaultCheckpointSize,
 		ShutdownTimeout:         0,
 		OverwritePipelines:      false,
+		LocalPipelines:          ingest.DefaultConfig(),
 	}
 )
 
@@ -21,6 +21,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/ingest"
 	input "github.com/elastic/beats/filebeat/prospector"
 	"github.com/elastic/beats/filebeat/registrar"
 	"github.com/elastic/beats/libbeat/beat"
@@ -144,10 +145,13 @@ func (p *inputsRunner) Start() {
 		}
 
 		// Register callback to try to load pipelines when connecting to ES.
-		callback := func(esClient *elasticsearch.Client) error {
-			return p.moduleRegistry.LoadPipelines(esClient, p.overwritePipelines)
+		// Pipelines executed by Filebeat are not loaded into ES.
+		if _, local := pipelineLoader.(*ingest.Registry); !local {
+			callback := func(esClient *elasticsearch.Client) error {
+				return p.moduleRegistry.LoadPipelines(esClient, p.overwritePipelines)
+			}
+			p.pipelineCallbackID = elasticsearch.RegisterConnectCallback(callback)
 		}
-		p.pipelineCallbackID = elasticsearch.RegisterConnectCallback(callback)
 	}
 
 	for _, input := range p.inputs {
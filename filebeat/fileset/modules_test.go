@@ -26,7 +26,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/filebeat/ingest"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/paths"
 )
@@ -89,6 +91,33 @@ func TestNewModuleRegistry(t *testing.T) {
 	}
 }
 
+func TestLoadPipelinesLocally(t *testing.T) {
+	modulesPath, err := filepath.Abs("../module")
+	require.NoError(t, err)
+
+	configs := []*ModuleConfig{
+		&ModuleConfig{Module: "nginx"},
+		&ModuleConfig{Module: "system"},
+	}
+
+	reg, err := newModuleRegistry(modulesPath, configs, nil, "6.4.0")
+	require.NoError(t, err)
+
+	pipelines := ingest.NewRegistry(ingest.DefaultConfig())
+	defer pipelines.Close()
+
+	// The required geoip and user_agent plugins are available locally.
+	require.NoError(t, reg.LoadPipelines(pipelines, false))
+
+	for module, filesets := range reg.registry {
+		for name, fileset := range filesets {
+			pipelineID, err := fileset.getPipelineID("6.4.0")
+			require.NoError(t, err)
+			assert.NotNil(t, pipelines.Get(pipelineID), "module: %s, fileset: %s", module, name)
+		}
+	}
+}
+
 func TestNewModuleRegistryConfig(t *testing.T) {
 	modulesPath, err := filepath.Abs("../module")
 	assert.NoError(t, err)
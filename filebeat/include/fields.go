@@ -24,12 +24,12 @@ import (
 )
 
 func init() {
-	if err := asset.SetFields("filebeat", "fields.yml", Asset); err != nil {
+	if err := asset.SetFields("filebeat", "/tmp/fb_fields.yml", Asset); err != nil {
 		panic(err)
 	}
 }
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsfet33LiR7/f5K3D0JfY97R75Md7Ee+6edSR7rMQPxZKTm3XmtNAkuhsjEuAAoOSePfnf70EBIEESfHVT0mS3/WFGTYJVPzyrUKgqPEHXZPsKJXz9HUKKqoS8Qu/5Gq1oQlDEmSJMfYdQTGQkaKYoZ6/Qf3yHEEInnClMmdTfmuIJZUTOv0NoRUkSy1dQ7AliOCWvkOS5iAg8QkhtM/JKc77lIrbPBPklp4LEr5ASuSsY4Kv/XW6IYbkSPEW3GxptkNoYBOgWSyQIjufockOlAQNVAbS6GF5KnuSKoAyrDVIcHmp684LDWy4Q+YbTTDfI1fc3WHyf8PX3cisVSecJX1/Nv6vUj69WkqhK/RLO1o3KrXAih9bO0AR0gmRcKBKbKkqFhZIIqxqIlEiJ19VWVuSbg0XXjAuywEt+Q16h4x0b3o4KxFdlm+v2Np0Bj+yIqKGTShCcDhoCA1pJj1JDEd1uCAMIlK1dTxOhYcgZijBDS4J+J1XMc/U7xAX8TYT4XRVeJrjMSKS4mGtw3a2TCRJhpR+/nD/vbzPKslxBnetDltzottRjdk0YEZpmZeBSiWAMmEF6g5OcIA2TriiJCx4rLuD9lWZxhTiAQJTBQ8Nckgge2m57SxOyJFjp9lpR21/o0emb889vTl5fvjl9hSQh6Ao+hga5elxtr/LNjgPpX6RRqrXWw2yhaEqkwmnWXckzhiIsieW3JlKhjGYEZkyGhSRmOSqoVWeQnWdyhqhCUnFBZEFZl+GCrinDCbr6z4LCFXok9NiUhCk9GRx5M0Uc5coy+di0CC2JQxvXqq1bQhI1T3mcJwP6tmhJ8wFSG6zKzgR+ppdb+OhfI7jYzwazkVuZ8PV8hSOaULWdbtm2BBH5pgSONIaiTzNBuaBqG4bi3k4GxRF0Y9vw6WoNSW6I/mKR4CVJplqnNZZNnmKzQuNlQpBj1N0pdw7DMZo35EBEpJxngq/FdPJKA9AMXH9Y8m3MaTzdSKCxxxTIV5maMeF6ZTK+jqBjHhx6RNzQiPjzPdTSLVwuzNdAq0ZYj6SE3HQOoHbNYq0XT/g8QHaV4LXsq35Y84RPu9rDPIsE0etXBXqMVU+bV76t8tUfI86CItZ+MC/EFV8VJIsVQ5q1lMoOSYKW22JFNtR4mmFBJWcFwVJUaVrekNSrdVgOah5zdLZCS642CAuCaKzFW4STgixnydanLTc8T2Kt9+WS1GXZRqlsLojMOJNkLhVWuVxEPCZtI7+lvd9dXp4jRwd5dNw2othAvDh+0QWBJDiTxKgVIzG8MZ8aIb8k6paAKvxLrpUNzOISH2UopUlCtc7DWVxfA6qIrO6xSAhbq81ITCd2g2A+dqO92lpLHtfXXYsAoM9TojY8Hj93P9uqm+/n331nN7h6TJY73D+aX1272oinKWfIahd6P4vwDaYJSA7KEE4SO4c0usq2t1IrmAzDtBlfOmiESBIWOy1OzwS7vZMwG4pS8Jmnvmk9yCq5Ro3NBQYlVytJM/2cGT3J6M1UmjmiaVKlfzKufGLwCdpwqSwnW/6SI7c7LXDM9DujdOufV+UErSjfTVzzZqM5jgPkusMGC5HKBSOwGIGWnGldULei2btXV0EA7rWdyBmjbB1AoyfYr5wNQONK3iWaGyIkLZbVDjC2oBtWMJyHKslH5YJ61CaKgnu+FRcpVpVyxVL4Ol/nUqFnL9UGPTt++nKGnj579fyHVz88nz9//mxY65o1vhBEZhrqCSJIxEVc2zhWK6V6ZfdrsaRKYLGFsqa1rBFBj/eMCNNRenXVP5TATGLYR5b7s21WV0jM6lBpR778mURurpkfixFrXbFW5ZKIck6BagvM6rqFEFxUAKwFz3v2sG/0R24FtDqFHr84jqkuixNE2YrrmW2VB8NHOiHoGwNRq60KhexVHbBKaJbOvMHAk+goJL0GUffFuTeISqMHapFPg6ibYWJFVJTwPC5l1In+qbWjGxoTXU2FY6xwWGx9sG+N5hRVPpW6r8olCMfxAgosHEmngnHRKsV00Tl8NXdk6xObRD2z96Mn3qoI5+icS0n1wAWZJEHLI9GzGVpHZIa4QDFdU4UTHhHMGkbPAhtlUmEWkQXtmTpntiA6O3WQtBBBKY42Wt3s59AvmQoevlwfxsUWWHjjrGhn9WyekpjmaTf3D4aEMa+NYm7VHLMH90RegSCXTwiW6snTqGch9QghkIi0lHZUGjhUlmKuY8jB2lj0agHFvnnybfjQs59oLD9yvk6ImWnt3AVZ94raz1Cmr352osc8uob5Y2f6qfsdIG7eweZCL79JQkqjknmn56zccKEWRgKU23PMog0Xjt+TYpa3nNAUsFBQPrSt44W9fU7j/dbEL4z+khPPgE/j0KpesEtD4mMUR39cADmnnVoAWpFY5jRRiLMuKN5isCOSk4KnsWW08wKrmGxwq+gSqFuf6MFyBi1h+BSDVg/mcsi+M78CRM60MuANVGuDry495djUz3tHpuU9blzu3yfv7Lai2RsTjXSzQAQGORbRhioSqVxMUIcKOfSIzNdz9O33LxcvX8wQFukMZVk0QynN5OMmFC7nWYKVVun3Q/LpAjlCFkNEmOJyhvJlzlQ+Q7eUxfy2BUR1x7M7BksnyGOFU5ps92ZhyNhKChJvsJqhmCwpZjO0EoQsZdxT22siGEn2Q3IZ2G/+TiJDur0daNZgW3nUwfE9lXAsfHb+BMexIFIS2WSQ4mi/ijk2GyziWyxIyWyGcpnjJNmiD69PfAxuFbvOl7r6Co7S7Fr2Z/9ZgG35vlDCqxp1SRT5K1m3UC4/6l3+KqDRqEUw4/EEwslrgYzHZmUNssr3XRg9Tuc8Rl/OTpuM9H9lhqPpKlVSbDLT+79JW1BTbGnCoaJ9GCNDDaU4a3LCjHEF1rfJ2HkkwzynVJc8vlFFc+piO4HCGORr6NoVBmc42pBn5fJy9No8OQqvLvYt+uAO1qvLhrWqhZaFkhMaY9BxDJ2JyDxtW0BwpJemRqP5fHqarLBSWRuS0wgdjneXl+enlg+47My9z+uwUMUTI+WKLCrCqatbe3AC1oQSptDZObKyYx7knEsiFrVBvCdnLazBjAfGglyS2Ng3l1jSCOFcbcyRl7GiWxN8EFzl5GQIsmIz/eOby/Gg3VkTHO+4U5dgo4lk2uaqcP7y+X2Y7UapbNFUHifgD3wbahSqjFBz2rWomSJRmzlyDOfiKK1qovT5L3m8XUjC1Hy5VUQOReDM96GPBqBjebokQitoQKDwPyHihoj6CWC42VZEiMIUUcW7X3c50mHGeG28XZtca0bpASxP/OP3nD0Bf6/YzHHgg6QSlK3n6BNLtsj6bCFqGksXa5A0n71JsFQ0kkTv6lCW5GvK7Kmdd0LJBTxoXyZgDWuvcH2BH1tjW90vZXWNV9lUtS1rilkcqGZYdPgNEJMbGtVnJeoZZwOaAYV8fTZbSSOcWKZ1qP7e6GfebIqOuToCENCuHwiW47EDFGV3B0rT3gVUhlW0ubveA/K74AqoBUNgFUL4ZCN4kMIOw24IXl5f4Yeg3QFL3RLRhWhx79NgHLr7ng+j0O04AO+0SwW+bYUUEK0D8SD0Gd/6EtU4tCzJigsjgTS25db6Xj/RJZ+YkkaQhCXjmvCWPcU+QvFHws/O4ahcK1e6c9dYbYggsdbxSYw4s4Eedlfj3LDrFEMC1BAfJCsb9HaRnXrvSxlh6h5HW8GzfZhFPGdKbBdU8pDKPRGwE8MFnV18CujeqOIsajZsrTjWhC8yThs62Igm0ssNVXlsFKEEK/jRMRXhpPKO+80wqR1l1ZFEVG3vGIdm0YPCtsfdDhl7PNwcMSHXHLSLleWtNa44x1ljXTF0R1lVfJ/pIS0wYOdV+IEDbTefG347PooIDDHTwiitOqUXCph7Gv5wtt3a7Ct1vyW0xzbRTOH1msTdDZLRsElnNwOCPXFAZ6dhbmpSbmoD3uFtzCohRVV+O/e1jTrKBI/zyHOhrbSzs9jmMVWxb7CFBy32WmOnBSum0zAMgWKWDTfgOsZojP22PtNr3FGHMddE+VabeI815j1l+TfDH+Ii0EeuwC/a+UsLgmIe5Slhel5pZQctSYRzWe1ttSFbU3jLcEojkGQ3WGy17mbIl57Ww63DERfxouapN3D4dDH1lO0kXuC8MVV66L81CzJl9cAKUKmT2DI/OzVWYGcuBzUXYrqQ4g2iQAOohqEycjs1VEZuC6hzr9XOTp23LOAPgRU4ImiVgzuCo8zLWupHVrOlwgZ7qC2KNljr8ehRQq+bcnpJIp7q2Sg4V4/bO0yONWn29pckEjZr0/fYtFh1h5VY5+hM1ToKKUoQDm0QdA1qHbbc+sSCVZDkl5ywho1tH1HiT0xH3hqcW0y6UbSDRDZ7ygj2E8hGFkgeUdAPbqna+PFbIbZNcT1EQTlthOkFad8lcapIupfNHwhAgAzraiBdbDwb/ZULAWcxjbAi0npcwiueF2kJFFc4qeNqbgMgUM+WohL9SgR/Avvxf0fY2hP4Ch2jlGAmbXyMyRYhpAKiLePueHztDE0s1iAx3ZJoA0UinCStp0zjeQki80R5scGOB3okc3MWywVaYZrkgrQspw9rKLkyis9cax5ar79qkOw4cTgYTO5rC15BBMHWbWDuxTLhwzEMD+Ykv312NCfds/nE7tyIP3+9DVzlecs+rlKm9L4J7dPqbNDw7Vqrc3LAraxC4Mh3Tdelj7ySxWHR0c3fPv5J/tfzo8a2rt7eZdKWmHzr5nymi0DxMM+VDfF+oohUTyCPylj+tNXdynKncZg3/vTj+vR2+eXz6uSvP/zb64vol+XJ+nY4e7nBIu5kX6RKgKJhFMfDGYKQ2n3T3Wmpw9vGsXm1MjChdalqfh0X7ukyyEAaI0GkmplYxowL/Q7RbLGiiSLiqMalbAn9Vf1t+4SvJDno3ZoDfBe+ZPfiG6wQj6JcQMgpZpxtU57LhXEfW8SEURLPav5SC63GwONaKfNzLTBT+nfEGTP5gILP3GcKp5lWRxbWAWmGRM4W2CNkf5sP2huvyn98M5ru62/Hv4HlRXkuU/WOR4+ab8yYwejzm4tL9Pr8zH382B8lxXcmB0RE6E2poZXF9NadkeTxDGRYsgAf2EfGJhdpNV3/plLm1vzqWLW3XUln53azxuDeIejZjWtpqpqN1g746R+ezZ++/P386fzFszDkmi5dZoShLKIZrhvlm0CLkuiR3sDqzx+bKWMmQG1atGNdFBNrfOPWAqHbsPp6mPnEINXjiHwjUd7ZmFGSS0XEq5Qzqrj4PsW0UZ1+qLmgvThh9BMWg1qFvnw+awX1/eJbhqPr7yWJckHV9vuF19zDzdulYgVja/AC6cbiiFY8SQgWF5HgSWLTZoxvQ8t2seTxtherLlQq33bxpCtEmN5sdSDVH4axVU5cStcuk54vFC+0s+gtdr3N6JURNvQfT4pcZVUH7BBLn222wTI8inbYbFtLvk2ZFyHFNTBgMXZne3fbNV8D/vHExRTqlSII1Ot+m1hkIUnUCm2VcLzjPumkhqRgCCZDYVK2GOPNn/ANRjdUqBwnfvhjGLiMRL5cyG265MlC6TkBKYHuqh7oHEO2FppCZLbNC4SihGBI8ZBnyGBBgCVgPasBB4fWewA+ADdA6cV9S/D1QpCVXFijKOC/Q+SXGrPMwAWp4AgwjGsyYRGRXqW6/B8FThKSLASREWb3hdpr7xSLa0iTRm+IDRoCY2xCEM6yxAtWkIpnWdNo5h/3YykXOUu4Ta55DzUx3GC8MDgAARADWz/Kcj9bVxNjaFEeiPHcHs6fnH8xY9yOFyJWXKQmxa1bgAIQ25dsVHf/Djcy6m3ogRXR/2qV4LmSNDabEROIGqqAt7Bs5QOgpKwOEnWiFAQn9wHzEs40bLa4OmjFId9eQpTLYlBIKdi2QM5oOMejjMpN2KT/8026EDlrmYLtFRniBaKhApI//fWDRZNn3mybISwRNuT1KDcqd9fhnnEskQs461noVaZt8dgZ+Y9YLPG60pqWqz1h0lxtN4QWjWIg6yUQpIvDPHUTawiK82vdxQaUxdmJy0uHVYWwk+vNjyfgZGNE77qF5YbgyU6N3hGcIZw4yzgYrW2/0F9H67L6m8X1snVRp0yRdSBUZZjoAVi68sBHD/xrmnCIkWoXNFoy3RmkLxLccnDWAcb3nViTcAjdDh33KYmdyx04ukdRnmEWbX/7PQidx1fg+uHV4DfQna1t2t+7W56z9ZT9+3dN8F+8h7f1OvwG+rijXcPoSmcccVNhWjXPXJjoTHdzQ/OAoz4Gmv1UHpumGWd1990qu/eQ396Wq1p2SqsPn5N5NE/nH4jCp1jhE0hUDAdENvFz9cs2wRW03NQRGdEVItgc/V12Ghg0XXPlyHThjyft5q6wqSs0C8OzpVizWXODUsVS59SFosNzq9AmbpuObpMzLLtzwW+I2BAcd/Rr2+AK9XSFUTFxEn5bdZytzRzz3vnFgYb7pn4A3eT/9dnx098/OX755NkfLp8evzp++erpi9kfnj//6evZx7ef0E9fzUmpITG3IOa/5ERsf0JfbxZ//dPm57/+hL6mRAkawXnsy/nz+fETTXd+/HL+7OVPX49/ApXw64v5D6n8aQY/FpAFWn59Ab+14ryhSn59+ocXz3/Qj7YZkV9/mpmUc/AHQIBjpq9/+fLm898Xl+/efFy8fXN58q6gAael8utTXR5u+vn63/84ArT/OHr13/84SrGKNgucJObnknOp/nH06un8+J///OdPs33WG3DrFt2LzdpmVmgbDcHGXhFV7b3+JUY3cAcSUNKpKvR0a6OH/Ro0Vhu+58fHqQxBqUUcFDh0L3YB0e/HTI32KsM46WB1obCiMBvG8GuplzcWu1gapw5dqo1nfSCPrDMM8QV0WReOhN929+uISTKileDykUXlxq0QvDe6mK2L73A3QT95C03fdIC54JLT271qC4IXz0ZORre6dWEw2zKqJmVqlsNetrrvKYmNr0kbgGfjAAieK1qT0FXen02Jtm6Wx0/f/dezv/zx+g8/375YqzV+q9i46UE7BPJZPMmq07MCXHZM/ZhHXbxcvkucCf5t63mV2Sct/mT2bcOTzFgOC9tHQRXt70RmTxDqHpMVGrXMt5DMqUmo3SHqvIgUa0ho0JYatExM3aI9ZWBRwLlSt8blWcduRhUtAvMuT849nxwtQ22TzluhZFy0ZjPzijg4moPxgOoFUwKZD2ydlYC7MeLOPqsUKqI3vI50BdAjLlBCpdLbwccWYuGFA/npy8tVangb0JY4uu5D5pcJAbPvg7husUSS2LSwiqMUMy/hrtehZbKgAErzohOkVySEUavmLhmR4p43j4fCYC0uRECV0MiULOywEeSXNhC1Yg6IsXu4k0tf5Fkr/i2mYPxecYEwWuVJ4hIXGfeOIvjODstHjCvjqAy5Dni8fYzwShHhRSkst4pUHLSGjlaoxC85yVvbuiwxuob2KpAbLCjPJQIichQyNxptx3VirJXdqT+aQ5VIhZcJld4logwndnTNEGVRksMRo9C7tJHVs+PYJczqrF6t7M7VK+eF1PO4OgIN7RmqDLkYKzyqWs5ZorM+hUcFLa9IrLjcCZJiyvQqFyl6A9WzgmDmlureJnAHae7apNqMqToqVT0cwYbpFhNXumj9cvkY1i629zpnml+mWrcyxslCdSFRt0T412vZvCjg/2uT8XtdDoSHAnazqRNxpdCkkC3l30m0TvjS6NEjwNM+KUc7RJwRa/YGFFBaamK3V9BCboFFM41ABUOlkMNB/AtPXDj7covevT4H3bN+B0uzRSrbtQayekDXaO+20UFcpTIYyHYzZeBWPWiry4TbGay1Z2KCAQFaA8OP9gDSHXLUE27UHWo0KN1Ff4hRf0zYnv3QmqGmLzRuT74tGWmGhVPtwbsRQtUUzkSklFnHSVV1Wa4K6HpBtzxpqUqtiwQposO1MIR7UUj1OWFxcTkXqs4+u3bKNgTFMuOUR5gqsH30vvZUg5aw56GW5qp3+01ntIVjGhZ3EWcQvMJUBSmvQGy2lW5Ds9zXjx1Dgg4FNpxdkIvt5E6gi68nRG0FfBdot9Uch3mDWZyUmfsdkQmhN85ZG8itwjUOuFQ0Sdzg4hXNbULwdpvShd4WKVQjH7jbGbky5FtGBCUsci0O97ZbkIBabK2ztNuX1fb/rfDNg+r/PDObUq0ay3lCtI6E49h/PnRVQCGLa3OHVuNp/e0FSbC1fqh6GueARXLcwXPzHtvw6CuLuT4EHOY5CIfy1koL1Ghpvb2BUHgybxWRC1E/pq3P56JU28ww2aQhqCylStXhVS8mphIRU2pX2BHOVC5IvIg4v6YjkwjVPgbdlNlLUHGCjjSL/wvpJY4QAV3M5rMwuaqw8iu2wfaSTkfMZYq0l/UOrMeG4JiIkbkiiq+LXOaWDFQJdkNwFYt96IrXMaI4J667jEYfFWrwkf2oLGyoHcGYJKl1O/dlTbhPw5ub7pCq4dO0+e0us/Q3MKqs2Nj419NiN7YguQ1V0lX4vgaXiSIbObaMYWbY0DJlJx5Z3tjCt0UtEtrwu6mK0FrZUn+27tsVi68u4ZsSTe5EteHxrHLRt38dgcst318ZrzrlH/YIjEaUrbF3AnYGD1oOwMzL7kwKBUW0/+lXTJb5Xuns2i4msRUB+qNSZ65wBHddTrdruzABepDkCKvSYw4u2TIwrRWoN6OmS8I5HbjQna1H0GpHM3TEuKIR0X/5ngUzdHSLBaNsfYQCObSPIkHhYv+jh869WXDEdI8Y0t5Bpskfxtj/8jEGsTD5NIbX8DCzHA4j7X/ZSHOCnEpfip9dDM9te3Z2UTiFw9AJinXafjNhC2o/l2yDB7r3C8k0hB2uILOHclNeQXZZauh915AdbvqqsAVHShuEfjf8gYPVrSFxBWYtV1jVfI7QnmlPHQDwS+qKiPxN30x3Bxf2XZZWkr7Z8mC3ij30LXASUjNglQ++AG4oc5kvPbNhmPstZc+fTc//b+b2ZNTL3220wa2kkdp2ikkZ8i1p6QmqyB3MTk3WzE7MYkSZVLgvLXLYjW4CLP75thVjYOx3TnZOzlv71C2W5c0LLeHCD3iBY9BIt+9yBYam0r3U2N2kycdXuJF0i5eNvZl+WmRwHb2xkAGfbgz/opdLAuzCEfK3BN2sJu3ID7dT7hOQHLqdMj/cTqkOt1MebqfshXW4ndJDdLid8nA75aCETYfbKQ+XLQz3550I2P+I6wTutt8Ot1NW/9397ZRtJvfx11M+tA0RuE9s3bXMe427D3vaYLlPXHfLvLfuD2kFOpyzVNg+tD1bECw5W2Qb0ZYRe19rvqaPDP3Wo6b8Liy5cAzp5c7NOE86okUOuuBBFzzoggddcEIsbVdtXePVte8K+mf9u8WNBN6V1zqHPEYcObS/H+ielxobsAlfg6PtYD1U0ZRIhdORi6xLhAyflskcHPuWQMzAlexlOpu/vf78sZ45b5irkCH80F5wqLIshlJH7hnr6rzMvNAQe1Wwbv8WIAlu3Ay0a+XhDgggOAoC3JI8lXBH6BIuXaasY7wNkKaBZkHTLDy1VjJ3RHe1E+odrWgSK94HG26f4TInEKBrh7PKk/p8nQYL3BKbJ4lrnnpvusWaLjHzV2vzoGW5Ni+7HfcLiuhfdsGeNNH5n02b9Sc7r0dS78n3xIaGmrhrvrJAWvet9du8DWtzWUftlXm4CCZDS/haKiz9Ozbdo5ZB5V53DyuPLpp8YFmg7z2g1WYYMeh8d1U95RzRUbaraWVqyxG+nhghRl3KxJ671kKVcMuj5T9zUYvC7Oohou49X7/42RRvc2t1I2ZCiIYm4sKKmNviSsTaTZhdF01M1HFn3s4aL3luNBORM2bCuyAGtgSoW7cHXsLXC6jH8Nneg/GamDzt5swK3ODXJmlXgT0QGVgseo2UyaMnXJPEYWYdZta9z6z2WTUe3Wd8i+I8zYoDasM6CTAp3EjAMjaxobGSEhQYdPFWzdtl9xkx9rbKkvcrdMayXMkZegt3DcsZ+pQr/USPqRMek6jt6hrOrxeUhdIM726IfgMZuSGHDdxXZOOonIlyiJevw8Uwa7iv3BksYNaFynZnhgVu8YIeP6IvzC17RkhUehVFnK3oupnqrwXQIiik9pNfT/6jiqwCyQQy2DQwdX+LQX9Y1TjlbM3jpacZ2yfDY6w+6A9O/9gfZ1XyQmNirarqq8etN9hqTyEeOPhtQxBC0RPu1zc47TelAA0J78KOdlZ53LbEdRuqehC9zRnkx8EJirAiay7or/YSlx5wJ58+fHj98XQkRNaY0QMUH/JN9cKhjCrMYpMKcRSoENkhSobLg9hlvvJWMTc3t/KXxJuZH7YXf3k/fF5qVvBJdWbKDRdqYVaTV0iJvG1369ijXQMjWwCgjhk7vatGFch4j437tJQbFW9BwwrleLH7Grz0Tc1/mP/b/JlVvF2GIqNR0niO3nJhy1lXAokyQTmk0vW+bHCAloO5Wjqn2yxwtOXYv+c4wAYkd1S0e6vx0OcBE24ie8ay5jBqKAciAQZU1DADR1DIwRPBNVYmyB0iSttjfMYzgxgeqGe5z+lg7Xqhzdu04V4wxImhzOc/HRAT3KsXhPnU95KWCaJLNFqHn+11N2nCo+s7wYtTntvwsSrmW0x1k7q9gQagV58lKd0q5ppCg6rRkqncq76C30oIB5to6a1GTGnqZbYqq7Z3TB5AoxdFyshUwiCASEaYDQPUJgX3AZMz+s2TkQpfE1aucVcXby7Lt1dd4Jr3GA3z3SuuN2pZPKZseS+95NlpMcgtd6vvsTVl3zx976P+PU7fg0921Pcce7SPvhcAgO49HUYJZIekGIVf2EJvEIJDAAuBRw6418x8ZZLGaw6eoCFyjs6UlzZuSSKcS7hrzZwhp+bCBpNGjczQkkgaE+mlWWxwLMnPKqxMX7msdAm9Jujq/z15y8UtFjGJ9V9Xc3RBCMKJNHnproo2uQo5y92hc/NJw7HZHCLDNQdZvkxo1BDYVcTQi1em8efobIUYLz9s8CtbCQuXj09ZrTmg61ocgt5g1dQcQkCaHAFYq772m82GcfAqrrB9SAfvh/Zo/hcNpX+wjCqHSPipI+G/HCLhD5Hwh0j4QyT8IRL+EAkfhnSIhD9EPzULHKKfDtFP/4Ojn+oo7iQSvrS2jT9dndjp8I0BAB4Tj8h8PTeQZsilMn7c4l00ma33vDj9JEzRFSUCPTo/O23hqya0MduzXMe2LULJmaGnO2U+KU3bfeynP4at3CnpDOlcuiMBZ0r/ZJ60GNOtEZt8y7hQ5XnIlaVz1R0MWHJD+wcBCCLzRO03RcFavArXydBHKVFCi3A1dKJOb4b0ha49tdxgVabTNEZXcC5tMaNEAaG3B6i3XCDKIgEXq+hNNFZ4hlIsrsEtWGtRxjG4SP2J47hxPIdMGsyU35AYrPoRZmhJ4P5XvkJH8M3RDB3ZMkcz/cGRZDiTG65acq1vuFSLcnZN2xPeWuXWcziHr2Q+taPcqsBUOr/kpsj7qFXPJNkWhJqSsbAOMfoNTpknWoq+VI8U7eiCMeQfhyNJWWS9vDMebeboi7RHzxFPs1y547Sr//ROICOe5GlbplWcEBZjEaxMvnPvWA9VQawiXrjbGU01Sdxd3jQlcOZt1H47322XFeeLGZdqLUjVqezcPBztWVZ+t+NxYwUN2t0htArkrn1C6+edbc3g/v1mXMtoSn7l3Rc7tbP61a5eBdv78V/z1anw+tG06JauZDhOKRvlSOZCCxpkC2MuVnjZTNtS8ky3xnN6NMsg5WEuc29fX75+P7XDXOCae9Tp+lPieX48Px4F59Q5tfMVwmMdPUq+F2/evzm5RP8Hvf386QP0ofz3UTj+Yu9HsHerPZQnoV2tBYkr95581r9b1mh41x2r6sihB4+ANmCL1XLgYjndFu3Sc1I9O3XS1KAKXdxaOmVNHXymKVb5u+z3c3RSURuvUiwVEVczdCUTfEP0H9GGJvEVeqQl8+fTt9+//vQW3ep9LlsjePd4FtJNr7QiQRlJrob7504VB9ioFoRm6srcELHkEuplLiu6Ar34yl5Q1IL1TiZjg+qELr0XzmcX/EvMRcM3WvXUUtwMgRuKEUaMqFsurr0N+1CtIkrHeGUMcl1LU8xiRCCIq+2g1wmM+WT3ZLyDpmJrRBU4tCLFHQZ332RqboxAKY1Ed/zYpKtHuWp0CKtrMuH1XprrNdlWt2SuAfRWtLtzsJgyewS48Yp1roWkNFeuhkFFOEk0JCvRzPGNJ9Iu4MHwfYchsON+o+CO9vFvDEFAXQ6OudpMud94T1n+DaiW4Vf3Hs4CV+/iuESl8XSnRmq58mNgSADYinbgmgm+FjjdXT/YmfGk6815ueA4YGArky4vVD+g6SXloKC2/UJPwJxTRl2UBkHjYCWR4oGoV5+vlHXnjZ2PWO1MlOYWyEhLo4uLd7relBlUctj5Zldw/oAtsW6YGuO6WnX0OopIpoyd8S2mSWFmPGM3OKHx0dwrE+CREswkwkjm4D+9yhPDbl5SsGWKa7mhm6x/mAtVLo6bAyzsWX6Br06vrCJWiqSZghu/V1C43s6dPqkjmrTm/2rdTOuNm2EptdA8ghY1vsTXZHvUhqpxyu8GYeDFIKhltudagFK1vbQETnHzkLbQ2ATPMhI3/bUnxqdbtlRjbRdr9ZdnhJk7v9KUxBQrkmwdqjbQgfzNnR4xYwBDFue9mlTSNcMqF80BPwhH8Xlh4rXAjL/6Ndm2MQ45k3StdQMAjXYpubJTWs+ieUuogPk3tW9J2Luk3b9khIdJ/7n8QB+iEX4mw3wX7g4ZVY1xhga7dtwZLMO2s7X6/XImQ9fvnTPIP2eIh86I9hrqpTPGL2WyJmv1TvHxyDzmd6ixGT2tiN91B/2a65Xbuo7U4mouNeZfYZUGtejjp0s4fcxjTkTTEXaQbKg4OmhqEZZGRGmyxba7W0FSjSvHB3K/vPy7JxQrHGmb8cET2rc7KmWRzRcZU0EixcV2DxBB7/+inwTnO+riCos1UXabwj1LSB2gvKUq2gSOzL2sLGlIvA1rqpqVDuyIGkLPDknjxnF4t3qnc84y3nHaBaXPoIYqw9+WhLK1ceJoHTSNffxgbbOL/dlpqyI3OUPoxA6Om1AcwAC6+ju04knsuY0wYhylW/XjDQmkFh7ALCYrnCfKEOhgFxzi0AIPMsYd53sf5L7ipFsJgNzBmGsFUFqsAuw9k+xdpUgxpD1z7QNbSC2ee7eRDuF7R1bSQawbQ28Kc+gQzvdoELXHH0pgsqLX3vnHpXkyzvHKftSfbq/kh/Y58QjyQw+S08FB2SerQ7DDJ8pN0KpgHaL4D1H8hyj+ELpDFD86RPEfovjZIYr/EMU/GNYhiv8QxX+I4h+H5xDFf4jir8E6RPEfovg9HL/5KP4qEtjPLmAUT7hb9DLEGg4yyH4lOFOExe2Gjd1saP4cdjxg0QlvWXF0rUG0WQt6MITtKqK4rciSt2eOzoJAwR5lkmV+9/8DAAD//3LvWus="
+	return "eJzsfW1z2ziy7nf9CpS+TFIlcxwnk7PjW/fUzdpOot0kk42dmXNONkVDJCRhTBEcALSiObX//VbjhQQp8E2mk5lab01txSLQ/aDRABqNRuMI3ZDdKUrYaoKQpDIhp+gNW6ElTQiKWCpJKicIxUREnGaSsvQU/ecEIYTOWCoxTQXU1cUTmhIRTBBaUpLE4lQVO0Ip3pBTJFjOI6J+QkjuMnIKnLeMx+Y3Tn7LKSfxKZI8twU9fOG/qzXRLJecbdB2TaM1kmuNAG2xQJzgOEBXayo0GNUUhRaK4YVgSS4JyrBcI8lUXaAXFBxeMo7IF7zJQCDX399i/n3CVt+LnZBkEyRsdR1MKu1jy6UgstK+hKWrvcYtcSL6tk7TVOg4yRiXJNZNFBJzKRCWNRAbIgReWfIahSRfLCy6ShknIV6wW3KKjvew9RO80QrElqXMQd66M9RPRiNq6ITkBG96qUAPKYGWaopouyap6nKarmxPEw6KKWYowilaEPSdkDHL5XeIcfVvwvl3VXgZZyIjkWQ8AMntYapIJ+MkwhI69HnwtB0oyIymWS5Vm+sqS25BlqCzK5ISDjQriksFUjqglfQWJzlBAJMuKbFyQ2jJuPp+DSyuEVPSQjRVP2rmgkTqR9NtL2lCFgRLkNeSmv5Cj84v3n+4OHtxdXF+igQh6FpVVgK5flyVV/mlXVR/dqFUWw1qFkq6IULiTdbeyHmKIiyI4bciQqKMZkQN4QxzQYT6VFCrjiAzzsQMUYmEZJyIgjKUYZyuaIoTdP3/CgrX6BEnGSeCpBIGgyWvh4ilXJkmH2uJ0JK4mjFrzQb1EEQGGxbnSY++LSSpKyC5xrLsTMVP93IDHxD2AC6mWm82YicStgqWOKIJlbvxpm1DEJEvkuNIEmdWzDhlnMqdH4r9OhoUS9Dqtm5ymzQEuSVQI0zwgiRjzdPQT+t8g/UMjRcJQZZRe6fcOwzLyA/jlnBBWTpafxh6tjs+vDz74dnJM5ikNlj6IWScRTQeUwJAkQiB5ueAw2IwZoPQswSV3wmUMokwSvPNgnA/uI1YjYsNGuhD5WcvJM8jmXMShzGW2FACGqeILX4lkTV69B/hOOLDHG+IJFzYbrw8P7p4c/H24t2V8GGfoRVneUZitNihy/Oj+XmtNTd4eYMDyTIajSnLvwNZpMiqucfgKSZ9tdJ4oWSYSyrHVHyNpaA7EM/IdrUxqdmyAsPYBDWkXjw3ZDdmR92QYoLW3A0kv9LTGNb1JSV8TAxmZSiJW0QZZyuONzOUixwnyQ5RKRQgP7qMxqP1kzNRFVufxc5FhXIBFo7hXQVkartWxCiSAnqOcBQPP2fyhUS5hGVmTP7ufrXk0A9RtInHhBKxzQanZj/aC0A+onrkgnCziHUzXo3IWE3nvTlHOMMLMAgpEWMKnyyXsJu7Jcjl0A8TzmMqA0HEnnVzR1CKMDKEh2BJ2Iqm4ZjqoSj2UxLt0YmDPKVyTGkYugjo9kQgCA/vDQbIwvZNf0wioRG5DzyKcD8MkRpzY4KAnTVniRnMalI1FoCFt6aEYx6tPXsDhclIckxQlrXtpL6Ky7Yp4aOOHkWxPnpq8BrA0PSWRRiaF9J4TOmUhD2YQJ8bAEmOUwH2w1hgXrOt4k1SyXfGfo0IvdX2ya8s5yne854UPxvrbmThGKqOZAxDjXJmTbtd4RIyWy3HBm5CHLGYBOBeGROwPgywTm6FGPho982WM0lKGbcCA+tjNL03qFyL5jBUyzwddTcH9MzWqT+SNRNS/d99GsFAfziqBWNy5CEAJB39V7iwLP2fBTA1ZLecSknSVpAbHK1pOvpQ1VTrUIeIMMqFZJuv4/AwTM2JHtpSuWY5eIZiElN1AGJ89apAUOiHQJiDGbYlHDzgMVJbBXNCk6cx4SICh7YqxsmG3ZL6nLlM2DYYT/jzvd0tMJihmHB667ppyRdYLghXkB3nLZMsYskM4TjmRIDvHgpA2cLwBoq+VqjjOnVoYODovomxJIc05cpVauCJFAO/BBMs7os1kEYZjm5Kh4pCAyNMkL3hBd+CJZxYGHIayoKxhOD0EDQvATCiy2q/Ff6CAtCCRDgXxaSF9dYJ2sFyOUOCoQ1TehgxHld6E21pkqAlSxp6dnTzQgnYEi20zoU0Qwm9IUhGGRxl5nHmw3Wb4HS0dfHnNy/eORNWo5ar5TOg9lBMy4Jmh7Kdv799Zkeb5a2X6Fbut8/H4/98MP89XbiL4AvtQkC2N4YNHtX6ePvibLAYhMRSBCmR4WIniQglkzgZTS76WALAKOoITjvRYjcUmp667g+cod8TXkyEvN/BAxxoij2ubRfAvY6fPhC+whDqA+P+R1EfFN9uIA1D9y3GUjPClEgF0glOGfWIErhra2Gm5hZYiXV4iWhAYq2TwOjAmKhqalWw6kAy9gF3YazYk+5clIc2BVc0f/9y/l8QwWOLPTnukpmeues7sRG2+tqoeUfkS+jE2x+RiRhiC0H4rdIsFLMNptbrpMB3wc0zMC3DDU0SKkaDrKlW8P6g9ii3Pxa2r4BYHs2XRCyN69poPYnmCG1MJawdjcEJXRPzEfsxo3G7l1TtTovIltH4WoKWuTeqhvBbGpGuQ8gGLpe6tu+4Fc5aE3JLkuFU37DVCpyAqrqH7DLBK9FOoimoVlVtk4f+LeIE3AZNu9EGppW6Vb6wlUUs9UYPmgpBEYnHlgXJIhgKyFABM1JzkBwsOTbYTFNjmwxzKor5E6EyCg9oOSoJW9HiU+FRsDwCNF+iBZNr5QvRntqoWEMRYmmyc2mLNcuTGEJaYX6tyXgtZRZwIjKWCm3q5iIEJ2aT5jfI+/XV1Xtk6SCHjo2QLmKjnx0/a4NAEpwJEu97ILoxXOiqSnZoQeSWqCjf33KIo4SJr8BnJz3kn/WqiExYZZiQdCXXAzGdmdhnXdlqe1VaCxbv/AgU9GBD5JrFw8fuB10f6frBZGJi90Eny+D9v+q/2gL24eieQVA0Nn47gfAtpomKJaApwklixhCgq0T0V1oFBNzZrefqAAhhTxTb0wgYCeYUQqjRUJQC4siJTIUQT3NSpyN0c64XaThJmMHvxkeu46SpgFOsWNGkEkZlyuzWAkFY9azwwBpOpvwVQzbwvsABUTDaj3wNha8LOtW44n1cwb7Qam75FsEV2NRBk8x5WtpULINoWhMEI8mmOgsq4I7seJ6mNF150MAA+52lPdDYkveJxpiF3WCs/WjUCir3jv+dlhPqtGkp8oaz63DNSrliKnyRr3Ih0clzuUYnx0+ez9CTk9OnP5z+8DR4+vSku0HlHF8sRHoYwgDR241aTHy1UbJz7X7BF1RyzHeqrJaWuR8B+p4RrjsKZlf4QzmgwF3qLHMwJ9SkqZz/4qATiQagxVylTqGLMQUTlGZWQ0A4Z9WYODdooIHJBVQy9KxNAaMJx7GK/8MJoumSIVoYD5qPsIuge8/JRWMms+J3z1WcFlglNEMn2GPgrOje1asXdXc5L0k7W+am9akXdagY2CUqSlgel2vUGfwJ+4FbGhNopsQmhtdD9q35qk9ookpVAS6VcgrCcRyqAqElaU0wxhtXMSgaqFqBJVsf2CTqGL3vnOWtijBA75kQFBRXrUn6YIxEJzO0isgMNpwxXVGJExYRnAaN2GgqJE7dXXADlrkpWD9iNCePPTh0r0wFD3dd78fFFAgdPSvkLE+CDYlpvmnn/tacobremH7MjZkDEXm70FnyCgS5OCJYyKMnUTuEFw4hBIQQLVc7KrTEqSiXuSZEGWcwUZa9WkAxX46+tCNxVc9UASyvGFslRI+0Zu6crDqX2g+qTFf7zECPWXRDeDnSz+3fHuL6m9pcgE2aJKS8L6O/wZgVa8ZlqFeAcnuO02jNuOV3VIxyZ5C7TS5g+dcHt4pbzawJhJfn0AfOiR9T+ltOSoKIxkEbuw1e3XEWdvVCkbPWqQEAhsQip4lELG2D4kwGByIxaznhxpfRzEtd+BF73Cq2RIc90YFlriSh+RRKC4O1VNnX+i8PkTkYA46iMu6ZekrdBLKdmml4D9PLu/fJa7Ot2O+NkTQd2uVVcoiqpJKoqzt34wRtqJBDj0iwCtCXvzwPnz+bIcw3M5Rl0QxtaCYe70NhIsgSLMGkvxuSny6RJWQwRCSVTMxQvshTmc/QlqYx2zaAqO54Dsdg6Hh5LPGGJrs7s9BkTCM5iddYQgjNguJ0hpackIWIO1p7Q3hKkrshufLsN78TSJNulgPN9tjSrB/HN1So08P5+yNz3ELEPoPyzPDAhlk2a8zjLeakZFZewnn74szFYGexm3wBzZdElHPZ393fPGzL74URXrWoS6KlJd25KJeVOqe/sujgSTBj8QiLkyOBjMWK9MTLqgywvjun9yxGH+fn+4zg/0WGIzIaq5LiPjPY/40qwRSiZf0i7Lu092OkqaENzvY54TRlUnnfRmPnkPTzHNNccvgWZBuEWrIdwWD08tV0zQyDMxytyUk5vUxf6F+m/tnFfEVvbc6A6rRhvGq+aaHk5J8TGpphGVoXUfsEgqOoPIj38+ln2ZrjMFFYhBYHnAucGz5wpuSsGPuwXGgQEStJWFmc2rq1Ayf8d5ZQcCXO39twgcDLGfxtYU2J78i5uK8HZMGJHmv/5gILGiGcg9cejrxgMBQueC+4yslJH2TFZvrVxdVw0PasCbqxOHXx4cp5MgDUUM4fP7zxs4VTpdAYO+PyVy3eM6Nc3va0yz1cbHVHDuFcHKVVXZQufzhkCyEYKFCRS30RWPe9r1IPdPV4KbO/hmARwkvYAK5JbEvCeeGKGLO7LGk/Y7zSibwQ6nBK92BZTHtgIebpkUplY64YKj5ISA5HTegnOLs26WhsnDYU2yOpq10kWEgaCQK7OpQl+Yqm5tTOOaFkXGWnaJ4mgEPY3OD6BD+0xaa5H8vmmssPI7W2bCkcw+w30790uAKIyW15bbOvnvUQQzEMXJ/jeidohBPDNGgEtcG/Fkc0vcbqAECKtt33WWSlPraAoun9gaLpYaAyLKP1pPJpzN5T5A/B5TEL+sAqFuGzNWcbcjhw97ChD14mDkB7AJa6J6INUfjVh8EwdF97PAxCd6AC3muXcrz1UGxcWnviQegD3jpKbnLcLcgSLirBCgQiW+xMWrkjKHmkS+plM5j4wK4Io9noi+Irwubv1VE5GFegeiss14SDGwmDuW+S/hS7GrNg7lH0LaCaeK+1co/eIWsn7KlpCgFiX0/bCp5BC6wc7oSGVDCfyT0SsDPNBc0vf/LY3i6exNzG95AxCkVYmDGaysOQgIhgMqQyj1XnogRL9UczJn2aec/9ppnUjrLqSCI4X75fHMCiA4WRx/2qjDke3tcYX2hO83zTwumlca7YwFntXdGhOIO8Km7MdB8JdLS+EgeuaNvxvBe346KIlCNmXBilV6eYUhSX/Xg4Izc/NIN70nMt6wEsYasVidsFUt4H6LQ2enA0Jw5ofu7nJkflJtcqOryJWSVb6kh9rWnCcUycR04IbUXO1mMLKZmcwKvpC/VDg79W+2mVF9NaGCpBU1yMsv4OXMvYP+Ibmlkf6TXuvvFtGeoE5gg1cRw4x7yhaf5FtwLYB+gd5MNMkiJemhOIPck3JIVxBcaOvWVeoSvXZKcivuJdijfg7oQLOxCAudgZ8mWktatD9Xa6bdWhoG4EVY8WWvVpY1qyYEkc4uqRVw/6kDRdp96qXayAzmRJbJjPz8GyLQMowHjV6WqRZHtEFQ1F1Q81JduxoaZkW0ANHKnNz220rMLvA8txRNAyV+EIljIrWwk/GcuWcpOWR+5QtMZgx6NHcLV/j+iCRGwDo5EzJh/7pQAdJogYUQgsKZI93UOPjYsVOqzEGqC5rHUUkpQgPKlQ1BsEaEGtwxY7l5i3CQIc9GlERlxK3IFpyZsLsH4MOIrkcDZKDXGk9hPI3CwQLKIQT67yu5Rx532X6x5cy1hUsz430L5P4lSSzZ18/ooABEJio4TNfIazgVo2u32qc+sIE3GpPkHaHdNKdce6jquKpbioZ0pRgX4nnB0tsCDx/0HY+BPYEh2jDcGpgBzzZjAtKYfYrT2vh20ftg9HDGidpon5Sq2YdkrULh8U4STxs3JT3vfmxYnIk0JYDg/0SOT6LBbC/jFNck4e/xEdJddqLojhmRF1X/x6UqPYduLw4DDRDpP734JXEKnE/vZrHcxX8Uy4cDTDB3fSCO6kr+w+MTs34o5fZwNX+b1hH1cpU0bfuAPZtrFSdFIRuDPPedtRnxfawsoqBKZuaDqUnk48h0XT21/e/U38z9PppEveljFNY/KlnfMciqjifp5Lc8X7SIJTXT0RM5Q/jTu409jPG//0anW+XXz8sDz7+Yf/eHEZ/bY4W237sxcQM9rKvkiVoIr6URz3Z6gWqUnX+ujVnaZ1xZJO8G7v2LzaGDWgoVT16SB73dM+jqNeaOJEyJmTCYhxRLNwSRNJuNvcqiSgVv2rXyAucmUXdm7Np24yGbMXB08di6Kcq6wbOGXpbsNyEerwsTAmKSXxrBYvFS4xTdTPtVL6zxXH4J+YQURfChnDWer9zVaD+7BwbhOaAKQZXPAJsUPI/K0rNAvPgDbVhotRd1+3HH8B68mseArxXsejR/tftM5g9OHi8gq9eD+3lR+7WlLUq+T4NRZaWQy27ilJHs/UGpaEMKGhR4BI/a2ibBEVIjfuV8uqWXYlnYPlZpzBraKr+Y1rL3DtC60Z8JMfT4Inz/8SPAmenfgh08yLNuM0jWiGk06gRUn0CDaw0NjH2rmtB0BtWDRjDYuBNVy4tYvQTVhdOwy7eXuxMI82tAkzSnIhCT/dsJRKxr+H5EPDoeacduJU2k/SWJ3SoY8f5o2gvg+/QP6t7wWJcjjt+D50xE0GgzO61QnQTpBWFwdI8SwhmF9GnCWJSZsxPRRmCNF8nVihkO10U3EGOzKSQsxaC1KoOO0+cbGg7MuDVUW849Jria+iw2ki9OrMvt5mGAQtLF222RrX3OZN3DsQOJ588xpgBK6GV2eaRd3U92FycdVMyW7N6QWwfjnz1Zm9UwjeSy/QElJsEouEgrh9Zf+noS0Thg/cJ53VkBQMIYqdcZ2yRTtv/oZvMbqlXOY4ca8/+oGLiOeLUOw2C5aE6l0clRLovtqB3quEv8ACHHYmLxCKINMwtCHPkMaCFBbRCVwFtH4F4D1wKyiduLcE34ScLEVonKIK/z0ivwJZiwxs2ZKjgqFDk8GfLZxGNUPPMMdJQpKQExHh9GuhduS9wfwGhJxAnmh9aUg5YxOCcJYlxsoAf5qQLMtI3NyYKMFChHmaMBx/rZZobtCAPAWXngbRU/pRlrvZuvpNyj0xvjeH82fvPyLp6AvhEJgPgMup0AOxecp2GwAGYoOQuwXdsyHwX60RLJeCqtciiLmIGrTCFDvxDVDStA4StaLkBCdfA+aVOtMw2eLqoCVcuwd7yTyP4KyXatui0t/DurSkKRXrYOJrya+3m5DnacMQbG5IRwNs4ia9p/zbz28hoQWXMFOXo20GCbOwlhNouTa52w73dGCJyacbwiwTjo38FeYLvKpI03BFiiu8OZyZbvBNGhYqFMvU6mIxjy1igCAZu4EuBm5WOu24nHRYfUy3LmmdwfGzSp4JhP0s1wRnk75zZgfD1wRnEHJiPOMqcsT0C/19sC0r6O8kvFnsfbcAaSrJynNVpRNmOXih8YoPLDM3NGHqjlTQCAlWpnuD9BGmEYWoGYwFArETK5KO1XE/JbENuYN+A59ehtNo98fvQdV5bIlYtQV/gO5slGl37+5Ynq7G7N//BoJ/8h7e1dvwB+jjFrn60RVyU9cvJw3MppBJmahAJ+WfmE66dGC/nywnsEJYWg/frbJ7w1ZluenE7/VhAQmiYBNAcrtzLPGZSlSsjqdM4ufppM/C5fXc1BHppWs66aP9Ph21TJTSVL7UOekufHXW7O6qf2nC4UdSYilztzVhqXNqQ9ESuWUZyi27f4aW2SoK2S3ha4LjSV+GTcw8jCwbkbBtNXC2yuBSf7dxccrCrQSWTCc+/p9Ojp/85ej4+dHJj1dPjk+Pn58+eTb78enTz5/m717+hD5/0iel+mw7MCCC33LCd5/Rp9vw57+tf/35M/q0IZLTSJ3HPg+eBsdHQDc4fh6cPP/86fizMgk/PQt+2IjPM/WHSbn/6Zn6GwznNZXi05Mfnz39AX6CbMafPs/AQpf6HwqCOmb69I+PFx/+O7x6ffEufHlxdfa6oKFOS8WnJ1BePUTw6X//OVVo/zk9/d9/TjdwnzLESaL/XDAm5D+np0+C43/961+fZ9NJl7bva7rtILA4CW9RAcjnbjIrNGmDV9hLIqO1T0+apxgQcAsS5f6hsrDTjY9e7deUsJrwPT0+3ojppMP/7eCAXmwDAt+bmA1rstKTFlaXkBRGhWkM4dfQLkcX21iqUkqVm3jWFXlgm5WKh6rL2nDAAxSt/TpgkAyQEvkiOQ41yBZ4F1DMtMUNuGsCOwCBM9G0ACj3rLUXORoQPDvxIGjupXJ2a8MAhRAUGpOpng472YJuUBIjXbwBwMkwAJzlcCe3hfcHXaKB3VQcP3n9Pyf/+OvNj79un63kCr+U6XQQBBo3c5/HDWyHseiYAa5ahn7MojZeJrZsjTPOvuycqDLzS0M8mfm6F0mGKqFkptSkaaXzEN5f+iwxc4JQj5is0HAP0Uz5yaQ9Wr1S3zqj5+cTr7W0R0vf3KtmZapQLArYUGonvsIA1UVMoAVNqaTFxbyrs/dOTA6soUakQSMU56m0JjBQxMIBDu4zaC1gSiBBT+ksuXobI27ts0ohC8sNmLAF0CO470GFhG32YwOxiMKBfbbpcg/ePWgLHN10IXPL+ICZ715cECEkiEkLKxna4NRJuGtwFUHvajvqQamzCLWCdIr4MIJpbugDtzKax0GhsabSAwBcvaFRG05+awJRK2aBqNWhOLl0lzzjxd9iCnOzukSE0TJPEpu4SIVLlJfvjFo+giw/KlAZakGcxGOElxKeJShuFICLsfIIe19tBZxg5OSktZmqxOAWmqdAbjGnLBdIERGDkFltNB3XirFW9qD+2FdVeBxjkVCxtnqrXu412jVDNI2SXIUDcNilDWye0WObMKu1ebWyBzevHBfw9kxNAzWOGaqoHDwgMKhZNliitT1FRIVtiH1O2Y4DTiDmC2Y5/U4xTe1CMDNDulsE9iDNPptUGzFsWeFYiXCEubWYTGzpQvrl9NFPLqb3WkeaW6baveUdJwPVXonaEl4s+pBmROdFUfG/Jhm/0+WKeV/AdjS1Iq4UGhWyofydQKuELXAyEDztWuVsAe/yoZY18wKKMlpqy251lvCsICq3QGhuoTdhqBSyOIj74Im9zr7Yodcv3oPm773BEkxat2t7yOoXuvyeGK+NeuAlLrNC+rPdjHlxq35pq25T976s1bQx6XkK3uOCVs/rR3cA0n7lqOO6UftVox4i6HPFqHa9aPx+aMxQ03U17o58GzLS9LtOdQfee1eoJnXWkkBwkQmclNWQ5QrxvYJ2eoJVlZoQCVLcDofFkKbla132d5LGJPbgKOdO0YSgmGas8aiGiso06tR2TIOGa8/++a1tatAkK59q2CxT/3IXsVRdXkmls0YIxCoQ92UFMtTTfeBViepC5wK2W8U2yMV28iDQRe0RUZsFvg20KTJQ0GucxkmZud8SGRG6NqfakBuDaxhwIWmSWI1mFcttRPBmm9KG3hSxcq8AN2iLMuRLBv7NNLISp6IEqVDznQmWNrXr+/9G+BULZlJvByR9njQ04H1CIGYbx7H7e99ZAU18UtvbodV4mnh7ThJsvB97L6JWKvshtE9N++/YeqE4xWwfKhz6d7U4lK9WGqDaSuvsDYS8wGB7JEJeP6at4SpLNY0MVULfPdtQ6cix8NCZcQBBDHDHUZc6FHaEM0hQAy4DdkPJsOW2VhmahFPz+j1O0BRY/F+VXmKKiLLFTD4LNRzgCo/TsDU2j3RaYjZTpHmst+De3g44KCdcHNgQy82SUU1STj7oG/ujLV7HiOKc2O7SFn1UmMFTU6ksrKlN1RE02Ziwc3et8fepf3NTSsLsNSeNzW8dpvt1DxmlNcU4sDPupFVm2Vi7z9NiZEiq5DZUCtvgUtLtzTEKcGB7DLOhuqUq9VQtXXZkzSoFwfHW3gcO4cHyNkHUy9oJrwjfdjVOPbHuuhKhpH5nYVaUgY2U+xyBzS3f3RinOeU/zBEYhcx52DkBm6sfGg7A9Mf2TAoFRf/w8VDdH02WVkwW+WpSb1x9NPZxVph0ddYHahqi6FfS6nWN7SWO1FuXlY932rVd6gt6BDx6WJYRc6DfBqbxAnVm1LRJOMcD53uzdaqkNp2hacokBOjN0NTxAsGHLeZwS2uKPDm0pxGncF82mfobYVrYR63vmHuz4Ihpt214uJKBE/tBx/7NdUzdhcmze1Qzw+FB0/7NNM0u5NR57nA6n1/2z207n18WQeHCfR7cbQgtorz2FbcBtTG11XH2Hg+falpeOmnKpC6vO44VgHDAE2TmUK5yaHJHPbwqLfQyMCbwcn946avy0hcE0+3MJfT74a84GNtaJa7AacMTVrWYowa/w2AAQNZ4RP6EL9MVT1uMC854SbpGyzd7Vcy6NZpPdA5TCsPa+Eyaz/GESs0A3r2xmYt84bgN/dy3NH16Mj7/X/Rb0aiTvxk6+r7tZkwIdlD6Ykv8WASV5B5GJ5A1WYrhECcVEnelRTYL1/2eb5tljKVOkJ1d541/Cg62zGU2Ev/xHnD0OunuyBVO/Y3RC0IyfjcV48B4EUbSvryszcv04yJbs8JDptSpHcOf9HFJBdtK+Q8FXc8mzcgfXqcc+3XK/OF1yofXKR9ep3x4nfLhdcqH1ykfXqd8eJ3y4XXKh9cpH16nHP91yiaX+/DnKb+1D1FxH9m7a5h3One/7WmD4T5y2w3zzrZ/Sy/QwzlL5ZzFOll8M9LX8GdzggVLw2zNmzJiHywAAwHoI03fD0FdafOtDnfkD3Oimzs3YyzxrBAPtuCDLfhgCz7Ygl/DFjQhJDd4eeOGgv4d/m4II1Hfymed3SFq22LJ+WcsL876SB/pUWMNFo6lINC2YvvUObpc4dBPwEtAla+tnGxXF1XLZA6WfeDl5XuSvTAxpr+8+PBuOhyFYgmE/TxNCNGkp0/kgNgkH9ciJGzSX7E7WJ8VUWZW0OoihXoqGOTfAARywY/UePUGhEouPwiCeiW5Rsyv3T0wIHQF5Gy0u1/f/BrfJZau/umFbk9KqvWtcurW1tZO6wkLobd6JIDHrji0Veia4UDSjHvBAtMHEEfS35t2sqYLnLqztf6hYbrWH9sD9wuKfi30gq8r0zeesEdNdP53JY8eyc7rN6nvyPfM3GlVZEEbNRA/7w2Rtsvs/zRr/VhH7ZP+MayCMwoFHhqJhfvGpv2pQans53a1sqUmTbrgFUe9mx1qxW8l0DeGx/QuSueGq8KQs0QH+a78s1TjrNB3v1Y9wofJwMeozZi4g0JWTAk7PRr+M3trkWuXgbqw+Yatnv2qizcMmcJwHBGipglxB2oJQ9viScTaS5h+SPophZE6bu7srPECniwHmfE8hUByw8oBCNLtgJewVaja0X+0d2C8ITpPuz6zUpd61ETneAVKKJM6HpNAd1JHMmDA7ZN4GFkPI+urj6zmUTUc3Qe8RXG+yWxfGtaJh4llrw+Cfa6HO/RaJSWoYtDGW+6yEXlf7bIa71M0hxekxQy9VG8Nixn6KZfwC8zWZywmUYM2qxzDNPWlGT7cEX2hMnKDCwS26cU9Kuui7BPla3GlOGVfDZZi1obKdCc8G7cRI2n0pboJYRaJSq+CD3RJV/up/hoAhd5F6m7r19F/VpFVIClnsk0DU4+36PUPYxpvWLpi8cKxjM0v/e9YvYUK53/tvmdV8vKvqY1Ccc1Xh1uhKvW11TK84yLuOfhtQuBf4Vuv+7UyRujS1CkXUN/iXfjR5pM+U5wF5HdUdSB6maeRSdUATwKvGKe/m0dcOsCd/fT27Yt35wMhpnsjugMg9Bb5IjvhQEpmSKSkUiEOAuUj2wHqqjR72t1Xzixmx+ZO/JY4I/Pt7vIfb/qPS2ClqlRHplgzLkM9m5wiyfOm3a1l7x87Dc2u7zQ9ANpG7PihGlUgwyM2Cnd35Wuvbi+qlij8XV/wUiZeWMs/f/iy+0JdMNAt/yH4j+DEGN42Q5Fih2gcoJeMGwmZUAKBMk7BemBuzT0OSnIocnccNgscjf2N9O0ziqE5/cVcSG5paPtWw8/UN3APNxzazgNG3ER26DJwGKTKnpsAPRqq1QLq6ifuI/WMVVw++hJ4mcFtnOHMoJYeLeU+p4W17YWmaFOaDYdQBhKNCEQVUpf4gjEfzSzSjBUJphUj9SjJ7E5vkyYsurkXvHgDyS5hXqphhtTxJC72BgAAZp8FKcMqAqCwR1VbyVTcqb2cbeGp3lR62zp86q3emALqZbaqxa5r8ED5ECZFyBF+f4jg5e1+gJpWwbuAyVP6pSSMJL4h5q1fGFbXlxdX5dfrNnD77xj14i+K5438ZEdbhs21SZtecn5eKLnhbuy9dEXTL4699w7+HmbvqSoH2nuWvX+t6mnveQD4liXLUyetmNRl7DIeukga0WogByTFKOLCQsjgVili4WHO8UCFe5HqWmo2UBychYaIAM2lkzZuQSKcC4KoNGfIG3CdMJNGjczQgsAT3ybvmoq/3eNYkp9VWOkhZrPSJfSGoOv/OnrJ+BbzmMTwr+sAXRKCcCJ0XrrrQibXvmC5PcnVsDTtqnqI7WwvsFkNXf3MQZYvEho5H53Zo8CievFaCz9A8yVKWVlxj58hZBLomOA/YzV7bF2Dg9NbLEkvIPscFTCvPP/Q2TAeooorUcXfMsD7W0c0/0mv0n+zjCoPN+HHvgn/8eEmfPNN+P/P3tXsto1r4b2fgvCqvQh0C9zl3UzQNGiAtuk07mJWESPSthBJNEg5qfv0g8MfUZJJmZLldEPMZhpZ/D6S4uHh+eFxzErMhI+Z8DETPmbCx0z4mAkfM+FjJnzMhI+Z8JMz4a21bbx3deagw0+KADSK3tFkkyhKV8hcZfw+cdLoVpAe3GRPMDAFp3NCqzpf55Sjd9/vbjy49Yw2Zu3LNbBuQGuGns/L/NGatk/Ba2/pInCDPtnnXk1Jk0DChHEJGFP6vfqLx5iujdj0F+TxW39IqttJbeRp+1s2nbJo7iXl7EV/UZjGOBX7oj5viUpr8drdJ9U+KqHEVSZoZ5frc2rzcgjQM9Zpf9PVXktwrTbXaSqjqwwudX9MOHNsemeQghiIvMq4LKwCh2hc4ytUYv4M9SsoaFFyCO3Vn5iQI/ccApsIaFdQqBqs+hmu0BMUPZMqxlK+Azex698sr+CFpajwTmxZ7blrHfzij3Z1zddpmAnbbiPPAa9786n+yrUKnAsTl9zlC/99A7teURyaho53RtMtcAFKL/NMouhn16Wovy75DbXd4UjkcMMs8KM7lm0T9FNo1zNExu11wSGK0r9aHsiMFfvSo8xnuKAVwdzZmf3k2dERqpxqRbwJtwPqGSsKLXcBVfr4ldqv1zsTXf/ijol6w2k3qOy7+uPoyDL73kR3Y4eNW9J5R6ftdewSaTSPvjCbKSa0jTw0DAi5abSpNN9W52nQkm1eDQ4ty0v6m1V0GtRvLb0a2LeJX2urU05Ah/26sXAtMSnzaii13JtacNSswYNq8U/H17ZYzPJAniZBOlse0pIt5u316vrL3AFzjjL3w6E/ls//PiQfRtG5MUHtbI3w2EAPi/vw6cunjyv0H3T74/4r2Bu4+P8oHn/r+gi6tpqbg1E1XeMyTYXVoWO64UZac0o6dU9+wL89Mlo+Q1+HtFTTnFvqOWn2ZddMIlSRbaRl61kf8DJHtFUrSPXuxuymipWrcKthwNncyWfQYhff3H6foI8dtTEtsagpT69QKgr8QuF/sm1ekBS9A7Xlx83tf6/vb9ErnHOrDZLP3l8doTKOUnAA5hUt0iRY2JzZTytr+t2SqZnQmRfKn5iQ/VLFilKpF6e6QFH6hovxqNUZQ3ofTMyujC9RhYZfQPWEXVx9Ai85RhhVtH5l/Ll1YE8CF0pWknlnL2NlCUY/KpO4+lbc/oaRzFYn47McKsjarWVAKwRAaQ7aUqt4yay2jA/nj80qPazUGNisnulh3nmApLDOkcwMABxFhycH8zlvjwDRhflmD+dkoUquuklluCgoaXY05VVqbWkP8g/h5w7VwMTzRoPuXriePvf1fRcF14psRn5fb88RGH38L3m1/yUDtWz61RiDa6PBd54GTXnzKhw0QdG3rICPEZuJE9dT8iMA1rw5BXXH2YZjM+kjQI1+MBl4Vnnz3QocQ0ymYghzL9RpQvrhjDtlUFLbwEEtAEKac2zWhTUIqgArgWpm4Zy4ormcZXgFBhDSK1GoKpAZ7EYPD5+h33mlWHUWoW8hDifnn2ShpG8PuK9WLa+zjO5qZWe8xXnRmBnvqhdc5GSZtH7jwCgpriAYWexl/PR6X6h+JrYF/ZumLLecJh0fZlKVG3ezA0L78ht+/fZsF8GeVe5qWfF7LTuTeEbUGZM6Ykh78a86zLQ/uDssBGyaUDQTLVUs8TM9LH2sjrz85iPMd9Oo2tueewlK3fGCHbjEhPp4Ec52O0oeL80PZtKqsXqKQf1lO1pBrADKy5KSHNe0OBhWPtKO+5sHZOs4wtD2eUMq8k2FoWL7NB7N60baG2LyGwNlzQfsCiYZknUBhEaHlKR6ScMqSjypApeJLXFHl/jk76gIk2FFOXAoXS6vgTiTsNiFyzHL68MQqeHQjovRUrCDo3U6Lmc2dqejc4Lic0IidEaMV2iUztFkvsWQeaNT2nzEnrCFc4Rm0dikuiSa/F3j6AfU1Bxdk0WIFPGF1PSs0lIt+na/kt7HPWGUi8Xo0TsKdIDWMizUFgXkm2P3sIJU14dp6KvVP61NsYOY+4wPFnb3SqbBZvq+SJJzmtWMH84g4TiCtOaJM1ZP41hjvqG1Tg5nLfNMn6B4zets63CZG4b6t9NoGCAzDNKOCBQs2sIFCrwxIW+/5jTwxGXn3H2CBsqmvz1RMCrJgIzEA7M/OscHa5tD8Hc3PsDN7IByEgcQt648gIB24T20ZgVphY1U9FV20IcltrQopoARusb7olYNDMAtXKhyBP7IN26Q3/wjbytOMCmSSOKBOeOb8xK4uxmAN8DiIM70pxzFoxoTnWq6Za79wxZSzUfv34kT+RI20hDcC1lJg6BzMh72pDk0BFk/fAuDqHZ/1BzTdf7c8n+s1F/CHSDQrn6p64Jof9CmhxbPvbQ8XdLSrZlGJ55rERlcdefCoj+o56xqxjtUzrnVofN0+Gwz+m4CJ3LM4o9Z/DGLP2bxxyz+mMUfs/hjFn/M4o9Z/DGLP2bxxyz+mMUfs/hjFn94Fn+XiTzPPsqveBG4tYw6j2kE4YRfc7hrvyKuKZl6COqvYYMhhY6TxRPOnmlFHn3WghMc3HYV3lQr0s1rn6MeD/DkrRl/xZxQsvh3ADJ/Ym4="
 }
@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func init() {
+	conversions["auto"] = convertAuto
+	registerProcessor("convert", newConvert)
+}
+
+type conversion func(string) (interface{}, error)
+
+var conversions = map[string]conversion{
+	"integer": func(s string) (interface{}, error) {
+		i, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("unable to convert [%v] to integer", s)
+		}
+		return int(i), nil
+	},
+	"long": func(s string) (interface{}, error) {
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("unable to convert [%v] to long", s)
+		}
+		return i, nil
+	},
+	"float": func(s string) (interface{}, error) {
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return nil, errors.Errorf("unable to convert [%v] to float", s)
+		}
+		return float32(f), nil
+	},
+	"double": func(s string) (interface{}, error) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Errorf("unable to convert [%v] to double", s)
+		}
+		return f, nil
+	},
+	"boolean": func(s string) (interface{}, error) {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, errors.Errorf("[%v] is not a boolean value, cannot convert to boolean", s)
+	},
+	"string": func(s string) (interface{}, error) {
+		return s, nil
+	},
+}
+
+// convertAuto tries the conversions in the same order as Elasticsearch and
+// keeps the string if none is applicable.
+func convertAuto(s string) (interface{}, error) {
+	for _, name := range []string{"boolean", "integer", "long"} {
+		if v, err := conversions[name](s); err == nil {
+			return v, nil
+		}
+	}
+	if f, err := strconv.ParseFloat(s, 32); err == nil && !math.IsInf(f, 0) {
+		return float32(f), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+	return s, nil
+}
+
+type convertProcessor struct {
+	fieldConfig
+	convert conversion
+}
+
+func newConvert(cfg *common.Config, _ *compileContext) (processor, error) {
+	p := &convertProcessor{}
+	if err := cfg.Unpack(&p.fieldConfig); err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		Type string `config:"type" validate:"required"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	convert, found := conversions[strings.ToLower(config.Type)]
+	if !found {
+		return nil, errors.Errorf("type [%v] not supported, cannot convert field", config.Type)
+	}
+	p.convert = convert
+	return p, nil
+}
+
+func (p *convertProcessor) run(d *document) error {
+	value, err := d.get(p.Field)
+	if err != nil || value == nil {
+		if p.IgnoreMissing {
+			return nil
+		}
+		return errors.Errorf("field [%v] not present as part of path [%v]", p.Field, p.Field)
+	}
+
+	var converted interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, x := range v {
+			if list[i], err = p.convert(toString(x)); err != nil {
+				return err
+			}
+		}
+		converted = list
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, x := range v {
+			if list[i], err = p.convert(x); err != nil {
+				return err
+			}
+		}
+		converted = list
+	default:
+		if converted, err = p.convert(toString(v)); err != nil {
+			return err
+		}
+	}
+	return d.put(p.target(), converted)
+}
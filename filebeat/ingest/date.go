@@ -0,0 +1,230 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// timestampLayout is the format used by Elasticsearch to write dates.
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+var iso8601 = regexp.MustCompile(`^([+-]?\d{4,9})(?:-(\d{1,2})(?:-(\d{1,2}))?)?` +
+	`(?:T(\d{1,2})(?::?(\d{2})(?::?(\d{2})(?:[.,](\d{1,9}))?)?)?)?` +
+	`(Z|[+-]\d{2}(?::?\d{2})?)?$`)
+
+func init() {
+	registerProcessor("date", newDate)
+}
+
+// dateParser parses a timestamp using the location as default time zone.
+type dateParser func(value string, loc *time.Location) (time.Time, error)
+
+type dateProcessor struct {
+	field    string
+	target   string
+	parsers  []dateParser
+	timezone *template
+}
+
+func newDate(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field       string   `config:"field" validate:"required"`
+		TargetField string   `config:"target_field"`
+		Formats     []string `config:"formats" validate:"required"`
+		Timezone    string   `config:"timezone"`
+		Locale      string   `config:"locale"`
+	}{
+		TargetField: timestampField,
+		Timezone:    "UTC",
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(config.Locale) {
+	case "", "en", "english", "root", "en_us", "en-us", "en_gb", "en-gb":
+	default:
+		return nil, errors.Errorf("locale [%v] is not supported, only English dates can be parsed", config.Locale)
+	}
+
+	p := &dateProcessor{
+		field:    config.Field,
+		target:   config.TargetField,
+		timezone: compileTemplate(config.Timezone),
+	}
+	for _, format := range config.Formats {
+		parser, err := newDateParser(format)
+		if err != nil {
+			return nil, err
+		}
+		p.parsers = append(p.parsers, parser)
+	}
+	return p, nil
+}
+
+func newDateParser(format string) (dateParser, error) {
+	switch format {
+	case "ISO8601":
+		return parseISO8601, nil
+	case "UNIX":
+		return parseUnix, nil
+	case "UNIX_MS":
+		return parseUnixMs, nil
+	case "TAI64N":
+		return parseTAI64N, nil
+	}
+
+	layout, err := compileJoda(format)
+	if err != nil {
+		return nil, err
+	}
+	return layout.parse, nil
+}
+
+func (p *dateProcessor) run(d *document) error {
+	value, err := d.get(p.field)
+	if err != nil {
+		return err
+	}
+	str := toString(value)
+
+	loc, err := loadLocation(p.timezone.render(d))
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, parse := range p.parsers {
+		t, err := parse(str, loc)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if p.target == timestampField {
+			return d.put(p.target, t.UTC())
+		}
+		return d.put(p.target, t.Format(timestampLayout))
+	}
+	return errors.Wrapf(lastErr, "unable to parse date [%v]", str)
+}
+
+// loadLocation resolves time zone IDs and offsets like '+01:00'.
+func loadLocation(tz string) (*time.Location, error) {
+	switch strings.ToUpper(tz) {
+	case "", "UTC", "Z", "GMT":
+		return time.UTC, nil
+	}
+
+	if tz[0] == '+' || tz[0] == '-' {
+		var f jodaFields
+		if rest, err := parseOffset(tz, &f); err == nil && rest == "" {
+			return f.loc, nil
+		}
+		return nil, errors.Errorf("invalid time zone offset [%v]", tz)
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid time zone [%v]", tz)
+	}
+	return loc, nil
+}
+
+func parseISO8601(value string, loc *time.Location) (time.Time, error) {
+	m := iso8601.FindStringSubmatch(value)
+	if m == nil {
+		return time.Time{}, errors.Errorf("invalid format: '%v' is not an ISO8601 date", value)
+	}
+
+	num := func(s string, def int) int {
+		if s == "" {
+			return def
+		}
+		v, _ := strconv.Atoi(s)
+		return v
+	}
+
+	nanos := 0
+	if m[7] != "" {
+		nanos = num((m[7] + "00000000")[:9], 0)
+	}
+
+	if m[8] != "" {
+		var f jodaFields
+		if _, err := parseOffset(m[8], &f); err != nil {
+			return time.Time{}, err
+		}
+		loc = f.loc
+	}
+
+	year, month, day := num(m[1], 0), num(m[2], 1), num(m[3], 1)
+	t := time.Date(year, time.Month(month), day, num(m[4], 0), num(m[5], 0), num(m[6], 0), nanos, loc)
+	if t.Day() != day || int(t.Month()) != month || t.Hour() != num(m[4], 0) {
+		return time.Time{}, errors.Errorf("cannot parse '%v': value out of range", value)
+	}
+	return t, nil
+}
+
+func parseUnix(value string, _ *time.Location) (time.Time, error) {
+	secs, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid UNIX timestamp '%v'", value)
+	}
+	ms := int64(secs * 1000)
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+}
+
+func parseUnixMs(value string, _ *time.Location) (time.Time, error) {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid UNIX_MS timestamp '%v'", value)
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+}
+
+func parseTAI64N(value string, _ *time.Location) (time.Time, error) {
+	value = strings.TrimPrefix(value, "@")
+	if len(value) != 24 {
+		return time.Time{}, errors.Errorf("invalid TAI64N timestamp '%v'", value)
+	}
+
+	// Seconds are offset by 2^62 and TAI is 10 seconds ahead of UTC.
+	secs, err := strconv.ParseUint(value[:16], 16, 64)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid TAI64N timestamp '%v'", value)
+	}
+	nanos, err := strconv.ParseUint(value[16:], 16, 32)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid TAI64N timestamp '%v'", value)
+	}
+	ms := int64(nanos) / int64(time.Millisecond)
+	return time.Unix(int64(secs-1<<62)-10, ms*int64(time.Millisecond)).UTC(), nil
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateFormats(t *testing.T) {
+	year := time.Now().Year()
+	plus2 := time.FixedZone("", 2*3600)
+
+	tests := []struct {
+		format   string
+		value    string
+		expected time.Time
+	}{
+		{"dd/MMM/YYYY:H:m:s Z", "07/Dec/2016:11:05:07 +0100", time.Date(2016, 12, 7, 10, 5, 7, 0, time.UTC)},
+		{"yyyy-MM-dd HH:mm:ss,SSS", "2018-03-12 10:55:01,123", time.Date(2018, 3, 12, 10, 55, 1, 123000000, time.UTC)},
+		{"yyyy-MM-dd'T'HH:mm:ss.SSSZZ", "2017-04-19T08:33:06.123-05:00", time.Date(2017, 4, 19, 13, 33, 6, 123000000, time.UTC)},
+		{"MMM  d HH:mm:ss", "Feb  3 04:05:06", time.Date(year, 2, 3, 4, 5, 6, 0, time.UTC)},
+		{"MMM dd HH:mm:ss", "Dec 12 18:59:34", time.Date(year, 12, 12, 18, 59, 34, 0, time.UTC)},
+		{"YYMMdd H:m:s", "170102 3:04:05", time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"EEE MMM dd H:m:s.SSSSSS YYYY", "Mon Sep 04 10:12:15.123456 2017", time.Date(2017, 9, 4, 10, 12, 15, 123456000, time.UTC)},
+		{"dd MMM H:m:s.SSS", "30 May 12:23:00.567", time.Date(year, 5, 30, 12, 23, 0, 567000000, time.UTC)},
+		{"YYYY/MM/dd H:m:s", "2017/10/06 7:30:01", time.Date(2017, 10, 6, 7, 30, 1, 0, time.UTC)},
+		{"yyyy-MM-dd HH:mm:ss Z", "2017-06-01 14:10:33 +0200", time.Date(2017, 6, 1, 12, 10, 33, 0, time.UTC)},
+		{"h:mm a", "3:07 PM", time.Date(year, 1, 1, 15, 7, 0, 0, time.UTC)},
+		{"ISO8601", "2017-12-31T23:59:59.999Z", time.Date(2017, 12, 31, 23, 59, 59, 999000000, time.UTC)},
+		{"ISO8601", "2017-12-31T23:59:59+02:00", time.Date(2017, 12, 31, 21, 59, 59, 0, time.UTC)},
+		{"ISO8601", "2017-12-31", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"UNIX", "1496330733.123", time.Date(2017, 6, 1, 15, 25, 33, 123000000, time.UTC)},
+		{"UNIX_MS", "1496330733123", time.Date(2017, 6, 1, 15, 25, 33, 123000000, time.UTC)},
+		{"TAI64N", "4000000050d506482dbdf024", time.Date(2012, 12, 22, 1, 0, 46, 767000000, time.UTC)},
+	}
+
+	for _, test := range tests {
+		parse, err := newDateParser(test.format)
+		require.NoError(t, err, test.format)
+
+		ts, err := parse(test.value, time.UTC)
+		if assert.NoError(t, err, test.format) {
+			assert.Equal(t, test.expected, ts.UTC(), test.format)
+		}
+	}
+
+	// The time zone is used when the value has no offset.
+	parse, err := newDateParser("yyyy-MM-dd HH:mm:ss")
+	require.NoError(t, err)
+	ts, err := parse("2018-01-02 03:04:05", plus2)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 1, 2, 1, 4, 5, 0, time.UTC), ts.UTC())
+}
+
+func TestDateFormatErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		value  string
+	}{
+		{"yyyy-MM-dd", "2018-02-30"},
+		{"yyyy-MM-dd", "2018-13-01"},
+		{"yyyy-MM-dd", "2018-01-01 trailing"},
+		{"HH:mm", "25:00"},
+		{"MMM dd", "Foo 01"},
+		{"ISO8601", "01/02/2018"},
+		{"UNIX_MS", "1.5"},
+	}
+
+	for _, test := range tests {
+		parse, err := newDateParser(test.format)
+		require.NoError(t, err, test.format)
+
+		_, err = parse(test.value, time.UTC)
+		assert.Error(t, err, "%v: %v", test.format, test.value)
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	for _, tz := range []string{"UTC", "+02:00", "-0530", "Europe/Amsterdam"} {
+		_, err := loadLocation(tz)
+		assert.NoError(t, err, tz)
+	}
+
+	_, err := loadLocation("+25:00")
+	assert.Error(t, err)
+}
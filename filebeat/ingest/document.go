@@ -0,0 +1,206 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const (
+	timestampField = "@timestamp"
+	ingestPrefix   = "_ingest."
+	sourcePrefix   = "_source."
+)
+
+// document is the view of an event used by the ingest processors. It maps
+// the field names used in ingest pipelines to the event. '@timestamp' is
+// the event timestamp and '_ingest.*' fields are the ingest metadata, which
+// are not part of the published event.
+//
+// Pipelines can remove '@timestamp', e.g. to rename it before parsing the
+// timestamp from the message. A published event always has a timestamp, so
+// removing it only hides it from the following processors and the event
+// keeps its original timestamp unless a new one is set.
+type document struct {
+	event            *beat.Event
+	ingest           common.MapStr
+	dropped          bool
+	timestampRemoved bool
+}
+
+func newDocument(event *beat.Event) *document {
+	if event.Fields == nil {
+		event.Fields = common.MapStr{}
+	}
+	return &document{
+		event: event,
+		ingest: common.MapStr{
+			"timestamp": time.Now().UTC(),
+		},
+	}
+}
+
+func (d *document) resolve(key string) (common.MapStr, string) {
+	if strings.HasPrefix(key, ingestPrefix) {
+		return d.ingest, key[len(ingestPrefix):]
+	}
+	return d.event.Fields, strings.TrimPrefix(key, sourcePrefix)
+}
+
+func (d *document) get(key string) (interface{}, error) {
+	if key == timestampField {
+		if d.timestampRemoved {
+			return nil, errors.Errorf("field [%v] not present as part of path [%v]", key, key)
+		}
+		return common.Time(d.event.Timestamp), nil
+	}
+
+	m, k := d.resolve(key)
+	v, err := m.GetValue(k)
+	if err != nil {
+		return nil, errors.Errorf("field [%v] not present as part of path [%v]", key, key)
+	}
+	return v, nil
+}
+
+func (d *document) has(key string) bool {
+	if key == timestampField {
+		return !d.timestampRemoved
+	}
+
+	m, k := d.resolve(key)
+	has, _ := m.HasKey(k)
+	return has
+}
+
+func (d *document) getString(key string) (string, error) {
+	v, err := d.get(key)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", errors.Errorf("field [%v] of type [%T] cannot be cast to [string]", key, v)
+	}
+	return s, nil
+}
+
+func (d *document) put(key string, value interface{}) error {
+	if key == timestampField {
+		return d.putTimestamp(value)
+	}
+
+	m, k := d.resolve(key)
+	_, err := m.Put(k, value)
+	return err
+}
+
+func (d *document) remove(key string) error {
+	if key == timestampField {
+		if d.timestampRemoved {
+			return errors.Errorf("field [%v] not present as part of path [%v]", key, key)
+		}
+		d.timestampRemoved = true
+		return nil
+	}
+
+	m, k := d.resolve(key)
+	if err := m.Delete(k); err != nil {
+		return errors.Errorf("field [%v] not present as part of path [%v]", key, key)
+	}
+	return nil
+}
+
+func (d *document) putTimestamp(value interface{}) error {
+	var ts time.Time
+	switch v := value.(type) {
+	case time.Time:
+		ts = v
+	case common.Time:
+		ts = time.Time(v)
+	case string:
+		t, err := parseISO8601(v, time.UTC)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for field [%v]", timestampField)
+		}
+		ts = t
+	default:
+		return errors.Errorf("field [%v] of type [%T] cannot be cast to a timestamp", timestampField, value)
+	}
+
+	d.event.Timestamp = ts.UTC()
+	d.timestampRemoved = false
+	return nil
+}
+
+// append adds values to the field, converting an existing scalar value to
+// a list.
+func (d *document) append(key string, values ...interface{}) error {
+	var list []interface{}
+	if old, err := d.get(key); err == nil {
+		switch v := old.(type) {
+		case []interface{}:
+			list = v
+		case []string:
+			for _, s := range v {
+				list = append(list, s)
+			}
+		default:
+			list = []interface{}{v}
+		}
+	}
+	return d.put(key, append(list, values...))
+}
+
+// normalizeValue converts nested objects into common.MapStr so that nested
+// keys can be accessed with dotted paths.
+func normalizeValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(common.MapStr, len(val))
+		for k, x := range val {
+			m[k] = normalizeValue(x)
+		}
+		return m
+	case common.MapStr:
+		for k, x := range val {
+			val[k] = normalizeValue(x)
+		}
+		return val
+	case []interface{}:
+		for i, x := range val {
+			val[i] = normalizeValue(x)
+		}
+		return val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	default:
+		return v
+	}
+}
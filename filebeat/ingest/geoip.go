@@ -0,0 +1,269 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/oschwald/geoip2-golang"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+)
+
+const defaultGeoIPDatabase = "GeoLite2-City.mmdb"
+
+var (
+	defaultCityProperties = []string{"continent_name", "country_iso_code",
+		"region_iso_code", "region_name", "city_name", "location"}
+	defaultCountryProperties = []string{"continent_name", "country_iso_code"}
+	defaultASNProperties     = []string{"ip", "asn", "organization_name"}
+)
+
+func init() {
+	registerProcessor("geoip", newGeoIP)
+}
+
+// geoipDatabases opens the MaxMind databases referenced by the pipelines.
+// Databases are shared by all pipelines of a registry.
+type geoipDatabases struct {
+	dir string
+	log *logp.Logger
+
+	mu      sync.Mutex
+	readers map[string]*geoip2.Reader
+}
+
+func newGeoIPDatabases(dir string, log *logp.Logger) *geoipDatabases {
+	return &geoipDatabases{
+		dir:     paths.Resolve(paths.Config, dir),
+		log:     log,
+		readers: map[string]*geoip2.Reader{},
+	}
+}
+
+func (g *geoipDatabases) open(name string) (*geoip2.Reader, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if r, found := g.readers[name]; found {
+		return r, nil
+	}
+
+	// The database is read into memory instead of being memory mapped, so
+	// that closing the registry cannot invalidate lookups still in flight.
+	path := filepath.Join(g.dir, filepath.Base(name))
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open GeoIP database %v", path)
+	}
+	r, err := geoip2.FromBytes(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open GeoIP database %v", path)
+	}
+	g.log.Infof("Loaded GeoIP database %v (%v)", path, r.Metadata().DatabaseType)
+	g.readers[name] = r
+	return r, nil
+}
+
+func (g *geoipDatabases) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for name, r := range g.readers {
+		r.Close()
+		delete(g.readers, name)
+	}
+}
+
+type geoipProcessor struct {
+	field         string
+	target        string
+	ignoreMissing bool
+	properties    map[string]bool
+	lookup        func(ip net.IP) (common.MapStr, error)
+}
+
+func newGeoIP(cfg *common.Config, ctx *compileContext) (processor, error) {
+	config := struct {
+		Field         string   `config:"field" validate:"required"`
+		TargetField   string   `config:"target_field"`
+		DatabaseFile  string   `config:"database_file"`
+		Properties    []string `config:"properties"`
+		IgnoreMissing bool     `config:"ignore_missing"`
+	}{
+		TargetField:  "geoip",
+		DatabaseFile: defaultGeoIPDatabase,
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	reader, err := ctx.geoip.open(config.DatabaseFile)
+	if err != nil {
+		ctx.log.Warnf("The geoip processor of pipeline %v is disabled: %v", ctx.pipeline, err)
+		return nil, nil
+	}
+
+	p := &geoipProcessor{
+		field:         config.Field,
+		target:        config.TargetField,
+		ignoreMissing: config.IgnoreMissing,
+	}
+
+	var defaults []string
+	dbType := reader.Metadata().DatabaseType
+	switch {
+	case strings.HasSuffix(dbType, "City"):
+		defaults = defaultCityProperties
+		p.lookup = func(ip net.IP) (common.MapStr, error) {
+			city, err := reader.City(ip)
+			if err != nil {
+				return nil, err
+			}
+			return cityFields(ip, city), nil
+		}
+	case strings.HasSuffix(dbType, "Country"):
+		defaults = defaultCountryProperties
+		p.lookup = func(ip net.IP) (common.MapStr, error) {
+			country, err := reader.Country(ip)
+			if err != nil {
+				return nil, err
+			}
+			return countryFields(ip, country), nil
+		}
+	case strings.HasSuffix(dbType, "ASN"):
+		defaults = defaultASNProperties
+		p.lookup = func(ip net.IP) (common.MapStr, error) {
+			asn, err := reader.ASN(ip)
+			if err != nil {
+				return nil, err
+			}
+			return asnFields(ip, asn), nil
+		}
+	default:
+		return nil, errors.Errorf("unsupported database type [%v] for file [%v]", dbType, config.DatabaseFile)
+	}
+
+	if len(config.Properties) == 0 {
+		config.Properties = defaults
+	}
+	p.properties = map[string]bool{}
+	for _, name := range config.Properties {
+		p.properties[strings.ToLower(name)] = true
+	}
+	return p, nil
+}
+
+func (p *geoipProcessor) run(d *document) error {
+	value, err := d.get(p.field)
+	if err != nil || value == nil {
+		if p.ignoreMissing {
+			return nil
+		}
+		return errors.Errorf("field [%v] is null, cannot extract geoip information.", p.field)
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return errors.Errorf("field [%v] of type [%T] cannot be cast to [string]", p.field, value)
+	}
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return errors.Errorf("'%v' is not an IP string literal.", str)
+	}
+
+	fields, err := p.lookup(ip)
+	if err != nil {
+		return err
+	}
+
+	geo := common.MapStr{}
+	for k, v := range fields {
+		if p.properties[k] {
+			geo[k] = v
+		}
+	}
+	if len(geo) == 0 {
+		// The address was not found in the database.
+		return nil
+	}
+	return d.put(p.target, geo)
+}
+
+// putString adds the value only if it is not empty.
+func putString(m common.MapStr, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func cityFields(ip net.IP, city *geoip2.City) common.MapStr {
+	m := common.MapStr{}
+	putString(m, "continent_name", city.Continent.Names["en"])
+	putString(m, "country_iso_code", city.Country.IsoCode)
+	putString(m, "country_name", city.Country.Names["en"])
+	putString(m, "city_name", city.City.Names["en"])
+	putString(m, "timezone", city.Location.TimeZone)
+	if len(city.Subdivisions) > 0 {
+		sub := city.Subdivisions[0]
+		putString(m, "region_name", sub.Names["en"])
+		if sub.IsoCode != "" && city.Country.IsoCode != "" {
+			m["region_iso_code"] = city.Country.IsoCode + "-" + sub.IsoCode
+		}
+	}
+	if city.Location.Latitude != 0 || city.Location.Longitude != 0 {
+		m["location"] = common.MapStr{
+			"lat": city.Location.Latitude,
+			"lon": city.Location.Longitude,
+		}
+	}
+	if len(m) > 0 {
+		m["ip"] = ip.String()
+	}
+	return m
+}
+
+func countryFields(ip net.IP, country *geoip2.Country) common.MapStr {
+	m := common.MapStr{}
+	putString(m, "continent_name", country.Continent.Names["en"])
+	putString(m, "country_iso_code", country.Country.IsoCode)
+	putString(m, "country_name", country.Country.Names["en"])
+	if len(m) > 0 {
+		m["ip"] = ip.String()
+	}
+	return m
+}
+
+func asnFields(ip net.IP, asn *geoip2.ASN) common.MapStr {
+	m := common.MapStr{}
+	if asn.AutonomousSystemNumber != 0 {
+		m["asn"] = int64(asn.AutonomousSystemNumber)
+	}
+	putString(m, "organization_name", asn.AutonomousSystemOrganization)
+	if len(m) > 0 {
+		m["ip"] = ip.String()
+	}
+	return m
+}
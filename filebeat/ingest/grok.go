@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/grok"
+)
+
+// grokMatchTimeout protects against patterns backtracking catastrophically.
+const grokMatchTimeout = time.Second
+
+func init() {
+	registerProcessor("grok", newGrok)
+}
+
+type grokProcessor struct {
+	field         string
+	patterns      []*grok.Grok
+	traceMatch    bool
+	ignoreMissing bool
+}
+
+func newGrok(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field              string            `config:"field" validate:"required"`
+		Patterns           []string          `config:"patterns"`
+		PatternDefinitions map[string]string `config:"pattern_definitions"`
+		TraceMatch         bool              `config:"trace_match"`
+		IgnoreMissing      bool              `config:"ignore_missing"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+	if len(config.Patterns) == 0 {
+		return nil, errors.New("[patterns] List of patterns must not be empty")
+	}
+
+	p := &grokProcessor{
+		field:         config.Field,
+		traceMatch:    config.TraceMatch,
+		ignoreMissing: config.IgnoreMissing,
+	}
+	for _, pattern := range config.Patterns {
+		g, err := grok.Compile(pattern, config.PatternDefinitions)
+		if err != nil {
+			return nil, err
+		}
+		g.SetMatchTimeout(grokMatchTimeout)
+		p.patterns = append(p.patterns, g)
+	}
+	return p, nil
+}
+
+func (p *grokProcessor) run(d *document) error {
+	if p.ignoreMissing && !d.has(p.field) {
+		return nil
+	}
+
+	value, err := d.getString(p.field)
+	if err != nil {
+		return err
+	}
+
+	for i, g := range p.patterns {
+		captures, err := g.Match(value)
+		if err != nil {
+			return err
+		}
+		if captures == nil {
+			continue
+		}
+
+		for field, v := range captures {
+			if err := d.put(field, v); err != nil {
+				return err
+			}
+		}
+		if p.traceMatch && len(p.patterns) > 1 {
+			d.ingest["_grok_match_index"] = i
+		}
+		return nil
+	}
+
+	return errors.Errorf("Provided Grok expressions do not match field value: [%v]", value)
+}
@@ -0,0 +1,430 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// jodaLayout is a parser for the Joda-Time patterns used by the date
+// processor (e.g. 'dd/MMM/yyyy:HH:mm:ss Z'). Only parsing is supported and
+// text fields (months, days of the week, AM/PM) are matched in English.
+type jodaLayout struct {
+	pattern string
+	elems   []jodaElem
+}
+
+type jodaElem struct {
+	letter  byte   // pattern letter, 0 for literals
+	count   int    // number of repetitions of the letter
+	literal string // text that must match for literals
+	fixed   bool   // numeric value has exactly count digits
+}
+
+type jodaFields struct {
+	year, month, day, yearDay   int
+	hour, minute, second, nanos int
+	pm, hasYear, hasPM          bool
+	loc                         *time.Location
+}
+
+var (
+	monthNames = []string{"january", "february", "march", "april", "may", "june",
+		"july", "august", "september", "october", "november", "december"}
+	dayNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday",
+		"saturday", "sunday"}
+)
+
+func isNumericLetter(letter byte, count int) bool {
+	switch letter {
+	case 'y', 'Y', 'u', 'x', 'd', 'D', 'H', 'h', 'k', 'K', 'm', 's', 'S':
+		return true
+	case 'M':
+		return count < 3
+	}
+	return false
+}
+
+func compileJoda(pattern string) (*jodaLayout, error) {
+	layout := &jodaLayout{pattern: pattern}
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			end := i + 1
+			var lit strings.Builder
+			for {
+				if end >= len(pattern) {
+					return nil, errors.Errorf("unterminated quote in date format '%v'", pattern)
+				}
+				if pattern[end] == '\'' {
+					if end+1 < len(pattern) && pattern[end+1] == '\'' {
+						lit.WriteByte('\'')
+						end += 2
+						continue
+					}
+					break
+				}
+				lit.WriteByte(pattern[end])
+				end++
+			}
+			if end == i+1 {
+				lit.WriteByte('\'')
+			}
+			layout.elems = append(layout.elems, jodaElem{literal: lit.String()})
+			i = end + 1
+
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == c {
+				n++
+			}
+			if !strings.ContainsRune("yYuxMdDHhkKmsSEeaZz", rune(c)) {
+				return nil, errors.Errorf("unsupported pattern letter '%c' in date format '%v'", c, pattern)
+			}
+			layout.elems = append(layout.elems, jodaElem{letter: c, count: n})
+			i += n
+
+		default:
+			layout.elems = append(layout.elems, jodaElem{literal: string(c)})
+			i++
+		}
+	}
+
+	// Numeric fields followed by another numeric field have a fixed width,
+	// e.g. 'yyyyMMdd'.
+	for i := 0; i+1 < len(layout.elems); i++ {
+		cur, next := &layout.elems[i], layout.elems[i+1]
+		if cur.letter != 0 && isNumericLetter(cur.letter, cur.count) &&
+			next.letter != 0 && isNumericLetter(next.letter, next.count) {
+			cur.fixed = true
+		}
+	}
+	return layout, nil
+}
+
+// parse parses value. Fields missing from the layout default to the start
+// of the current year in loc.
+func (l *jodaLayout) parse(value string, loc *time.Location) (time.Time, error) {
+	f := jodaFields{month: 1, day: 1, loc: loc}
+	s := value
+
+	fail := func() (time.Time, error) {
+		return time.Time{}, errors.Errorf("invalid format: '%v' does not match '%v'", value, l.pattern)
+	}
+
+	for _, e := range l.elems {
+		if e.letter == 0 {
+			if !strings.HasPrefix(s, e.literal) {
+				return fail()
+			}
+			s = s[len(e.literal):]
+			continue
+		}
+
+		var err error
+		if s, err = l.parseElem(e, s, &f); err != nil {
+			return time.Time{}, errors.Wrapf(err, "invalid format: '%v' does not match '%v'", value, l.pattern)
+		}
+	}
+	if s != "" {
+		return fail()
+	}
+
+	if !f.hasYear {
+		f.year = time.Now().In(f.loc).Year()
+	}
+	if f.hasPM {
+		f.hour = f.hour % 12
+		if f.pm {
+			f.hour += 12
+		}
+	}
+
+	if f.yearDay > 0 {
+		t := time.Date(f.year, time.January, f.yearDay, f.hour, f.minute, f.second, f.nanos, f.loc)
+		if t.Year() != f.year {
+			return time.Time{}, errors.Errorf("cannot parse '%v': value out of range", value)
+		}
+		return t, nil
+	}
+
+	t := time.Date(f.year, time.Month(f.month), f.day, f.hour, f.minute, f.second, f.nanos, f.loc)
+	if t.Day() != f.day || int(t.Month()) != f.month {
+		return time.Time{}, errors.Errorf("cannot parse '%v': value out of range", value)
+	}
+	return t, nil
+}
+
+func (l *jodaLayout) parseElem(e jodaElem, s string, f *jodaFields) (string, error) {
+	number := func(maxDigits int) (int, error) {
+		min, max := 1, maxDigits
+		if e.fixed {
+			min, max = e.count, e.count
+		}
+		v, rest, ok := parseDigits(s, min, max)
+		if !ok {
+			return 0, errors.Errorf("expected number at '%v'", s)
+		}
+		s = rest
+		return v, nil
+	}
+	inRange := func(v, min, max int) error {
+		if v < min || v > max {
+			return errors.Errorf("value %v for '%c' must be in the range [%v,%v]", v, e.letter, min, max)
+		}
+		return nil
+	}
+
+	var (
+		v   int
+		err error
+	)
+	switch e.letter {
+	case 'y', 'Y', 'u', 'x':
+		if e.count == 2 {
+			var ok bool
+			if v, s, ok = parseDigits(s, 2, 2); !ok {
+				return s, errors.Errorf("expected two digit year at '%v'", s)
+			}
+			f.year = pivotYear(v)
+		} else {
+			if v, err = number(9); err != nil {
+				return s, err
+			}
+			f.year = v
+		}
+		f.hasYear = true
+
+	case 'M':
+		if e.count >= 3 {
+			if v, s, err = parseName(s, monthNames); err != nil {
+				return s, err
+			}
+			f.month = v + 1
+		} else {
+			if v, err = number(2); err != nil {
+				return s, err
+			}
+			if err = inRange(v, 1, 12); err != nil {
+				return s, err
+			}
+			f.month = v
+		}
+
+	case 'd':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		if err = inRange(v, 1, 31); err != nil {
+			return s, err
+		}
+		f.day = v
+
+	case 'D':
+		if v, err = number(3); err != nil {
+			return s, err
+		}
+		if err = inRange(v, 1, 366); err != nil {
+			return s, err
+		}
+		f.yearDay = v
+
+	case 'H':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		f.hour, err = v, inRange(v, 0, 23)
+
+	case 'k':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		f.hour, err = v%24, inRange(v, 1, 24)
+
+	case 'h':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		f.hour, err = v, inRange(v, 1, 12)
+
+	case 'K':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		f.hour, err = v, inRange(v, 0, 11)
+
+	case 'm':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		f.minute, err = v, inRange(v, 0, 59)
+
+	case 's':
+		if v, err = number(2); err != nil {
+			return s, err
+		}
+		f.second, err = v, inRange(v, 0, 59)
+
+	case 'S':
+		n := 0
+		for n < len(s) && n < 9 && s[n] >= '0' && s[n] <= '9' && (n < e.count || !e.fixed) {
+			n++
+		}
+		if n == 0 {
+			return s, errors.Errorf("expected fraction of second at '%v'", s)
+		}
+		v, _, _ = parseDigits(s[:n], n, n)
+		for i := n; i < 9; i++ {
+			v *= 10
+		}
+		f.nanos, s = v, s[n:]
+
+	case 'E', 'e':
+		if e.letter == 'e' {
+			_, err = number(1)
+		} else {
+			_, s, err = parseName(s, dayNames)
+		}
+
+	case 'a':
+		switch {
+		case len(s) >= 2 && strings.EqualFold(s[:2], "am"):
+			f.pm = false
+		case len(s) >= 2 && strings.EqualFold(s[:2], "pm"):
+			f.pm = true
+		default:
+			return s, errors.Errorf("expected AM/PM at '%v'", s)
+		}
+		f.hasPM, s = true, s[2:]
+
+	case 'Z':
+		if e.count >= 3 {
+			s, err = parseZoneID(s, f)
+		} else {
+			s, err = parseOffset(s, f)
+		}
+
+	case 'z':
+		n := 0
+		for n < len(s) && ((s[n] >= 'A' && s[n] <= 'Z') || (s[n] >= 'a' && s[n] <= 'z')) {
+			n++
+		}
+		switch strings.ToUpper(s[:n]) {
+		case "UTC", "GMT", "Z":
+			f.loc = time.UTC
+		default:
+			return s, errors.Errorf("time zone name '%v' is not supported", s[:n])
+		}
+		s = s[n:]
+	}
+	return s, err
+}
+
+// parseDigits parses between min and max decimal digits from the start of s.
+func parseDigits(s string, min, max int) (int, string, bool) {
+	n, v := 0, 0
+	for n < len(s) && n < max && s[n] >= '0' && s[n] <= '9' {
+		v = v*10 + int(s[n]-'0')
+		n++
+	}
+	if n < min {
+		return 0, s, false
+	}
+	return v, s[n:], true
+}
+
+// parseName matches the full or three letter abbreviated name at the start
+// of s, ignoring case. It returns the index of the name.
+func parseName(s string, names []string) (int, string, error) {
+	lower := strings.ToLower(s)
+	for i, name := range names {
+		if strings.HasPrefix(lower, name) {
+			return i, s[len(name):], nil
+		}
+	}
+	for i, name := range names {
+		if strings.HasPrefix(lower, name[:3]) {
+			return i, s[3:], nil
+		}
+	}
+	return 0, s, errors.Errorf("unknown name at '%v'", s)
+}
+
+// parseOffset parses a UTC offset like 'Z', '+01', '+0100' or '+01:00'.
+func parseOffset(s string, f *jodaFields) (string, error) {
+	if strings.HasPrefix(s, "Z") {
+		f.loc = time.UTC
+		return s[1:], nil
+	}
+	if s == "" || (s[0] != '+' && s[0] != '-') {
+		return s, errors.Errorf("expected time zone offset at '%v'", s)
+	}
+
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	hours, rest, ok := parseDigits(s[1:], 2, 2)
+	if !ok || hours > 23 {
+		return s, errors.Errorf("invalid time zone offset at '%v'", s)
+	}
+
+	var minutes int
+	if strings.HasPrefix(rest, ":") {
+		rest = rest[1:]
+	}
+	if m, r, ok := parseDigits(rest, 2, 2); ok {
+		minutes, rest = m, r
+	}
+
+	offset := sign * (hours*3600 + minutes*60)
+	f.loc = time.FixedZone("", offset)
+	return rest, nil
+}
+
+func parseZoneID(s string, f *jodaFields) (string, error) {
+	n := 0
+	for n < len(s) && strings.IndexByte(" ,;]\"'", s[n]) < 0 {
+		n++
+	}
+	loc, err := loadLocation(s[:n])
+	if err != nil {
+		return s, err
+	}
+	f.loc = loc
+	return s[n:], nil
+}
+
+// pivotYear resolves a two digit year to the century closest to the
+// current year.
+func pivotYear(yy int) int {
+	now := time.Now().Year()
+	year := now - now%100 + yy
+	switch {
+	case year > now+50:
+		year -= 100
+	case year <= now-50:
+		year += 100
+	}
+	return year
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// moduleDirective matches the template directives used in module pipelines,
+// like the references to other pipelines of the fileset.
+var moduleDirective = regexp.MustCompile(`{<[^>]*>}`)
+
+func loadModulePipeline(t *testing.T, path string) map[string]interface{} {
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(moduleDirective.ReplaceAllString(string(raw), "")), &content), path)
+	return content
+}
+
+func TestModulePipelinesCompile(t *testing.T) {
+	paths, err := filepath.Glob("../module/*/*/ingest/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		assert.NoError(t, newTestRegistry().Register("test", loadModulePipeline(t, path)), path)
+	}
+}
+
+func TestModulePipelineMongoDB(t *testing.T) {
+	r := newTestRegistry()
+	require.NoError(t, r.Register("test", loadModulePipeline(t, "../module/mongodb/log/ingest/pipeline.json")))
+	p := r.Get("test")
+
+	logFile := "../module/mongodb/log/test/mongodb-debian-3.2.11.log"
+	raw, err := ioutil.ReadFile(logFile + "-expected.json")
+	require.NoError(t, err)
+	var expected []common.MapStr
+	require.NoError(t, json.Unmarshal(raw, &expected))
+
+	f, err := os.Open(logFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	i := 0
+	for scanner := bufio.NewScanner(f); scanner.Scan(); i++ {
+		require.True(t, i < len(expected))
+
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": scanner.Text()}})
+		require.NoError(t, err)
+
+		fields := event.Fields.Flatten()
+		for key, value := range expected[i] {
+			switch {
+			case key == "@timestamp":
+				assert.Equal(t, value, formatTimestamp(event.Timestamp))
+			case strings.HasPrefix(key, "mongodb."):
+				assert.Equal(t, value, fields[key], key)
+			}
+		}
+	}
+	assert.Equal(t, len(expected), i)
+}
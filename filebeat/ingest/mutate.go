@@ -0,0 +1,251 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func init() {
+	registerProcessor("set", newSet)
+	registerProcessor("remove", newRemove)
+	registerProcessor("rename", newRename)
+	registerProcessor("append", newAppend)
+	registerProcessor("lowercase", newStringTransform(strings.ToLower))
+	registerProcessor("uppercase", newStringTransform(strings.ToUpper))
+	registerProcessor("trim", newStringTransform(strings.TrimSpace))
+	registerProcessor("fail", newFail)
+	registerProcessor("drop", newDrop)
+}
+
+type setProcessor struct {
+	field    *template
+	value    interface{}
+	override bool
+}
+
+func newSet(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field    string      `config:"field" validate:"required"`
+		Value    interface{} `config:"value" validate:"required"`
+		Override bool        `config:"override"`
+	}{
+		Override: true,
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	return &setProcessor{
+		field:    compileTemplate(config.Field),
+		value:    compileValue(config.Value),
+		override: config.Override,
+	}, nil
+}
+
+func (p *setProcessor) run(d *document) error {
+	field := p.field.render(d)
+	if !p.override && d.has(field) {
+		return nil
+	}
+	return d.put(field, renderValue(p.value, d))
+}
+
+type removeProcessor struct {
+	fields        []*template
+	ignoreMissing bool
+}
+
+func newRemove(cfg *common.Config, _ *compileContext) (processor, error) {
+	fields, err := fieldNames(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		IgnoreMissing bool `config:"ignore_missing"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	p := &removeProcessor{ignoreMissing: config.IgnoreMissing}
+	for _, f := range fields {
+		p.fields = append(p.fields, compileTemplate(f))
+	}
+	return p, nil
+}
+
+func (p *removeProcessor) run(d *document) error {
+	for _, f := range p.fields {
+		field := f.render(d)
+		if p.ignoreMissing && !d.has(field) {
+			continue
+		}
+		if err := d.remove(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type renameProcessor struct {
+	field         *template
+	target        *template
+	ignoreMissing bool
+}
+
+func newRename(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field         string `config:"field" validate:"required"`
+		TargetField   string `config:"target_field" validate:"required"`
+		IgnoreMissing bool   `config:"ignore_missing"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	return &renameProcessor{
+		field:         compileTemplate(config.Field),
+		target:        compileTemplate(config.TargetField),
+		ignoreMissing: config.IgnoreMissing,
+	}, nil
+}
+
+func (p *renameProcessor) run(d *document) error {
+	field, target := p.field.render(d), p.target.render(d)
+
+	value, err := d.get(field)
+	if err != nil {
+		if p.ignoreMissing {
+			return nil
+		}
+		return errors.Errorf("field [%v] doesn't exist", field)
+	}
+	if d.has(target) {
+		return errors.Errorf("field [%v] already exists", target)
+	}
+
+	if err := d.remove(field); err != nil {
+		return err
+	}
+	return d.put(target, value)
+}
+
+type appendProcessor struct {
+	field *template
+	value interface{}
+}
+
+func newAppend(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field string      `config:"field" validate:"required"`
+		Value interface{} `config:"value" validate:"required"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	return &appendProcessor{
+		field: compileTemplate(config.Field),
+		value: compileValue(config.Value),
+	}, nil
+}
+
+func (p *appendProcessor) run(d *document) error {
+	value := renderValue(p.value, d)
+	if list, ok := value.([]interface{}); ok {
+		return d.append(p.field.render(d), list...)
+	}
+	return d.append(p.field.render(d), value)
+}
+
+// fieldConfig is the configuration shared by processors transforming the
+// value of a single field.
+type fieldConfig struct {
+	Field         string `config:"field" validate:"required"`
+	TargetField   string `config:"target_field"`
+	IgnoreMissing bool   `config:"ignore_missing"`
+}
+
+func (c *fieldConfig) target() string {
+	if c.TargetField == "" {
+		return c.Field
+	}
+	return c.TargetField
+}
+
+type stringTransformProcessor struct {
+	fieldConfig
+	transform func(string) string
+}
+
+func newStringTransform(transform func(string) string) constructor {
+	return func(cfg *common.Config, _ *compileContext) (processor, error) {
+		p := &stringTransformProcessor{transform: transform}
+		if err := cfg.Unpack(&p.fieldConfig); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+}
+
+func (p *stringTransformProcessor) run(d *document) error {
+	if p.IgnoreMissing && !d.has(p.Field) {
+		return nil
+	}
+
+	value, err := d.getString(p.Field)
+	if err != nil {
+		return err
+	}
+	return d.put(p.target(), p.transform(value))
+}
+
+type failProcessor struct {
+	message *template
+}
+
+func newFail(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Message string `config:"message" validate:"required"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+	return &failProcessor{message: compileTemplate(config.Message)}, nil
+}
+
+func (p *failProcessor) run(d *document) error {
+	return errors.New(p.message.render(d))
+}
+
+type dropProcessor struct{}
+
+func newDrop(_ *common.Config, _ *compileContext) (processor, error) {
+	return dropProcessor{}, nil
+}
+
+func (dropProcessor) run(d *document) error {
+	d.dropped = true
+	return nil
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ingest executes Elasticsearch Ingest Node pipelines inside
+// Filebeat. It allows the pipelines of the Filebeat modules to be used with
+// any output.
+package ingest
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// Pipeline is a compiled ingest pipeline.
+type Pipeline struct {
+	id          string
+	description string
+	content     map[string]interface{}
+	steps       []*step
+	onFailure   []*step
+	log         *logp.Logger
+}
+
+type pipelineConfig struct {
+	Description string        `config:"description"`
+	Processors  []interface{} `config:"processors"`
+	OnFailure   []interface{} `config:"on_failure"`
+}
+
+func compilePipeline(id string, content map[string]interface{}, ctx *compileContext) (*Pipeline, error) {
+	cfg, err := common.NewConfigFrom(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var config pipelineConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+	if config.Processors == nil {
+		return nil, errors.New("[processors] required property is missing")
+	}
+
+	ctx.pipeline = id
+	steps, err := compileSteps(config.Processors, ctx)
+	if err != nil {
+		return nil, err
+	}
+	onFailure, err := compileSteps(config.OnFailure, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipeline{
+		id:          id,
+		description: config.Description,
+		content:     content,
+		steps:       steps,
+		onFailure:   onFailure,
+		log:         ctx.log,
+	}, nil
+}
+
+// ID returns the pipeline ID.
+func (p *Pipeline) ID() string {
+	return p.id
+}
+
+// Run executes the pipeline. Like in Elasticsearch, events that fail
+// processing without an on_failure handler are dropped.
+func (p *Pipeline) Run(event *beat.Event) (*beat.Event, error) {
+	d := newDocument(event)
+
+	err := runSteps(p.steps, d)
+	if perr, ok := err.(*processorError); ok && len(p.onFailure) > 0 {
+		err = runOnFailure(p.onFailure, d, perr)
+	}
+	if err != nil {
+		p.log.Warnf("Dropping event, pipeline %v failed: %v", p.id, err)
+		return nil, errors.Wrapf(err, "pipeline %v failed", p.id)
+	}
+
+	if d.dropped {
+		return nil, nil
+	}
+	return event, nil
+}
+
+func (p *Pipeline) String() string {
+	return fmt.Sprintf("ingest_pipeline=[id=%v]", p.id)
+}
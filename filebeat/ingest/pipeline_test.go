@@ -0,0 +1,370 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func newTestRegistry() *Registry {
+	return NewRegistry(Config{GeoIP: GeoIPConfig{DatabaseDir: "testdata"}})
+}
+
+func compileTestPipeline(t *testing.T, definition string) *Pipeline {
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(definition), &content))
+
+	r := newTestRegistry()
+	require.NoError(t, r.Register("test", content))
+	return r.Get("test")
+}
+
+func runTestPipeline(t *testing.T, definition string, fields common.MapStr) *beat.Event {
+	p := compileTestPipeline(t, definition)
+	evt, _ := p.Run(&beat.Event{Fields: fields})
+	return evt
+}
+
+func TestPipelineGrokDateAndRemove(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"description": "test",
+		"processors": [
+			{"grok": {
+				"field": "message",
+				"patterns": ["%{IPORHOST:web.remote_ip} - \\[%{HTTPDATE:web.time}\\] \"%{WORD:web.method} %{DATA:web.url}\" %{NUMBER:web.code:int}"]
+			}},
+			{"remove": {"field": "message"}},
+			{"date": {"field": "web.time", "formats": ["dd/MMM/YYYY:H:m:s Z"]}},
+			{"remove": {"field": "web.time"}}
+		]
+	}`, common.MapStr{
+		"message": `10.0.0.2 - [07/Dec/2016:11:05:07 +0100] "GET /ocelot"  200`,
+	})
+	require.Nil(t, evt)
+
+	evt = runTestPipeline(t, `{
+		"processors": [
+			{"grok": {
+				"field": "message",
+				"patterns": ["%{IPORHOST:web.remote_ip} - \\[%{HTTPDATE:web.time}\\] \"%{WORD:web.method} %{DATA:web.url}\" %{NUMBER:web.code:int}"]
+			}},
+			{"remove": {"field": "message"}},
+			{"date": {"field": "web.time", "formats": ["dd/MMM/YYYY:H:m:s Z"]}},
+			{"remove": {"field": "web.time"}}
+		]
+	}`, common.MapStr{
+		"message": `10.0.0.2 - [07/Dec/2016:11:05:07 +0100] "GET /ocelot" 200`,
+	})
+	require.NotNil(t, evt)
+
+	assert.Equal(t, time.Date(2016, 12, 7, 10, 5, 7, 0, time.UTC), evt.Timestamp)
+	assert.Equal(t, common.MapStr{
+		"web": common.MapStr{
+			"remote_ip": "10.0.0.2",
+			"method":    "GET",
+			"url":       "/ocelot",
+			"code":      200,
+		},
+	}, evt.Fields)
+}
+
+func TestPipelineOnFailure(t *testing.T) {
+	definition := `{
+		"processors": [
+			{"rename": {"field": "a", "target_field": "b"}},
+			{"set": {"field": "done", "value": true}}
+		],
+		"on_failure": [
+			{"set": {"field": "error.message", "value": "{{ _ingest.on_failure_message }}"}}
+		]
+	}`
+
+	evt := runTestPipeline(t, definition, common.MapStr{"a": 1})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{"b": 1, "done": true}, evt.Fields)
+
+	evt = runTestPipeline(t, definition, common.MapStr{"c": 1})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{
+		"c":     1,
+		"error": common.MapStr{"message": "field [a] doesn't exist"},
+	}, evt.Fields)
+}
+
+func TestProcessorOnFailureAndIgnoreFailure(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"convert": {"field": "n", "type": "integer", "ignore_failure": true}},
+			{"grok": {
+				"field": "msg",
+				"patterns": ["^%{NUMBER:num}$"],
+				"tag": "number",
+				"on_failure": [
+					{"set": {"field": "failed", "value": "{{ _ingest.on_failure_processor_type }}/{{ _ingest.on_failure_processor_tag }}"}}
+				]
+			}},
+			{"set": {"field": "done", "value": true}}
+		]
+	}`, common.MapStr{"n": "x", "msg": "abc"})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{
+		"n":      "x",
+		"msg":    "abc",
+		"failed": "grok/number",
+		"done":   true,
+	}, evt.Fields)
+}
+
+func TestMutateProcessors(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"set": {"field": "service.name", "value": "{{ svc }}-{{ missing }}"}},
+			{"set": {"field": "svc", "value": "other", "override": false}},
+			{"append": {"field": "tags", "value": ["b", "{{ svc }}"]}},
+			{"lowercase": {"field": "up"}},
+			{"uppercase": {"field": "up", "target_field": "upper"}},
+			{"trim": {"field": "space"}},
+			{"remove": {"field": ["x", "y"], "ignore_missing": true}},
+			{"rename": {"field": "old", "target_field": "new.name"}}
+		]
+	}`, common.MapStr{
+		"svc":   "web",
+		"tags":  "a",
+		"up":    "MiXeD",
+		"space": "  text ",
+		"x":     1,
+		"old":   "value",
+	})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{
+		"svc":     "web",
+		"service": common.MapStr{"name": "web-"},
+		"tags":    []interface{}{"a", "b", "web"},
+		"up":      "mixed",
+		"upper":   "MIXED",
+		"space":   "text",
+		"new":     common.MapStr{"name": "value"},
+	}, evt.Fields)
+}
+
+func TestConvertProcessor(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"convert": {"field": "i", "type": "integer"}},
+			{"convert": {"field": "l", "type": "long"}},
+			{"convert": {"field": "f", "type": "float"}},
+			{"convert": {"field": "d", "type": "double"}},
+			{"convert": {"field": "b", "type": "boolean"}},
+			{"convert": {"field": "s", "type": "string"}},
+			{"convert": {"field": "list", "type": "integer", "target_field": "ints"}},
+			{"convert": {"field": "a", "type": "auto"}},
+			{"convert": {"field": "missing", "type": "auto", "ignore_missing": true}}
+		]
+	}`, common.MapStr{
+		"i":    "42",
+		"l":    "10000000000",
+		"f":    "1.5",
+		"d":    "2.25",
+		"b":    "TRUE",
+		"s":    int64(7),
+		"list": []interface{}{"1", "2"},
+		"a":    "false",
+	})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{
+		"i":    42,
+		"l":    int64(10000000000),
+		"f":    float32(1.5),
+		"d":    2.25,
+		"b":    true,
+		"s":    "7",
+		"list": []interface{}{"1", "2"},
+		"ints": []interface{}{1, 2},
+		"a":    false,
+	}, evt.Fields)
+}
+
+func TestTextProcessors(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"split": {"field": "ips", "separator": "\"?,?\\s+"}},
+			{"gsub": {"field": "query", "pattern": "\\s*# Time: \\d+$", "replacement": ""}},
+			{"kv": {"field": "kv", "field_split": "\\s+", "value_split": "=", "target_field": "audit", "exclude_keys": ["skip"]}},
+			{"json": {"field": "doc", "target_field": "parsed"}},
+			{"json": {"field": "root", "add_to_root": true}}
+		]
+	}`, common.MapStr{
+		"ips":   "10.0.0.2, 10.0.0.1, 127.0.0.1",
+		"query": "SELECT 1; # Time: 1234",
+		"kv":    "type=USER_AUTH pid=10 skip=yes pid=11",
+		"doc":   `{"a": {"b": 1, "c": 1.5}, "d": ["x"]}`,
+		"root":  `{"top": "level"}`,
+	})
+	require.NotNil(t, evt)
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.1", "127.0.0.1"}, evt.Fields["ips"])
+	assert.Equal(t, "SELECT 1;", evt.Fields["query"])
+	assert.Equal(t, common.MapStr{
+		"type": "USER_AUTH",
+		"pid":  []interface{}{"10", "11"},
+	}, evt.Fields["audit"])
+	assert.Equal(t, common.MapStr{
+		"a": common.MapStr{"b": int64(1), "c": 1.5},
+		"d": []interface{}{"x"},
+	}, evt.Fields["parsed"])
+	assert.Equal(t, "level", evt.Fields["top"])
+}
+
+func TestDateProcessor(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"date": {
+				"field": "ts",
+				"target_field": "parsed",
+				"formats": ["ISO8601", "yyyy-MM-dd HH:mm:ss,SSS"],
+				"timezone": "{{ tz }}"
+			}},
+			{"date": {"field": "ms", "formats": ["UNIX_MS"]}}
+		]
+	}`, common.MapStr{
+		"ts": "2018-03-12 10:55:01,123",
+		"tz": "+02:00",
+		"ms": "1520851233123",
+	})
+	require.NotNil(t, evt)
+	assert.Equal(t, "2018-03-12T10:55:01.123+02:00", evt.Fields["parsed"])
+	assert.Equal(t, time.Date(2018, 3, 12, 10, 40, 33, 123000000, time.UTC), evt.Timestamp)
+
+	evt = runTestPipeline(t, `{
+		"processors": [{"date": {"field": "ts", "formats": ["yyyy-MM-dd"]}}]
+	}`, common.MapStr{"ts": "not a date"})
+	assert.Nil(t, evt)
+}
+
+func TestDropProcessor(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"drop": {}},
+			{"set": {"field": "not", "value": "reached"}}
+		]
+	}`, common.MapStr{"a": 1})
+	assert.Nil(t, evt)
+}
+
+func TestUserAgentProcessor(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"user_agent": {"field": "agent", "target_field": "ua"}}
+		]
+	}`, common.MapStr{
+		"agent": "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.12; rv:49.0) Gecko/20100101 Firefox/49.0",
+	})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{
+		"name":     "Firefox",
+		"major":    "49",
+		"minor":    "0",
+		"os":       "Mac OS X 10.12",
+		"os_name":  "Mac OS X",
+		"os_major": "10",
+		"os_minor": "12",
+		"device":   "Other",
+	}, evt.Fields["ua"])
+}
+
+func TestGeoIPProcessor(t *testing.T) {
+	definition := `{
+		"processors": [
+			{"geoip": {"field": "ip", "database_file": "GeoLite2-City-Test.mmdb"}},
+			{"geoip": {"field": "ip", "target_field": "as", "database_file": "GeoLite2-ASN-Test.mmdb"}}
+		]
+	}`
+
+	evt := runTestPipeline(t, definition, common.MapStr{"ip": "89.160.20.128"})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{
+		"continent_name":   "Europe",
+		"country_iso_code": "SE",
+		"region_iso_code":  "SE-E",
+		"region_name":      "Östergötland County",
+		"city_name":        "Linköping",
+		"location":         common.MapStr{"lat": 58.4167, "lon": 15.6167},
+	}, evt.Fields["geoip"])
+	assert.Equal(t, common.MapStr{
+		"ip":                "89.160.20.128",
+		"asn":               int64(29518),
+		"organization_name": "Bredband2 AB",
+	}, evt.Fields["as"])
+
+	// Private addresses are not in the database.
+	evt = runTestPipeline(t, definition, common.MapStr{"ip": "192.168.1.1"})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{"ip": "192.168.1.1"}, evt.Fields)
+
+	// Invalid addresses fail the pipeline.
+	evt = runTestPipeline(t, definition, common.MapStr{"ip": "invalid"})
+	assert.Nil(t, evt)
+}
+
+func TestGeoIPProcessorMissingDatabase(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"geoip": {"field": "ip", "database_file": "missing.mmdb"}},
+			{"set": {"field": "done", "value": true}}
+		]
+	}`, common.MapStr{"ip": "89.160.20.128"})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{"ip": "89.160.20.128", "done": true}, evt.Fields)
+}
+
+func TestScriptProcessorIsSkipped(t *testing.T) {
+	evt := runTestPipeline(t, `{
+		"processors": [
+			{"script": {"lang": "painless", "inline": "ctx.a = 2"}},
+			{"set": {"field": "done", "value": true}}
+		]
+	}`, common.MapStr{"a": 1})
+	require.NotNil(t, evt)
+	assert.Equal(t, common.MapStr{"a": 1, "done": true}, evt.Fields)
+}
+
+func TestCompileErrors(t *testing.T) {
+	definitions := []string{
+		`{}`,
+		`{"processors": [{"unknown": {}}]}`,
+		`{"processors": [{"set": {"field": "a"}}]}`,
+		`{"processors": [{"grok": {"field": "a", "patterns": ["%{UNKNOWN}"]}}]}`,
+		`{"processors": [{"date": {"field": "a", "formats": ["yyyy-MM-dd QQ"]}}]}`,
+		`{"processors": [{"convert": {"field": "a", "type": "decimal"}}]}`,
+		`{"processors": [{"set": {"field": "a", "value": 1, "if": "ctx.b == 1"}}]}`,
+		`{"processors": [{"set": {"field": "a", "value": 1}, "remove": {"field": "a"}}]}`,
+	}
+
+	for _, definition := range definitions {
+		var content map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(definition), &content))
+		assert.Error(t, newTestRegistry().Register("test", content), definition)
+	}
+}
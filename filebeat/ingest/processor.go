@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// processor is a single ingest processor like grok or rename.
+type processor interface {
+	run(d *document) error
+}
+
+type constructor func(cfg *common.Config, ctx *compileContext) (processor, error)
+
+var constructors = map[string]constructor{}
+
+func registerProcessor(name string, c constructor) {
+	if _, exists := constructors[name]; exists {
+		panic(errors.Errorf("ingest processor '%v' is already registered", name))
+	}
+	constructors[name] = c
+}
+
+// SupportedProcessors returns the names of the ingest processors that can be
+// executed locally.
+func SupportedProcessors() []string {
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// compileContext holds the shared resources available to processors while
+// compiling a pipeline.
+type compileContext struct {
+	pipeline string
+	geoip    *geoipDatabases
+	log      *logp.Logger
+}
+
+// step wraps a processor with the options common to all ingest processors.
+type step struct {
+	typ           string
+	tag           string
+	processor     processor
+	ignoreFailure bool
+	onFailure     []*step
+}
+
+type stepConfig struct {
+	Tag           string        `config:"tag"`
+	IgnoreFailure bool          `config:"ignore_failure"`
+	If            string        `config:"if"`
+	OnFailure     []interface{} `config:"on_failure"`
+}
+
+// processorError records the processor that failed. The message and
+// processor are exposed as ingest metadata to on_failure handlers.
+type processorError struct {
+	typ   string
+	tag   string
+	cause error
+}
+
+func (e *processorError) Error() string {
+	return e.cause.Error()
+}
+
+func compileSteps(definitions []interface{}, ctx *compileContext) ([]*step, error) {
+	steps := make([]*step, 0, len(definitions))
+	for _, def := range definitions {
+		m, ok := def.(map[string]interface{})
+		if !ok || len(m) != 1 {
+			return nil, errors.Errorf("each processor needs to have exactly one type, but found %v", def)
+		}
+
+		for typ, settings := range m {
+			s, err := compileStep(typ, settings, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				steps = append(steps, s)
+			}
+		}
+	}
+	return steps, nil
+}
+
+func compileStep(typ string, settings interface{}, ctx *compileContext) (*step, error) {
+	c, found := constructors[typ]
+	if !found {
+		return nil, errors.Errorf("No processor type exists with name [%v]", typ)
+	}
+
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	cfg, err := common.NewConfigFrom(settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid settings for processor [%v]", typ)
+	}
+
+	var options stepConfig
+	if err := cfg.Unpack(&options); err != nil {
+		return nil, errors.Wrapf(err, "invalid settings for processor [%v]", typ)
+	}
+	if options.If != "" {
+		return nil, errors.Errorf("conditional execution ('if') of processor [%v] is not supported", typ)
+	}
+
+	p, err := c(cfg, ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile processor [%v]", typ)
+	}
+	if p == nil {
+		// The processor is not supported and was disabled with a warning.
+		return nil, nil
+	}
+
+	onFailure, err := compileSteps(options.OnFailure, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &step{
+		typ:           typ,
+		tag:           options.Tag,
+		processor:     p,
+		ignoreFailure: options.IgnoreFailure,
+		onFailure:     onFailure,
+	}, nil
+}
+
+func (s *step) run(d *document) error {
+	err := s.processor.run(d)
+	if err == nil || s.ignoreFailure {
+		return nil
+	}
+
+	perr, ok := err.(*processorError)
+	if !ok {
+		perr = &processorError{typ: s.typ, tag: s.tag, cause: err}
+	}
+	if len(s.onFailure) == 0 {
+		return perr
+	}
+	return runOnFailure(s.onFailure, d, perr)
+}
+
+func runSteps(steps []*step, d *document) error {
+	for _, s := range steps {
+		if err := s.run(d); err != nil {
+			return err
+		}
+		if d.dropped {
+			return nil
+		}
+	}
+	return nil
+}
+
+// runOnFailure executes an on_failure handler. The failure is available to
+// the handler in the _ingest.on_failure_* metadata fields.
+func runOnFailure(steps []*step, d *document, err *processorError) error {
+	d.ingest["on_failure_message"] = err.cause.Error()
+	d.ingest["on_failure_processor_type"] = err.typ
+	d.ingest["on_failure_processor_tag"] = err.tag
+	defer func() {
+		delete(d.ingest, "on_failure_message")
+		delete(d.ingest, "on_failure_processor_type")
+		delete(d.ingest, "on_failure_processor_tag")
+	}()
+
+	return runSteps(steps, d)
+}
+
+// fieldNames unpacks a setting that can be a single field name or a list of
+// field names.
+func fieldNames(cfg *common.Config) ([]string, error) {
+	var config struct {
+		Field interface{} `config:"field" validate:"required"`
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	switch v := config.Field.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, name := range v {
+			s, ok := name.(string)
+			if !ok {
+				return nil, errors.Errorf("field name %v is not a string", name)
+			}
+			names = append(names, s)
+		}
+		return names, nil
+	}
+	return nil, errors.Errorf("invalid field setting %v", config.Field)
+}
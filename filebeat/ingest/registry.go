@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/version"
+)
+
+const pipelinePath = "/_ingest/pipeline/"
+
+// Registry holds the pipelines executed locally.
+//
+// Registry implements the subset of the Elasticsearch client API used by
+// the Filebeat modules to load their pipelines (fileset.PipelineLoader),
+// so that the module pipelines can be loaded into it instead of
+// Elasticsearch.
+type Registry struct {
+	log   *logp.Logger
+	geoip *geoipDatabases
+
+	mu        sync.RWMutex
+	pipelines map[string]*Pipeline
+}
+
+// NewRegistry creates an empty pipeline registry.
+func NewRegistry(config Config) *Registry {
+	log := logp.NewLogger("ingest")
+	return &Registry{
+		log:       log,
+		geoip:     newGeoIPDatabases(config.GeoIP.DatabaseDir, log),
+		pipelines: map[string]*Pipeline{},
+	}
+}
+
+// Register compiles the pipeline definition and adds it to the registry,
+// replacing a pipeline with the same ID.
+func (r *Registry) Register(id string, content map[string]interface{}) error {
+	p, err := compilePipeline(id, content, &compileContext{geoip: r.geoip, log: r.log})
+	if err != nil {
+		return errors.Wrapf(err, "failed to compile pipeline %v", id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pipelines[id] = p
+	return nil
+}
+
+// Get returns the pipeline or nil if no pipeline with the ID is registered.
+func (r *Registry) Get(id string) *Pipeline {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pipelines[id]
+}
+
+// Processor returns a processor executing the pipeline. The pipeline is
+// looked up for every event, so it can be registered or updated after the
+// processor was created.
+func (r *Registry) Processor(id string) processors.Processor {
+	return &pipelineRef{registry: r, id: id}
+}
+
+// Close releases the resources held by the pipelines.
+func (r *Registry) Close() {
+	r.geoip.close()
+}
+
+// LoadJSON registers the pipeline if path is a pipeline path.
+func (r *Registry) LoadJSON(path string, content map[string]interface{}) ([]byte, error) {
+	if !strings.HasPrefix(path, pipelinePath) {
+		return nil, errors.Errorf("unsupported path %v", path)
+	}
+
+	id := strings.TrimPrefix(path, pipelinePath)
+	if err := r.Register(id, content); err != nil {
+		return errorResponse("parse_exception", err), err
+	}
+	return []byte(`{"acknowledged":true}`), nil
+}
+
+// Request emulates the Elasticsearch requests used to check the available
+// processors and if a pipeline is already loaded.
+func (r *Registry) Request(
+	method, path string,
+	pipeline string,
+	params map[string]string,
+	body interface{},
+) (int, []byte, error) {
+	if method != "GET" {
+		return http.StatusMethodNotAllowed, nil, errors.Errorf("unsupported request %v %v", method, path)
+	}
+
+	switch {
+	case path == "/_nodes/ingest":
+		var available []map[string]string
+		for _, name := range SupportedProcessors() {
+			available = append(available, map[string]string{"type": name})
+		}
+		return marshalResponse(map[string]interface{}{
+			"nodes": map[string]interface{}{
+				"local": map[string]interface{}{
+					"ingest": map[string]interface{}{"processors": available},
+				},
+			},
+		})
+
+	case strings.HasPrefix(path, pipelinePath):
+		p := r.Get(strings.TrimPrefix(path, pipelinePath))
+		if p == nil {
+			return http.StatusNotFound, []byte("{}"), nil
+		}
+		return marshalResponse(map[string]interface{}{p.id: p.content})
+	}
+
+	return http.StatusNotFound, nil, errors.Errorf("unsupported request %v %v", method, path)
+}
+
+// GetVersion returns the version of the Elasticsearch features supported,
+// which is the version of the Beat.
+func (r *Registry) GetVersion() string {
+	return version.GetDefaultVersion()
+}
+
+func marshalResponse(v interface{}) (int, []byte, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	return http.StatusOK, body, nil
+}
+
+func errorResponse(typ string, err error) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"root_cause": []map[string]string{{"type": typ, "reason": err.Error()}},
+		},
+	})
+	return body
+}
+
+// pipelineRef is a processor that runs a pipeline from the registry.
+type pipelineRef struct {
+	registry *Registry
+	id       string
+	warnOnce sync.Once
+}
+
+func (p *pipelineRef) Run(event *beat.Event) (*beat.Event, error) {
+	pipeline := p.registry.Get(p.id)
+	if pipeline == nil {
+		p.warnOnce.Do(func() {
+			p.registry.log.Warnf("Pipeline %v is not loaded, events are published without processing.", p.id)
+		})
+		return event, nil
+	}
+	return pipeline.Run(event)
+}
+
+func (p *pipelineRef) String() string {
+	return fmt.Sprintf("ingest_pipeline=[id=%v]", p.id)
+}
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func init() {
+	registerProcessor("script", newScript)
+}
+
+// newScript accepts script processors so that pipelines using them can be
+// loaded, but scripts are not executed. Painless is only available inside
+// Elasticsearch.
+func newScript(cfg *common.Config, ctx *compileContext) (processor, error) {
+	config := struct {
+		Lang string `config:"lang"`
+	}{
+		Lang: "painless",
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	ctx.log.Warnf("The %v script processor of pipeline %v is not supported and will be skipped.",
+		config.Lang, ctx.pipeline)
+	return nil, nil
+}
@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// templateVar matches the mustache variables {{ name }} and {{{ name }}}
+// supported in field names and values.
+var templateVar = regexp.MustCompile(`{{{?\s*([^{}\s]+)\s*}?}}`)
+
+// template is a string that can reference document fields.
+type template struct {
+	literal string
+	parts   []templatePart
+}
+
+type templatePart struct {
+	text  string
+	field string
+}
+
+func compileTemplate(s string) *template {
+	matches := templateVar.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return &template{literal: s}
+	}
+
+	t := &template{}
+	last := 0
+	for _, m := range matches {
+		t.parts = append(t.parts,
+			templatePart{text: s[last:m[0]]},
+			templatePart{field: s[m[2]:m[3]]})
+		last = m[1]
+	}
+	t.parts = append(t.parts, templatePart{text: s[last:]})
+	return t
+}
+
+// render replaces the variables with the document values. Missing fields
+// render as empty strings.
+func (t *template) render(d *document) string {
+	if t.parts == nil {
+		return t.literal
+	}
+
+	var buf strings.Builder
+	for _, p := range t.parts {
+		if p.field == "" {
+			buf.WriteString(p.text)
+			continue
+		}
+		if v, err := d.get(p.field); err == nil {
+			buf.WriteString(toString(v))
+		}
+	}
+	return buf.String()
+}
+
+// compileValue prepares a value from the pipeline definition, compiling
+// all strings into templates.
+func compileValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return compileTemplate(val)
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, x := range val {
+			list[i] = compileValue(x)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, x := range val {
+			m[k] = compileValue(x)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
+// renderValue renders a value prepared by compileValue.
+func renderValue(v interface{}, d *document) interface{} {
+	switch val := v.(type) {
+	case *template:
+		return val.render(d)
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i, x := range val {
+			list[i] = renderValue(x, d)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, x := range val {
+			m[k] = renderValue(x, d)
+		}
+		return normalizeValue(m)
+	default:
+		return v
+	}
+}
+
+func toString(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case time.Time:
+		return formatTimestamp(val)
+	case common.Time:
+		return formatTimestamp(time.Time(val))
+	default:
+		return fmt.Sprint(v)
+	}
+}
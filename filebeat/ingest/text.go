@@ -0,0 +1,330 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func init() {
+	registerProcessor("split", newSplit)
+	registerProcessor("gsub", newGsub)
+	registerProcessor("kv", newKV)
+	registerProcessor("json", newJSON)
+}
+
+// compileRegexp compiles a Java regular expression as used in ingest
+// pipelines. regexp2 is used for compatibility with look-arounds and other
+// constructs not supported by the regexp package.
+func compileRegexp(expr string) (*regexp2.Regexp, error) {
+	re, err := regexp2.Compile(expr, regexp2.None)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid regular expression '%v'", expr)
+	}
+	return re, nil
+}
+
+// splitRegexp splits s around the matches of re like Java's String.split:
+// at most limit parts are returned (no limit if <= 0) and trailing empty
+// strings are removed when there is no limit.
+func splitRegexp(re *regexp2.Regexp, s string, limit int) ([]string, error) {
+	runes := []rune(s)
+	var parts []string
+	last := 0
+
+	m, err := re.FindRunesMatch(runes)
+	for ; m != nil && err == nil; m, err = re.FindNextMatch(m) {
+		if limit > 0 && len(parts) == limit-1 {
+			break
+		}
+		if m.Length == 0 && m.Index == 0 {
+			continue
+		}
+		parts = append(parts, string(runes[last:m.Index]))
+		last = m.Index + m.Length
+	}
+	if err != nil {
+		return nil, err
+	}
+	parts = append(parts, string(runes[last:]))
+
+	if limit <= 0 {
+		for len(parts) > 1 && parts[len(parts)-1] == "" {
+			parts = parts[:len(parts)-1]
+		}
+	}
+	return parts, nil
+}
+
+type splitProcessor struct {
+	fieldConfig
+	separator *regexp2.Regexp
+}
+
+func newSplit(cfg *common.Config, _ *compileContext) (processor, error) {
+	p := &splitProcessor{}
+	if err := cfg.Unpack(&p.fieldConfig); err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		Separator string `config:"separator" validate:"required"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	var err error
+	p.separator, err = compileRegexp(config.Separator)
+	return p, err
+}
+
+func (p *splitProcessor) run(d *document) error {
+	if p.IgnoreMissing && !d.has(p.Field) {
+		return nil
+	}
+
+	value, err := d.getString(p.Field)
+	if err != nil {
+		return err
+	}
+
+	parts, err := splitRegexp(p.separator, value, 0)
+	if err != nil {
+		return err
+	}
+	return d.put(p.target(), parts)
+}
+
+type gsubProcessor struct {
+	fieldConfig
+	pattern     *regexp2.Regexp
+	replacement string
+}
+
+func newGsub(cfg *common.Config, _ *compileContext) (processor, error) {
+	p := &gsubProcessor{}
+	if err := cfg.Unpack(&p.fieldConfig); err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		Pattern     string  `config:"pattern" validate:"required"`
+		Replacement *string `config:"replacement"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Replacement == nil {
+		return nil, errors.New("missing required field 'replacement'")
+	}
+
+	var err error
+	p.pattern, err = compileRegexp(config.Pattern)
+	p.replacement = *config.Replacement
+	return p, err
+}
+
+func (p *gsubProcessor) run(d *document) error {
+	if p.IgnoreMissing && !d.has(p.Field) {
+		return nil
+	}
+
+	value, err := d.getString(p.Field)
+	if err != nil {
+		return err
+	}
+
+	replaced, err := p.pattern.Replace(value, p.replacement, -1, -1)
+	if err != nil {
+		return err
+	}
+	return d.put(p.target(), replaced)
+}
+
+type kvProcessor struct {
+	field         string
+	targetField   string
+	fieldSplit    *regexp2.Regexp
+	valueSplit    *regexp2.Regexp
+	includeKeys   map[string]struct{}
+	excludeKeys   map[string]struct{}
+	prefix        string
+	ignoreMissing bool
+}
+
+func newKV(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field         string   `config:"field" validate:"required"`
+		TargetField   string   `config:"target_field"`
+		FieldSplit    string   `config:"field_split" validate:"required"`
+		ValueSplit    string   `config:"value_split" validate:"required"`
+		IncludeKeys   []string `config:"include_keys"`
+		ExcludeKeys   []string `config:"exclude_keys"`
+		Prefix        string   `config:"prefix"`
+		IgnoreMissing bool     `config:"ignore_missing"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	p := &kvProcessor{
+		field:         config.Field,
+		targetField:   config.TargetField,
+		prefix:        config.Prefix,
+		ignoreMissing: config.IgnoreMissing,
+	}
+
+	var err error
+	if p.fieldSplit, err = compileRegexp(config.FieldSplit); err != nil {
+		return nil, err
+	}
+	if p.valueSplit, err = compileRegexp(config.ValueSplit); err != nil {
+		return nil, err
+	}
+	if config.IncludeKeys != nil {
+		p.includeKeys = stringSet(config.IncludeKeys)
+	}
+	p.excludeKeys = stringSet(config.ExcludeKeys)
+	return p, nil
+}
+
+func stringSet(list []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(list))
+	for _, s := range list {
+		set[s] = struct{}{}
+	}
+	return set
+}
+
+func (p *kvProcessor) run(d *document) error {
+	if p.ignoreMissing && !d.has(p.field) {
+		return nil
+	}
+
+	value, err := d.getString(p.field)
+	if err != nil {
+		return err
+	}
+
+	pairs, err := splitRegexp(p.fieldSplit, value, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		kv, err := splitRegexp(p.valueSplit, pair, 2)
+		if err != nil {
+			return err
+		}
+		if len(kv) != 2 {
+			return errors.Errorf("field [%v] does not contain value_split [%v]", p.field, p.valueSplit)
+		}
+
+		key := kv[0]
+		if p.includeKeys != nil {
+			if _, included := p.includeKeys[key]; !included {
+				continue
+			}
+		}
+		if _, excluded := p.excludeKeys[key]; excluded {
+			continue
+		}
+
+		key = p.prefix + key
+		if p.targetField != "" {
+			key = p.targetField + "." + key
+		}
+
+		if d.has(key) {
+			err = d.append(key, kv[1])
+		} else {
+			err = d.put(key, kv[1])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonProcessor struct {
+	field       string
+	targetField string
+	addToRoot   bool
+}
+
+func newJSON(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field       string `config:"field" validate:"required"`
+		TargetField string `config:"target_field"`
+		AddToRoot   bool   `config:"add_to_root"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	if config.AddToRoot && config.TargetField != "" {
+		return nil, errors.New("Cannot set a target field while also setting `add_to_root` to true")
+	}
+
+	return &jsonProcessor{
+		field:       config.Field,
+		targetField: config.TargetField,
+		addToRoot:   config.AddToRoot,
+	}, nil
+}
+
+func (p *jsonProcessor) run(d *document) error {
+	value, err := d.getString(p.field)
+	if err != nil {
+		return err
+	}
+
+	var decoded interface{}
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return errors.Wrapf(err, "failed to decode JSON in field [%v]", p.field)
+	}
+	decoded = normalizeValue(decoded)
+
+	if !p.addToRoot {
+		target := p.targetField
+		if target == "" {
+			target = p.field
+		}
+		return d.put(target, decoded)
+	}
+
+	fields, ok := decoded.(common.MapStr)
+	if !ok {
+		return errors.Errorf("cannot add non-map fields to root of document")
+	}
+	for k, v := range fields {
+		d.event.Fields[k] = v
+	}
+	return nil
+}
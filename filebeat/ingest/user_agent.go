@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/ua-parser/uap-go/uaparser"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/paths"
+)
+
+var (
+	defaultUserAgentProperties = []string{"name", "major", "minor", "patch",
+		"build", "os", "os_name", "os_major", "os_minor", "device"}
+
+	// The bundled regular expressions are compiled once, on first use.
+	defaultUAParserOnce sync.Once
+	defaultUAParser     *uaparser.Parser
+)
+
+func init() {
+	registerProcessor("user_agent", newUserAgent)
+}
+
+type userAgentProcessor struct {
+	field         string
+	target        string
+	ignoreMissing bool
+	properties    []string
+	parser        *uaparser.Parser
+}
+
+func newUserAgent(cfg *common.Config, _ *compileContext) (processor, error) {
+	config := struct {
+		Field         string   `config:"field" validate:"required"`
+		TargetField   string   `config:"target_field"`
+		RegexFile     string   `config:"regex_file"`
+		Properties    []string `config:"properties"`
+		IgnoreMissing bool     `config:"ignore_missing"`
+	}{
+		TargetField: "user_agent",
+		Properties:  defaultUserAgentProperties,
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	p := &userAgentProcessor{
+		field:         config.Field,
+		target:        config.TargetField,
+		ignoreMissing: config.IgnoreMissing,
+	}
+	for _, name := range config.Properties {
+		p.properties = append(p.properties, strings.ToLower(name))
+	}
+
+	if config.RegexFile != "" {
+		parser, err := uaparser.New(paths.Resolve(paths.Config, config.RegexFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load user agent regex file %v", config.RegexFile)
+		}
+		p.parser = parser
+	} else {
+		defaultUAParserOnce.Do(func() {
+			defaultUAParser = uaparser.NewFromSaved()
+		})
+		p.parser = defaultUAParser
+	}
+	return p, nil
+}
+
+func (p *userAgentProcessor) run(d *document) error {
+	if p.ignoreMissing && !d.has(p.field) {
+		return nil
+	}
+
+	value, err := d.getString(p.field)
+	if err != nil {
+		return err
+	}
+
+	client := p.parser.Parse(value)
+	ua := common.MapStr{}
+	for _, name := range p.properties {
+		switch name {
+		case "name":
+			ua["name"] = orOther(client.UserAgent.Family)
+		case "major":
+			putString(ua, "major", client.UserAgent.Major)
+		case "minor":
+			putString(ua, "minor", client.UserAgent.Minor)
+		case "patch":
+			putString(ua, "patch", client.UserAgent.Patch)
+		case "os":
+			os := client.Os.Family
+			if version := osVersion(client.Os); os != "" && version != "" {
+				os += " " + version
+			}
+			ua["os"] = orOther(os)
+		case "os_name":
+			ua["os_name"] = orOther(client.Os.Family)
+		case "os_major":
+			putString(ua, "os_major", client.Os.Major)
+		case "os_minor":
+			putString(ua, "os_minor", client.Os.Minor)
+		case "device":
+			ua["device"] = orOther(client.Device.Family)
+		}
+	}
+	return d.put(p.target, ua)
+}
+
+func orOther(s string) string {
+	if s == "" {
+		return "Other"
+	}
+	return s
+}
+
+func osVersion(os *uaparser.Os) string {
+	var parts []string
+	for _, v := range []string{os.Major, os.Minor, os.Patch, os.PatchMinor} {
+		if v == "" {
+			break
+		}
+		parts = append(parts, v)
+	}
+	return strings.Join(parts, ".")
+}
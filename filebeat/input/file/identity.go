@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/beats/libbeat/common/file"
+)
+
+// Strategies to identify files. The identity of a file decides if a file found
+// during a scan is a file seen before or a new file.
+const (
+	// IdentityNative identifies files by inode and device (volume and file
+	// index on Windows).
+	IdentityNative = "native"
+
+	// IdentityFingerprint identifies files by a hash of their first bytes.
+	IdentityFingerprint = "fingerprint"
+
+	// IdentityPathFingerprint identifies files by their path and a hash of
+	// their first bytes.
+	IdentityPathFingerprint = "path_fingerprint"
+)
+
+// IdentityConfig configures how files are identified.
+type IdentityConfig struct {
+	Strategy    string `config:"strategy"`
+	Fingerprint struct {
+		Length int64 `config:"length" validate:"min=1"`
+	} `config:"fingerprint"`
+}
+
+// DefaultIdentityConfig returns the default configuration identifying files by
+// inode and device.
+func DefaultIdentityConfig() IdentityConfig {
+	c := IdentityConfig{Strategy: IdentityNative}
+	c.Fingerprint.Length = 1024
+	return c
+}
+
+// Validate checks the identity strategy.
+func (c *IdentityConfig) Validate() error {
+	switch c.Strategy {
+	case IdentityNative, IdentityFingerprint, IdentityPathFingerprint:
+		return nil
+	}
+	return fmt.Errorf("unknown file identity strategy '%s'", c.Strategy)
+}
+
+// Identifier sets the identity of file states according to a strategy.
+type Identifier struct {
+	strategy string
+	length   int64
+}
+
+// NewIdentifier creates an Identifier for the given configuration.
+func NewIdentifier(config IdentityConfig) (*Identifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Identifier{
+		strategy: config.Strategy,
+		length:   config.Fingerprint.Length,
+	}, nil
+}
+
+// Strategy returns the name of the identity strategy.
+func (i *Identifier) Strategy() string {
+	return i.strategy
+}
+
+// Identify sets the identity of the state based on the file under
+// state.Source. Files shorter than the fingerprint length don't get a
+// fingerprint and are identified by inode and device until they grew large
+// enough.
+func (i *Identifier) Identify(state *State) error {
+	state.Id = ""
+	state.IdentifierName = ""
+	state.Fingerprint = ""
+
+	if i.strategy == IdentityNative {
+		return nil
+	}
+
+	fingerprint, err := i.fingerprint(state.Source)
+	if err != nil {
+		return err
+	}
+
+	state.IdentifierName = i.strategy
+	state.Fingerprint = fingerprint
+	return nil
+}
+
+// Reidentify returns the state with the identity of the file under
+// state.Source as given by this identifier. It is used to migrate states
+// stored with a different strategy. The file is considered to be the file
+// the state was created for if either the inode and device or the
+// fingerprint stored in the state match, the second return value is false
+// if neither does.
+func (i *Identifier) Reidentify(state State) (State, bool) {
+	info, err := os.Stat(state.Source)
+	if err != nil {
+		return state, false
+	}
+
+	current := state
+	current.FileStateOS = file.GetOSState(info)
+	if err := i.Identify(&current); err != nil {
+		return state, false
+	}
+
+	sameFile := current.FileStateOS.IsSame(state.FileStateOS)
+	if !sameFile && state.Fingerprint != "" {
+		fingerprint, err := i.fingerprint(state.Source)
+		sameFile = err == nil && fingerprint == state.Fingerprint
+	}
+	if !sameFile {
+		return state, false
+	}
+	return current, true
+}
+
+// fingerprint returns the hex encoded SHA-256 hash of the first bytes of the
+// file. An empty fingerprint is returned if the file is too short.
+func (i *Identifier) fingerprint(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.CopyN(h, f, i.length)
+	if err == io.EOF && n < i.length {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint file %s: %v", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
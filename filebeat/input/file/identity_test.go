@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package file
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common/file"
+)
+
+func newIdentifier(t *testing.T, strategy string, length int64) *Identifier {
+	config := DefaultIdentityConfig()
+	config.Strategy = strategy
+	config.Fingerprint.Length = length
+
+	identifier, err := NewIdentifier(config)
+	require.NoError(t, err)
+	return identifier
+}
+
+func newTestState(t *testing.T, path string) State {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return NewState(info, path, "log", nil)
+}
+
+func TestIdentityConfigValidate(t *testing.T) {
+	config := DefaultIdentityConfig()
+	assert.NoError(t, config.Validate())
+
+	config.Strategy = "inode_marker"
+	assert.Error(t, config.Validate())
+}
+
+func TestIdentify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-identity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	content := bytes.Repeat([]byte("a log line\n"), 10)
+	sum := sha256.Sum256(content[:16])
+	fingerprint := hex.EncodeToString(sum[:])
+
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+	short := filepath.Join(dir, "short.log")
+	require.NoError(t, ioutil.WriteFile(first, content, 0644))
+	require.NoError(t, ioutil.WriteFile(second, content, 0644))
+	require.NoError(t, ioutil.WriteFile(short, content[:15], 0644))
+
+	t.Run("native", func(t *testing.T) {
+		identifier := newIdentifier(t, IdentityNative, 16)
+
+		a, b := newTestState(t, first), newTestState(t, second)
+		require.NoError(t, identifier.Identify(&a))
+		require.NoError(t, identifier.Identify(&b))
+
+		assert.Equal(t, a.FileStateOS.String(), a.ID())
+		assert.Empty(t, a.Fingerprint)
+		assert.False(t, a.IsSameFile(&b))
+	})
+
+	t.Run("fingerprint", func(t *testing.T) {
+		identifier := newIdentifier(t, IdentityFingerprint, 16)
+
+		a, b := newTestState(t, first), newTestState(t, second)
+		require.NoError(t, identifier.Identify(&a))
+		require.NoError(t, identifier.Identify(&b))
+
+		assert.Equal(t, fingerprint, a.Fingerprint)
+		assert.Equal(t, "fingerprint::"+fingerprint, a.ID())
+		assert.True(t, a.IsSameFile(&b))
+	})
+
+	t.Run("path_fingerprint", func(t *testing.T) {
+		identifier := newIdentifier(t, IdentityPathFingerprint, 16)
+
+		a, b := newTestState(t, first), newTestState(t, second)
+		require.NoError(t, identifier.Identify(&a))
+		require.NoError(t, identifier.Identify(&b))
+
+		assert.Equal(t, fingerprint, a.Fingerprint)
+		assert.Equal(t, "path::"+first+"::fingerprint::"+fingerprint, a.ID())
+		assert.False(t, a.IsSameFile(&b))
+	})
+
+	t.Run("short file", func(t *testing.T) {
+		identifier := newIdentifier(t, IdentityFingerprint, 16)
+
+		s := newTestState(t, short)
+		require.NoError(t, identifier.Identify(&s))
+
+		assert.Equal(t, IdentityFingerprint, s.IdentifierName)
+		assert.Empty(t, s.Fingerprint)
+		assert.Equal(t, s.FileStateOS.String(), s.ID())
+	})
+
+	t.Run("meta", func(t *testing.T) {
+		identifier := newIdentifier(t, IdentityFingerprint, 16)
+
+		a := newTestState(t, first)
+		a.Meta = map[string]string{"container": "a"}
+		b := newTestState(t, first)
+		require.NoError(t, identifier.Identify(&a))
+		require.NoError(t, identifier.Identify(&b))
+
+		assert.NotEqual(t, a.ID(), b.ID())
+		assert.True(t, a.IsSameFile(&b))
+	})
+}
+
+func TestReidentify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-identity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.log")
+	content := bytes.Repeat([]byte("a log line\n"), 10)
+	require.NoError(t, ioutil.WriteFile(path, content, 0644))
+
+	native := newIdentifier(t, IdentityNative, 16)
+	fingerprint := newIdentifier(t, IdentityFingerprint, 16)
+
+	state := newTestState(t, path)
+	state.Offset = 42
+
+	// native -> fingerprint
+	migrated, ok := fingerprint.Reidentify(state)
+	require.True(t, ok)
+	assert.Equal(t, IdentityFingerprint, migrated.IdentifierName)
+	assert.NotEmpty(t, migrated.Fingerprint)
+	assert.EqualValues(t, 42, migrated.Offset)
+	assert.NotEqual(t, state.ID(), migrated.ID())
+
+	// Same strategy keeps the identity
+	same, ok := fingerprint.Reidentify(migrated)
+	require.True(t, ok)
+	assert.Equal(t, migrated.ID(), same.ID())
+
+	// fingerprint -> native after the file got a new inode, for example
+	// because the volume was remounted
+	migrated.FileStateOS = file.StateOS{}
+	back, ok := native.Reidentify(migrated)
+	require.True(t, ok)
+	assert.Empty(t, back.Fingerprint)
+	current := newTestState(t, path)
+	assert.Equal(t, current.ID(), back.ID())
+
+	// A different file under the same path is not migrated
+	other := filepath.Join(dir, "other.log")
+	require.NoError(t, ioutil.WriteFile(other, []byte("some other content\n"), 0644))
+	require.NoError(t, os.Rename(other, path))
+	_, ok = fingerprint.Reidentify(state)
+	assert.False(t, ok)
+
+	// Removed file
+	require.NoError(t, os.Remove(path))
+	_, ok = fingerprint.Reidentify(state)
+	assert.False(t, ok)
+}
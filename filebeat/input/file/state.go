@@ -39,7 +39,15 @@ type State struct {
 	TTL         time.Duration     `json:"ttl"`
 	Type        string            `json:"type"`
 	Meta        map[string]string `json:"meta"`
+	Cursor      string            `json:"cursor,omitempty"`      // position of inputs not reading files
+	Compression string            `json:"compression,omitempty"` // compression format of compressed files
+	Completed   bool              `json:"completed,omitempty"`   // compressed file was read until the end
 	FileStateOS file.StateOS
+
+	// IdentifierName is the identity strategy used for the state, Fingerprint
+	// the hash of the first bytes of the file if the strategy uses one.
+	IdentifierName string `json:"identifier_name,omitempty"`
+	Fingerprint    string `json:"fingerprint,omitempty"`
 }
 
 // NewState creates a new file state
@@ -64,14 +72,14 @@ func (s *State) ID() string {
 	// Generate id on first request. This is needed as id is not set when converting back from json
 	if s.Id == "" {
 		if len(s.Meta) == 0 {
-			s.Id = s.FileStateOS.String()
+			s.Id = s.fileID()
 		} else {
 			hashValue, _ := hashstructure.Hash(s.Meta, nil)
 			var hashBuf [17]byte
 			hash := strconv.AppendUint(hashBuf[:0], hashValue, 16)
 			hash = append(hash, '-')
 
-			fileID := s.FileStateOS.String()
+			fileID := s.fileID()
 
 			var b strings.Builder
 			b.Grow(len(hash) + len(fileID))
@@ -85,6 +93,27 @@ func (s *State) ID() string {
 	return s.Id
 }
 
+// fileID returns the identity of the file, independent of the meta data.
+// States without fingerprint, either because of the native strategy or
+// because the file was too short, are identified by inode and device.
+func (s *State) fileID() string {
+	if s.Fingerprint == "" {
+		return s.FileStateOS.String()
+	}
+
+	switch s.IdentifierName {
+	case IdentityPathFingerprint:
+		return "path::" + s.Source + "::fingerprint::" + s.Fingerprint
+	default:
+		return "fingerprint::" + s.Fingerprint
+	}
+}
+
+// IsSameFile returns true if both states identify the same file, ignoring the meta data.
+func (s *State) IsSameFile(c *State) bool {
+	return s.fileID() == c.fileID()
+}
+
 // IsEqual compares the state to an other state supporting stringer based on the unique string
 func (s *State) IsEqual(c *State) bool {
 	return s.ID() == c.ID()
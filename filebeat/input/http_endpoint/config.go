@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/common/cfgtype"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+)
+
+type config struct {
+	harvester.ForwarderConfig `config:",inline"`
+
+	Host        string                  `config:"host"`
+	URL         string                  `config:"url"`
+	Prefix      string                  `config:"prefix"`
+	MaxBodySize cfgtype.ByteSize        `config:"max_body_size" validate:"nonzero,positive"`
+	TLS         *tlscommon.ServerConfig `config:"ssl"`
+
+	// Basic authentication
+	Username string `config:"username"`
+	Password string `config:"password"`
+
+	// Shared secret passed in a request header
+	Secret struct {
+		Header string `config:"header"`
+		Value  string `config:"value"`
+	} `config:"secret"`
+}
+
+func defaultConfig() config {
+	return config{
+		ForwarderConfig: harvester.ForwarderConfig{
+			Type: "http_endpoint",
+		},
+		Host:        "localhost:8080",
+		URL:         "/",
+		Prefix:      "json",
+		MaxBodySize: 10 * humanize.MiByte,
+	}
+}
+
+// Validate validates the http_endpoint input configuration.
+func (c *config) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("need to specify the host using the `host:port` syntax")
+	}
+
+	if c.MaxBodySize == 0 {
+		return fmt.Errorf("max_body_size must be greater than 0")
+	}
+
+	if !strings.HasPrefix(c.URL, "/") {
+		return fmt.Errorf("url path must start with '/', got '%v'", c.URL)
+	}
+
+	if (c.Username == "") != (c.Password == "") {
+		return fmt.Errorf("both username and password must be set for basic authentication")
+	}
+
+	if (c.Secret.Header == "") != (c.Secret.Value == "") {
+		return fmt.Errorf("both secret.header and secret.value must be set")
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/jsontransform"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+var errEmptyBody = errors.New("body is empty")
+
+// handler decodes the JSON documents sent in the request bodies and publishes
+// them. A request is only answered with success after all of its events have
+// been accepted by the publisher pipeline, so clients can safely retry
+// on errors.
+type handler struct {
+	config    *config
+	forwarder *harvester.Forwarder
+	log       *logp.Logger
+}
+
+func newHandler(config *config, forwarder *harvester.Forwarder, log *logp.Logger) *handler {
+	return &handler{config: config, forwarder: forwarder, log: log}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != h.config.URL {
+		sendResponse(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		sendResponse(w, http.StatusMethodNotAllowed, "only POST requests are allowed")
+		return
+	}
+
+	if !h.authorized(r) {
+		if h.config.Username != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="filebeat"`)
+		}
+		sendResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	maxSize := int64(h.config.MaxBodySize)
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		sendResponse(w, http.StatusBadRequest, fmt.Sprintf("failed to read body: %v", err))
+		return
+	}
+	if int64(len(body)) > maxSize {
+		sendResponse(w, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("body exceeds the maximum size of %v bytes", maxSize))
+		return
+	}
+
+	objs, err := decodeBody(body)
+	if err != nil {
+		sendResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+
+	for _, obj := range objs {
+		if err := h.forwarder.Send(createEvent(obj, h.config.Prefix)); err != nil {
+			h.log.Debugw("Failed to publish events", "error", err)
+			sendResponse(w, http.StatusServiceUnavailable, "input is shutting down")
+			return
+		}
+	}
+
+	sendResponse(w, http.StatusOK, "success")
+}
+
+// authorized checks the basic authentication credentials and the shared
+// secret header of the request, if configured.
+func (h *handler) authorized(r *http.Request) bool {
+	if h.config.Username != "" {
+		user, password, ok := r.BasicAuth()
+		if !ok || !secureEqual(user, h.config.Username) || !secureEqual(password, h.config.Password) {
+			return false
+		}
+	}
+
+	if h.config.Secret.Header != "" {
+		if !secureEqual(r.Header.Get(h.config.Secret.Header), h.config.Secret.Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// decodeBody decodes a single JSON object, an array of objects or newline
+// delimited JSON objects.
+func decodeBody(body []byte) ([]common.MapStr, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, errEmptyBody
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var objs []common.MapStr
+	if body[0] == '[' {
+		if err := dec.Decode(&objs); err != nil {
+			return nil, err
+		}
+		if _, err := dec.Token(); err != io.EOF {
+			return nil, errors.New("unexpected data after the array")
+		}
+	} else {
+		for {
+			var obj common.MapStr
+			err := dec.Decode(&obj)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, obj)
+		}
+	}
+
+	for _, obj := range objs {
+		if obj == nil {
+			return nil, errors.New("null is not a JSON object")
+		}
+		jsontransform.TransformNumbers(obj)
+	}
+	return objs, nil
+}
+
+func createEvent(obj common.MapStr, prefix string) *util.Data {
+	event := beat.Event{
+		Timestamp: time.Now(),
+		Fields:    common.MapStr{},
+	}
+	if prefix == "" {
+		jsontransform.WriteJSONKeys(&event, obj, false)
+	} else {
+		event.Fields[prefix] = obj
+	}
+
+	data := util.NewData()
+	data.Event = event
+	return data
+}
+
+func sendResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(common.MapStr{"message": message})
+}
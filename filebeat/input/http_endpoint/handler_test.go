@@ -0,0 +1,185 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+type mockOutlet struct {
+	sync.Mutex
+	closed bool
+	events []common.MapStr
+}
+
+func (o *mockOutlet) OnEvent(data *util.Data) bool {
+	o.Lock()
+	defer o.Unlock()
+	if o.closed {
+		return false
+	}
+	o.events = append(o.events, data.GetEvent().Fields)
+	return true
+}
+
+func newTestHandler(t *testing.T, settings map[string]interface{}) (*handler, *mockOutlet) {
+	config := defaultConfig()
+	require.NoError(t, common.MustNewConfigFrom(settings).Unpack(&config))
+
+	out := &mockOutlet{}
+	return newHandler(&config, harvester.NewForwarder(out), logp.NewLogger("test")), out
+}
+
+func post(h http.Handler, path, body string, modify func(*http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	if modify != nil {
+		modify(req)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestDecodeBody(t *testing.T) {
+	tests := map[string]int{
+		`{"a": 1}`:                      1,
+		`[{"a": 1}, {"b": 2}]`:          2,
+		"{\"a\": 1}\n{\"b\": 2}\n":      2,
+		"\n  {\"a\": {\"b\": [1]}}  \n": 1,
+	}
+
+	for body, count := range tests {
+		objs, err := decodeBody([]byte(body))
+		if assert.NoError(t, err, body) {
+			assert.Len(t, objs, count, body)
+		}
+	}
+
+	objs, err := decodeBody([]byte(`{"n": 12, "f": 1.5}`))
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{"n": int64(12), "f": 1.5}, objs[0])
+
+	for _, body := range []string{"", "   ", "hello", `[1, 2]`, `[{"a": 1}] {}`, `{"a": 1`, `null`, `[null]`, `"string"`} {
+		_, err := decodeBody([]byte(body))
+		assert.Error(t, err, body)
+	}
+}
+
+func TestHandlerPublishes(t *testing.T) {
+	h, out := newTestHandler(t, map[string]interface{}{})
+
+	w := post(h, "/", `[{"message": "first"}, {"message": "second"}]`, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message": "success"}`, w.Body.String())
+
+	require.Len(t, out.events, 2)
+	assert.Equal(t, common.MapStr{"json": common.MapStr{"message": "first"}}, out.events[0])
+	assert.Equal(t, common.MapStr{"json": common.MapStr{"message": "second"}}, out.events[1])
+}
+
+func TestHandlerNoPrefix(t *testing.T) {
+	h, out := newTestHandler(t, map[string]interface{}{"prefix": ""})
+
+	w := post(h, "/", `{"message": "hello", "@timestamp": "2018-01-01T00:00:00Z"}`, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	require.Len(t, out.events, 1)
+	assert.Equal(t, common.MapStr{"message": "hello"}, out.events[0])
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h, out := newTestHandler(t, map[string]interface{}{
+		"url":           "/logs",
+		"max_body_size": 32,
+	})
+
+	assert.Equal(t, http.StatusNotFound, post(h, "/other", `{}`, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, post(h, "/logs", `{"a":`, nil).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge,
+		post(h, "/logs", `{"message": "this body is way too large"}`, nil).Code)
+
+	req := httptest.NewRequest("GET", "/logs", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	assert.Empty(t, out.events)
+
+	// Events that can't be published are reported so the client can retry.
+	out.closed = true
+	assert.Equal(t, http.StatusServiceUnavailable, post(h, "/logs", `{"a": 1}`, nil).Code)
+}
+
+func TestHandlerBasicAuth(t *testing.T) {
+	h, out := newTestHandler(t, map[string]interface{}{
+		"username": "beats",
+		"password": "secret",
+	})
+
+	w := post(h, "/", `{"a": 1}`, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+	w = post(h, "/", `{"a": 1}`, func(r *http.Request) { r.SetBasicAuth("beats", "wrong") })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = post(h, "/", `{"a": 1}`, func(r *http.Request) { r.SetBasicAuth("beats", "secret") })
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, out.events, 1)
+}
+
+func TestHandlerSecretHeader(t *testing.T) {
+	h, out := newTestHandler(t, map[string]interface{}{
+		"secret.header": "X-Secret",
+		"secret.value":  "s3cr3t",
+	})
+
+	w := post(h, "/", `{"a": 1}`, func(r *http.Request) { r.Header.Set("X-Secret", "wrong") })
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = post(h, "/", `{"a": 1}`, func(r *http.Request) { r.Header.Set("X-Secret", "s3cr3t") })
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, out.events, 1)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"host": ""},
+		{"url": "logs"},
+		{"username": "beats"},
+		{"secret.header": "X-Secret"},
+		{"max_body_size": 0},
+	}
+
+	for _, settings := range tests {
+		config := defaultConfig()
+		assert.Error(t, common.MustNewConfigFrom(settings).Unpack(&config), "%v", settings)
+	}
+}
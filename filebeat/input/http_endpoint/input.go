@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+func init() {
+	err := input.Register("http_endpoint", NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input is an HTTP server receiving JSON events pushed by clients.
+type Input struct {
+	sync.Mutex
+	config    config
+	tlsConfig *tls.Config
+	outlet    channel.Outleter
+	server    *http.Server
+	started   bool
+	wg        sync.WaitGroup
+	log       *logp.Logger
+}
+
+// NewInput creates a new http_endpoint input
+func NewInput(
+	cfg *common.Config,
+	outlet channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("HTTP endpoint input is used")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSServerConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := outlet(cfg, context.DynamicFields)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Input{
+		config: config,
+		outlet: out,
+		log:    logp.NewLogger("http_endpoint input").With("address", config.Host),
+	}
+	if tlsConfig != nil {
+		p.tlsConfig = tlsConfig.BuildModuleConfig(config.Host)
+	}
+	return p, nil
+}
+
+// Run starts the HTTP server.
+func (p *Input) Run() {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.started {
+		return
+	}
+
+	p.log.Info("Starting HTTP endpoint input")
+	listener, err := p.listen()
+	if err != nil {
+		p.log.Errorw("Error starting the HTTP server", "error", err)
+		return
+	}
+
+	h := newHandler(&p.config, harvester.NewForwarder(p.outlet), p.log)
+	p.server = &http.Server{Handler: h}
+	p.started = true
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if err := p.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			p.log.Errorw("HTTP server failed", "error", err)
+		}
+	}()
+}
+
+func (p *Input) listen() (net.Listener, error) {
+	if p.tlsConfig != nil {
+		p.log.Info("Listening over TLS")
+		return tls.Listen("tcp", p.config.Host, p.tlsConfig)
+	}
+	return net.Listen("tcp", p.config.Host)
+}
+
+// Stop stops the HTTP server. The outlet is closed first, so requests waiting
+// for their events to be accepted are answered with an error and can be
+// retried by the clients.
+func (p *Input) Stop() {
+	p.outlet.Close()
+
+	p.Lock()
+	defer p.Unlock()
+
+	if !p.started {
+		return
+	}
+
+	p.log.Info("Stopping HTTP endpoint input")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.server.Shutdown(ctx); err != nil {
+		p.log.Errorw("Error stopping the HTTP server", "error", err)
+	}
+	p.wg.Wait()
+	p.started = false
+}
+
+// Wait stops the http_endpoint input.
+func (p *Input) Wait() {
+	p.Stop()
+}
@@ -92,6 +92,7 @@ func New(
 	}
 
 	context := Context{
+		ID:            input.ID,
 		States:        states,
 		Done:          input.done,
 		BeatDone:      input.beatDone,
@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journald
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/filebeat/harvester"
+)
+
+type config struct {
+	harvester.ForwarderConfig `config:",inline"`
+
+	// ID identifies the input in the registry. Inputs reading the same paths
+	// with different filters need different IDs.
+	ID            string        `config:"id"`
+	Paths         []string      `config:"paths"`
+	Seek          seekMode      `config:"seek"`
+	Backoff       time.Duration `config:"backoff" validate:"nonzero,positive"`
+	ScanFrequency time.Duration `config:"scan_frequency" validate:"nonzero,positive"`
+
+	Units       []string `config:"units"`
+	Identifiers []string `config:"identifiers"`
+	Priority    priority `config:"priority"`
+}
+
+func defaultConfig() config {
+	return config{
+		ForwarderConfig: harvester.ForwarderConfig{
+			Type: "journald",
+		},
+		Paths:         []string{"/var/log/journal"},
+		Seek:          seekHead,
+		Backoff:       1 * time.Second,
+		ScanFrequency: 10 * time.Second,
+		Priority:      -1,
+	}
+}
+
+// Validate validates the journald input configuration.
+func (c *config) Validate() error {
+	if len(c.Paths) == 0 {
+		return fmt.Errorf("no paths configured")
+	}
+	return nil
+}
+
+// seekMode selects where to start reading if no cursor is stored in the
+// registry.
+type seekMode string
+
+const (
+	seekHead seekMode = "head"
+	seekTail seekMode = "tail"
+)
+
+func (m *seekMode) Unpack(s string) error {
+	switch mode := seekMode(strings.ToLower(s)); mode {
+	case seekHead, seekTail:
+		*m = mode
+		return nil
+	}
+	return fmt.Errorf("invalid seek mode '%v', expected head or tail", s)
+}
+
+// priority is the highest (least important) syslog severity of the entries
+// to read. It can be set by number or by name, -1 reads all entries.
+type priority int
+
+var priorityNames = map[string]priority{
+	"emerg":         0,
+	"emergency":     0,
+	"alert":         1,
+	"crit":          2,
+	"critical":      2,
+	"err":           3,
+	"error":         3,
+	"warning":       4,
+	"warn":          4,
+	"notice":        5,
+	"info":          6,
+	"informational": 6,
+	"debug":         7,
+}
+
+func (p *priority) Unpack(v interface{}) error {
+	var n int64
+	switch v := v.(type) {
+	case int64:
+		n = v
+	case uint64:
+		n = int64(v)
+	case string:
+		if named, ok := priorityNames[strings.ToLower(v)]; ok {
+			*p = named
+			return nil
+		}
+		var err error
+		if n, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("invalid priority '%v'", v)
+		}
+	default:
+		return fmt.Errorf("invalid priority '%v'", v)
+	}
+
+	if n < 0 || n > 7 {
+		return fmt.Errorf("priority %v out of range, expected 0-7", n)
+	}
+	*p = priority(n)
+	return nil
+}
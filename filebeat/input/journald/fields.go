@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journald
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/filebeat/input/journald/journal"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+type fieldConversion struct {
+	name    string
+	integer bool
+}
+
+// journaldFields maps the well-known journal fields to event fields. All other
+// fields are added to journald.custom.
+var journaldFields = map[string]fieldConversion{
+	"MESSAGE":           {"message", false},
+	"MESSAGE_ID":        {"journald.message_id", false},
+	"PRIORITY":          {"event.severity", true},
+	"SYSLOG_FACILITY":   {"syslog.facility", true},
+	"SYSLOG_IDENTIFIER": {"syslog.identifier", false},
+	"SYSLOG_PID":        {"syslog.pid", true},
+	"CODE_FILE":         {"journald.code.file", false},
+	"CODE_LINE":         {"journald.code.line", true},
+	"CODE_FUNC":         {"journald.code.func", false},
+
+	"_PID":            {"process.pid", true},
+	"_UID":            {"process.uid", true},
+	"_GID":            {"process.gid", true},
+	"_COMM":           {"process.name", false},
+	"_EXE":            {"process.executable", false},
+	"_CMDLINE":        {"process.cmd", false},
+	"_CAP_EFFECTIVE":  {"process.capabilities", false},
+	"_AUDIT_SESSION":  {"process.audit.session", false},
+	"_AUDIT_LOGINUID": {"process.audit.login_uid", true},
+
+	"_SYSTEMD_UNIT":          {"systemd.unit", false},
+	"_SYSTEMD_USER_UNIT":     {"systemd.user_unit", false},
+	"_SYSTEMD_SLICE":         {"systemd.slice", false},
+	"_SYSTEMD_CGROUP":        {"systemd.cgroup", false},
+	"_SYSTEMD_SESSION":       {"systemd.session", false},
+	"_SYSTEMD_OWNER_UID":     {"systemd.owner_uid", true},
+	"_SYSTEMD_INVOCATION_ID": {"systemd.invocation_id", false},
+	"_TRANSPORT":             {"systemd.transport", false},
+
+	"_HOSTNAME":   {"journald.host.hostname", false},
+	"_BOOT_ID":    {"journald.host.boot_id", false},
+	"_MACHINE_ID": {"journald.host.machine_id", false},
+}
+
+// createEvent converts a journal entry into an event.
+func createEvent(e *journal.Entry, source string) beat.Event {
+	fields := common.MapStr{
+		"source": source,
+	}
+
+	custom := common.MapStr{}
+	for name, value := range e.Fields {
+		if conv, ok := journaldFields[name]; ok {
+			if !conv.integer {
+				fields.Put(conv.name, value)
+				continue
+			}
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				fields.Put(conv.name, n)
+				continue
+			}
+		}
+		custom[strings.ToLower(strings.TrimLeft(name, "_"))] = value
+	}
+
+	if len(custom) > 0 {
+		fields.Put("journald.custom", custom)
+	}
+
+	return beat.Event{
+		Timestamp: e.Time(),
+		Fields:    fields,
+	}
+}
+
+// matcher filters the entries by unit, syslog identifier and priority. An
+// entry must match all configured filters.
+type matcher struct {
+	units       map[string]bool
+	identifiers map[string]bool
+	priority    priority
+}
+
+func newMatcher(config *config) *matcher {
+	m := &matcher{priority: config.Priority}
+
+	if len(config.Units) > 0 {
+		m.units = map[string]bool{}
+		for _, unit := range config.Units {
+			// Like journalctl, use the service unit if no unit type is given.
+			if !strings.Contains(unit, ".") {
+				unit += ".service"
+			}
+			m.units[unit] = true
+		}
+	}
+
+	if len(config.Identifiers) > 0 {
+		m.identifiers = map[string]bool{}
+		for _, identifier := range config.Identifiers {
+			m.identifiers[identifier] = true
+		}
+	}
+
+	return m
+}
+
+func (m *matcher) match(fields map[string]string) bool {
+	if m.units != nil && !m.matchUnit(fields) {
+		return false
+	}
+
+	if m.identifiers != nil && !m.identifiers[fields["SYSLOG_IDENTIFIER"]] {
+		return false
+	}
+
+	if m.priority >= 0 {
+		p, err := strconv.Atoi(fields["PRIORITY"])
+		if err != nil || priority(p) > m.priority {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchUnit matches the entries written by the unit and the messages of
+// systemd and coredumps about the unit.
+func (m *matcher) matchUnit(fields map[string]string) bool {
+	if m.units[fields["_SYSTEMD_UNIT"]] || m.units[fields["COREDUMP_UNIT"]] {
+		return true
+	}
+	return fields["_PID"] == "1" && (m.units[fields["UNIT"]] || m.units[fields["OBJECT_SYSTEMD_UNIT"]])
+}
@@ -0,0 +1,199 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journald
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/input/journald/journal"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+func init() {
+	err := input.Register("journald", NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input reads entries from the journal files written by systemd-journald.
+// The cursor of the last published entry is stored in the registry.
+type Input struct {
+	config    config
+	matcher   *matcher
+	outlet    channel.Outleter
+	forwarder *harvester.Forwarder
+	state     file.State
+	cursor    *journal.Cursor
+	log       *logp.Logger
+
+	runOnce  sync.Once
+	stopOnce sync.Once
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewInput creates a new journald input
+func NewInput(
+	cfg *common.Config,
+	outlet channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("Journald input is used")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	out, err := outlet(cfg, context.DynamicFields)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Input{
+		config:    config,
+		matcher:   newMatcher(&config),
+		outlet:    out,
+		forwarder: harvester.NewForwarder(out),
+		state:     newState(&config),
+		log:       logp.NewLogger("journald input").With("paths", config.Paths),
+		done:      make(chan struct{}),
+	}
+
+	for _, state := range context.States {
+		if state.ID() != p.state.ID() || state.Cursor == "" {
+			continue
+		}
+
+		cursor, err := journal.ParseCursor(state.Cursor)
+		if err != nil {
+			p.log.Warnw("Ignoring invalid cursor in registry", "error", err)
+			break
+		}
+		p.cursor = &cursor
+		p.state.Cursor = state.Cursor
+		break
+	}
+
+	return p, nil
+}
+
+// newState creates the registry state of the input. The state is not related
+// to a file, it's identified by the input ID or the paths.
+func newState(config *config) file.State {
+	id := config.ID
+	if id == "" {
+		id = strings.Join(config.Paths, ",")
+	}
+
+	return file.State{
+		Source: strings.Join(config.Paths, ","),
+		Type:   config.Type,
+		TTL:    -1,
+		Meta:   map[string]string{"journald": id},
+	}
+}
+
+// Run starts reading the journal, the input runs until it is stopped.
+func (p *Input) Run() {
+	p.runOnce.Do(func() {
+		p.log.Info("Starting journald input")
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.run()
+		}()
+	})
+}
+
+// Stop stops reading the journal.
+func (p *Input) Stop() {
+	p.stopOnce.Do(func() {
+		p.log.Info("Stopping journald input")
+		close(p.done)
+		p.outlet.Close()
+		p.wg.Wait()
+	})
+}
+
+// Wait stops the journald input.
+func (p *Input) Wait() {
+	p.Stop()
+}
+
+func (p *Input) run() {
+	r := newReader(p.config.Paths, p.config.Seek, p.cursor, p.log)
+	defer r.close()
+
+	r.scan()
+	lastScan := time.Now()
+
+	for {
+		if time.Since(lastScan) >= p.config.ScanFrequency {
+			r.scan()
+			lastScan = time.Now()
+		}
+
+		e, source, err := r.next()
+		if err == io.EOF {
+			select {
+			case <-p.done:
+				return
+			case <-time.After(p.config.Backoff):
+			}
+			continue
+		}
+
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+
+		if !p.matcher.match(e.Fields) {
+			continue
+		}
+
+		if !p.publish(e, source) {
+			return
+		}
+	}
+}
+
+func (p *Input) publish(e *journal.Entry, source string) bool {
+	p.state.Cursor = e.Cursor().String()
+	p.state.Timestamp = time.Now()
+
+	data := util.NewData()
+	data.Event = createEvent(e, source)
+	data.SetState(p.state)
+
+	return p.forwarder.Send(data) == nil
+}
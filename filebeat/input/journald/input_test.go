@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journald
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/input/journald/journal"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func readFixture(t *testing.T) []*journal.Entry {
+	j, err := journal.Open(compactFixture)
+	require.NoError(t, err)
+	defer j.Close()
+
+	var entries []*journal.Entry
+	for {
+		e, err := j.Next()
+		if err != nil {
+			return entries
+		}
+		entries = append(entries, e)
+	}
+}
+
+func TestCreateEvent(t *testing.T) {
+	entries := readFixture(t)
+
+	event := createEvent(entries[2], "/var/log/journal/system.journal")
+	assert.Equal(t, entries[2].Time(), event.Timestamp)
+
+	expected := map[string]interface{}{
+		"source":                    "/var/log/journal/system.journal",
+		"message":                   "nginx started",
+		"event.severity":            int64(6),
+		"syslog.facility":           int64(3),
+		"syslog.identifier":         "nginx",
+		"systemd.unit":              "nginx.service",
+		"systemd.slice":             "system.slice",
+		"systemd.cgroup":            "/system.slice/nginx.service",
+		"systemd.transport":         "journal",
+		"process.name":              "python3",
+		"process.uid":               int64(0),
+		"journald.code.file":        "main.c",
+		"journald.code.line":        int64(42),
+		"journald.code.func":        "main",
+		"journald.host.hostname":    "vm",
+		"journald.host.boot_id":     "a1a0994e6fc64468a0a4251f223ce3a2",
+		"journald.custom.namespace": "fixture",
+	}
+	for key, value := range expected {
+		v, err := event.Fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, v, key)
+		}
+	}
+
+	pid, err := event.Fields.GetValue("process.pid")
+	require.NoError(t, err)
+	assert.IsType(t, int64(0), pid)
+
+	custom := createEvent(entries[3], "").Fields["journald"].(common.MapStr)["custom"]
+	assert.Equal(t, "abc123", custom.(common.MapStr)["request_id"])
+}
+
+func TestMatcher(t *testing.T) {
+	entries := readFixture(t)
+
+	tests := []struct {
+		settings map[string]interface{}
+		count    int
+	}{
+		{map[string]interface{}{}, 28},
+		{map[string]interface{}{"units": []string{"nginx"}}, 2},
+		{map[string]interface{}{"units": []string{"nginx.service", "ssh.service"}}, 4},
+		{map[string]interface{}{"identifiers": []string{"counter"}}, 20},
+		{map[string]interface{}{"priority": "err"}, 1},
+		{map[string]interface{}{"priority": 5}, 2},
+		{map[string]interface{}{"units": []string{"ssh"}, "priority": "notice"}, 1},
+		{map[string]interface{}{"units": []string{"ssh"}, "identifiers": []string{"nginx"}}, 0},
+	}
+
+	for _, test := range tests {
+		config := defaultConfig()
+		require.NoError(t, common.MustNewConfigFrom(test.settings).Unpack(&config))
+
+		m := newMatcher(&config)
+		count := 0
+		for _, e := range entries {
+			if m.match(e.Fields) {
+				count++
+			}
+		}
+		assert.Equal(t, test.count, count, "%v", test.settings)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	for _, settings := range []map[string]interface{}{
+		{"priority": "verbose"},
+		{"priority": 8},
+		{"seek": "cursor"},
+		{"backoff": 0},
+	} {
+		config := defaultConfig()
+		assert.Error(t, common.MustNewConfigFrom(settings).Unpack(&config), "%v", settings)
+	}
+}
+
+type mockOutlet struct {
+	sync.Mutex
+	data []*util.Data
+}
+
+func (o *mockOutlet) OnEvent(data *util.Data) bool {
+	o.Lock()
+	defer o.Unlock()
+	o.data = append(o.data, data)
+	return true
+}
+
+func (o *mockOutlet) Close() error { return nil }
+
+func (o *mockOutlet) count() int {
+	o.Lock()
+	defer o.Unlock()
+	return len(o.data)
+}
+
+func TestInputResumesFromRegistry(t *testing.T) {
+	entries := readFixture(t)
+
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"paths":       []string{compactFixture},
+		"identifiers": []string{"counter"},
+		"backoff":     "10ms",
+	})
+
+	config := defaultConfig()
+	require.NoError(t, cfg.Unpack(&config))
+	state := newState(&config)
+	state.Cursor = entries[10].Cursor().String()
+
+	out := &mockOutlet{}
+	connector := func(*common.Config, *common.MapStrPointer) (channel.Outleter, error) {
+		return out, nil
+	}
+
+	p, err := NewInput(cfg, connector, input.Context{States: []file.State{state}})
+	require.NoError(t, err)
+	p.Run()
+	defer p.Stop()
+
+	// Entries 7 to 26 are written by counter, the first 5 were already read.
+	for i := 0; i < 100 && out.count() < 15; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	p.Stop()
+
+	require.Len(t, out.data, 15)
+	first := out.data[0]
+	assert.Equal(t, "message 5", first.GetEvent().Fields["message"])
+	assert.Equal(t, entries[11].Cursor().String(), first.GetState().Cursor)
+
+	last := out.data[14].GetState()
+	assert.Equal(t, entries[25].Cursor().String(), last.Cursor)
+	assert.Equal(t, state.ID(), last.ID())
+	assert.Equal(t, "journald", last.Type)
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+	"github.com/ulikunitz/xz"
+)
+
+// maxDecompressedSize protects against corrupted size prefixes of LZ4 data.
+const maxDecompressedSize = 64 << 20
+
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+
+// decompress returns the payload of a data object, decompressed according to
+// the object flags.
+func decompress(flags uint8, payload []byte) ([]byte, error) {
+	switch {
+	case flags&objectCompressedZSTD != 0:
+		return zstdDecoder.DecodeAll(payload, nil)
+
+	case flags&objectCompressedXZ != 0:
+		r, err := xz.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(r)
+
+	case flags&objectCompressedLZ4 != 0:
+		return decompressLZ4(payload)
+	}
+	return payload, nil
+}
+
+// decompressLZ4 decodes a LZ4 block prefixed with the uncompressed size.
+func decompressLZ4(payload []byte) (out []byte, err error) {
+	if len(payload) < 8 {
+		return nil, fmt.Errorf("LZ4 data too short")
+	}
+
+	size := binary.LittleEndian.Uint64(payload)
+	if size > maxDecompressedSize {
+		return nil, fmt.Errorf("LZ4 data too large (%v bytes)", size)
+	}
+
+	// The LZ4 decoder doesn't check all bounds on invalid input.
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, fmt.Errorf("invalid LZ4 data: %v", r)
+		}
+	}()
+
+	out = make([]byte, size)
+	n, err := lz4.UncompressBlock(payload[8:], out, 0)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journal
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ID128 is a 128-bit ID as used for boot, machine and file IDs.
+type ID128 [16]byte
+
+// String returns the ID in the lowercase hex format used by systemd.
+func (id ID128) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// parseID128 parses the hex format of an ID.
+func parseID128(s string) (ID128, error) {
+	var id ID128
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(id) {
+		return id, fmt.Errorf("invalid ID '%v'", s)
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// Entry is a journal entry.
+type Entry struct {
+	SeqnumID  ID128
+	Seqnum    uint64
+	BootID    ID128
+	Realtime  uint64 // microseconds since the epoch
+	Monotonic uint64 // microseconds since boot
+	XorHash   uint64
+	Fields    map[string]string
+}
+
+// Time returns the time the entry was received by journald.
+func (e *Entry) Time() time.Time {
+	return time.Unix(0, int64(e.Realtime)*int64(time.Microsecond))
+}
+
+// Cursor returns the cursor pointing to the entry.
+func (e *Entry) Cursor() Cursor {
+	return Cursor{
+		SeqnumID:  e.SeqnumID,
+		Seqnum:    e.Seqnum,
+		BootID:    e.BootID,
+		Realtime:  e.Realtime,
+		Monotonic: e.Monotonic,
+		XorHash:   e.XorHash,
+	}
+}
+
+// Before reports whether the entry was written before the other entry. Entries
+// sharing the sequence number space are compared by sequence number, others by
+// their realtime timestamp.
+func (e *Entry) Before(other *Entry) bool {
+	if e.SeqnumID == other.SeqnumID {
+		return e.Seqnum < other.Seqnum
+	}
+	return e.Realtime < other.Realtime
+}
+
+// Cursor identifies the position of an entry in the journal. The string
+// format is compatible with the cursors used by journalctl.
+type Cursor struct {
+	SeqnumID  ID128
+	Seqnum    uint64
+	BootID    ID128
+	Realtime  uint64
+	Monotonic uint64
+	XorHash   uint64
+}
+
+func (c Cursor) String() string {
+	return fmt.Sprintf("s=%v;i=%x;b=%v;m=%x;t=%x;x=%x",
+		c.SeqnumID, c.Seqnum, c.BootID, c.Monotonic, c.Realtime, c.XorHash)
+}
+
+// ParseCursor parses a cursor in the format used by journalctl.
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+	var found int
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return c, fmt.Errorf("invalid cursor '%v'", s)
+		}
+
+		var err error
+		switch kv[0] {
+		case "s":
+			c.SeqnumID, err = parseID128(kv[1])
+		case "i":
+			c.Seqnum, err = strconv.ParseUint(kv[1], 16, 64)
+		case "b":
+			c.BootID, err = parseID128(kv[1])
+		case "m":
+			c.Monotonic, err = strconv.ParseUint(kv[1], 16, 64)
+		case "t":
+			c.Realtime, err = strconv.ParseUint(kv[1], 16, 64)
+		case "x":
+			c.XorHash, err = strconv.ParseUint(kv[1], 16, 64)
+		default:
+			continue
+		}
+		if err != nil {
+			return c, fmt.Errorf("invalid cursor '%v': %v", s, err)
+		}
+		found++
+	}
+
+	if found != 6 {
+		return c, fmt.Errorf("incomplete cursor '%v'", s)
+	}
+	return c, nil
+}
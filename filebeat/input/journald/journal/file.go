@@ -0,0 +1,482 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Layout of the journal files as documented in
+// https://systemd.io/JOURNAL_FILE_FORMAT/. All numbers are little endian.
+
+const signature = "LPKSHHRH"
+
+// Incompatible header flags.
+const (
+	incompatibleCompressedXZ   = 1 << 0
+	incompatibleCompressedLZ4  = 1 << 1
+	incompatibleKeyedHash      = 1 << 2
+	incompatibleCompressedZSTD = 1 << 3
+	incompatibleCompact        = 1 << 4
+
+	incompatibleSupported = incompatibleCompressedXZ | incompatibleCompressedLZ4 |
+		incompatibleKeyedHash | incompatibleCompressedZSTD | incompatibleCompact
+)
+
+// Object types.
+const (
+	objectData       = 1
+	objectEntry      = 3
+	objectEntryArray = 6
+)
+
+// Object flags.
+const (
+	objectCompressedXZ   = 1 << 0
+	objectCompressedLZ4  = 1 << 1
+	objectCompressedZSTD = 1 << 2
+)
+
+const (
+	// minHeaderSize is the size of the header written by the oldest journal
+	// versions. All fields used by the reader are part of it.
+	minHeaderSize = 208
+
+	objectHeaderSize = 16
+	entryHeaderSize  = objectHeaderSize + 48
+	arrayHeaderSize  = objectHeaderSize + 8
+
+	// maxObjectSize protects against corrupted files.
+	maxObjectSize = 256 << 20
+)
+
+// ErrCorruptedTail is returned by Next if the remaining entries of a file can
+// not be read, because the file is truncated or the entry arrays are broken.
+// journald doesn't append to such files, but rotates them.
+var ErrCorruptedTail = errors.New("journal file has a corrupted tail")
+
+var (
+	errTruncated = errors.New("unexpected end of file")
+	errNotLinked = errors.New("entry is not linked")
+)
+
+type header struct {
+	incompatibleFlags uint32
+	state             uint8
+	fileID            ID128
+	machineID         ID128
+	seqnumID          ID128
+	headerSize        uint64
+	arenaSize         uint64
+	nEntries          uint64
+	entryArrayOffset  uint64
+}
+
+// File reads the entries of a single journal file. Entries appended to the
+// file by journald while it is open are picked up by subsequent calls to Next.
+type File struct {
+	path    string
+	file    *os.File
+	header  header
+	compact bool
+
+	// index of the entry returned by the next call to Next
+	next uint64
+
+	// set once the remaining entries can not be read
+	corrupted bool
+
+	// the entry array containing the most recently resolved entry index
+	array struct {
+		offset uint64
+		first  uint64 // index of the first item
+		items  []uint64
+		nextOf uint64 // offset of the next entry array
+	}
+}
+
+// Open opens a journal file for reading, positioned at its first entry.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &File{path: path, file: f}
+	if err := j.readHeader(); err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "failed to read journal file %v", path)
+	}
+	return j, nil
+}
+
+// Close closes the journal file.
+func (j *File) Close() error {
+	return j.file.Close()
+}
+
+// Path returns the path the file was opened with.
+func (j *File) Path() string {
+	return j.path
+}
+
+// FileID returns the unique ID of the journal file. It doesn't change when
+// journald renames the file during rotation.
+func (j *File) FileID() ID128 {
+	return j.header.fileID
+}
+
+// SeqnumID returns the ID of the sequence number space of the entries.
+func (j *File) SeqnumID() ID128 {
+	return j.header.seqnumID
+}
+
+func (j *File) readHeader() error {
+	var buf [minHeaderSize]byte
+	if _, err := j.file.ReadAt(buf[:], 0); err != nil {
+		if err == io.EOF {
+			return errors.New("file too small")
+		}
+		return err
+	}
+
+	if string(buf[:8]) != signature {
+		return errors.New("invalid signature")
+	}
+
+	h := header{
+		incompatibleFlags: le32(buf[12:]),
+		state:             buf[16],
+		headerSize:        le64(buf[88:]),
+		arenaSize:         le64(buf[96:]),
+		nEntries:          le64(buf[152:]),
+		entryArrayOffset:  le64(buf[176:]),
+	}
+	copy(h.fileID[:], buf[24:40])
+	copy(h.machineID[:], buf[40:56])
+	copy(h.seqnumID[:], buf[72:88])
+
+	if unsupported := h.incompatibleFlags &^ incompatibleSupported; unsupported != 0 {
+		return fmt.Errorf("unsupported incompatible flags 0x%x", unsupported)
+	}
+	if h.headerSize < minHeaderSize {
+		return fmt.Errorf("invalid header size %v", h.headerSize)
+	}
+
+	j.header = h
+	j.compact = h.incompatibleFlags&incompatibleCompact != 0
+	return nil
+}
+
+// SeekHead positions the reader at the first entry.
+func (j *File) SeekHead() {
+	j.next = 0
+}
+
+// SeekTail positions the reader after the last entry written so far.
+func (j *File) SeekTail() error {
+	if err := j.readHeader(); err != nil {
+		return err
+	}
+	j.next = j.header.nEntries
+	return nil
+}
+
+// SeekCursor positions the reader after the entry identified by the cursor.
+// If the cursor belongs to the sequence number space of the file, the
+// sequence number is used. Otherwise the reader is positioned after all
+// entries with an older or equal realtime timestamp.
+func (j *File) SeekCursor(c Cursor) error {
+	if err := j.readHeader(); err != nil {
+		return err
+	}
+
+	sameSeqnum := c.SeqnumID == j.header.seqnumID
+	var seekErr error
+	n := sort.Search(int(j.header.nEntries), func(i int) bool {
+		if seekErr != nil {
+			return true
+		}
+		e, err := j.entryHeaderAt(uint64(i))
+		if err != nil {
+			seekErr = err
+			return true
+		}
+		if sameSeqnum {
+			return e.Seqnum > c.Seqnum
+		}
+		return e.Realtime > c.Realtime
+	})
+	if seekErr != nil {
+		return seekErr
+	}
+
+	j.next = uint64(n)
+	return nil
+}
+
+// Next returns the next entry. io.EOF is returned if no more entries are
+// available yet. An entry that can not be read is skipped, so the error is
+// returned only once. If the end of the file is corrupted, an error caused by
+// ErrCorruptedTail is returned and no more entries are read from the file.
+func (j *File) Next() (*Entry, error) {
+	if j.corrupted {
+		return nil, ErrCorruptedTail
+	}
+
+	if j.next >= j.header.nEntries {
+		if err := j.readHeader(); err != nil {
+			return nil, err
+		}
+		if j.next >= j.header.nEntries {
+			return nil, io.EOF
+		}
+	}
+
+	offset, err := j.entryOffset(j.next)
+	if err != nil && errors.Cause(err) != errNotLinked {
+		// The entry arrays are broken, so the remaining entries can't be found.
+		return nil, j.corruptedTail(err)
+	}
+	if err == nil {
+		var e *Entry
+		if e, err = j.readEntry(offset); err == nil {
+			j.next++
+			return e, nil
+		}
+		if errors.Cause(err) == errTruncated {
+			return nil, j.corruptedTail(err)
+		}
+	}
+
+	err = errors.Wrapf(err, "skipping entry %v of journal file %v", j.next, j.path)
+	j.next++
+	return nil, err
+}
+
+func (j *File) corruptedTail(err error) error {
+	j.corrupted = true
+	return errors.Wrapf(ErrCorruptedTail, "failed to read entry %v of journal file %v: %v", j.next, j.path, err)
+}
+
+func (j *File) entryHeaderAt(i uint64) (*Entry, error) {
+	offset, err := j.entryOffset(i)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, entryHeaderSize)
+	if err := j.readObject(offset, objectEntry, buf); err != nil {
+		return nil, err
+	}
+	return j.parseEntryHeader(buf), nil
+}
+
+// entryOffset resolves the offset of the i-th entry by walking the chain of
+// entry arrays.
+func (j *File) entryOffset(i uint64) (uint64, error) {
+	a := &j.array
+	if a.offset == 0 || i < a.first {
+		if j.header.entryArrayOffset == 0 {
+			return 0, errors.New("journal file has no entry array")
+		}
+		if err := j.loadArray(j.header.entryArrayOffset, 0); err != nil {
+			return 0, err
+		}
+	}
+
+	for i >= a.first+uint64(len(a.items)) {
+		if a.nextOf == 0 {
+			// The file might have grown since the array was loaded.
+			if err := j.loadArray(a.offset, a.first); err != nil {
+				return 0, err
+			}
+			if a.nextOf == 0 {
+				return 0, fmt.Errorf("entry %v not found in entry arrays", i)
+			}
+		}
+		if err := j.loadArray(a.nextOf, a.first+uint64(len(a.items))); err != nil {
+			return 0, err
+		}
+	}
+
+	offset := a.items[i-a.first]
+	if offset == 0 {
+		// The array slot might have been written after the array was loaded.
+		if err := j.loadArray(a.offset, a.first); err != nil {
+			return 0, err
+		}
+		if i-a.first < uint64(len(a.items)) {
+			offset = a.items[i-a.first]
+		}
+		if offset == 0 {
+			return 0, errors.Wrapf(errNotLinked, "entry %v", i)
+		}
+	}
+	return offset, nil
+}
+
+func (j *File) loadArray(offset, first uint64) error {
+	buf, err := j.readFullObject(offset, objectEntryArray)
+	if err != nil {
+		return err
+	}
+	if len(buf) < arrayHeaderSize {
+		return fmt.Errorf("entry array at %v is too small", offset)
+	}
+
+	a := &j.array
+	a.offset = offset
+	a.first = first
+	a.nextOf = le64(buf[objectHeaderSize:])
+	a.items = a.items[:0]
+
+	items := buf[arrayHeaderSize:]
+	if j.compact {
+		for len(items) >= 4 {
+			a.items = append(a.items, uint64(le32(items)))
+			items = items[4:]
+		}
+	} else {
+		for len(items) >= 8 {
+			a.items = append(a.items, le64(items))
+			items = items[8:]
+		}
+	}
+	return nil
+}
+
+func (j *File) parseEntryHeader(buf []byte) *Entry {
+	e := &Entry{
+		SeqnumID:  j.header.seqnumID,
+		Seqnum:    le64(buf[16:]),
+		Realtime:  le64(buf[24:]),
+		Monotonic: le64(buf[32:]),
+		XorHash:   le64(buf[56:]),
+	}
+	copy(e.BootID[:], buf[40:56])
+	return e
+}
+
+func (j *File) readEntry(offset uint64) (*Entry, error) {
+	buf, err := j.readFullObject(offset, objectEntry)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < entryHeaderSize {
+		return nil, fmt.Errorf("entry at %v is too small", offset)
+	}
+
+	e := j.parseEntryHeader(buf)
+
+	var dataOffsets []uint64
+	items := buf[entryHeaderSize:]
+	if j.compact {
+		for ; len(items) >= 4; items = items[4:] {
+			dataOffsets = append(dataOffsets, uint64(le32(items)))
+		}
+	} else {
+		for ; len(items) >= 16; items = items[16:] {
+			dataOffsets = append(dataOffsets, le64(items))
+		}
+	}
+
+	e.Fields = make(map[string]string, len(dataOffsets))
+	for _, dataOffset := range dataOffsets {
+		if dataOffset == 0 {
+			continue
+		}
+		payload, err := j.readData(dataOffset)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, c := range payload {
+			if c == '=' {
+				e.Fields[string(payload[:i])] = string(payload[i+1:])
+				break
+			}
+		}
+	}
+	return e, nil
+}
+
+func (j *File) readData(offset uint64) ([]byte, error) {
+	buf, err := j.readFullObject(offset, objectData)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadOffset := objectHeaderSize + 48
+	if j.compact {
+		payloadOffset += 8
+	}
+	if len(buf) < payloadOffset {
+		return nil, fmt.Errorf("data object at %v is too small", offset)
+	}
+
+	return decompress(buf[1], buf[payloadOffset:])
+}
+
+// readFullObject reads the complete object at the offset.
+func (j *File) readFullObject(offset uint64, typ uint8) ([]byte, error) {
+	var hdr [objectHeaderSize]byte
+	if err := j.readObject(offset, typ, hdr[:]); err != nil {
+		return nil, err
+	}
+
+	size := le64(hdr[8:])
+	if size < objectHeaderSize || size > maxObjectSize {
+		return nil, fmt.Errorf("invalid size %v of object at %v", size, offset)
+	}
+
+	buf := make([]byte, size)
+	if err := j.readObject(offset, typ, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// readObject fills buf with the start of the object at the offset and
+// checks the object type.
+func (j *File) readObject(offset uint64, typ uint8, buf []byte) error {
+	if offset < j.header.headerSize || offset%8 != 0 {
+		return fmt.Errorf("invalid object offset %v", offset)
+	}
+
+	if _, err := j.file.ReadAt(buf, int64(offset)); err != nil {
+		if err == io.EOF {
+			return errors.Wrapf(errTruncated, "object at %v", offset)
+		}
+		return err
+	}
+
+	if buf[0] != typ {
+		return fmt.Errorf("expected object type %v at %v, found %v", typ, offset, buf[0])
+	}
+	return nil
+}
+
+func le32(b []byte) uint32 { return binary.LittleEndian.Uint32(b) }
+func le64(b []byte) uint64 { return binary.LittleEndian.Uint64(b) }
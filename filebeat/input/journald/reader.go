@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journald
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/filebeat/input/journald/journal"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// reader reads the entries of all journal files found in the configured
+// paths, merged in the order they were written. New files are picked up by
+// scan, so the rotation of journal files is handled.
+type reader struct {
+	paths []string
+	seek  seekMode
+	log   *logp.Logger
+
+	files map[journal.ID128]*journalFile
+
+	// cursor of the last entry returned
+	cursor *journal.Cursor
+
+	// scanned is set after the first scan, files found later are new and
+	// are read from the beginning
+	scanned bool
+}
+
+type journalFile struct {
+	*journal.File
+	path string
+
+	// the next entry of the file, if already read
+	next *journal.Entry
+
+	// set if the end of the file is corrupted. No more entries are read from
+	// the file. journald continues writing to a new file.
+	corrupted bool
+}
+
+func newReader(paths []string, seek seekMode, cursor *journal.Cursor, log *logp.Logger) *reader {
+	return &reader{
+		paths:  paths,
+		seek:   seek,
+		log:    log,
+		files:  map[journal.ID128]*journalFile{},
+		cursor: cursor,
+	}
+}
+
+// scan looks for new journal files and closes files that were removed.
+func (r *reader) scan() {
+	found := map[string]bool{}
+	for _, path := range r.journalPaths() {
+		found[path] = true
+		if r.isOpen(path) {
+			continue
+		}
+
+		if err := r.open(path); err != nil {
+			r.log.Warnw("Failed to open journal file", "path", path, "error", err)
+		}
+	}
+
+	for id, f := range r.files {
+		if !found[f.path] {
+			r.log.Debugw("Closing removed journal file", "path", f.path)
+			f.Close()
+			delete(r.files, id)
+		}
+	}
+
+	r.scanned = true
+}
+
+// journalPaths returns the journal files in the configured paths. Paths can
+// be journal files or directories. Directories are searched for journal files
+// and for journal files in their subdirectories, as journald creates one
+// subdirectory per machine ID.
+func (r *reader) journalPaths() []string {
+	var paths []string
+	for _, path := range r.paths {
+		if strings.HasSuffix(path, ".journal") {
+			paths = append(paths, path)
+			continue
+		}
+
+		for _, pattern := range []string{"*.journal", "*/*.journal"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				r.log.Warnw("Invalid journal path", "path", path, "error", err)
+				continue
+			}
+			paths = append(paths, matches...)
+		}
+	}
+	return paths
+}
+
+func (r *reader) isOpen(path string) bool {
+	for _, f := range r.files {
+		if f.path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reader) open(path string) error {
+	j, err := journal.Open(path)
+	if err != nil {
+		return err
+	}
+
+	// journald renames files when rotating them.
+	if f, exists := r.files[j.FileID()]; exists {
+		r.log.Debugw("Journal file was renamed", "old", f.path, "new", path)
+		f.path = path
+		return j.Close()
+	}
+
+	switch {
+	case r.cursor != nil:
+		err = j.SeekCursor(*r.cursor)
+	case !r.scanned && r.seek == seekTail:
+		err = j.SeekTail()
+	default:
+		j.SeekHead()
+	}
+	if err != nil {
+		j.Close()
+		return err
+	}
+
+	r.log.Debugw("Opened journal file", "path", path)
+	r.files[j.FileID()] = &journalFile{File: j, path: path}
+	return nil
+}
+
+// next returns the oldest entry not read yet of all journal files and the path
+// of the file it was read from. io.EOF is returned if no new entries are
+// available.
+func (r *reader) next() (*journal.Entry, string, error) {
+	var oldest *journalFile
+	for _, f := range r.files {
+		if f.next == nil {
+			r.readNext(f)
+		}
+		if f.next == nil {
+			continue
+		}
+
+		if oldest == nil || f.next.Before(oldest.next) {
+			oldest = f
+		}
+	}
+
+	if oldest == nil {
+		return nil, "", io.EOF
+	}
+
+	e := oldest.next
+	oldest.next = nil
+	cursor := e.Cursor()
+	r.cursor = &cursor
+	return e, oldest.path, nil
+}
+
+// readNext reads the next entry of a file. Entries that can't be read are
+// skipped.
+func (r *reader) readNext(f *journalFile) {
+	for !f.corrupted {
+		e, err := f.Next()
+		switch {
+		case err == nil:
+			f.next = e
+			return
+		case err == io.EOF:
+			return
+		case errors.Cause(err) == journal.ErrCorruptedTail:
+			r.log.Errorw("Stop reading journal file with corrupted tail until it is rotated", "path", f.path, "error", err)
+			f.corrupted = true
+		default:
+			r.log.Warnw("Skipping journal entry that can't be read", "path", f.path, "error", err)
+		}
+	}
+}
+
+func (r *reader) close() {
+	for id, f := range r.files {
+		f.Close()
+		delete(r.files, id)
+	}
+}
@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journald
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/input/journald/journal"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+const (
+	compactFixture = "journal/testdata/compact-zstd.journal"
+	regularFixture = "journal/testdata/regular-zstd.journal"
+)
+
+// setupJournalDir creates a journal directory containing the fixtures in a
+// machine ID subdirectory, like journald does.
+func setupJournalDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "journald")
+	require.NoError(t, err)
+
+	machineDir := filepath.Join(dir, "fed6b2924c424cf1b9a322f606b4de6d")
+	require.NoError(t, os.Mkdir(machineDir, 0755))
+
+	for _, fixture := range []string{compactFixture, regularFixture} {
+		data, err := ioutil.ReadFile(fixture)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(machineDir, filepath.Base(fixture)), data, 0644))
+	}
+	return dir
+}
+
+func readEntries(t *testing.T, r *reader) []*journal.Entry {
+	var entries []*journal.Entry
+	for {
+		e, _, err := r.next()
+		if err == io.EOF {
+			return entries
+		}
+		require.NoError(t, err)
+		entries = append(entries, e)
+	}
+}
+
+func TestReaderMergesFiles(t *testing.T) {
+	dir := setupJournalDir(t)
+	defer os.RemoveAll(dir)
+
+	r := newReader([]string{dir}, seekHead, nil, logp.NewLogger("test"))
+	defer r.close()
+	r.scan()
+	require.Len(t, r.files, 2)
+
+	entries := readEntries(t, r)
+	require.Len(t, entries, 56)
+	for i := 1; i < len(entries); i++ {
+		assert.False(t, entries[i].Before(entries[i-1]), "entry %v out of order", i)
+	}
+
+	// The compact file was written first.
+	assert.Equal(t, uint64(1), entries[28].Seqnum)
+	assert.NotEqual(t, entries[0].SeqnumID, entries[28].SeqnumID)
+}
+
+func TestReaderCursor(t *testing.T) {
+	dir := setupJournalDir(t)
+	defer os.RemoveAll(dir)
+
+	log := logp.NewLogger("test")
+	r := newReader([]string{dir}, seekHead, nil, log)
+	all := readEntriesAfterScan(t, r)
+	r.close()
+
+	cursor := all[30].Cursor()
+	r = newReader([]string{dir}, seekHead, &cursor, log)
+	defer r.close()
+	rest := readEntriesAfterScan(t, r)
+	require.Len(t, rest, 25)
+	assert.Equal(t, all[31].Cursor(), rest[0].Cursor())
+}
+
+func TestReaderSeekTail(t *testing.T) {
+	dir := setupJournalDir(t)
+	defer os.RemoveAll(dir)
+
+	r := newReader([]string{dir}, seekTail, nil, logp.NewLogger("test"))
+	defer r.close()
+	assert.Empty(t, readEntriesAfterScan(t, r))
+
+	// Files found after the first scan are read from the beginning.
+	machineDir := filepath.Join(dir, "fed6b2924c424cf1b9a322f606b4de6d")
+	require.NoError(t, os.Remove(filepath.Join(machineDir, "regular-zstd.journal")))
+	r.scan()
+	require.Len(t, r.files, 1)
+
+	data, err := ioutil.ReadFile(regularFixture)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(machineDir, "user-1000.journal"), data, 0644))
+	assert.Len(t, readEntriesAfterScan(t, r), 28)
+}
+
+func TestReaderRenamedFile(t *testing.T) {
+	dir := setupJournalDir(t)
+	defer os.RemoveAll(dir)
+
+	r := newReader([]string{dir}, seekHead, nil, logp.NewLogger("test"))
+	defer r.close()
+	require.Len(t, readEntriesAfterScan(t, r), 56)
+
+	// journald renames the active file when rotating it.
+	machineDir := filepath.Join(dir, "fed6b2924c424cf1b9a322f606b4de6d")
+	require.NoError(t, os.Rename(
+		filepath.Join(machineDir, "compact-zstd.journal"),
+		filepath.Join(machineDir, "system@0005-0001.journal")))
+
+	assert.Empty(t, readEntriesAfterScan(t, r))
+	assert.Len(t, r.files, 2)
+}
+
+func TestReaderSkipsCorruptedEntry(t *testing.T) {
+	dir := setupJournalDir(t)
+	defer os.RemoveAll(dir)
+
+	// overwrite the object type of the fifth entry
+	path := filepath.Join(dir, "fed6b2924c424cf1b9a322f606b4de6d", "compact-zstd.journal")
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	data[findEntry(t, data, 5)] = 0xff
+	require.NoError(t, ioutil.WriteFile(path, data, 0644))
+
+	r := newReader([]string{dir}, seekHead, nil, logp.NewLogger("test"))
+	defer r.close()
+	entries := readEntriesAfterScan(t, r)
+	require.Len(t, entries, 55)
+
+	// The compact file was written first.
+	var seqnums []uint64
+	for _, e := range entries {
+		if e.SeqnumID == entries[0].SeqnumID {
+			seqnums = append(seqnums, e.Seqnum)
+		}
+	}
+	require.Len(t, seqnums, 27)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 6, 7}, seqnums[:6])
+}
+
+func TestReaderCorruptedTail(t *testing.T) {
+	dir := setupJournalDir(t)
+	defer os.RemoveAll(dir)
+
+	// cut the file within the twentieth entry
+	path := filepath.Join(dir, "fed6b2924c424cf1b9a322f606b4de6d", "compact-zstd.journal")
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data[:findEntry(t, data, 20)+8], 0644))
+
+	r := newReader([]string{dir}, seekHead, nil, logp.NewLogger("test"))
+	defer r.close()
+	entries := readEntriesAfterScan(t, r)
+	require.Len(t, entries, 28+19)
+
+	corrupted := 0
+	for _, f := range r.files {
+		if f.corrupted {
+			corrupted++
+			assert.Equal(t, path, f.path)
+		}
+	}
+	assert.Equal(t, 1, corrupted)
+
+	// No more entries are read from the file.
+	assert.Empty(t, readEntriesAfterScan(t, r))
+}
+
+// findEntry returns the offset of the entry object with the sequence number.
+func findEntry(t *testing.T, data []byte, seqnum uint64) int {
+	const objectEntry = 3
+	for off := 0; off+64 <= len(data); off += 8 {
+		size := binary.LittleEndian.Uint64(data[off+8:])
+		if data[off] == objectEntry && size >= 64 && size < 4096 &&
+			binary.LittleEndian.Uint64(data[off+16:]) == seqnum {
+			return off
+		}
+	}
+	t.Fatalf("entry %v not found", seqnum)
+	return 0
+}
+
+func readEntriesAfterScan(t *testing.T, r *reader) []*journal.Entry {
+	r.scan()
+	return readEntries(t, r)
+}
@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cluster "github.com/bsm/sarama-cluster"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/common/kafka"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/reader/readjson"
+)
+
+type kafkaInputConfig struct {
+	harvester.ForwarderConfig `config:",inline"`
+	Hosts                     []string          `config:"hosts" validate:"required"`
+	Topics                    []string          `config:"topics" validate:"required"`
+	GroupID                   string            `config:"group_id" validate:"required"`
+	ClientID                  string            `config:"client_id"`
+	Version                   kafka.Version     `config:"version"`
+	InitialOffset             initialOffset     `config:"initial_offset"`
+	ConnectBackoff            time.Duration     `config:"connect_backoff" validate:"min=0"`
+	ConsumeBackoff            time.Duration     `config:"consume_backoff" validate:"min=0"`
+	MaxWaitTime               time.Duration     `config:"max_wait_time" validate:"min=1"`
+	Fetch                     fetchConfig       `config:"fetch"`
+	Rebalance                 rebalanceConfig   `config:"rebalance"`
+	TLS                       *tlscommon.Config `config:"ssl"`
+	Username                  string            `config:"username"`
+	Password                  string            `config:"password"`
+	JSON                      *readjson.Config  `config:"json"`
+}
+
+type fetchConfig struct {
+	Min     int32 `config:"min" validate:"min=1"`
+	Default int32 `config:"default" validate:"min=1"`
+	Max     int32 `config:"max" validate:"min=0"`
+}
+
+type rebalanceConfig struct {
+	Strategy string `config:"strategy"`
+}
+
+// initialOffset is the offset used by a consumer group with no committed
+// offset, it's either sarama.OffsetOldest or sarama.OffsetNewest.
+type initialOffset int64
+
+const (
+	initialOffsetOldest initialOffset = initialOffset(sarama.OffsetOldest)
+	initialOffsetNewest initialOffset = initialOffset(sarama.OffsetNewest)
+)
+
+var initialOffsets = map[string]initialOffset{
+	"oldest": initialOffsetOldest,
+	"newest": initialOffsetNewest,
+}
+
+var rebalanceStrategies = map[string]cluster.Strategy{
+	"range":      cluster.StrategyRange,
+	"roundrobin": cluster.StrategyRoundRobin,
+}
+
+func defaultConfig() kafkaInputConfig {
+	return kafkaInputConfig{
+		ForwarderConfig: harvester.ForwarderConfig{
+			Type: "kafka",
+		},
+		ClientID:       "filebeat",
+		Version:        kafka.Version("1.0.0"),
+		InitialOffset:  initialOffsetOldest,
+		ConnectBackoff: 30 * time.Second,
+		ConsumeBackoff: 2 * time.Second,
+		MaxWaitTime:    250 * time.Millisecond,
+		Fetch: fetchConfig{
+			Min:     1,
+			Default: 1 << 20, // 1 MB
+			Max:     0,
+		},
+		Rebalance: rebalanceConfig{
+			Strategy: "range",
+		},
+	}
+}
+
+// Unpack sets the initial offset from its name.
+func (off *initialOffset) Unpack(value string) error {
+	v, ok := initialOffsets[value]
+	if !ok {
+		return fmt.Errorf("invalid initial offset '%s', use oldest or newest", value)
+	}
+	*off = v
+	return nil
+}
+
+func (c *kafkaInputConfig) Validate() error {
+	if len(c.Hosts) == 0 {
+		return errors.New("no hosts configured")
+	}
+
+	if len(c.Topics) == 0 {
+		return errors.New("no topics configured")
+	}
+
+	if err := c.Version.Validate(); err != nil {
+		return err
+	}
+
+	if _, ok := rebalanceStrategies[c.Rebalance.Strategy]; !ok {
+		return fmt.Errorf("unknown rebalance strategy '%v', use range or roundrobin", c.Rebalance.Strategy)
+	}
+
+	if c.Username != "" && c.Password == "" {
+		return fmt.Errorf("password must be set when username is configured")
+	}
+
+	return nil
+}
+
+func newSaramaConfig(config *kafkaInputConfig) (*cluster.Config, error) {
+	k := cluster.NewConfig()
+
+	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tls != nil {
+		k.Net.TLS.Enable = true
+		k.Net.TLS.Config = tls.BuildModuleConfig("")
+	}
+
+	if config.Username != "" {
+		k.Net.SASL.Enable = true
+		k.Net.SASL.User = config.Username
+		k.Net.SASL.Password = config.Password
+	}
+
+	k.ClientID = config.ClientID
+
+	version, ok := config.Version.Get()
+	if !ok {
+		return nil, fmt.Errorf("unknown/unsupported kafka version: %v", config.Version)
+	}
+	k.Version = version
+
+	k.Consumer.Return.Errors = true
+	k.Consumer.Offsets.Initial = int64(config.InitialOffset)
+	k.Consumer.Retry.Backoff = config.ConsumeBackoff
+	k.Consumer.MaxWaitTime = config.MaxWaitTime
+	k.Consumer.Fetch.Min = config.Fetch.Min
+	k.Consumer.Fetch.Default = config.Fetch.Default
+	k.Consumer.Fetch.Max = config.Fetch.Max
+
+	k.Group.Return.Notifications = true
+	k.Group.PartitionStrategy = rebalanceStrategies[config.Rebalance.Strategy]
+
+	if err := k.Validate(); err != nil {
+		logp.Err("Invalid kafka configuration: %v", err)
+		return nil, err
+	}
+	return k, nil
+}
@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cluster "github.com/bsm/sarama-cluster"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/reader/readjson"
+)
+
+func init() {
+	err := input.Register("kafka", NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input is an input consuming messages from Kafka topics as part of a
+// consumer group.
+type Input struct {
+	config       kafkaInputConfig
+	saramaConfig *cluster.Config
+	outlet       channel.Outleter
+	log          *logp.Logger
+
+	runOnce  sync.Once
+	stopOnce sync.Once
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewInput creates a new kafka input
+func NewInput(
+	cfg *common.Config,
+	outlet channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("Kafka input is used")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	saramaConfig, err := newSaramaConfig(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := outlet(cfg, context.DynamicFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		config:       config,
+		saramaConfig: saramaConfig,
+		outlet:       out,
+		log:          logp.NewLogger("kafka input").With("hosts", config.Hosts),
+		done:         make(chan struct{}),
+	}, nil
+}
+
+// Run starts the consumer, the consumer runs until the input is stopped.
+func (p *Input) Run() {
+	p.runOnce.Do(func() {
+		p.log.Infow("Starting Kafka input", "topics", p.config.Topics, "group_id", p.config.GroupID)
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.run()
+		}()
+	})
+}
+
+// Stop stops the consumer. The offsets of the events acknowledged by the
+// outputs are committed before the consumer leaves the group.
+func (p *Input) Stop() {
+	p.stopOnce.Do(func() {
+		p.log.Info("Stopping Kafka input")
+		close(p.done)
+		p.outlet.Close()
+		p.wg.Wait()
+	})
+}
+
+// Wait stops the kafka input.
+func (p *Input) Wait() {
+	p.Stop()
+}
+
+func (p *Input) run() {
+	for {
+		consumer, err := cluster.NewConsumer(p.config.Hosts, p.config.GroupID, p.config.Topics, p.saramaConfig)
+		if err == nil {
+			p.consume(consumer)
+			if err := consumer.Close(); err != nil {
+				p.log.Errorw("Error closing Kafka consumer", "error", err)
+			}
+			return
+		}
+
+		p.log.Errorw("Error creating Kafka consumer", "error", err)
+		select {
+		case <-p.done:
+			return
+		case <-time.After(p.config.ConnectBackoff):
+		}
+	}
+}
+
+func (p *Input) consume(consumer *cluster.Consumer) {
+	forwarder := harvester.NewForwarder(p.outlet)
+	for {
+		select {
+		case <-p.done:
+			return
+
+		case err, ok := <-consumer.Errors():
+			if ok {
+				p.log.Errorw("Kafka consumer error", "error", err)
+			}
+
+		case n, ok := <-consumer.Notifications():
+			if ok {
+				p.log.Infow("Kafka consumer group rebalanced", "type", n.Type.String(), "partitions", n.Current)
+			}
+
+		case msg, ok := <-consumer.Messages():
+			if !ok {
+				return
+			}
+
+			event := p.createEvent(msg)
+			event.Private = &messageACK{consumer: consumer, msg: msg}
+			if err := forwarder.Send(&util.Data{Event: event}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (p *Input) createEvent(msg *sarama.ConsumerMessage) beat.Event {
+	timestamp := msg.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	kafkaFields := common.MapStr{
+		"topic":     msg.Topic,
+		"partition": msg.Partition,
+		"offset":    msg.Offset,
+	}
+	if len(msg.Key) > 0 {
+		kafkaFields["key"] = string(msg.Key)
+	}
+	fields := common.MapStr{
+		"kafka": kafkaFields,
+	}
+
+	text := string(msg.Value)
+	if p.config.JSON != nil {
+		content, jsonFields := readjson.Decode(msg.Value, p.config.JSON)
+		text = string(content)
+		if len(jsonFields) > 0 {
+			fields["json"] = jsonFields
+			ts := readjson.MergeJSONFields(fields, jsonFields, &text, *p.config.JSON)
+			if !ts.IsZero() {
+				// there was a `@timestamp` key in the message, so overwrite
+				// the resulting timestamp
+				timestamp = ts
+			}
+			return beat.Event{Timestamp: timestamp, Fields: fields}
+		}
+	}
+
+	fields["message"] = text
+	return beat.Event{Timestamp: timestamp, Fields: fields}
+}
+
+// messageACK marks the offset of the message as processed once the event
+// has been acknowledged by the outputs, the marked offsets are committed
+// periodically by the consumer.
+type messageACK struct {
+	consumer *cluster.Consumer
+	msg      *sarama.ConsumerMessage
+}
+
+func (a *messageACK) ACK() {
+	a.consumer.MarkOffset(a.msg, "")
+}
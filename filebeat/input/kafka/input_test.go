@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cluster "github.com/bsm/sarama-cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/reader/readjson"
+)
+
+func TestConfig(t *testing.T) {
+	config := defaultConfig()
+	err := common.MustNewConfigFrom(map[string]interface{}{
+		"hosts":              []string{"localhost:9092"},
+		"topics":             []string{"logs"},
+		"group_id":           "filebeat",
+		"version":            "0.11",
+		"initial_offset":     "newest",
+		"rebalance.strategy": "roundrobin",
+		"username":           "beats",
+		"password":           "secret",
+	}).Unpack(&config)
+	require.NoError(t, err)
+
+	k, err := newSaramaConfig(&config)
+	require.NoError(t, err)
+	assert.Equal(t, sarama.OffsetNewest, k.Consumer.Offsets.Initial)
+	assert.Equal(t, cluster.StrategyRoundRobin, k.Group.PartitionStrategy)
+	assert.True(t, k.Version.IsAtLeast(sarama.V0_11_0_0))
+	assert.True(t, k.Net.SASL.Enable)
+	assert.True(t, k.Consumer.Return.Errors)
+	assert.True(t, k.Group.Return.Notifications)
+	assert.Equal(t, "filebeat", k.ClientID)
+}
+
+func TestConfigErrors(t *testing.T) {
+	base := map[string]interface{}{
+		"hosts":    []string{"localhost:9092"},
+		"topics":   []string{"logs"},
+		"group_id": "filebeat",
+	}
+
+	tests := map[string]map[string]interface{}{
+		"missing group_id":       {"group_id": ""},
+		"invalid version":        {"version": "0.7"},
+		"invalid initial offset": {"initial_offset": "latest"},
+		"invalid strategy":       {"rebalance.strategy": "sticky"},
+		"username only":          {"username": "beats"},
+	}
+
+	for name, settings := range tests {
+		cfg := common.MustNewConfigFrom(base)
+		require.NoError(t, cfg.Merge(settings))
+
+		config := defaultConfig()
+		assert.Error(t, cfg.Unpack(&config), name)
+	}
+}
+
+func TestCreateEvent(t *testing.T) {
+	ts := time.Date(2018, 9, 1, 10, 0, 0, 0, time.UTC)
+	msg := &sarama.ConsumerMessage{
+		Topic:     "logs",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("host-1"),
+		Value:     []byte(`{"level":"info","msg":"hello"}`),
+		Timestamp: ts,
+	}
+
+	t.Run("raw message", func(t *testing.T) {
+		p := &Input{config: defaultConfig()}
+		event := p.createEvent(msg)
+
+		assert.Equal(t, ts, event.Timestamp)
+		assert.Equal(t, common.MapStr{
+			"message": `{"level":"info","msg":"hello"}`,
+			"kafka": common.MapStr{
+				"topic":     "logs",
+				"partition": int32(2),
+				"offset":    int64(42),
+				"key":       "host-1",
+			},
+		}, event.Fields)
+	})
+
+	t.Run("json message", func(t *testing.T) {
+		p := &Input{config: defaultConfig()}
+		p.config.JSON = &readjson.Config{MessageKey: "msg", KeysUnderRoot: true}
+		event := p.createEvent(msg)
+
+		assert.Equal(t, "hello", event.Fields["msg"])
+		assert.Equal(t, "info", event.Fields["level"])
+		assert.NotContains(t, event.Fields, "json")
+		assert.NotContains(t, event.Fields, "message")
+	})
+
+	t.Run("json message under json key", func(t *testing.T) {
+		p := &Input{config: defaultConfig()}
+		p.config.JSON = &readjson.Config{}
+		event := p.createEvent(msg)
+
+		assert.Equal(t, common.MapStr{"level": "info", "msg": "hello"}, event.Fields["json"])
+	})
+
+	t.Run("invalid json message", func(t *testing.T) {
+		p := &Input{config: defaultConfig()}
+		p.config.JSON = &readjson.Config{IgnoreDecodingError: true}
+		event := p.createEvent(&sarama.ConsumerMessage{Topic: "logs", Value: []byte("plain text")})
+
+		assert.Equal(t, "plain text", event.Fields["message"])
+		assert.False(t, event.Timestamp.IsZero())
+	})
+}
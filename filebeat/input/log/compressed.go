@@ -0,0 +1,202 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported compression formats of rotated log files.
+const (
+	compressionGzip  = "gzip"
+	compressionZstd  = "zstd"
+	compressionBzip2 = "bzip2"
+)
+
+var compressionFormats = []struct {
+	name      string
+	extension string
+	magic     []byte
+}{
+	{compressionGzip, ".gz", []byte{0x1f, 0x8b}},
+	{compressionZstd, ".zst", []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{compressionBzip2, ".bz2", []byte("BZh")},
+}
+
+// ErrCompressedFileTooShort is returned for files with the extension of a
+// compressed format that are too short to check their magic bytes. The file
+// is probably still being written and is tried again on the next scan.
+var ErrCompressedFileTooShort = errors.New("compressed file too short to detect its compression")
+
+// detectCompression returns the compression format of the file. A file is
+// only considered compressed if both its extension and its magic bytes match
+// the format, so plain files starting with the same bytes by chance are read
+// as plain files. An empty string is returned for uncompressed files. The read
+// offset of the file is not modified.
+func detectCompression(f *os.File) (string, error) {
+	ext := filepath.Ext(f.Name())
+	for _, c := range compressionFormats {
+		if ext != c.extension {
+			continue
+		}
+
+		header := make([]byte, len(c.magic))
+		n, err := f.ReadAt(header, 0)
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		if n < len(header) {
+			return "", ErrCompressedFileTooShort
+		}
+
+		if bytes.Equal(header, c.magic) {
+			return c.name, nil
+		}
+		return "", nil
+	}
+	return "", nil
+}
+
+// CompressedFile is a harvester source reading the decompressed content of a
+// compressed file. Compressed files are not expected to grow, so the source
+// is not continuable and reading stops at the end of the file.
+//
+// Offsets reported by the source are offsets into the decompressed data.
+// Seeking is emulated by decompressing and discarding the data up to the
+// requested offset.
+type CompressedFile struct {
+	File
+	compression string
+	reader      io.Reader
+	closer      func()
+	offset      int64
+}
+
+// NewCompressedFile creates a source decompressing f with the given format.
+func NewCompressedFile(f *os.File, compression string) (*CompressedFile, error) {
+	c := &CompressedFile{
+		File:        File{File: f},
+		compression: compression,
+	}
+	if err := c.reset(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (CompressedFile) Continuable() bool { return false }
+
+// Compression returns the compression format of the file.
+func (c *CompressedFile) Compression() string { return c.compression }
+
+func (c *CompressedFile) Read(buf []byte) (int, error) {
+	n, err := c.reader.Read(buf)
+	c.offset += int64(n)
+
+	// Report the end of the file only on the next read, as the harvester
+	// drops data returned together with io.EOF by non continuable sources.
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek moves the decompressed read offset. Only absolute offsets and the
+// current offset are supported. Seeking backwards restarts decompression at
+// the beginning of the file.
+func (c *CompressedFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += c.offset
+	default:
+		return c.offset, fmt.Errorf("unsupported seek whence %d on compressed file", whence)
+	}
+
+	if offset < 0 {
+		return c.offset, fmt.Errorf("invalid seek offset %d on compressed file", offset)
+	}
+
+	if offset < c.offset {
+		if err := c.reset(); err != nil {
+			return c.offset, err
+		}
+	}
+
+	if skip := offset - c.offset; skip > 0 {
+		n, err := io.CopyN(ioutil.Discard, c.reader, skip)
+		c.offset += n
+		if err != nil {
+			if err == io.EOF {
+				err = fmt.Errorf("offset %d is beyond the decompressed size %d", offset, c.offset)
+			}
+			return c.offset, err
+		}
+	}
+	return c.offset, nil
+}
+
+func (c *CompressedFile) Close() error {
+	if c.closer != nil {
+		c.closer()
+	}
+	return c.File.Close()
+}
+
+// reset restarts decompression at the beginning of the file.
+func (c *CompressedFile) reset() error {
+	if c.closer != nil {
+		c.closer()
+		c.closer = nil
+	}
+
+	if _, err := c.File.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	c.offset = 0
+
+	switch c.compression {
+	case compressionGzip:
+		r, err := gzip.NewReader(c.File.File)
+		if err != nil {
+			return err
+		}
+		c.reader, c.closer = r, func() { r.Close() }
+	case compressionZstd:
+		r, err := zstd.NewReader(c.File.File, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		c.reader, c.closer = r, r.Close
+	case compressionBzip2:
+		c.reader = bzip2.NewReader(c.File.File)
+	default:
+		return fmt.Errorf("unsupported compression format '%s'", c.compression)
+	}
+	return nil
+}
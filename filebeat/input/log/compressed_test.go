@@ -0,0 +1,260 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const compressedContent = "first line\nsecond line\nthird line\n"
+
+// bzip2 compressed compressedContent, the standard library has no encoder.
+var bzip2Content = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x70, 0x59,
+	0x17, 0x03, 0x00, 0x00, 0x06, 0xd1, 0x80, 0x00, 0x10, 0x40, 0x00, 0x0f,
+	0x65, 0x9c, 0x00, 0x20, 0x00, 0x22, 0x34, 0x4d, 0x34, 0x36, 0x9a, 0x84,
+	0x00, 0x00, 0x91, 0x29, 0xe3, 0x0b, 0x32, 0xaa, 0xc0, 0x4d, 0x84, 0x82,
+	0x76, 0xe9, 0xb8, 0xf8, 0xbb, 0x92, 0x29, 0xc2, 0x84, 0x83, 0x82, 0xc8,
+	0xb8, 0x18,
+}
+
+func gzipContent(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdContent(t *testing.T, content string) []byte {
+	w, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer w.Close()
+	return w.EncodeAll([]byte(content), nil)
+}
+
+func writeTempFile(t *testing.T, dir string, name string, content []byte) *os.File {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, content, 0644))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	return f
+}
+
+func TestDetectCompression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-compressed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := map[string]struct {
+		content  []byte
+		expected string
+	}{
+		"plain.log":            {[]byte(compressedContent), ""},
+		"empty.log":            {nil, ""},
+		"app.log.gz":           {gzipContent(t, compressedContent), compressionGzip},
+		"app.log.zst":          {zstdContent(t, compressedContent), compressionZstd},
+		"app.log.bz2":          {bzip2Content, compressionBzip2},
+		"gzip-no-extension":    {gzipContent(t, compressedContent), ""},
+		"bzip2-no-extension":   {bzip2Content, ""},
+		"plain-bzip2-magic":    {[]byte("BZh is not compressed\n"), ""},
+		"plain.log.gz":         {[]byte(compressedContent), ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := writeTempFile(t, dir, name, test.content)
+			defer f.Close()
+
+			compression, err := detectCompression(f)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, compression)
+
+			// Detection must not move the read offset
+			offset, err := f.Seek(0, io.SeekCurrent)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, offset)
+		})
+	}
+}
+
+func TestCompressedFileRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-compressed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := map[string][]byte{
+		compressionGzip:  gzipContent(t, compressedContent),
+		compressionZstd:  zstdContent(t, compressedContent),
+		compressionBzip2: bzip2Content,
+	}
+
+	for compression, content := range tests {
+		t.Run(compression, func(t *testing.T) {
+			f := writeTempFile(t, dir, compression, content)
+			c, err := NewCompressedFile(f, compression)
+			require.NoError(t, err)
+			defer c.Close()
+
+			assert.False(t, c.Continuable())
+			assert.True(t, c.HasState())
+			assert.Equal(t, compression, c.Compression())
+
+			data, err := ioutil.ReadAll(c)
+			require.NoError(t, err)
+			assert.Equal(t, compressedContent, string(data))
+
+			offset, err := c.Seek(0, io.SeekCurrent)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(compressedContent), offset)
+		})
+	}
+}
+
+func TestCompressedFileSeek(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-compressed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	f := writeTempFile(t, dir, "test.gz", gzipContent(t, compressedContent))
+	c, err := NewCompressedFile(f, compressionGzip)
+	require.NoError(t, err)
+	defer c.Close()
+
+	second := int64(len("first line\n"))
+	third := int64(len("first line\nsecond line\n"))
+
+	// Forward
+	offset, err := c.Seek(third, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, third, offset)
+	data, err := ioutil.ReadAll(c)
+	require.NoError(t, err)
+	assert.Equal(t, "third line\n", string(data))
+
+	// Backward
+	offset, err = c.Seek(second, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, second, offset)
+	data, err = ioutil.ReadAll(c)
+	require.NoError(t, err)
+	assert.Equal(t, "second line\nthird line\n", string(data))
+
+	// Beyond the end of the decompressed data
+	_, err = c.Seek(int64(len(compressedContent))+1, io.SeekStart)
+	assert.Error(t, err)
+
+	_, err = c.Seek(0, io.SeekEnd)
+	assert.Error(t, err)
+}
+
+func TestCompressedFileTruncated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-compressed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	content := gzipContent(t, compressedContent)
+	f := writeTempFile(t, dir, "test.gz", content[:len(content)-4])
+	c, err := NewCompressedFile(f, compressionGzip)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = ioutil.ReadAll(c)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestDetectCompressionOfGrowingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-compressed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	content := gzipContent(t, compressedContent)
+	path := filepath.Join(dir, "app.log.gz")
+
+	for _, size := range []int{0, 1} {
+		require.NoError(t, ioutil.WriteFile(path, content[:size], 0644))
+		f, err := os.Open(path)
+		require.NoError(t, err)
+
+		_, err = detectCompression(f)
+		assert.Equal(t, ErrCompressedFileTooShort, err, "size %d", size)
+		f.Close()
+	}
+
+	require.NoError(t, ioutil.WriteFile(path, content, 0644))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	compression, err := detectCompression(f)
+	require.NoError(t, err)
+	assert.Equal(t, compressionGzip, compression)
+}
+
+func TestHarvesterFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-compressed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("short compressed file is retried", func(t *testing.T) {
+		f := writeTempFile(t, dir, "short.log.gz", []byte{0x1f})
+		defer f.Close()
+
+		h := Harvester{config: config{Decompress: true}}
+		_, err := h.newFileSource(f)
+		assert.Equal(t, ErrCompressedFileTooShort, err)
+		assert.Empty(t, h.state.Compression)
+	})
+
+	t.Run("plain file with compression magic bytes", func(t *testing.T) {
+		f := writeTempFile(t, dir, "plain.log", []byte("BZh is not compressed\n"))
+		defer f.Close()
+
+		h := Harvester{config: config{Decompress: true}}
+		source, err := h.newFileSource(f)
+		require.NoError(t, err)
+		assert.IsType(t, File{}, source)
+		assert.Empty(t, h.state.Compression)
+	})
+
+	t.Run("compressed file", func(t *testing.T) {
+		f := writeTempFile(t, dir, "app.log.bz2", bzip2Content)
+		defer f.Close()
+
+		h := Harvester{config: config{Decompress: true}}
+		source, err := h.newFileSource(f)
+		require.NoError(t, err)
+		defer source.Close()
+		assert.IsType(t, &CompressedFile{}, source)
+		assert.Equal(t, compressionBzip2, h.state.Compression)
+	})
+}
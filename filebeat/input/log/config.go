@@ -53,6 +53,7 @@ var (
 		ScanSort:       "",
 		ScanOrder:      "asc",
 		RecursiveGlob:  true,
+		FileIdentity:   file.DefaultIdentityConfig(),
 
 		// Harvester
 		BufferSize: 16 * humanize.KiByte,
@@ -79,20 +80,22 @@ type config struct {
 	CleanInactive time.Duration `config:"clean_inactive" validate:"min=0"`
 
 	// Input
-	Enabled        bool            `config:"enabled"`
-	ExcludeFiles   []match.Matcher `config:"exclude_files"`
-	IgnoreOlder    time.Duration   `config:"ignore_older"`
-	Paths          []string        `config:"paths"`
-	ScanFrequency  time.Duration   `config:"scan_frequency" validate:"min=0,nonzero"`
-	CleanRemoved   bool            `config:"clean_removed"`
-	HarvesterLimit uint32          `config:"harvester_limit" validate:"min=0"`
-	Symlinks       bool            `config:"symlinks"`
-	TailFiles      bool            `config:"tail_files"`
-	RecursiveGlob  bool            `config:"recursive_glob.enabled"`
+	Enabled        bool                `config:"enabled"`
+	ExcludeFiles   []match.Matcher     `config:"exclude_files"`
+	IgnoreOlder    time.Duration       `config:"ignore_older"`
+	Paths          []string            `config:"paths"`
+	ScanFrequency  time.Duration       `config:"scan_frequency" validate:"min=0,nonzero"`
+	CleanRemoved   bool                `config:"clean_removed"`
+	HarvesterLimit uint32              `config:"harvester_limit" validate:"min=0"`
+	Symlinks       bool                `config:"symlinks"`
+	TailFiles      bool                `config:"tail_files"`
+	RecursiveGlob  bool                `config:"recursive_glob.enabled"`
+	FileIdentity   file.IdentityConfig `config:"file_identity"`
 
 	// Harvester
 	BufferSize int    `config:"harvester_buffer_size"`
 	Encoding   string `config:"encoding"`
+	Decompress bool   `config:"decompress"`
 	ScanOrder  string `config:"scan.order"`
 	ScanSort   string `config:"scan.sort"`
 
@@ -17,7 +17,18 @@
 
 package log
 
-import "os"
+import (
+	"io"
+	"os"
+
+	"github.com/elastic/beats/filebeat/harvester"
+)
+
+// fileSource is a harvester source backed by a file the harvester can seek in.
+type fileSource interface {
+	harvester.Source
+	io.Seeker
+}
 
 type File struct {
 	*os.File
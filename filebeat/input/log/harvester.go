@@ -101,6 +101,10 @@ type Harvester struct {
 	outletFactory OutletFactory
 	publishState  func(*util.Data) bool
 
+	// progress metrics, reported by the input
+	inputMetrics *inputMetrics
+	metrics      *fileMetrics
+
 	onTerminate func()
 }
 
@@ -223,6 +227,12 @@ func (h *Harvester) Run() error {
 	harvesterStarted.Add(1)
 	harvesterRunning.Add(1)
 
+	h.metrics = newFileMetrics(h.source, h.state.Compression != "", h.state.Offset)
+	if h.inputMetrics != nil {
+		h.inputMetrics.addFile(h.state.Source, h.metrics)
+		defer h.inputMetrics.removeFile(h.state.Source, h.metrics)
+	}
+
 	// Closes reader after timeout or when done channel is closed
 	// This routine is also responsible to properly stop the reader
 	go func(source string) {
@@ -260,6 +270,7 @@ func (h *Harvester) Run() error {
 			case ErrFileTruncate:
 				logp.Info("File was truncated. Begin reading file from offset 0: %s", h.state.Source)
 				h.state.Offset = 0
+				h.metrics.setOffset(0)
 				filesTruncated.Add(1)
 			case ErrRemoved:
 				logp.Info("File was removed: %s. Closing because close_removed is enabled.", h.state.Source)
@@ -268,15 +279,30 @@ func (h *Harvester) Run() error {
 			case ErrClosed:
 				logp.Info("Reader was closed: %s. Closing.", h.state.Source)
 			case io.EOF:
-				logp.Info("End of file reached: %s. Closing because close_eof is enabled.", h.state.Source)
+				if h.state.Compression != "" {
+					logp.Info("End of compressed file reached: %s. Marking file as completed.", h.state.Source)
+					h.state.Completed = true
+				} else {
+					logp.Info("End of file reached: %s. Closing because close_eof is enabled.", h.state.Source)
+				}
 			case ErrInactive:
 				logp.Info("File is inactive: %s. Closing because close_inactive of %v reached.", h.state.Source, h.config.CloseInactive)
 			default:
 				logp.Err("Read line error: %v; File: %v", err, h.state.Source)
+
+				// A compressed file ending unexpectedly might still be written,
+				// so it is read again on the next scan. Any other decompression
+				// error will not go away by retrying.
+				if h.state.Compression != "" && err != io.ErrUnexpectedEOF {
+					logp.Err("Giving up reading corrupted compressed file: %v", h.state.Source)
+					h.state.Completed = true
+				}
 			}
 			return nil
 		}
 
+		h.metrics.lineRead(time.Now())
+
 		// Strip UTF-8 BOM if beginning of file
 		// As all BOMS are converted to UTF-8 it is enough to only remove this one
 		if h.state.Offset == 0 {
@@ -341,6 +367,7 @@ func (h *Harvester) Run() error {
 
 		// Update state of harvester as successfully sent
 		h.state = state
+		h.metrics.setOffset(state.Offset)
 	}
 }
 
@@ -430,7 +457,21 @@ func (h *Harvester) openFile() error {
 		return err
 	}
 
-	h.source = File{File: f}
+	source, err := h.newFileSource(f)
+	if err != nil {
+		f.Close()
+		harvesterOpenFiles.Add(-1)
+		return err
+	}
+
+	err = h.initFileSource(source)
+	if err != nil {
+		source.Close()
+		harvesterOpenFiles.Add(-1)
+		return err
+	}
+
+	h.source = source
 	return nil
 }
 
@@ -449,19 +490,48 @@ func (h *Harvester) validateFile(f *os.File) error {
 		return errors.New("file info is not identical with opened file. Aborting harvesting and retrying file later again")
 	}
 
-	h.encoding, err = h.encodingFactory(f)
+	return nil
+}
+
+// newFileSource returns the source to read the file from. If decompress is
+// enabled and the file is compressed, the decompressed content is read.
+func (h *Harvester) newFileSource(f *os.File) (fileSource, error) {
+	if !h.config.Decompress {
+		return File{File: f}, nil
+	}
+
+	compression, err := detectCompression(f)
+	if err == ErrCompressedFileTooShort {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed detecting compression of file %s: %s", h.state.Source, err)
+	}
+	if compression == "" {
+		return File{File: f}, nil
+	}
+
+	logp.Debug("harvester", "Reading %s compressed file: %s", compression, h.state.Source)
+	h.state.Compression = compression
+	return NewCompressedFile(f, compression)
+}
+
+// initFileSource detects the encoding and sets the read offset of the source.
+func (h *Harvester) initFileSource(source fileSource) error {
+	var err error
+	h.encoding, err = h.encodingFactory(source)
 	if err != nil {
 
 		if err == transform.ErrShortSrc {
-			logp.Info("Initialising encoding for '%v' failed due to file being too short", f)
+			logp.Info("Initialising encoding for '%v' failed due to file being too short", source.Name())
 		} else {
-			logp.Err("Initialising encoding for '%v' failed: %v", f, err)
+			logp.Err("Initialising encoding for '%v' failed: %v", source.Name(), err)
 		}
 		return err
 	}
 
 	// get file offset. Only update offset if no error
-	offset, err := h.initFileOffset(f)
+	offset, err := h.initFileOffset(source)
 	if err != nil {
 		return err
 	}
@@ -472,7 +542,7 @@ func (h *Harvester) validateFile(f *os.File) error {
 	return nil
 }
 
-func (h *Harvester) initFileOffset(file *os.File) (int64, error) {
+func (h *Harvester) initFileOffset(file io.Seeker) (int64, error) {
 	// continue from last known offset
 	if h.state.Offset > 0 {
 		logp.Debug("harvester", "Set previous offset for file: %s. Offset: %d ", h.state.Source, h.state.Offset)
@@ -68,6 +68,9 @@ type Input struct {
 	done          chan struct{}
 	numHarvesters atomic.Uint32
 	meta          map[string]string
+	identifier    *file.Identifier
+	id            uint64
+	metrics       *inputMetrics
 }
 
 // NewInput instantiates a new Log
@@ -107,6 +110,7 @@ func NewInput(
 		states:      file.NewStates(),
 		done:        context.Done,
 		meta:        meta,
+		id:          context.ID,
 	}
 
 	if err := cfg.Unpack(&p.config); err != nil {
@@ -119,6 +123,11 @@ func NewInput(
 		return nil, fmt.Errorf("Failed to normalize globs patterns: %v", err)
 	}
 
+	p.identifier, err = file.NewIdentifier(p.config.FileIdentity)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create empty harvester to check if configs are fine
 	// TODO: Do config validation instead
 	_, err = p.createHarvester(file.State{}, nil)
@@ -156,6 +165,11 @@ func (p *Input) loadStates(states []file.State) error {
 				return fmt.Errorf("Can only start an input when all related states are finished: %+v", state)
 			}
 
+			// Migrate the state in case the file identity strategy was changed
+			if migrated, ok := p.migrateState(state); ok {
+				state = migrated
+			}
+
 			// Update input states and send new states to registry
 			err := p.updateState(state)
 			if err != nil {
@@ -169,10 +183,67 @@ func (p *Input) loadStates(states []file.State) error {
 	return nil
 }
 
+// migrateState checks if the file of a state loaded from the registry has a
+// different identity with the configured strategy. In this case the state is
+// removed from the registry and the state with the new identity is returned.
+func (p *Input) migrateState(state file.State) (file.State, bool) {
+	migrated, ok := p.identifier.Reidentify(state)
+	if !ok || migrated.ID() == state.ID() {
+		return state, false
+	}
+
+	logp.Info("Migrating state of file %s to file identity %s", state.Source, p.identifier.Strategy())
+	p.removeState(state)
+	return migrated, true
+}
+
+// takeOverState returns the state of a file that was stored under its inode
+// and device, because the file was too short to be fingerprinted before.
+// The old state is removed and the state is returned with the fingerprint
+// identity of newState.
+func (p *Input) takeOverState(newState file.State) file.State {
+	unfingerprinted := newState
+	unfingerprinted.Id = ""
+	unfingerprinted.Fingerprint = ""
+
+	oldState := p.states.FindPrevious(unfingerprinted)
+	if oldState.IsEmpty() || oldState.TTL == 0 || oldState.Fingerprint != "" {
+		return file.State{}
+	}
+
+	// Harvesters keep updating the state they were started with
+	if !oldState.Finished {
+		return oldState
+	}
+
+	// The path is part of the identity, so the file can't have been renamed
+	if newState.IdentifierName == file.IdentityPathFingerprint && oldState.Source != newState.Source {
+		return file.State{}
+	}
+
+	logp.Debug("input", "File is large enough to be fingerprinted: %s", newState.Source)
+	p.removeState(oldState)
+
+	state := oldState
+	state.Id = ""
+	state.IdentifierName = newState.IdentifierName
+	state.Fingerprint = newState.Fingerprint
+	if err := p.updateState(state); err != nil {
+		logp.Err("Fingerprint state update error: %s", err)
+	}
+	return state
+}
+
 // Run runs the input
 func (p *Input) Run() {
 	logp.Debug("input", "Start next scan")
 
+	// Metrics are registered on the first scan, so inputs only created to
+	// check their configuration are not reported.
+	if p.metrics == nil {
+		p.metrics = getInputMetrics(p.id)
+	}
+
 	// TailFiles is like ignore_older = 1ns and only on startup
 	if p.config.TailFiles {
 		ignoreOlder := p.config.IgnoreOlder
@@ -211,7 +282,11 @@ func (p *Input) Run() {
 			} else {
 				// Check if existing source on disk and state are the same. Remove if not the case.
 				newState := file.NewState(stat, state.Source, p.config.Type, p.meta)
-				if !newState.FileStateOS.IsSame(state.FileStateOS) {
+				if err := p.identifier.Identify(&newState); err != nil {
+					logp.Err("input state for %s was not removed: %s", state.Source, err)
+					continue
+				}
+				if !newState.IsSameFile(&state) {
 					p.removeState(state)
 					logp.Debug("input", "Remove state for file as file removed or renamed: %s", state.Source)
 				}
@@ -404,6 +479,9 @@ func getFileState(path string, info os.FileInfo, p *Input) (file.State, error) {
 	logp.Debug("input", "Check file for harvesting: %s", absolutePath)
 	// Create new state for comparison
 	newState := file.NewState(info, absolutePath, p.config.Type, p.meta)
+	if err := p.identifier.Identify(&newState); err != nil {
+		return file.State{}, err
+	}
 	return newState, nil
 }
 
@@ -458,11 +536,22 @@ func (p *Input) scan() {
 		newState, err := getFileState(path, info, p)
 		if err != nil {
 			logp.Err("Skipping file %s due to error %s", path, err)
+			continue
 		}
 
 		// Load last state
 		lastState := p.states.FindPrevious(newState)
 
+		// Files are identified by inode and device as long as they are too short to be
+		// fingerprinted. Continue with the state stored under the old identity.
+		if lastState.IsEmpty() && newState.Fingerprint != "" {
+			lastState = p.takeOverState(newState)
+			if !lastState.IsEmpty() && !lastState.Finished {
+				logp.Debug("input", "Harvester for file is still running: %s", newState.Source)
+				continue
+			}
+		}
+
 		// Ignores all files which fall under ignore_older
 		if p.isIgnoreOlder(newState) {
 			err := p.handleIgnoreOlder(lastState, newState)
@@ -493,10 +582,21 @@ func (p *Input) scan() {
 func (p *Input) harvestExistingFile(newState file.State, oldState file.State) {
 	logp.Debug("input", "Update existing file for harvesting: %s, offset: %v", newState.Source, oldState.Offset)
 
+	// Compressed files are read once from start to end. The offset is an offset into the
+	// decompressed data, so it can't be compared with the size of the file.
+	if oldState.Compression != "" && oldState.Finished && !oldState.Completed {
+		logp.Debug("input", "Resuming harvesting of compressed file: %s, offset: %d", newState.Source, oldState.Offset)
+		err := p.startHarvester(newState, oldState.Offset)
+		if err != nil {
+			logp.Err("Harvester could not be started on existing file: %s, Err: %s", newState.Source, err)
+		}
+		return
+	}
+
 	// No harvester is running for the file, start a new harvester
 	// It is important here that only the size is checked and not modification time, as modification time could be incorrect on windows
 	// https://blogs.technet.microsoft.com/asiasupp/2010/12/14/file-date-modified-property-are-not-updating-while-modifying-a-file-without-closing-it/
-	if oldState.Finished && newState.Fileinfo.Size() > oldState.Offset {
+	if oldState.Compression == "" && oldState.Finished && newState.Fileinfo.Size() > oldState.Offset {
 		// Resume harvesting of an old file we've stopped harvesting from
 		// This could also be an issue with force_close_older that a new harvester is started after each scan but not needed?
 		// One problem with comparing modTime is that it is in seconds, and scans can happen more then once a second
@@ -509,7 +609,7 @@ func (p *Input) harvestExistingFile(newState file.State, oldState file.State) {
 	}
 
 	// File size was reduced -> truncated file
-	if oldState.Finished && newState.Fileinfo.Size() < oldState.Offset {
+	if oldState.Compression == "" && oldState.Finished && newState.Fileinfo.Size() < oldState.Offset {
 		logp.Debug("input", "Old file was truncated. Starting from the beginning: %s, offset: %d, new size: %d ", newState.Source, newState.Fileinfo.Size())
 		err := p.startHarvester(newState, 0)
 		if err != nil {
@@ -644,6 +744,7 @@ func (p *Input) createHarvester(state file.State, onTerminate func()) (*Harveste
 	)
 	if err == nil {
 		h.onTerminate = onTerminate
+		h.inputMetrics = p.metrics
 	}
 	return h, err
 }
@@ -728,4 +829,9 @@ func (p *Input) Stop() {
 
 	// stop all communication between harvesters and publisher pipeline
 	p.outlet.Close()
+
+	if p.metrics != nil {
+		releaseInputMetrics(p.id, p.metrics)
+		p.metrics = nil
+	}
 }
@@ -20,13 +20,18 @@
 package log
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/util"
 	"github.com/elastic/beats/libbeat/common/match"
 )
 
@@ -146,3 +151,112 @@ func (t TestFileInfo) Mode() os.FileMode  { return 0 }
 func (t TestFileInfo) ModTime() time.Time { return t.time }
 func (t TestFileInfo) IsDir() bool        { return false }
 func (t TestFileInfo) Sys() interface{}   { return nil }
+
+// stateOutlet records the states sent to the registrar
+type stateOutlet struct {
+	states []file.State
+}
+
+func (o *stateOutlet) OnEvent(data *util.Data) bool {
+	o.states = append(o.states, data.GetState())
+	return true
+}
+func (o *stateOutlet) Close() error { return nil }
+
+func newIdentityTestInput(t *testing.T, strategy string) (*Input, *stateOutlet) {
+	identityConfig := file.DefaultIdentityConfig()
+	identityConfig.Strategy = strategy
+	identityConfig.Fingerprint.Length = 16
+
+	identifier, err := file.NewIdentifier(identityConfig)
+	require.NoError(t, err)
+
+	outlet := &stateOutlet{}
+	return &Input{
+		config:     config{Paths: []string{"/tmp/*"}},
+		states:     file.NewStates(),
+		outlet:     outlet,
+		identifier: identifier,
+	}, outlet
+}
+
+func TestLoadStatesMigratesFileIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-identity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte(strings.Repeat("a log line\n", 10)), 0644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	state := file.NewState(info, path, "log", nil)
+	state.Finished = true
+	state.Offset = 22
+
+	p, outlet := newIdentityTestInput(t, file.IdentityFingerprint)
+	p.config.Paths = []string{filepath.Join(dir, "*")}
+	require.NoError(t, p.loadStates([]file.State{state}))
+
+	// The old state is removed from the registry and replaced by the migrated one
+	require.Len(t, outlet.states, 2)
+	assert.Equal(t, state.ID(), outlet.states[0].ID())
+	assert.EqualValues(t, 0, outlet.states[0].TTL)
+
+	migrated := outlet.states[1]
+	assert.Equal(t, file.IdentityFingerprint, migrated.IdentifierName)
+	assert.NotEmpty(t, migrated.Fingerprint)
+	assert.EqualValues(t, 22, migrated.Offset)
+	assert.EqualValues(t, -1, migrated.TTL)
+
+	found := p.states.FindPrevious(migrated)
+	assert.EqualValues(t, 22, found.Offset)
+}
+
+func TestTakeOverStateOfShortFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filebeat-identity")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("short\n"), 0644))
+
+	p, outlet := newIdentityTestInput(t, file.IdentityFingerprint)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	short, err := getFileState(path, info, p)
+	require.NoError(t, err)
+	assert.Empty(t, short.Fingerprint)
+
+	// The harvester is still running on the short file
+	short.Offset = 6
+	require.NoError(t, p.updateState(short))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("short\nand now long enough\n"), 0644))
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	long, err := getFileState(path, info, p)
+	require.NoError(t, err)
+	assert.NotEmpty(t, long.Fingerprint)
+	previous := p.states.FindPrevious(long)
+	assert.True(t, previous.IsEmpty())
+
+	state := p.takeOverState(long)
+	assert.False(t, state.Finished)
+	assert.Len(t, outlet.states, 1)
+
+	// The harvester finished, the state is moved to the fingerprint
+	short.Finished = true
+	require.NoError(t, p.updateState(short))
+
+	state = p.takeOverState(long)
+	assert.True(t, state.Finished)
+	assert.EqualValues(t, 6, state.Offset)
+	assert.Equal(t, long.ID(), state.ID())
+	assert.EqualValues(t, 6, p.states.FindPrevious(long).Offset)
+
+	require.Len(t, outlet.states, 4)
+	assert.EqualValues(t, 0, outlet.states[2].TTL)
+	assert.Equal(t, short.ID(), outlet.states[2].ID())
+}
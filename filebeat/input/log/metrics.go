@@ -0,0 +1,172 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+var (
+	inputsMetrics = monitoring.GetNamespace("inputs").GetRegistry()
+
+	inputMetricsLock sync.Mutex
+	inputMetricsByID = map[uint64]*inputMetrics{}
+)
+
+// inputMetrics tracks the progress of the harvesters of an input. The metrics
+// are reported in the inputs monitoring namespace under the input ID, with the
+// files keyed by source path.
+type inputMetrics struct {
+	key string
+	ref int
+
+	mu    sync.Mutex
+	files map[string]*fileMetrics
+}
+
+// fileMetrics tracks the progress of a harvester reading a file.
+type fileMetrics struct {
+	source     harvester.Source
+	compressed bool
+	offset     atomic.Int64
+	lastRead   atomic.Int64 // unix time in nanoseconds
+}
+
+// getInputMetrics returns the metrics of the input with the given ID. Inputs
+// with the same ID share their metrics. The metrics must be released with
+// releaseInputMetrics.
+func getInputMetrics(id uint64) *inputMetrics {
+	inputMetricsLock.Lock()
+	defer inputMetricsLock.Unlock()
+
+	if m := inputMetricsByID[id]; m != nil {
+		m.ref++
+		return m
+	}
+
+	m := &inputMetrics{
+		key:   strconv.FormatUint(id, 10),
+		ref:   1,
+		files: map[string]*fileMetrics{},
+	}
+	monitoring.NewFunc(inputsMetrics, m.key, m.report)
+
+	inputMetricsByID[id] = m
+	return m
+}
+
+func releaseInputMetrics(id uint64, m *inputMetrics) {
+	inputMetricsLock.Lock()
+	defer inputMetricsLock.Unlock()
+
+	m.ref--
+	if m.ref > 0 {
+		return
+	}
+
+	delete(inputMetricsByID, id)
+	inputsMetrics.Remove(m.key)
+}
+
+// addFile starts reporting the metrics of a file. A file harvested again
+// under the same source path replaces the metrics of the previous harvester.
+func (m *inputMetrics) addFile(path string, f *fileMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[path] = f
+}
+
+// removeFile stops reporting the metrics of a file, unless they were
+// replaced by another harvester.
+func (m *inputMetrics) removeFile(path string, f *fileMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.files[path] == f {
+		delete(m.files, path)
+	}
+}
+
+func (m *inputMetrics) report(mode monitoring.Mode, V monitoring.Visitor) {
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	m.mu.Lock()
+	files := make(map[string]*fileMetrics, len(m.files))
+	for path, f := range m.files {
+		files[path] = f
+	}
+	m.mu.Unlock()
+
+	monitoring.ReportString(V, "type", "log")
+	monitoring.ReportInt(V, "harvesters", int64(len(files)))
+	monitoring.ReportNamespace(V, "files", func() {
+		for path, f := range files {
+			monitoring.ReportNamespace(V, path, func() {
+				f.report(V)
+			})
+		}
+	})
+}
+
+func newFileMetrics(source harvester.Source, compressed bool, offset int64) *fileMetrics {
+	f := &fileMetrics{source: source, compressed: compressed}
+	f.offset.Store(offset)
+	return f
+}
+
+// setOffset records the offset up to which the file has been processed.
+func (f *fileMetrics) setOffset(offset int64) {
+	f.offset.Store(offset)
+}
+
+// lineRead records the time a line was read from the file.
+func (f *fileMetrics) lineRead(ts time.Time) {
+	f.lastRead.Store(ts.UnixNano())
+}
+
+// report reports the offset, the current size of the file and how many bytes
+// are not yet read. The offset of compressed files counts decompressed bytes,
+// so the number of bytes behind cannot be reported for them.
+func (f *fileMetrics) report(V monitoring.Visitor) {
+	offset := f.offset.Load()
+	monitoring.ReportInt(V, "offset", offset)
+
+	if info, err := f.source.Stat(); err == nil {
+		size := info.Size()
+		monitoring.ReportInt(V, "size", size)
+
+		if !f.compressed {
+			behind := size - offset
+			if behind < 0 {
+				behind = 0
+			}
+			monitoring.ReportInt(V, "bytes_behind", behind)
+		}
+	}
+
+	if lastRead := f.lastRead.Load(); lastRead > 0 {
+		monitoring.ReportString(V, "last_read", common.Time(time.Unix(0, lastRead).UTC()).String())
+	}
+}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func inputsSnapshot() map[string]interface{} {
+	return monitoring.CollectStructSnapshot(inputsMetrics, monitoring.Full, false)
+}
+
+func TestInputMetricsFiles(t *testing.T) {
+	f, err := ioutil.TempFile("", "filebeat-metrics")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+	_, err = f.WriteString("first line\nsecond line\n")
+	require.NoError(t, err)
+
+	const id = 4711
+	m := getInputMetrics(id)
+	defer releaseInputMetrics(id, m)
+
+	fm := newFileMetrics(File{f}, false, 0)
+	m.addFile("/var/log/app.log", fm)
+
+	fm.lineRead(time.Date(2018, time.July, 16, 10, 45, 1, 0, time.UTC))
+	fm.setOffset(11)
+
+	input := inputsSnapshot()["4711"].(map[string]interface{})
+	assert.Equal(t, int64(1), input["harvesters"])
+
+	files := input["files"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"/var/log/app.log": map[string]interface{}{
+			"offset":       int64(11),
+			"size":         int64(23),
+			"bytes_behind": int64(12),
+			"last_read":    "2018-07-16T10:45:01.000Z",
+		},
+	}, files)
+
+	m.removeFile("/var/log/app.log", fm)
+	input = inputsSnapshot()["4711"].(map[string]interface{})
+	assert.Equal(t, int64(0), input["harvesters"])
+	assert.NotContains(t, input, "files")
+}
+
+func TestInputMetricsReplacedFile(t *testing.T) {
+	const id = 4712
+	m := getInputMetrics(id)
+	defer releaseInputMetrics(id, m)
+
+	old, current := newFileMetrics(nil, false, 0), newFileMetrics(nil, false, 0)
+	m.addFile("app.log", old)
+	m.addFile("app.log", current)
+
+	// The old harvester closing does not remove the metrics of the new one
+	m.removeFile("app.log", old)
+	assert.Equal(t, map[string]*fileMetrics{"app.log": current}, m.files)
+}
+
+func TestInputMetricsRelease(t *testing.T) {
+	const id = 4713
+	m := getInputMetrics(id)
+	assert.Equal(t, m, getInputMetrics(id))
+	assert.Contains(t, inputsSnapshot(), "4713")
+
+	releaseInputMetrics(id, m)
+	assert.Contains(t, inputsSnapshot(), "4713")
+
+	releaseInputMetrics(id, m)
+	assert.NotContains(t, inputsSnapshot(), "4713")
+	assert.Nil(t, inputsMetrics.Get("4713"))
+}
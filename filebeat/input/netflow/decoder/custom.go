@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadFields reads custom information element definitions from a YAML
+// file. The top-level keys are private enterprise numbers, 0 being used for
+// IANA and NetFlow v9 elements. Each of them maps element IDs to a pair of
+// name and abstract data type:
+//
+//	4242:
+//	  1: [myCounter, unsigned64]
+//	  2: [myLabel, string]
+func LoadFields(path string) (FieldDict, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions map[uint32]map[uint16][]string
+	if err := yaml.Unmarshal(content, &definitions); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse field definitions in %v", path)
+	}
+
+	dict := FieldDict{}
+	for pen, elements := range definitions {
+		for id, def := range elements {
+			if len(def) != 2 || def[0] == "" {
+				return nil, fmt.Errorf("invalid definition of field %v of enterprise %v in %v: expected [name, type]", id, pen, path)
+			}
+			if pen != 0 && id&enterpriseBit != 0 {
+				return nil, fmt.Errorf("invalid field ID %v of enterprise %v in %v", id, pen, path)
+			}
+			typ, err := ParseType(def[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid definition of field %v of enterprise %v in %v", id, pen, path)
+			}
+			dict[FieldKey{EnterpriseID: pen, ElementID: id}] = &Field{Name: def[0], Type: typ}
+		}
+	}
+	return dict, nil
+}
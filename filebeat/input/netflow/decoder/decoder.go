@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package decoder decodes NetFlow v5, NetFlow v9 and IPFIX packets into flow
+// records.
+package decoder
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// Config of the decoder.
+type Config struct {
+	// CustomFields are added to the known information elements. They
+	// replace the standard elements with the same key.
+	CustomFields FieldDict
+
+	// ExpirationTimeout is the time after which templates that were not
+	// refreshed by the exporter are removed. Templates don't expire if zero.
+	ExpirationTimeout time.Duration
+}
+
+// RecordType distinguishes flow records from the records of options
+// templates, which describe the exporter itself.
+type RecordType uint8
+
+// Record types.
+const (
+	Flow RecordType = iota
+	Options
+)
+
+func (t RecordType) String() string {
+	if t == Options {
+		return "options"
+	}
+	return "flow"
+}
+
+// Exporter describes the device that sent a record.
+type Exporter struct {
+	// Address is the address the packet was received from.
+	Address string
+
+	// Version is the protocol version. IPFIX is version 10.
+	Version uint16
+
+	// SourceID is the source ID of NetFlow v9 or the observation domain ID
+	// of IPFIX.
+	SourceID uint32
+
+	// Uptime is the time since the exporter booted. It is only sent by
+	// NetFlow v5 and v9 exporters.
+	Uptime time.Duration
+}
+
+// Record is a decoded data record.
+type Record struct {
+	Type RecordType
+
+	// Timestamp is the time the packet was exported.
+	Timestamp time.Time
+
+	Exporter Exporter
+
+	// Fields contains the values of the information elements by name.
+	Fields map[string]interface{}
+}
+
+// Decoder decodes the packets of all exporters. Templates are cached per
+// exporter and observation domain. A Decoder can be used concurrently.
+type Decoder struct {
+	ipfixFields FieldDict
+	v9Fields    FieldDict
+	templates   *templateCache
+	log         *logp.Logger
+
+	now func() time.Time
+}
+
+// NewDecoder creates a decoder.
+func NewDecoder(config Config) *Decoder {
+	d := &Decoder{
+		ipfixFields: IPFIXFields(),
+		v9Fields:    NetflowV9Fields(),
+		templates:   newTemplateCache(config.ExpirationTimeout),
+		log:         logp.NewLogger("netflow"),
+		now:         time.Now,
+	}
+
+	d.ipfixFields.Merge(config.CustomFields)
+	for key, f := range config.CustomFields {
+		if key.EnterpriseID == 0 {
+			d.v9Fields[key] = f
+		}
+	}
+	return d
+}
+
+// Read decodes a packet received from the source address. Records decoded
+// before an error occurred are returned together with the error.
+func (d *Decoder) Read(data []byte, source net.Addr) ([]Record, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("packet of %v bytes is too short", len(data))
+	}
+
+	var address string
+	if source != nil {
+		address = source.String()
+	}
+
+	switch version := binary.BigEndian.Uint16(data); version {
+	case 5:
+		return d.readV5(data, address)
+	case 9:
+		return d.readV9(data, address)
+	case 10:
+		return d.readIPFIX(data, address)
+	default:
+		return nil, fmt.Errorf("unsupported netflow version %v", version)
+	}
+}
+
+// readRecords decodes the data records of a set. Trailing bytes that are too
+// short to hold another record are padding.
+func readRecords(t *template, data []byte, base Record) ([]Record, error) {
+	var records []Record
+	for len(data) >= t.minLength {
+		fields := make(map[string]interface{}, len(t.fields))
+		for _, f := range t.fields {
+			length := int(f.length)
+			if f.length == variableLength {
+				if len(data) < 1 {
+					return records, fmt.Errorf("record of template %v is truncated", t.id)
+				}
+				length, data = int(data[0]), data[1:]
+				if length == 255 {
+					if len(data) < 2 {
+						return records, fmt.Errorf("record of template %v is truncated", t.id)
+					}
+					length, data = int(binary.BigEndian.Uint16(data)), data[2:]
+				}
+			}
+			if len(data) < length {
+				return records, fmt.Errorf("record of template %v is truncated", t.id)
+			}
+
+			value, err := f.field.Type.Decode(data[:length])
+			if err != nil {
+				// Keep the raw value if the exporter uses an unexpected
+				// encoding for the element.
+				value = hex.EncodeToString(data[:length])
+			}
+			fields[f.field.Name] = value
+			data = data[length:]
+		}
+
+		r := base
+		r.Fields = fields
+		if t.options {
+			r.Type = Options
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
@@ -0,0 +1,386 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exporter     = &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 2055}
+	exportTime   = time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC)
+	exportUptime = uint32(3600 * 1000)
+)
+
+// builder assembles packets in network byte order.
+type builder []byte
+
+func (b *builder) u8(v uint8) *builder   { *b = append(*b, v); return b }
+func (b *builder) u16(v uint16) *builder { *b = append(*b, byte(v>>8), byte(v)); return b }
+func (b *builder) u32(v uint32) *builder { return b.u16(uint16(v >> 16)).u16(uint16(v)) }
+func (b *builder) u64(v uint64) *builder { return b.u32(uint32(v >> 32)).u32(uint32(v)) }
+func (b *builder) raw(v ...byte) *builder {
+	*b = append(*b, v...)
+	return b
+}
+
+// set appends a set header followed by the content built by fn.
+func (b *builder) set(id uint16, fn func(*builder)) *builder {
+	var body builder
+	fn(&body)
+	return b.u16(id).u16(uint16(4 + len(body))).raw(body...)
+}
+
+func v9Packet(sourceID uint32, sets ...func(*builder)) []byte {
+	b := &builder{}
+	b.u16(9).u16(uint16(len(sets))).u32(exportUptime).u32(uint32(exportTime.Unix())).u32(1).u32(sourceID)
+	for _, s := range sets {
+		s(b)
+	}
+	return *b
+}
+
+func ipfixPacket(domain uint32, sets ...func(*builder)) []byte {
+	b := &builder{}
+	b.u16(10).u16(0).u32(uint32(exportTime.Unix())).u32(1).u32(domain)
+	for _, s := range sets {
+		s(b)
+	}
+	binary.BigEndian.PutUint16((*b)[2:], uint16(len(*b)))
+	return *b
+}
+
+// v9FlowTemplate announces template 256 with source and destination address
+// and port, protocol, bytes, packets and the uptime timestamps.
+func v9FlowTemplate(b *builder) {
+	b.set(0, func(s *builder) {
+		s.u16(256).u16(9)
+		s.u16(8).u16(4).u16(12).u16(4).u16(7).u16(2).u16(11).u16(2).u16(4).u16(1)
+		s.u16(1).u16(4).u16(2).u16(4).u16(22).u16(4).u16(21).u16(4)
+	})
+}
+
+func v9FlowData(b *builder) {
+	b.set(256, func(s *builder) {
+		s.raw(10, 0, 0, 1).raw(10, 0, 0, 2).u16(51000).u16(443).u8(6)
+		s.u32(1500).u32(3).u32(exportUptime - 5000).u32(exportUptime - 1000)
+		s.raw(10, 0, 0, 3).raw(10, 0, 0, 4).u16(53000).u16(53).u8(17)
+		s.u32(80).u32(1).u32(exportUptime - 100).u32(exportUptime - 100)
+		// Padding
+		s.raw(0, 0, 0)
+	})
+}
+
+func TestV5(t *testing.T) {
+	b := &builder{}
+	b.u16(5).u16(1).u32(exportUptime).u32(uint32(exportTime.Unix())).u32(500).u32(42).u8(1).u8(7).u16(0x4000 | 100)
+	b.raw(10, 0, 0, 1).raw(10, 0, 0, 2).raw(10, 0, 0, 254).u16(1).u16(2)
+	b.u32(3).u32(1500).u32(exportUptime - 5000).u32(exportUptime - 1000)
+	b.u16(51000).u16(443).u8(0).u8(0x1b).u8(6).u8(0).u16(64500).u16(64501).u8(24).u8(16).u16(0)
+
+	records, err := NewDecoder(Config{}).Read(*b, exporter)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	r := records[0]
+	assert.Equal(t, Flow, r.Type)
+	assert.Equal(t, exportTime.Add(500), r.Timestamp)
+	assert.Equal(t, Exporter{Address: "192.0.2.1:2055", Version: 5, Uptime: time.Hour}, r.Exporter)
+	assert.Equal(t, "10.0.0.1", r.Fields["sourceIPv4Address"].(net.IP).String())
+	assert.Equal(t, "10.0.0.2", r.Fields["destinationIPv4Address"].(net.IP).String())
+	assert.Equal(t, uint64(51000), r.Fields["sourceTransportPort"])
+	assert.Equal(t, uint64(443), r.Fields["destinationTransportPort"])
+	assert.Equal(t, uint64(6), r.Fields["protocolIdentifier"])
+	assert.Equal(t, uint64(1500), r.Fields["octetDeltaCount"])
+	assert.Equal(t, uint64(3), r.Fields["packetDeltaCount"])
+	assert.Equal(t, uint64(0x1b), r.Fields["tcpControlBits"])
+	assert.Equal(t, uint64(64500), r.Fields["bgpSourceAsNumber"])
+	assert.Equal(t, uint64(1), r.Fields["samplerMode"])
+	assert.Equal(t, uint64(100), r.Fields["samplingInterval"])
+	assert.Equal(t, uint64(7), r.Fields["engineId"])
+
+	_, err = NewDecoder(Config{}).Read((*b)[:60], exporter)
+	assert.Error(t, err)
+}
+
+func TestV9(t *testing.T) {
+	d := NewDecoder(Config{})
+
+	// Data sets are skipped until the template is known.
+	records, err := d.Read(v9Packet(1, v9FlowData), exporter)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	records, err = d.Read(v9Packet(1, v9FlowTemplate, v9FlowData), exporter)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	r := records[0]
+	assert.Equal(t, exportTime, r.Timestamp)
+	assert.Equal(t, Exporter{Address: "192.0.2.1:2055", Version: 9, SourceID: 1, Uptime: time.Hour}, r.Exporter)
+	assert.Equal(t, "10.0.0.1", r.Fields["sourceIPv4Address"].(net.IP).String())
+	assert.Equal(t, uint64(51000), r.Fields["sourceTransportPort"])
+	assert.Equal(t, uint64(6), r.Fields["protocolIdentifier"])
+	assert.Equal(t, uint64(1500), r.Fields["octetDeltaCount"])
+	assert.Equal(t, uint64(exportUptime-5000), r.Fields["flowStartSysUpTime"])
+	assert.Equal(t, uint64(17), records[1].Fields["protocolIdentifier"])
+
+	// Templates are scoped to the source ID.
+	records, err = d.Read(v9Packet(2, v9FlowData), exporter)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// and to the exporter.
+	records, err = d.Read(v9Packet(1, v9FlowData), &net.UDPAddr{IP: net.IPv4(192, 0, 2, 2), Port: 2055})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestV9OptionsTemplate(t *testing.T) {
+	options := func(b *builder) {
+		b.set(1, func(s *builder) {
+			s.u16(257).u16(4).u16(8)
+			s.u16(1).u16(4)
+			s.u16(34).u16(4).u16(35).u16(1)
+			s.raw(0, 0)
+		})
+		b.set(257, func(s *builder) {
+			s.raw(192, 0, 2, 1).u32(100).u8(2)
+		})
+	}
+
+	records, err := NewDecoder(Config{}).Read(v9Packet(1, options), exporter)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, Options, records[0].Type)
+	assert.Equal(t, map[string]interface{}{
+		"scopeSystem":       "c0000201",
+		"samplingInterval":  uint64(100),
+		"samplingAlgorithm": uint64(2),
+	}, records[0].Fields)
+}
+
+func TestV9Errors(t *testing.T) {
+	d := NewDecoder(Config{})
+
+	_, err := d.Read(v9Packet(1)[:10], exporter)
+	assert.Error(t, err)
+
+	invalidLength := v9Packet(1, func(b *builder) { b.u16(256).u16(100).u32(0) })
+	_, err = d.Read(invalidLength, exporter)
+	assert.Error(t, err)
+
+	truncatedTemplate := v9Packet(1, func(b *builder) {
+		b.set(0, func(s *builder) { s.u16(256).u16(3).u16(8).u16(4) })
+	})
+	_, err = d.Read(truncatedTemplate, exporter)
+	assert.Error(t, err)
+
+	_, err = d.Read([]byte{0, 7, 0, 0}, exporter)
+	assert.Error(t, err)
+}
+
+func TestIPFIX(t *testing.T) {
+	custom := FieldDict{
+		{EnterpriseID: 4242, ElementID: 1}: {Name: "tenant", Type: String},
+	}
+	d := NewDecoder(Config{CustomFields: custom})
+
+	packet := ipfixPacket(7,
+		func(b *builder) {
+			b.set(2, func(s *builder) {
+				s.u16(300).u16(7)
+				s.u16(27).u16(16).u16(28).u16(16)
+				s.u16(152).u16(8).u16(153).u16(8)
+				s.u16(1).u16(4)
+				// Variable-length application name
+				s.u16(96).u16(variableLength)
+				// Enterprise-specific elements
+				s.u16(enterpriseBit | 1).u16(variableLength).u32(4242)
+			})
+		},
+		func(b *builder) {
+			b.set(300, func(s *builder) {
+				s.raw(net.ParseIP("2001:db8::1")...).raw(net.ParseIP("2001:db8::2")...)
+				s.u64(uint64(exportTime.Unix()*1000 - 4500))
+				s.u64(uint64(exportTime.Unix()*1000 - 500))
+				s.u32(9000)
+				s.u8(5).raw([]byte("https")...)
+				s.u8(255).u16(4).raw([]byte("acme")...)
+			})
+		},
+	)
+
+	records, err := d.Read(packet, exporter)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	r := records[0]
+	assert.Equal(t, Exporter{Address: "192.0.2.1:2055", Version: 10, SourceID: 7}, r.Exporter)
+	assert.Equal(t, "2001:db8::1", r.Fields["sourceIPv6Address"].(net.IP).String())
+	assert.Equal(t, "2001:db8::2", r.Fields["destinationIPv6Address"].(net.IP).String())
+	assert.Equal(t, exportTime.Add(-4500*time.Millisecond), r.Fields["flowStartMilliseconds"])
+	assert.Equal(t, exportTime.Add(-500*time.Millisecond), r.Fields["flowEndMilliseconds"])
+	assert.Equal(t, uint64(9000), r.Fields["octetDeltaCount"])
+	assert.Equal(t, "https", r.Fields["applicationName"])
+	assert.Equal(t, "acme", r.Fields["tenant"])
+}
+
+func TestIPFIXTemplateWithdrawal(t *testing.T) {
+	d := NewDecoder(Config{})
+
+	template := func(b *builder) {
+		b.set(2, func(s *builder) { s.u16(256).u16(1).u16(2).u16(8) })
+	}
+	data := func(b *builder) {
+		b.set(256, func(s *builder) { s.u64(10) })
+	}
+	withdraw := func(b *builder) {
+		b.set(2, func(s *builder) { s.u16(256).u16(0) })
+	}
+
+	records, err := d.Read(ipfixPacket(1, template, data), exporter)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(10), records[0].Fields["packetDeltaCount"])
+
+	records, err = d.Read(ipfixPacket(1, withdraw, data), exporter)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestIPFIXUnknownAndReverseFields(t *testing.T) {
+	d := NewDecoder(Config{})
+
+	packet := ipfixPacket(1,
+		func(b *builder) {
+			b.set(2, func(s *builder) {
+				s.u16(256).u16(3)
+				s.u16(1).u16(8)
+				s.u16(enterpriseBit | 1).u16(8).u32(reverseEnterpriseID)
+				s.u16(enterpriseBit | 9).u16(2).u32(9999)
+			})
+		},
+		func(b *builder) {
+			b.set(256, func(s *builder) { s.u64(100).u64(200).u16(0xabcd) })
+		},
+	)
+
+	records, err := d.Read(packet, exporter)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, map[string]interface{}{
+		"octetDeltaCount":        uint64(100),
+		"reverseOctetDeltaCount": uint64(200),
+		"unknown_field_9999_9":   "abcd",
+	}, records[0].Fields)
+}
+
+func TestTemplateExpiration(t *testing.T) {
+	d := NewDecoder(Config{ExpirationTimeout: time.Minute})
+	now := exportTime
+	d.now = func() time.Time { return now }
+
+	_, err := d.Read(v9Packet(1, v9FlowTemplate), exporter)
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+	records, err := d.Read(v9Packet(1, v9FlowData), exporter)
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	now = now.Add(2 * time.Minute)
+	records, err = d.Read(v9Packet(1, v9FlowData), exporter)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Empty(t, d.templates.sessions)
+}
+
+func TestTypeDecode(t *testing.T) {
+	tests := []struct {
+		typ      Type
+		data     []byte
+		expected interface{}
+	}{
+		{Unsigned32, []byte{0, 0, 1, 0}, uint64(256)},
+		{Unsigned64, []byte{1, 0}, uint64(256)},
+		{Signed16, []byte{0xff, 0xfe}, int64(-2)},
+		{Signed64, []byte{0x80}, int64(-128)},
+		{Float32, []byte{0x3f, 0xc0, 0, 0}, 1.5},
+		{Float64, []byte{0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, 1.5},
+		{Boolean, []byte{1}, true},
+		{Boolean, []byte{2}, false},
+		{MacAddress, []byte{0, 0x1b, 0x21, 0x3c, 0x4d, 0x5e}, "00:1b:21:3c:4d:5e"},
+		{String, []byte("eth0\x00\x00"), "eth0"},
+		{OctetArray, []byte{0xca, 0xfe}, "cafe"},
+		{DateTimeSeconds, []byte{0x5b, 0x61, 0x84, 0xa0}, exportTime},
+		{DateTimeMicroseconds, []byte{0xdf, 0x0c, 0x03, 0x20, 0x80, 0, 0, 0}, exportTime.Add(500 * time.Millisecond)},
+		{DateTimeNanoseconds, []byte{0xdf, 0x0c, 0x03, 0x20, 0x40, 0, 0, 0}, exportTime.Add(250 * time.Millisecond)},
+		{IPv4Address, []byte{192, 0, 2, 1}, net.IPv4(192, 0, 2, 1)},
+	}
+
+	for _, test := range tests {
+		value, err := test.typ.Decode(test.data)
+		if assert.NoError(t, err, "%v", test.typ) {
+			assert.Equal(t, test.expected, value, "%v", test.typ)
+		}
+	}
+
+	for _, typ := range []Type{Unsigned8, Float32, Boolean, MacAddress, DateTimeMilliseconds, IPv4Address, IPv6Address} {
+		_, err := typ.Decode(make([]byte, 9))
+		assert.Error(t, err, "%v", typ)
+	}
+}
+
+func TestLoadFields(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netflow")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fields.yml")
+	content := "0:\n  33000: [ingressAcl, octetArray]\n4242:\n  1: [tenant, String]\n  2: [latency, float64]\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	dict, err := LoadFields(path)
+	require.NoError(t, err)
+	assert.Equal(t, FieldDict{
+		{ElementID: 33000}:                 {Name: "ingressAcl", Type: OctetArray},
+		{EnterpriseID: 4242, ElementID: 1}: {Name: "tenant", Type: String},
+		{EnterpriseID: 4242, ElementID: 2}: {Name: "latency", Type: Float64},
+	}, dict)
+
+	for _, content := range []string{
+		"4242:\n  1: [tenant]\n",
+		"4242:\n  1: [tenant, text]\n",
+		"4242:\n  40000: [tenant, string]\n",
+		"not a map",
+	} {
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		_, err := LoadFields(path)
+		assert.Error(t, err, content)
+	}
+}
@@ -0,0 +1,247 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+)
+
+// Type is the abstract data type of an information element as defined in
+// RFC 7012, section 3.1.
+type Type uint8
+
+// Abstract data types.
+const (
+	OctetArray Type = iota
+	Unsigned8
+	Unsigned16
+	Unsigned32
+	Unsigned64
+	Signed8
+	Signed16
+	Signed32
+	Signed64
+	Float32
+	Float64
+	Boolean
+	MacAddress
+	String
+	DateTimeSeconds
+	DateTimeMilliseconds
+	DateTimeMicroseconds
+	DateTimeNanoseconds
+	IPv4Address
+	IPv6Address
+	BasicList
+	SubTemplateList
+	SubTemplateMultiList
+)
+
+var typeNames = map[Type]string{
+	OctetArray:           "octetArray",
+	Unsigned8:            "unsigned8",
+	Unsigned16:           "unsigned16",
+	Unsigned32:           "unsigned32",
+	Unsigned64:           "unsigned64",
+	Signed8:              "signed8",
+	Signed16:             "signed16",
+	Signed32:             "signed32",
+	Signed64:             "signed64",
+	Float32:              "float32",
+	Float64:              "float64",
+	Boolean:              "boolean",
+	MacAddress:           "macAddress",
+	String:               "string",
+	DateTimeSeconds:      "dateTimeSeconds",
+	DateTimeMilliseconds: "dateTimeMilliseconds",
+	DateTimeMicroseconds: "dateTimeMicroseconds",
+	DateTimeNanoseconds:  "dateTimeNanoseconds",
+	IPv4Address:          "ipv4Address",
+	IPv6Address:          "ipv6Address",
+	BasicList:            "basicList",
+	SubTemplateList:      "subTemplateList",
+	SubTemplateMultiList: "subTemplateMultiList",
+}
+
+func (t Type) String() string {
+	if name, found := typeNames[t]; found {
+		return name
+	}
+	return fmt.Sprintf("type(%d)", uint8(t))
+}
+
+// ParseType returns the type for its IANA name. The match is case-insensitive.
+func ParseType(name string) (Type, error) {
+	for t, n := range typeNames {
+		if strings.EqualFold(n, name) {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown information element type '%v'", name)
+}
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and
+// the UNIX epoch.
+const ntpEpochOffset = 2208988800
+
+// Decode converts the encoded value of an information element. Integers are
+// returned as uint64 or int64, floats as float64, timestamps as time.Time
+// and addresses as net.IP. Octet arrays and the structured list types are
+// returned hex encoded.
+//
+// Unsigned and signed integers and float64 support the reduced-size
+// encoding of RFC 7011, section 6.2.
+func (t Type) Decode(b []byte) (interface{}, error) {
+	switch t {
+	case Unsigned8, Unsigned16, Unsigned32, Unsigned64:
+		if len(b) == 0 || len(b) > 8 {
+			return nil, t.lengthError(b)
+		}
+		return decodeUint(b), nil
+
+	case Signed8, Signed16, Signed32, Signed64:
+		if len(b) == 0 || len(b) > 8 {
+			return nil, t.lengthError(b)
+		}
+		shift := uint(64 - 8*len(b))
+		return int64(decodeUint(b)<<shift) >> shift, nil
+
+	case Float32:
+		if len(b) != 4 {
+			return nil, t.lengthError(b)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+
+	case Float64:
+		switch len(b) {
+		case 4:
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+		case 8:
+			return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+		}
+		return nil, t.lengthError(b)
+
+	case Boolean:
+		if len(b) != 1 || (b[0] != 1 && b[0] != 2) {
+			return nil, fmt.Errorf("invalid boolean value %v", b)
+		}
+		return b[0] == 1, nil
+
+	case MacAddress:
+		if len(b) != 6 {
+			return nil, t.lengthError(b)
+		}
+		return net.HardwareAddr(b).String(), nil
+
+	case String:
+		return strings.TrimRight(string(b), "\x00"), nil
+
+	case DateTimeSeconds:
+		if len(b) != 4 {
+			return nil, t.lengthError(b)
+		}
+		return time.Unix(int64(binary.BigEndian.Uint32(b)), 0).UTC(), nil
+
+	case DateTimeMilliseconds:
+		if len(b) != 8 {
+			return nil, t.lengthError(b)
+		}
+		ms := int64(binary.BigEndian.Uint64(b))
+		return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC(), nil
+
+	case DateTimeMicroseconds, DateTimeNanoseconds:
+		if len(b) != 8 {
+			return nil, t.lengthError(b)
+		}
+		secs := int64(binary.BigEndian.Uint32(b)) - ntpEpochOffset
+		fraction := uint64(binary.BigEndian.Uint32(b[4:]))
+		if t == DateTimeMicroseconds {
+			// The 11 least significant bits are not used.
+			fraction &^= 0x7ff
+		}
+		nanos := int64((fraction * uint64(time.Second)) >> 32)
+		return time.Unix(secs, nanos).UTC(), nil
+
+	case IPv4Address:
+		if len(b) != 4 {
+			return nil, t.lengthError(b)
+		}
+		return net.IPv4(b[0], b[1], b[2], b[3]), nil
+
+	case IPv6Address:
+		if len(b) != 16 {
+			return nil, t.lengthError(b)
+		}
+		return net.IP(append([]byte(nil), b...)), nil
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func (t Type) lengthError(b []byte) error {
+	return fmt.Errorf("invalid length %v for %v value", len(b), t)
+}
+
+func decodeUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// Field describes an information element.
+type Field struct {
+	Name string
+	Type Type
+}
+
+// FieldKey identifies an information element. The enterprise number is 0
+// for the elements registered by IANA.
+type FieldKey struct {
+	EnterpriseID uint32
+	ElementID    uint16
+}
+
+// FieldDict maps information elements to their definition.
+type FieldDict map[FieldKey]*Field
+
+// Merge adds all fields of the other dictionary, replacing existing
+// definitions.
+func (d FieldDict) Merge(other FieldDict) {
+	for k, v := range other {
+		d[k] = v
+	}
+}
+
+func (d FieldDict) lookup(key FieldKey) *Field {
+	if f, found := d[key]; found {
+		return f
+	}
+	name := fmt.Sprintf("unknown_field_%d", key.ElementID)
+	if key.EnterpriseID != 0 {
+		name = fmt.Sprintf("unknown_field_%d_%d", key.EnterpriseID, key.ElementID)
+	}
+	return &Field{Name: name, Type: OctetArray}
+}
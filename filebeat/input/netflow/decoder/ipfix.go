@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	ipfixHeaderLength = 16
+
+	ipfixTemplateSetID        = 2
+	ipfixOptionsTemplateSetID = 3
+
+	enterpriseBit = 0x8000
+)
+
+// readIPFIX decodes an IPFIX message as described in RFC 7011.
+func (d *Decoder) readIPFIX(data []byte, address string) ([]Record, error) {
+	if len(data) < ipfixHeaderLength {
+		return nil, fmt.Errorf("ipfix header is truncated")
+	}
+
+	length := int(binary.BigEndian.Uint16(data[2:]))
+	if length < ipfixHeaderLength || length > len(data) {
+		return nil, fmt.Errorf("invalid ipfix message length %v", length)
+	}
+	exportTime := binary.BigEndian.Uint32(data[4:])
+	domain := binary.BigEndian.Uint32(data[12:])
+
+	base := Record{
+		Type:      Flow,
+		Timestamp: time.Unix(int64(exportTime), 0).UTC(),
+		Exporter: Exporter{
+			Address:  address,
+			Version:  10,
+			SourceID: domain,
+		},
+	}
+	session := sessionKey{version: 10, exporter: address, domain: domain}
+	now := d.now()
+
+	var records []Record
+	data = data[ipfixHeaderLength:length]
+	for len(data) >= 4 {
+		id := binary.BigEndian.Uint16(data)
+		length := int(binary.BigEndian.Uint16(data[2:]))
+		if length < 4 || length > len(data) {
+			return records, fmt.Errorf("invalid length %v of set %v", length, id)
+		}
+		body := data[4:length]
+		data = data[length:]
+
+		switch {
+		case id == ipfixTemplateSetID || id == ipfixOptionsTemplateSetID:
+			if err := d.readIPFIXTemplates(session, id, body, now); err != nil {
+				return records, err
+			}
+
+		case id >= minDataSetID:
+			t := d.templates.get(session, id, now)
+			if t == nil {
+				d.log.Debugw("Skipping set without known template",
+					"exporter", address, "observation_domain", domain, "template_id", id)
+				continue
+			}
+			rs, err := readRecords(t, body, base)
+			records = append(records, rs...)
+			if err != nil {
+				return records, err
+			}
+		}
+	}
+	return records, nil
+}
+
+// readIPFIXTemplates reads the template records of a template set or options
+// template set. A record without fields withdraws the template.
+func (d *Decoder) readIPFIXTemplates(session sessionKey, setID uint16, data []byte, now time.Time) error {
+	options := setID == ipfixOptionsTemplateSetID
+
+	for len(data) >= 4 {
+		id := binary.BigEndian.Uint16(data)
+		count := int(binary.BigEndian.Uint16(data[2:]))
+		data = data[4:]
+
+		if count == 0 {
+			if id == setID || id >= minDataSetID {
+				d.templates.withdraw(session, id)
+				continue
+			}
+			// Padding
+			return nil
+		}
+		if id < minDataSetID {
+			return fmt.Errorf("invalid template ID %v", id)
+		}
+
+		scopeCount := 0
+		if options {
+			if len(data) < 2 {
+				return fmt.Errorf("ipfix options template %v is truncated", id)
+			}
+			scopeCount = int(binary.BigEndian.Uint16(data))
+			data = data[2:]
+			if scopeCount == 0 || scopeCount > count {
+				return fmt.Errorf("invalid scope field count %v of ipfix options template %v", scopeCount, id)
+			}
+		}
+
+		fields := make([]templateField, 0, count)
+		for i := 0; i < count; i++ {
+			if len(data) < 4 {
+				return fmt.Errorf("ipfix template %v is truncated", id)
+			}
+			key := FieldKey{ElementID: binary.BigEndian.Uint16(data)}
+			length := binary.BigEndian.Uint16(data[2:])
+			data = data[4:]
+
+			if key.ElementID&enterpriseBit != 0 {
+				if len(data) < 4 {
+					return fmt.Errorf("ipfix template %v is truncated", id)
+				}
+				key.ElementID &^= enterpriseBit
+				key.EnterpriseID = binary.BigEndian.Uint32(data)
+				data = data[4:]
+			}
+
+			fields = append(fields, templateField{
+				field:  d.ipfixFields.lookup(key),
+				length: length,
+			})
+		}
+
+		t := newTemplate(id, fields, scopeCount, options)
+		if t.minLength == 0 {
+			return fmt.Errorf("ipfix template %v has no fields with content", id)
+		}
+		d.templates.add(session, t, now)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"sync"
+	"time"
+)
+
+// variableLength is the field length announcing a variable-length encoding
+// in IPFIX templates.
+const variableLength = 0xffff
+
+type templateField struct {
+	field  *Field
+	length uint16
+}
+
+// template describes the layout of the data records of a set.
+type template struct {
+	id          uint16
+	fields      []templateField
+	scopeFields int // number of leading scope fields of options templates
+	options     bool
+
+	// minLength is the minimum size of a record. Variable-length fields
+	// take at least one byte.
+	minLength int
+}
+
+func newTemplate(id uint16, fields []templateField, scopeFields int, options bool) *template {
+	t := &template{id: id, fields: fields, scopeFields: scopeFields, options: options}
+	for _, f := range fields {
+		if f.length == variableLength {
+			t.minLength++
+		} else {
+			t.minLength += int(f.length)
+		}
+	}
+	return t
+}
+
+// sessionKey identifies the template scope: templates are only valid for
+// the exporter and observation domain (source ID in NetFlow v9) that
+// announced them.
+type sessionKey struct {
+	version  uint16
+	exporter string
+	domain   uint32
+}
+
+type cachedTemplate struct {
+	*template
+	updated time.Time
+}
+
+// templateCache stores the templates received from all exporters. Templates
+// expire if they are not refreshed within the timeout.
+type templateCache struct {
+	sync.Mutex
+	timeout   time.Duration
+	sessions  map[sessionKey]map[uint16]cachedTemplate
+	lastSweep time.Time
+}
+
+func newTemplateCache(timeout time.Duration) *templateCache {
+	return &templateCache{
+		timeout:  timeout,
+		sessions: map[sessionKey]map[uint16]cachedTemplate{},
+	}
+}
+
+func (c *templateCache) add(key sessionKey, t *template, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	templates := c.sessions[key]
+	if templates == nil {
+		templates = map[uint16]cachedTemplate{}
+		c.sessions[key] = templates
+	}
+	templates[t.id] = cachedTemplate{template: t, updated: now}
+}
+
+// withdraw removes a template. The template ID of the template set (2) or
+// options template set (3) withdraws all templates of the session.
+func (c *templateCache) withdraw(key sessionKey, id uint16) {
+	c.Lock()
+	defer c.Unlock()
+
+	if id == ipfixTemplateSetID || id == ipfixOptionsTemplateSetID {
+		delete(c.sessions, key)
+		return
+	}
+	delete(c.sessions[key], id)
+}
+
+func (c *templateCache) get(key sessionKey, id uint16, now time.Time) *template {
+	c.Lock()
+	defer c.Unlock()
+
+	c.sweep(now)
+
+	t, found := c.sessions[key][id]
+	if !found {
+		return nil
+	}
+	if c.timeout > 0 && now.Sub(t.updated) > c.timeout {
+		delete(c.sessions[key], id)
+		return nil
+	}
+	return t.template
+}
+
+// sweep removes expired templates of all sessions, so that the cache doesn't
+// grow with exporters that went away.
+func (c *templateCache) sweep(now time.Time) {
+	if c.timeout <= 0 || now.Sub(c.lastSweep) < c.timeout {
+		return
+	}
+	c.lastSweep = now
+
+	for key, templates := range c.sessions {
+		for id, t := range templates {
+			if now.Sub(t.updated) > c.timeout {
+				delete(templates, id)
+			}
+		}
+		if len(templates) == 0 {
+			delete(c.sessions, key)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	v5HeaderLength = 24
+	v5RecordLength = 48
+)
+
+// readV5 decodes a NetFlow v5 packet. The fixed record format is mapped to
+// the equivalent IPFIX information elements.
+func (d *Decoder) readV5(data []byte, address string) ([]Record, error) {
+	if len(data) < v5HeaderLength {
+		return nil, fmt.Errorf("netflow v5 header is truncated")
+	}
+
+	count := int(binary.BigEndian.Uint16(data[2:]))
+	uptime := binary.BigEndian.Uint32(data[4:])
+	secs := binary.BigEndian.Uint32(data[8:])
+	nsecs := binary.BigEndian.Uint32(data[12:])
+	engineType := data[20]
+	engineID := data[21]
+	sampling := binary.BigEndian.Uint16(data[22:])
+
+	base := Record{
+		Type:      Flow,
+		Timestamp: time.Unix(int64(secs), int64(nsecs)).UTC(),
+		Exporter: Exporter{
+			Address: address,
+			Version: 5,
+			Uptime:  time.Duration(uptime) * time.Millisecond,
+		},
+	}
+
+	data = data[v5HeaderLength:]
+	if len(data) < count*v5RecordLength {
+		return nil, fmt.Errorf("netflow v5 packet announces %v records but contains %v bytes of records", count, len(data))
+	}
+
+	records := make([]Record, 0, count)
+	for i := 0; i < count; i++ {
+		b := data[i*v5RecordLength:]
+
+		r := base
+		r.Fields = map[string]interface{}{
+			"sourceIPv4Address":           net.IPv4(b[0], b[1], b[2], b[3]),
+			"destinationIPv4Address":      net.IPv4(b[4], b[5], b[6], b[7]),
+			"ipNextHopIPv4Address":        net.IPv4(b[8], b[9], b[10], b[11]),
+			"ingressInterface":            uint64(binary.BigEndian.Uint16(b[12:])),
+			"egressInterface":             uint64(binary.BigEndian.Uint16(b[14:])),
+			"packetDeltaCount":            uint64(binary.BigEndian.Uint32(b[16:])),
+			"octetDeltaCount":             uint64(binary.BigEndian.Uint32(b[20:])),
+			"flowStartSysUpTime":          uint64(binary.BigEndian.Uint32(b[24:])),
+			"flowEndSysUpTime":            uint64(binary.BigEndian.Uint32(b[28:])),
+			"sourceTransportPort":         uint64(binary.BigEndian.Uint16(b[32:])),
+			"destinationTransportPort":    uint64(binary.BigEndian.Uint16(b[34:])),
+			"tcpControlBits":              uint64(b[37]),
+			"protocolIdentifier":          uint64(b[38]),
+			"ipClassOfService":            uint64(b[39]),
+			"bgpSourceAsNumber":           uint64(binary.BigEndian.Uint16(b[40:])),
+			"bgpDestinationAsNumber":      uint64(binary.BigEndian.Uint16(b[42:])),
+			"sourceIPv4PrefixLength":      uint64(b[44]),
+			"destinationIPv4PrefixLength": uint64(b[45]),
+			"engineType":                  uint64(engineType),
+			"engineId":                    uint64(engineID),
+			"samplerMode":                 uint64(sampling >> 14),
+			"samplingInterval":            uint64(sampling & 0x3fff),
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	v9HeaderLength = 20
+
+	v9TemplateFlowSetID        = 0
+	v9OptionsTemplateFlowSetID = 1
+
+	// minDataSetID is the lowest set ID of data sets in NetFlow v9 and
+	// IPFIX. It is also the lowest valid template ID.
+	minDataSetID = 256
+)
+
+// readV9 decodes a NetFlow v9 packet as described in RFC 3954.
+func (d *Decoder) readV9(data []byte, address string) ([]Record, error) {
+	if len(data) < v9HeaderLength {
+		return nil, fmt.Errorf("netflow v9 header is truncated")
+	}
+
+	uptime := binary.BigEndian.Uint32(data[4:])
+	secs := binary.BigEndian.Uint32(data[8:])
+	sourceID := binary.BigEndian.Uint32(data[16:])
+
+	base := Record{
+		Type:      Flow,
+		Timestamp: time.Unix(int64(secs), 0).UTC(),
+		Exporter: Exporter{
+			Address:  address,
+			Version:  9,
+			SourceID: sourceID,
+			Uptime:   time.Duration(uptime) * time.Millisecond,
+		},
+	}
+	session := sessionKey{version: 9, exporter: address, domain: sourceID}
+	now := d.now()
+
+	var records []Record
+	data = data[v9HeaderLength:]
+	for len(data) >= 4 {
+		id := binary.BigEndian.Uint16(data)
+		length := int(binary.BigEndian.Uint16(data[2:]))
+		if length < 4 || length > len(data) {
+			return records, fmt.Errorf("invalid length %v of flowset %v", length, id)
+		}
+		body := data[4:length]
+		data = data[length:]
+
+		switch {
+		case id == v9TemplateFlowSetID:
+			if err := d.readV9Templates(session, body, now); err != nil {
+				return records, err
+			}
+
+		case id == v9OptionsTemplateFlowSetID:
+			if err := d.readV9OptionsTemplates(session, body, now); err != nil {
+				return records, err
+			}
+
+		case id >= minDataSetID:
+			t := d.templates.get(session, id, now)
+			if t == nil {
+				d.log.Debugw("Skipping flowset without known template",
+					"exporter", address, "source_id", sourceID, "template_id", id)
+				continue
+			}
+			rs, err := readRecords(t, body, base)
+			records = append(records, rs...)
+			if err != nil {
+				return records, err
+			}
+		}
+	}
+	return records, nil
+}
+
+func (d *Decoder) readV9Templates(session sessionKey, data []byte, now time.Time) error {
+	for len(data) >= 4 {
+		id := binary.BigEndian.Uint16(data)
+		count := int(binary.BigEndian.Uint16(data[2:]))
+		data = data[4:]
+		if id < minDataSetID {
+			// Padding
+			return nil
+		}
+
+		if len(data) < 4*count {
+			return fmt.Errorf("netflow v9 template %v is truncated", id)
+		}
+		fields := d.readV9Fields(data[:4*count], d.v9Fields)
+		data = data[4*count:]
+
+		t := newTemplate(id, fields, 0, false)
+		if t.minLength == 0 {
+			return fmt.Errorf("netflow v9 template %v has no fields", id)
+		}
+		d.templates.add(session, t, now)
+	}
+	return nil
+}
+
+func (d *Decoder) readV9OptionsTemplates(session sessionKey, data []byte, now time.Time) error {
+	for len(data) >= 6 {
+		id := binary.BigEndian.Uint16(data)
+		scopeLength := int(binary.BigEndian.Uint16(data[2:]))
+		optionLength := int(binary.BigEndian.Uint16(data[4:]))
+		data = data[6:]
+		if id < minDataSetID {
+			// Padding
+			return nil
+		}
+
+		if scopeLength%4 != 0 || optionLength%4 != 0 || len(data) < scopeLength+optionLength {
+			return fmt.Errorf("netflow v9 options template %v is truncated", id)
+		}
+		fields := d.readV9Fields(data[:scopeLength], v9ScopeFieldDict)
+		fields = append(fields, d.readV9Fields(data[scopeLength:scopeLength+optionLength], d.v9Fields)...)
+		data = data[scopeLength+optionLength:]
+
+		t := newTemplate(id, fields, scopeLength/4, true)
+		if t.minLength == 0 {
+			return fmt.Errorf("netflow v9 options template %v has no fields", id)
+		}
+		d.templates.add(session, t, now)
+	}
+	return nil
+}
+
+// readV9Fields parses a list of field type and length pairs.
+func (d *Decoder) readV9Fields(data []byte, dict FieldDict) []templateField {
+	fields := make([]templateField, 0, len(data)/4)
+	for ; len(data) >= 4; data = data[4:] {
+		key := FieldKey{ElementID: binary.BigEndian.Uint16(data)}
+		fields = append(fields, templateField{
+			field:  dict.lookup(key),
+			length: binary.BigEndian.Uint16(data[2:]),
+		})
+	}
+	return fields
+}
@@ -0,0 +1,252 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package netflow
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"hash/fnv"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/elastic/beats/filebeat/input/netflow/decoder"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// Values of the flowEndReason information element.
+const flowEndReasonActiveTimeout = 2
+
+var transportNames = map[uint64]string{
+	1:   "icmp",
+	6:   "tcp",
+	17:  "udp",
+	47:  "gre",
+	50:  "esp",
+	51:  "ah",
+	58:  "ipv6-icmp",
+	132: "sctp",
+}
+
+// toEvent converts a record. All information elements are published under
+// netflow, using snake case names. Flow records additionally get a flow
+// object modeled after the flow events of packetbeat.
+func toEvent(r decoder.Record) beat.Event {
+	netflow := common.MapStr{}
+	for name, value := range r.Fields {
+		switch v := value.(type) {
+		case net.IP:
+			value = v.String()
+		case time.Time:
+			value = common.Time(v)
+		}
+		netflow[snakeCase(name)] = value
+	}
+
+	exporter := common.MapStr{
+		"address":   r.Exporter.Address,
+		"version":   r.Exporter.Version,
+		"source_id": r.Exporter.SourceID,
+	}
+	if r.Exporter.Version != 10 {
+		exporter["uptime_millis"] = int64(r.Exporter.Uptime / time.Millisecond)
+	}
+	netflow["exporter"] = exporter
+	netflow["type"] = r.Type.String()
+
+	fields := common.MapStr{
+		"source":  r.Exporter.Address,
+		"netflow": netflow,
+	}
+	if r.Type == decoder.Flow {
+		fields["flow"] = flowFields(r)
+	}
+
+	return beat.Event{
+		Timestamp: r.Timestamp,
+		Fields:    fields,
+	}
+}
+
+func flowFields(r decoder.Record) common.MapStr {
+	start, end := flowTimes(r)
+	flow := common.MapStr{
+		"id":         flowID(r),
+		"start_time": common.Time(start),
+		"last_time":  common.Time(end),
+		"final":      true,
+	}
+
+	if reason, ok := r.Fields["flowEndReason"].(uint64); ok {
+		flow["final"] = reason != flowEndReasonActiveTimeout
+	}
+
+	if proto, ok := r.Fields["protocolIdentifier"].(uint64); ok {
+		if name, found := transportNames[proto]; found {
+			flow["transport"] = name
+		} else {
+			flow["transport"] = strconv.FormatUint(proto, 10)
+		}
+	}
+
+	for _, name := range []string{"vlanId", "dot1qVlanId"} {
+		if vlan, ok := r.Fields[name].(uint64); ok {
+			flow["vlan"] = vlan
+			break
+		}
+	}
+
+	source := endpoint(r, "source", "octetDeltaCount", "packetDeltaCount", "initiatorOctets", "initiatorPackets")
+	dest := endpoint(r, "destination", "reverseOctetDeltaCount", "reversePacketDeltaCount", "responderOctets", "responderPackets")
+	if len(source) > 0 {
+		flow["source"] = source
+	}
+	if len(dest) > 0 {
+		flow["dest"] = dest
+	}
+	return flow
+}
+
+// endpoint collects the address, port and statistics of one side of the
+// flow. The counters are looked up in order of preference.
+func endpoint(r decoder.Record, prefix string, counters ...string) common.MapStr {
+	e := common.MapStr{}
+	if ip, ok := r.Fields[prefix+"IPv4Address"].(net.IP); ok {
+		e["ip"] = ip.String()
+	}
+	if ip, ok := r.Fields[prefix+"IPv6Address"].(net.IP); ok {
+		e["ipv6"] = ip.String()
+	}
+	if port, ok := r.Fields[prefix+"TransportPort"].(uint64); ok {
+		e["port"] = port
+	}
+	if mac, ok := r.Fields[prefix+"MacAddress"].(string); ok {
+		e["mac"] = mac
+	}
+
+	stats := common.MapStr{}
+	for i := 0; i+1 < len(counters); i += 2 {
+		bytes, hasBytes := r.Fields[counters[i]].(uint64)
+		packets, hasPackets := r.Fields[counters[i+1]].(uint64)
+		if hasBytes || hasPackets {
+			if hasBytes {
+				stats["net_bytes_total"] = bytes
+			}
+			if hasPackets {
+				stats["net_packets_total"] = packets
+			}
+			break
+		}
+	}
+	if len(stats) > 0 {
+		e["stats"] = stats
+	}
+	return e
+}
+
+// flowTimes returns the start and end time of the flow. The export time is
+// used if the record doesn't contain any timestamps.
+func flowTimes(r decoder.Record) (start, end time.Time) {
+	end, hasEnd := recordTime(r, "flowEnd")
+	if !hasEnd {
+		end = r.Timestamp
+	}
+
+	start, hasStart := recordTime(r, "flowStart")
+	if !hasStart {
+		start = end
+		if ms, ok := r.Fields["flowDurationMilliseconds"].(uint64); ok {
+			start = end.Add(-time.Duration(ms) * time.Millisecond)
+		}
+	}
+	return start, end
+}
+
+// recordTime resolves one of the absolute, uptime-based or delta encodings
+// of the flow start or end time.
+func recordTime(r decoder.Record, prefix string) (time.Time, bool) {
+	for _, suffix := range []string{"Milliseconds", "Microseconds", "Nanoseconds", "Seconds"} {
+		if t, ok := r.Fields[prefix+suffix].(time.Time); ok {
+			return t, true
+		}
+	}
+
+	if ms, ok := r.Fields[prefix+"SysUpTime"].(uint64); ok {
+		offset := time.Duration(ms) * time.Millisecond
+		if r.Exporter.Uptime > 0 {
+			return r.Timestamp.Add(offset - r.Exporter.Uptime), true
+		}
+		if boot, ok := r.Fields["systemInitTimeMilliseconds"].(time.Time); ok {
+			return boot.Add(offset), true
+		}
+	}
+
+	if us, ok := r.Fields[prefix+"DeltaMicroseconds"].(uint64); ok {
+		return r.Timestamp.Add(-time.Duration(us) * time.Microsecond), true
+	}
+	return time.Time{}, false
+}
+
+// flowID hashes the exporter and the flow key, so that the records of a long
+// running flow reported in several active timeout intervals share the ID.
+func flowID(r decoder.Record) string {
+	h := fnv.New64a()
+	h.Write([]byte(r.Exporter.Address))
+
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], r.Exporter.SourceID)
+	h.Write(buf[:])
+
+	for _, name := range []string{
+		"protocolIdentifier",
+		"sourceIPv4Address", "sourceIPv6Address", "sourceTransportPort",
+		"destinationIPv4Address", "destinationIPv6Address", "destinationTransportPort",
+	} {
+		switch v := r.Fields[name].(type) {
+		case net.IP:
+			h.Write(v)
+		case uint64:
+			h.Write([]byte(strconv.FormatUint(v, 10)))
+		}
+		h.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// snakeCase converts information element names like sourceIPv4Address to
+// source_ipv4_address.
+func snakeCase(name string) string {
+	name = strings.NewReplacer("IPv4", "Ipv4", "IPv6", "Ipv6").Replace(name)
+
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package netflow
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/filebeat/input/netflow/decoder"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+var exportTime = time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC)
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"octetDeltaCount":                "octet_delta_count",
+		"sourceIPv4Address":              "source_ipv4_address",
+		"postNATSourceIPv6Address":       "post_nat_source_ipv6_address",
+		"natInstanceID":                  "nat_instance_id",
+		"IPSecSPI":                       "ip_sec_spi",
+		"dot1qVlanId":                    "dot1q_vlan_id",
+		"ipv4IHL":                        "ipv4_ihl",
+		"messageMD5Checksum":             "message_md5_checksum",
+		"selectorIDTotalFlowsObserved":   "selector_id_total_flows_observed",
+		"distinctCountOfSourceIPAddress": "distinct_count_of_source_ip_address",
+		"unknown_field_9999_9":           "unknown_field_9999_9",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, snakeCase(name))
+	}
+}
+
+func TestToEventV9(t *testing.T) {
+	r := decoder.Record{
+		Type:      decoder.Flow,
+		Timestamp: exportTime,
+		Exporter: decoder.Exporter{
+			Address:  "192.0.2.1:2055",
+			Version:  9,
+			SourceID: 1,
+			Uptime:   time.Hour,
+		},
+		Fields: map[string]interface{}{
+			"sourceIPv4Address":        net.IPv4(10, 0, 0, 1),
+			"destinationIPv4Address":   net.IPv4(10, 0, 0, 2),
+			"sourceTransportPort":      uint64(51000),
+			"destinationTransportPort": uint64(443),
+			"protocolIdentifier":       uint64(6),
+			"octetDeltaCount":          uint64(1500),
+			"packetDeltaCount":         uint64(3),
+			"flowStartSysUpTime":       uint64(3595000),
+			"flowEndSysUpTime":         uint64(3599000),
+			"flowEndReason":            uint64(2),
+			"vlanId":                   uint64(10),
+		},
+	}
+
+	event := toEvent(r)
+	assert.Equal(t, exportTime, event.Timestamp)
+	assert.Equal(t, "192.0.2.1:2055", event.Fields["source"])
+
+	netflow := event.Fields["netflow"].(common.MapStr)
+	assert.Equal(t, "flow", netflow["type"])
+	assert.Equal(t, "10.0.0.1", netflow["source_ipv4_address"])
+	assert.Equal(t, uint64(3595000), netflow["flow_start_sys_up_time"])
+	assert.Equal(t, common.MapStr{
+		"address":       "192.0.2.1:2055",
+		"version":       uint16(9),
+		"source_id":     uint32(1),
+		"uptime_millis": int64(3600000),
+	}, netflow["exporter"])
+
+	flow := event.Fields["flow"].(common.MapStr)
+	assert.NotEmpty(t, flow["id"])
+	assert.Equal(t, common.Time(exportTime.Add(-5*time.Second)), flow["start_time"])
+	assert.Equal(t, common.Time(exportTime.Add(-time.Second)), flow["last_time"])
+	assert.Equal(t, false, flow["final"])
+	assert.Equal(t, "tcp", flow["transport"])
+	assert.Equal(t, uint64(10), flow["vlan"])
+	assert.Equal(t, common.MapStr{
+		"ip":    "10.0.0.1",
+		"port":  uint64(51000),
+		"stats": common.MapStr{"net_bytes_total": uint64(1500), "net_packets_total": uint64(3)},
+	}, flow["source"])
+	assert.Equal(t, common.MapStr{
+		"ip":   "10.0.0.2",
+		"port": uint64(443),
+	}, flow["dest"])
+
+	// The ID of the flow doesn't depend on the counters.
+	r.Fields["octetDeltaCount"] = uint64(3000)
+	assert.Equal(t, flow["id"], toEvent(r).Fields["flow"].(common.MapStr)["id"])
+	r.Fields["sourceTransportPort"] = uint64(51001)
+	assert.NotEqual(t, flow["id"], toEvent(r).Fields["flow"].(common.MapStr)["id"])
+}
+
+func TestToEventIPFIXBiflow(t *testing.T) {
+	start := exportTime.Add(-10 * time.Second)
+	r := decoder.Record{
+		Type:      decoder.Flow,
+		Timestamp: exportTime,
+		Exporter:  decoder.Exporter{Address: "192.0.2.1:4739", Version: 10, SourceID: 7},
+		Fields: map[string]interface{}{
+			"sourceIPv6Address":        net.ParseIP("2001:db8::1"),
+			"destinationIPv6Address":   net.ParseIP("2001:db8::2"),
+			"protocolIdentifier":       uint64(58),
+			"flowStartMilliseconds":    start,
+			"flowEndDeltaMicroseconds": uint64(500000),
+			"octetDeltaCount":          uint64(100),
+			"reverseOctetDeltaCount":   uint64(200),
+			"reversePacketDeltaCount":  uint64(2),
+		},
+	}
+
+	event := toEvent(r)
+	netflow := event.Fields["netflow"].(common.MapStr)
+	assert.Equal(t, common.Time(start), netflow["flow_start_milliseconds"])
+	assert.NotContains(t, netflow["exporter"], "uptime_millis")
+
+	flow := event.Fields["flow"].(common.MapStr)
+	assert.Equal(t, common.Time(start), flow["start_time"])
+	assert.Equal(t, common.Time(exportTime.Add(-500*time.Millisecond)), flow["last_time"])
+	assert.Equal(t, true, flow["final"])
+	assert.Equal(t, "ipv6-icmp", flow["transport"])
+	assert.Equal(t, common.MapStr{
+		"ipv6":  "2001:db8::1",
+		"stats": common.MapStr{"net_bytes_total": uint64(100)},
+	}, flow["source"])
+	assert.Equal(t, common.MapStr{
+		"ipv6":  "2001:db8::2",
+		"stats": common.MapStr{"net_bytes_total": uint64(200), "net_packets_total": uint64(2)},
+	}, flow["dest"])
+}
+
+func TestToEventOptions(t *testing.T) {
+	event := toEvent(decoder.Record{
+		Type:      decoder.Options,
+		Timestamp: exportTime,
+		Exporter:  decoder.Exporter{Address: "192.0.2.1:2055", Version: 9},
+		Fields:    map[string]interface{}{"samplingInterval": uint64(100)},
+	})
+
+	assert.NotContains(t, event.Fields, "flow")
+	netflow := event.Fields["netflow"].(common.MapStr)
+	assert.Equal(t, "options", netflow["type"])
+	assert.Equal(t, uint64(100), netflow["sampling_interval"])
+}
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package netflow
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/input/netflow/decoder"
+	"github.com/elastic/beats/filebeat/inputsource"
+	"github.com/elastic/beats/filebeat/inputsource/udp"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+func init() {
+	err := input.Register("netflow", NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input collects NetFlow v5, NetFlow v9 and IPFIX records sent over UDP.
+type Input struct {
+	sync.Mutex
+	udp     *udp.Server
+	started bool
+	outlet  channel.Outleter
+	log     *logp.Logger
+}
+
+// NewInput creates a new netflow input.
+func NewInput(
+	cfg *common.Config,
+	outlet channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("Netflow input is used")
+
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	customFields := decoder.FieldDict{}
+	for _, path := range config.CustomDefinitions {
+		fields, err := decoder.LoadFields(path)
+		if err != nil {
+			return nil, err
+		}
+		customFields.Merge(fields)
+	}
+
+	out, err := outlet(cfg, context.DynamicFields)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logp.NewLogger("netflow").With("address", config.Host)
+	dec := decoder.NewDecoder(decoder.Config{
+		CustomFields:      customFields,
+		ExpirationTimeout: config.ExpirationTimeout,
+	})
+
+	forwarder := harvester.NewForwarder(out)
+	callback := func(data []byte, metadata inputsource.NetworkMetadata) {
+		records, err := dec.Read(data, metadata.RemoteAddr)
+		if err != nil {
+			log.Warnw("Error decoding netflow packet", "exporter", metadata.RemoteAddr, "error", err)
+		}
+		for _, r := range records {
+			if err := forwarder.Send(&util.Data{Event: toEvent(r)}); err != nil {
+				return
+			}
+		}
+	}
+
+	return &Input{
+		outlet: out,
+		udp:    udp.New(&config.Config, callback),
+		log:    log,
+	}, nil
+}
+
+// Run starts the UDP server receiving the packets of the exporters.
+func (p *Input) Run() {
+	p.Lock()
+	defer p.Unlock()
+
+	if !p.started {
+		p.log.Info("Starting netflow input")
+		if err := p.udp.Start(); err != nil {
+			p.log.Errorw("Error starting the UDP server", "error", err)
+		}
+		p.started = true
+	}
+}
+
+// Stop stops the netflow input.
+func (p *Input) Stop() {
+	defer p.outlet.Close()
+	p.Lock()
+	defer p.Unlock()
+
+	p.log.Info("Stopping netflow input")
+	p.udp.Stop()
+	p.started = false
+}
+
+// Wait stops the netflow input.
+func (p *Input) Wait() {
+	p.Stop()
+}
@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package netflow
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+type mockOutlet struct {
+	sync.Mutex
+	data []*util.Data
+}
+
+func (o *mockOutlet) OnEvent(data *util.Data) bool {
+	o.Lock()
+	defer o.Unlock()
+	o.data = append(o.data, data)
+	return true
+}
+
+func (o *mockOutlet) Close() error { return nil }
+
+func (o *mockOutlet) events() []*util.Data {
+	o.Lock()
+	defer o.Unlock()
+	return o.data
+}
+
+func TestInputReceivesV5(t *testing.T) {
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"host": "127.0.0.1:0",
+	})
+	out := &mockOutlet{}
+	connector := func(*common.Config, *common.MapStrPointer) (channel.Outleter, error) {
+		return out, nil
+	}
+
+	p, err := NewInput(cfg, connector, input.Context{})
+	require.NoError(t, err)
+	p.Run()
+	defer p.Stop()
+
+	packet := make([]byte, 24+48)
+	binary.BigEndian.PutUint16(packet, 5)
+	binary.BigEndian.PutUint16(packet[2:], 1)
+	binary.BigEndian.PutUint32(packet[8:], uint32(exportTime.Unix()))
+	copy(packet[24:], []byte{10, 0, 0, 1, 10, 0, 0, 2})
+	binary.BigEndian.PutUint32(packet[24+20:], 1500)
+	packet[24+38] = 17
+
+	conn, err := net.Dial("udp", p.(*Input).udp.Listener.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(packet)
+	require.NoError(t, err)
+
+	for i := 0; i < 100 && len(out.events()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	events := out.events()
+	require.Len(t, events, 1)
+
+	event := events[0].Event
+	assert.Equal(t, exportTime, event.Timestamp)
+	assert.Equal(t, conn.LocalAddr().String(), event.Fields["source"])
+	transport, _ := event.Fields.GetValue("flow.transport")
+	assert.Equal(t, "udp", transport)
+	bytes, _ := event.Fields.GetValue("flow.source.stats.net_bytes_total")
+	assert.Equal(t, uint64(1500), bytes)
+}
+
+func TestConfigValidation(t *testing.T) {
+	for _, settings := range []map[string]interface{}{
+		{"host": ""},
+		{"expiration_timeout": "-1s"},
+		{"max_message_size": 0},
+	} {
+		config := defaultConfig
+		err := common.MustNewConfigFrom(settings).Unpack(&config)
+		assert.Error(t, err, "%v", settings)
+	}
+
+	_, err := NewInput(common.MustNewConfigFrom(map[string]interface{}{
+		"custom_definitions": []string{"/does/not/exist.yml"},
+	}), nil, input.Context{})
+	assert.Error(t, err)
+}
@@ -27,6 +27,7 @@ import (
 )
 
 type Context struct {
+	ID            uint64
 	States        []file.State
 	Done          chan struct{}
 	BeatDone      chan struct{}
@@ -18,6 +18,7 @@
 package syslog
 
 import (
+	"bufio"
 	"fmt"
 	"time"
 
@@ -33,17 +34,47 @@ import (
 type config struct {
 	harvester.ForwarderConfig `config:",inline"`
 	Protocol                  common.ConfigNamespace `config:"protocol"`
+	Format                    string                 `config:"format"`
 }
 
+const (
+	formatAuto    = "auto"
+	formatRFC3164 = "rfc3164"
+	formatRFC5424 = "rfc5424"
+
+	framingDelimiter = "delimiter"
+	framingRFC6587   = "rfc6587"
+)
+
 var defaultConfig = config{
 	ForwarderConfig: harvester.ForwarderConfig{
 		Type: "syslog",
 	},
+	Format: formatAuto,
+}
+
+func (c *config) Validate() error {
+	switch c.Format {
+	case formatAuto, formatRFC3164, formatRFC5424:
+		return nil
+	default:
+		return fmt.Errorf("format %s is not supported, use %s, %s or %s", c.Format, formatAuto, formatRFC3164, formatRFC5424)
+	}
 }
 
 type syslogTCP struct {
 	tcp.Config    `config:",inline"`
 	LineDelimiter string `config:"line_delimiter" validate:"nonzero"`
+	Framing       string `config:"framing"`
+}
+
+func (c *syslogTCP) Validate() error {
+	switch c.Framing {
+	case framingDelimiter, framingRFC6587:
+		return nil
+	default:
+		return fmt.Errorf("framing %s is not supported, use %s or %s", c.Framing, framingDelimiter, framingRFC6587)
+	}
 }
 
 var defaultTCP = syslogTCP{
@@ -52,6 +83,7 @@ var defaultTCP = syslogTCP{
 		MaxMessageSize: 20 * humanize.MiByte,
 	},
 	LineDelimiter: "\n",
+	Framing:       framingRFC6587,
 }
 
 var defaultUDP = udp.Config{
@@ -72,7 +104,12 @@ func factory(
 			return nil, err
 		}
 
-		splitFunc := tcp.SplitFunc([]byte(config.LineDelimiter))
+		var splitFunc bufio.SplitFunc
+		if config.Framing == framingRFC6587 {
+			splitFunc = tcp.SplitFuncOctetCounting([]byte(config.LineDelimiter))
+		} else {
+			splitFunc = tcp.SplitFunc([]byte(config.LineDelimiter))
+		}
 		if splitFunc == nil {
 			return nil, fmt.Errorf("error creating splitFunc from delimiter %s", config.LineDelimiter)
 		}
@@ -71,6 +71,12 @@ type event struct {
 	nanosecond int
 	year       int
 	loc        *time.Location
+
+	// RFC5424 fields.
+	version        int
+	procID         string
+	msgID          string
+	structuredData map[string]map[string]string
 }
 
 // newEvent() return a new event.
@@ -267,6 +273,52 @@ func (s *event) HasPid() bool {
 	return s.pid > 0
 }
 
+// SetProcID sets the pid if the process id is numeric, the process id otherwise.
+func (s *event) SetProcID(b []byte) {
+	if len(b) == 0 || (len(b) == 1 && b[0] == nilValue) {
+		return
+	}
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			s.procID = string(b)
+			return
+		}
+	}
+	s.SetPid(b)
+}
+
+// ProcID returns the process id if it's not numeric.
+func (s *event) ProcID() string {
+	return s.procID
+}
+
+// Version returns the version of the RFC5424 format, 0 for other formats.
+func (s *event) Version() int {
+	return s.version
+}
+
+// MsgID returns the message type.
+func (s *event) MsgID() string {
+	return s.msgID
+}
+
+// StructuredData returns the parameters of the SD-ELEMENTs by SD-ID.
+func (s *event) StructuredData() map[string]map[string]string {
+	return s.structuredData
+}
+
+// setTime sets the date from a time.
+func (s *event) setTime(t time.Time) {
+	s.year = t.Year()
+	s.month = t.Month()
+	s.day = t.Day()
+	s.hour = t.Hour()
+	s.minute = t.Minute()
+	s.second = t.Second()
+	s.nanosecond = t.Nanosecond()
+	s.loc = t.Location()
+}
+
 // SetNanoSecond sets the nanosecond.
 func (s *event) SetNanosecond(b []byte) {
 	// We assume that we receive a byte array representing a nanosecond, this might not be
@@ -304,9 +356,11 @@ func (s *event) Timestamp(timezone *time.Location) time.Time {
 	).UTC()
 }
 
-// IsValid returns true if the date and the message are present.
+// IsValid returns true if the date and the message are present, the message is optional in
+// the RFC5424 format.
 func (s *event) IsValid() bool {
-	return s.day != -1 && s.hour != -1 && s.minute != -1 && s.second != -1 && s.message != ""
+	return s.day != -1 && s.hour != -1 && s.minute != -1 && s.second != -1 &&
+		(s.message != "" || s.version > 0)
 }
 
 // BytesToInt takes a variable length of bytes and assume ascii chars and convert it to int, this is
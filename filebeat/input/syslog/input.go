@@ -125,10 +125,10 @@ func NewInput(
 	forwarder := harvester.NewForwarder(out)
 	cb := func(data []byte, metadata inputsource.NetworkMetadata) {
 		ev := newEvent()
-		Parse(data, ev)
+		err := parse(config.Format, data, ev)
 		var d *util.Data
-		if !ev.IsValid() {
-			log.Errorw("can't not parse event as syslog rfc3164", "message", string(data))
+		if err != nil || !ev.IsValid() {
+			log.Errorw("can't parse event as syslog", "message", string(data), "error", err)
 			// On error revert to the raw bytes content, we need a better way to communicate this kind of
 			// error upstream this should be a global effort.
 			d = &util.Data{
@@ -217,6 +217,30 @@ func createEvent(ev *event, metadata inputsource.NetworkMetadata, timezone *time
 		process["program"] = ev.Program()
 	}
 
+	if ev.Version() > 0 {
+		syslog["version"] = ev.Version()
+	}
+
+	if ev.ProcID() != "" {
+		syslog["procid"] = ev.ProcID()
+	}
+
+	if ev.MsgID() != "" {
+		syslog["msgid"] = ev.MsgID()
+	}
+
+	if sd := ev.StructuredData(); len(sd) > 0 {
+		elements := common.MapStr{}
+		for id, params := range sd {
+			fields := common.MapStr{}
+			for name, value := range params {
+				fields[name] = value
+			}
+			elements[id] = fields
+		}
+		syslog["structured_data"] = elements
+	}
+
 	if ev.HasPriority() {
 		syslog["priority"] = ev.Priority()
 
@@ -250,6 +274,17 @@ func createEvent(ev *event, metadata inputsource.NetworkMetadata, timezone *time
 	}
 }
 
+// parse parses the data in the format configured, the format of each message is detected when
+// the format is auto.
+func parse(format string, data []byte, ev *event) error {
+	if format == formatRFC5424 || (format == formatAuto && isRFC5424(data)) {
+		return ParseRFC5424(data, ev)
+	}
+
+	Parse(data, ev)
+	return nil
+}
+
 func mapValueToName(v int, m mapper) (string, error) {
 	if v < 0 || v >= len(m) {
 		return "", errors.Errorf("value out of bound: %d", v)
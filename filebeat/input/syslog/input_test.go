@@ -82,6 +82,43 @@ func TestWhenPriorityIsNotSet(t *testing.T) {
 	assert.Equal(t, expected, event.Fields)
 }
 
+func TestRFC5424Fields(t *testing.T) {
+	e := newEvent()
+	err := ParseRFC5424([]byte(`<165>1 2003-10-11T22:14:15.003Z wopr evntslog worker ID47 [exampleSDID@32473 iut="3"] hello world`), e)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	m := dummyMetadata()
+	event := createEvent(e, m, time.Local, logp.NewLogger("syslog"))
+	expected := common.MapStr{
+		"source":   "127.0.0.1",
+		"message":  "hello world",
+		"hostname": "wopr",
+		"process": common.MapStr{
+			"program": "evntslog",
+		},
+		"event": common.MapStr{
+			"severity": 5,
+		},
+		"syslog": common.MapStr{
+			"facility":       20,
+			"severity_label": "Notice",
+			"facility_label": "local4",
+			"priority":       165,
+			"version":        1,
+			"procid":         "worker",
+			"msgid":          "ID47",
+			"structured_data": common.MapStr{
+				"exampleSDID@32473": common.MapStr{"iut": "3"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, event.Fields)
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), event.Timestamp)
+}
+
 func TestPid(t *testing.T) {
 	t.Run("is set", func(t *testing.T) {
 		e := newEvent()
@@ -0,0 +1,263 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const nilValue = '-'
+
+var utf8BOM = []byte("\xEF\xBB\xBF")
+
+// isRFC5424 returns true if the message starts with a priority followed by a version, like
+// "<165>1 ", which is the header of the RFC5424 format.
+func isRFC5424(data []byte) bool {
+	p := &rfc5424Parser{data: data}
+	if !p.consume('<') {
+		return false
+	}
+	if n := p.digits(3); n == 0 || !p.consume('>') {
+		return false
+	}
+	if n := p.digits(3); n == 0 || data[p.pos-n] == '0' {
+		return false
+	}
+	return p.consume(' ')
+}
+
+// ParseRFC5424 parses a syslog message in the format defined in RFC5424.
+//
+// <165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3"] An application event log entry...
+func ParseRFC5424(data []byte, event *event) error {
+	p := &rfc5424Parser{data: data}
+
+	if !p.consume('<') {
+		return p.errorf("expected priority")
+	}
+	priority, n := p.number(3)
+	if n == 0 || priority > 191 || !p.consume('>') {
+		return p.errorf("invalid priority")
+	}
+	version, n := p.number(3)
+	if n == 0 || version == 0 {
+		return p.errorf("invalid version")
+	}
+	if !p.consume(' ') {
+		return p.errorf("expected space after version")
+	}
+
+	ts, err := p.timestamp()
+	if err != nil {
+		return err
+	}
+
+	var header [4][]byte
+	maxLen := [4]int{255, 48, 128, 32}
+	for i := range header {
+		if !p.consume(' ') {
+			return p.errorf("expected space")
+		}
+		value := p.headerField()
+		if len(value) == 0 || len(value) > maxLen[i] {
+			return p.errorf("invalid header field")
+		}
+		header[i] = value
+	}
+
+	if !p.consume(' ') {
+		return p.errorf("expected space before structured data")
+	}
+	sd, err := p.structuredData()
+	if err != nil {
+		return err
+	}
+
+	var msg []byte
+	if p.consume(' ') {
+		msg = bytes.TrimPrefix(p.data[p.pos:], utf8BOM)
+	} else if p.pos < len(p.data) {
+		return p.errorf("expected space before message")
+	}
+
+	// Use the reception time when the sender doesn't know the time.
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	event.priority = priority
+	event.version = version
+	event.setTime(ts)
+	event.hostname = nilOrString(header[0])
+	event.program = nilOrString(header[1])
+	event.SetProcID(header[2])
+	event.msgID = nilOrString(header[3])
+	event.structuredData = sd
+	event.SetMessage(msg)
+	return nil
+}
+
+type rfc5424Parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *rfc5424Parser) errorf(msg string) error {
+	return errors.Errorf("invalid RFC5424 message: %s at position %d", msg, p.pos)
+}
+
+func (p *rfc5424Parser) consume(c byte) bool {
+	if p.pos < len(p.data) && p.data[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// digits consumes up to max digits and returns the number of digits consumed.
+func (p *rfc5424Parser) digits(max int) int {
+	n := 0
+	for n < max && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '9' {
+		p.pos++
+		n++
+	}
+	return n
+}
+
+// number consumes up to max digits and returns their value and the number of digits consumed.
+func (p *rfc5424Parser) number(max int) (int, int) {
+	start := p.pos
+	n := p.digits(max)
+	return bytesToInt(p.data[start:p.pos]), n
+}
+
+// timestamp parses a RFC3339 timestamp, a zero time is returned for the nil value.
+func (p *rfc5424Parser) timestamp() (time.Time, error) {
+	if p.consume(nilValue) {
+		return time.Time{}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.data) && p.data[p.pos] != ' ' {
+		p.pos++
+	}
+	ts, err := time.Parse(time.RFC3339Nano, string(p.data[start:p.pos]))
+	if err != nil {
+		p.pos = start
+		return time.Time{}, p.errorf("invalid timestamp")
+	}
+	return ts, nil
+}
+
+// headerField consumes printable ASCII characters.
+func (p *rfc5424Parser) headerField() []byte {
+	start := p.pos
+	for p.pos < len(p.data) && p.data[p.pos] >= 33 && p.data[p.pos] <= 126 {
+		p.pos++
+	}
+	return p.data[start:p.pos]
+}
+
+// sdName consumes the characters allowed in SD-IDs and parameter names.
+func (p *rfc5424Parser) sdName() string {
+	start := p.pos
+	for p.pos < len(p.data) && p.pos-start < 32 {
+		c := p.data[p.pos]
+		if c < 33 || c > 126 || c == '=' || c == ']' || c == '"' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// structuredData parses the SD-ELEMENTs into a map of SD-ID to parameters.
+func (p *rfc5424Parser) structuredData() (map[string]map[string]string, error) {
+	if p.consume(nilValue) {
+		return nil, nil
+	}
+
+	sd := map[string]map[string]string{}
+	for p.consume('[') {
+		id := p.sdName()
+		if id == "" {
+			return nil, p.errorf("invalid SD-ID")
+		}
+
+		params, found := sd[id]
+		if !found {
+			params = map[string]string{}
+			sd[id] = params
+		}
+
+		for p.consume(' ') {
+			name := p.sdName()
+			if name == "" || !p.consume('=') || !p.consume('"') {
+				return nil, p.errorf("invalid SD-PARAM")
+			}
+			value, err := p.paramValue()
+			if err != nil {
+				return nil, err
+			}
+			params[name] = value
+		}
+
+		if !p.consume(']') {
+			return nil, p.errorf("expected end of SD-ELEMENT")
+		}
+	}
+
+	if len(sd) == 0 {
+		return nil, p.errorf("invalid structured data")
+	}
+	return sd, nil
+}
+
+// paramValue parses a quoted parameter value, '"', '\' and ']' are escaped with a backslash.
+func (p *rfc5424Parser) paramValue() (string, error) {
+	var value []byte
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+
+		switch c {
+		case '"':
+			return string(value), nil
+		case '\\':
+			if p.pos < len(p.data) {
+				switch next := p.data[p.pos]; next {
+				case '"', '\\', ']':
+					c = next
+					p.pos++
+				}
+			}
+		}
+		value = append(value, c)
+	}
+	return "", p.errorf("unterminated SD-PARAM value")
+}
+
+func nilOrString(b []byte) string {
+	if len(b) == 1 && b[0] == nilValue {
+		return ""
+	}
+	return string(b)
+}
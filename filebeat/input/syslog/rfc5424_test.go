@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRFC5424(t *testing.T) {
+	tests := []struct {
+		title  string
+		log    string
+		syslog event
+	}{
+		{
+			title: "RFC5424 example without structured data",
+			log:   "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed for lonvick on /dev/pts/8",
+			syslog: event{
+				priority: 34,
+				version:  1,
+				hostname: "mymachine.example.com",
+				program:  "su",
+				pid:      -1,
+				msgID:    "ID47",
+				message:  "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			title: "structured data and BOM",
+			log:   "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut=\"3\" eventSource=\"Application\" eventID=\"1011\"] \xEF\xBB\xBFAn application event log entry...",
+			syslog: event{
+				priority: 165,
+				version:  1,
+				hostname: "mymachine.example.com",
+				program:  "evntslog",
+				pid:      1234,
+				msgID:    "ID47",
+				message:  "An application event log entry...",
+				structuredData: map[string]map[string]string{
+					"exampleSDID@32473": {"iut": "3", "eventSource": "Application", "eventID": "1011"},
+				},
+			},
+		},
+		{
+			title: "multiple SD-ELEMENTs without message",
+			log:   `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog worker ID47 [exampleSDID@32473 iut="3"][examplePriority@32473 class="high" path="C:\\logs\]\"a\""]`,
+			syslog: event{
+				priority: 165,
+				version:  1,
+				hostname: "mymachine.example.com",
+				program:  "evntslog",
+				pid:      -1,
+				procID:   "worker",
+				msgID:    "ID47",
+				structuredData: map[string]map[string]string{
+					"exampleSDID@32473":     {"iut": "3"},
+					"examplePriority@32473": {"class": "high", "path": `C:\logs]"a"`},
+				},
+			},
+		},
+		{
+			title: "nil values",
+			log:   "<13>1 2003-08-24T05:14:15.000003-07:00 - - - - - hello",
+			syslog: event{
+				priority: 13,
+				version:  1,
+				pid:      -1,
+				message:  "hello",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			l := newEvent()
+			require.NoError(t, ParseRFC5424([]byte(test.log), l))
+			assert.True(t, l.IsValid())
+			assert.Equal(t, test.syslog.Priority(), l.Priority())
+			assert.Equal(t, test.syslog.Version(), l.Version())
+			assert.Equal(t, test.syslog.Hostname(), l.Hostname())
+			assert.Equal(t, test.syslog.Program(), l.Program())
+			assert.Equal(t, test.syslog.Pid(), l.Pid())
+			assert.Equal(t, test.syslog.ProcID(), l.ProcID())
+			assert.Equal(t, test.syslog.MsgID(), l.MsgID())
+			assert.Equal(t, test.syslog.Message(), l.Message())
+			assert.Equal(t, test.syslog.StructuredData(), l.StructuredData())
+		})
+	}
+}
+
+func TestParseRFC5424Timestamp(t *testing.T) {
+	l := newEvent()
+	require.NoError(t, ParseRFC5424([]byte("<13>1 2003-08-24T05:14:15.000003-07:00 host app - - - hello"), l))
+	assert.Equal(t, time.Date(2003, 8, 24, 12, 14, 15, 3000, time.UTC), l.Timestamp(time.Local))
+
+	// The nil value is replaced with the reception time.
+	l = newEvent()
+	before := time.Now()
+	require.NoError(t, ParseRFC5424([]byte("<13>1 - host app - - - hello"), l))
+	ts := l.Timestamp(time.Local)
+	assert.False(t, ts.Before(before.Truncate(time.Second)))
+}
+
+func TestParseRFC5424Errors(t *testing.T) {
+	tests := map[string]string{
+		"no priority":             "1 2003-10-11T22:14:15.003Z host app - - - hello",
+		"priority out of range":   "<192>1 2003-10-11T22:14:15.003Z host app - - - hello",
+		"no version":              "<13> 2003-10-11T22:14:15.003Z host app - - - hello",
+		"invalid timestamp":       "<13>1 Oct 11 22:14:15 host app - - - hello",
+		"missing header fields":   "<13>1 2003-10-11T22:14:15.003Z host app",
+		"app name too long":       "<13>1 2003-10-11T22:14:15.003Z host aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa - - - hello",
+		"invalid structured data": "<13>1 2003-10-11T22:14:15.003Z host app - - hello",
+		"unterminated SD-ELEMENT": `<13>1 2003-10-11T22:14:15.003Z host app - - [id a="b" hello`,
+		"unterminated SD-PARAM":   `<13>1 2003-10-11T22:14:15.003Z host app - - [id a="b] hello`,
+		"unquoted SD-PARAM":       `<13>1 2003-10-11T22:14:15.003Z host app - - [id a=b] hello`,
+		"no space before message": `<13>1 2003-10-11T22:14:15.003Z host app - - [id a="b"]hello`,
+	}
+
+	for title, log := range tests {
+		l := newEvent()
+		assert.Error(t, ParseRFC5424([]byte(log), l), title)
+		assert.False(t, l.IsValid(), title)
+	}
+}
+
+func TestFormatDetection(t *testing.T) {
+	tests := []struct {
+		format  string
+		log     string
+		version int
+		valid   bool
+	}{
+		{formatAuto, "<13>1 2003-10-11T22:14:15.003Z host app - - - hello", 1, true},
+		{formatAuto, "<13>Oct 11 22:14:15 host app: hello", 0, true},
+		{formatAuto, "<13>10 11 22:14:15 host app: hello", 0, false},
+		{formatRFC3164, "<13>1 2003-10-11T22:14:15.003Z host app - - - hello", 0, false},
+		{formatRFC5424, "<13>Oct 11 22:14:15 host app: hello", 0, false},
+	}
+
+	for _, test := range tests {
+		l := newEvent()
+		err := parse(test.format, []byte(test.log), l)
+		assert.Equal(t, test.valid, err == nil && l.IsValid(), "%v: %v", test.format, test.log)
+		assert.Equal(t, test.version, l.Version(), "%v: %v", test.format, test.log)
+	}
+}
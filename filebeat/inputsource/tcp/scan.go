@@ -20,8 +20,12 @@ package tcp
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 )
 
+// maxFrameLengthDigits limits the size of the length prefix of an octet-counted frame.
+const maxFrameLengthDigits = 10
+
 // factoryDelimiter return a function to split line using a custom delimiter supporting multibytes
 // delimiter, the delimiter is stripped from the returned value.
 func factoryDelimiter(delimiter []byte) bufio.SplitFunc {
@@ -46,3 +50,50 @@ func dropDelimiter(data []byte, delimiter []byte) []byte {
 	}
 	return data
 }
+
+// factoryOctetCounting return a function to split octet-counted frames as defined in RFC6587, the
+// frames are prefixed with the length of the message followed by a space: "11 hello world". Frames
+// that don't start with a length are split using the fallback function, so that senders using the
+// non-transparent framing are still supported.
+func factoryOctetCounting(fallback bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, eof bool) (int, []byte, error) {
+		if eof && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		// Some senders terminate the frames with a newline, skip it.
+		if data[0] == '\n' || data[0] == '\r' {
+			return 1, nil, nil
+		}
+
+		if data[0] < '1' || data[0] > '9' {
+			return fallback(data, eof)
+		}
+
+		length := 0
+		for i, c := range data {
+			switch {
+			case c >= '0' && c <= '9' && i < maxFrameLengthDigits:
+				length = length*10 + int(c-'0')
+				continue
+			case c == ' ' && i > 0:
+				end := i + 1 + length
+				if end <= len(data) {
+					return end, data[i+1 : end], nil
+				}
+				if eof {
+					return 0, nil, fmt.Errorf("incomplete frame, expected %d bytes but got %d", length, len(data)-i-1)
+				}
+				return 0, nil, nil
+			}
+
+			// Not a length prefix.
+			return fallback(data, eof)
+		}
+
+		if eof {
+			return fallback(data, eof)
+		}
+		return 0, nil, nil
+	}
+}
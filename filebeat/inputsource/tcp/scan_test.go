@@ -106,3 +106,63 @@ func TestCustomDelimiter(t *testing.T) {
 		})
 	}
 }
+
+func TestOctetCounting(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "Octet-counted frames",
+			text:     "5 hello7 bonjour4 hola",
+			expected: []string{"hello", "bonjour", "hola"},
+		},
+		{
+			name:     "Frames containing newlines",
+			text:     "11 hello\nworld3 hey",
+			expected: []string{"hello\nworld", "hey"},
+		},
+		{
+			name:     "Frames terminated by newlines",
+			text:     "5 hello\n7 bonjour\r\n",
+			expected: []string{"hello", "bonjour"},
+		},
+		{
+			name:     "Mixed with non-transparent framing",
+			text:     "<13>hello\n6 <13>hi<13>hola\n",
+			expected: []string{"<13>hello", "<13>hi", "<13>hola"},
+		},
+		{
+			name:     "Digits without length prefix",
+			text:     "2018-09-01 hello\n",
+			expected: []string{"2018-09-01 hello"},
+		},
+		{
+			name:     "Incomplete frame",
+			text:     "5 hello10 bonjour",
+			expected: []string{"hello"},
+			err:      true,
+		},
+		{
+			name:     "Empty string",
+			text:     "",
+			expected: []string(nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := strings.NewReader(test.text)
+			scanner := bufio.NewScanner(buf)
+			scanner.Split(SplitFuncOctetCounting([]byte("\n")))
+			var elements []string
+			for scanner.Scan() {
+				elements = append(elements, scanner.Text())
+			}
+			assert.EqualValues(t, test.expected, elements)
+			assert.Equal(t, test.err, scanner.Err() != nil)
+		})
+	}
+}
@@ -200,3 +200,9 @@ func SplitFunc(lineDelimiter []byte) bufio.SplitFunc {
 	}
 	return factoryDelimiter(ld)
 }
+
+// SplitFuncOctetCounting allows to create a `bufio.SplitFunc` that splits octet-counted frames
+// as defined in RFC6587 and splits the other frames with the delimiter provided.
+func SplitFuncOctetCounting(lineDelimiter []byte) bufio.SplitFunc {
+	return factoryOctetCounting(SplitFunc(lineDelimiter))
+}
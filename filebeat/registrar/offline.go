@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/elastic/beats/filebeat/input/file"
+)
+
+// WriteStates replaces all states of the registry with the given states. It
+// is meant for editing the registry while filebeat is not running. The
+// current registry files are copied to backup files first, their paths are
+// returned. The states are written atomically in the format of the configured
+// backend.
+func WriteStates(registryFile string, fileMode os.FileMode, config StoreConfig, states []file.State) ([]string, error) {
+	var (
+		files []string
+		write func() error
+	)
+
+	switch config.Backend {
+	case "", BackendJSON:
+		files = []string{registryFile}
+		write = func() error {
+			return (&jsonStore{path: registryFile, fileMode: fileMode}).writeStates(states)
+		}
+	case BackendLog:
+		store := newLogStore(registryFile, fileMode, config.CheckpointSize)
+		if _, err := os.Stat(store.checkpointFile); os.IsNotExist(err) {
+			// Filebeat migrates the JSON registry on the next start
+			files = []string{registryFile}
+			write = func() error {
+				return (&jsonStore{path: registryFile, fileMode: fileMode}).writeStates(states)
+			}
+			break
+		}
+
+		files = []string{store.checkpointFile, store.logFile}
+		write = func() error {
+			// Loading sets the sequence number, so all entries of the log are
+			// superseded by the new checkpoint.
+			if _, err := store.Load(); err != nil {
+				return err
+			}
+			defer store.Close()
+			return store.writeCheckpoint(states)
+		}
+	default:
+		return nil, fmt.Errorf("unknown registry backend '%s'", config.Backend)
+	}
+
+	suffix := backupSuffix(files, time.Now())
+	var backups []string
+	for _, path := range files {
+		backup := path + suffix
+		copied, err := copyFile(path, backup, fileMode)
+		if err != nil {
+			return backups, fmt.Errorf("failed to backup %s: %v", path, err)
+		}
+		if copied {
+			backups = append(backups, backup)
+		}
+	}
+
+	return backups, write()
+}
+
+// backupSuffix returns a suffix for backup files that is not used by any
+// existing backup of the given files.
+func backupSuffix(files []string, now time.Time) string {
+	base := "." + now.Format("20060102T150405")
+	for n := 0; ; n++ {
+		suffix := base + ".bak"
+		if n > 0 {
+			suffix = fmt.Sprintf("%s.%d.bak", base, n)
+		}
+
+		used := false
+		for _, path := range files {
+			if _, err := os.Lstat(path + suffix); err == nil {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return suffix
+		}
+	}
+}
+
+// CompactStates merges duplicate entries of the same file and drops the
+// states removed by filebeat or whose TTL expired at the given time.
+func CompactStates(states []file.State, now time.Time) []file.State {
+	states = fixStates(states)
+
+	compacted := make([]file.State, 0, len(states))
+	for _, state := range states {
+		expired := state.TTL == 0 || (state.TTL > 0 && now.Sub(state.Timestamp) > state.TTL)
+		if !expired {
+			compacted = append(compacted, state)
+		}
+	}
+	return compacted
+}
+
+// copyFile copies src to dst. It returns false if src does not exist.
+func copyFile(src, dst string, fileMode os.FileMode) (bool, error) {
+	in, err := os.Open(src)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fileMode)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err == nil, err
+}
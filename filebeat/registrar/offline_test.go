@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package registrar
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/input/file"
+)
+
+func TestWriteStatesJSON(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendJSON}
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	a, b := testState("a.log", 1, 10), testState("b.log", 2, 20)
+	require.NoError(t, store.Write(Changes{All: allStates(a, b)}))
+	require.NoError(t, store.Close())
+	original, err := ioutil.ReadFile(registryFile)
+	require.NoError(t, err)
+
+	a.Offset = 0
+	backups, err := WriteStates(registryFile, 0600, config, []file.State{a})
+	require.NoError(t, err)
+
+	states, err := LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 0}, offsets(states))
+
+	require.Len(t, backups, 1)
+	backup, err := ioutil.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, original, backup)
+}
+
+func TestWriteStatesLog(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendLog}
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	a, b := testState("a.log", 1, 10), testState("b.log", 2, 20)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a, b}}))
+	require.NoError(t, store.Close())
+
+	b.Offset = 5
+	backups, err := WriteStates(registryFile, 0600, config, []file.State{b})
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+
+	states, err := LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"b.log": 5}, offsets(states))
+
+	// Filebeat continues appending to the log after the new checkpoint
+	store, err = OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	a.Offset = 15
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a}}))
+	require.NoError(t, store.Close())
+
+	states, err = LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 15, "b.log": 5}, offsets(states))
+}
+
+func TestCompactStates(t *testing.T) {
+	now := time.Date(2018, time.July, 16, 12, 0, 0, 0, time.UTC)
+
+	active := testState("active.log", 1, 10)
+	removed := testState("removed.log", 2, 20)
+	removed.TTL = 0
+	expired := testState("expired.log", 3, 30)
+	expired.TTL = time.Hour
+	valid := testState("valid.log", 4, 40)
+	valid.TTL = 24 * time.Hour
+	older := active
+	older.Offset = 5
+	older.Timestamp = active.Timestamp.Add(-time.Minute)
+
+	states := CompactStates([]file.State{older, active, removed, expired, valid}, now)
+	assert.Equal(t, map[string]int64{"active.log": 10, "valid.log": 40}, offsets(states))
+	assert.Len(t, states, 2)
+}
+
+func TestWriteStatesKeepsBackups(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendJSON}
+	require.NoError(t, ioutil.WriteFile(registryFile, []byte("[]"), 0600))
+
+	first, err := WriteStates(registryFile, 0600, config, []file.State{testState("a.log", 1, 10)})
+	require.NoError(t, err)
+	second, err := WriteStates(registryFile, 0600, config, nil)
+	require.NoError(t, err)
+
+	require.Len(t, first, 1)
+	require.Len(t, second, 1)
+	assert.NotEqual(t, first[0], second[0])
+
+	states, err := LoadStates(first[0], config)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+	states, err = LoadStates(second[0], config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 10}, offsets(states))
+}
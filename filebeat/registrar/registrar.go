@@ -27,7 +27,6 @@ import (
 	"time"
 
 	"github.com/elastic/beats/filebeat/input/file"
-	helper "github.com/elastic/beats/libbeat/common/file"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/monitoring"
 	"github.com/elastic/beats/libbeat/paths"
@@ -39,6 +38,8 @@ type Registrar struct {
 	done         chan struct{}
 	registryFile string      // Path to the Registry File
 	fileMode     os.FileMode // Permissions to apply on the Registry File
+	storeConfig  StoreConfig // Backend used to persist the registry
+	store        Store
 	wg           sync.WaitGroup
 
 	states               *file.States // Map with all file paths inside and the corresponding state
@@ -46,6 +47,9 @@ type Registrar struct {
 	gcEnabled            bool         // gcEnabled indicates the registry contains some state that can be gc'ed in the future
 	flushTimeout         time.Duration
 	bufferedStateUpdates int
+
+	updated map[string]file.State // states updated since the last write
+	removed map[string]struct{}   // IDs of states removed since the last write
 }
 
 type successLogger interface {
@@ -63,16 +67,19 @@ var (
 
 // New creates a new Registrar instance, updating the registry file on
 // `file.State` updates. New fails if the file can not be opened or created.
-func New(registryFile string, fileMode os.FileMode, flushTimeout time.Duration, out successLogger) (*Registrar, error) {
+func New(registryFile string, fileMode os.FileMode, flushTimeout time.Duration, storeConfig StoreConfig, out successLogger) (*Registrar, error) {
 	r := &Registrar{
 		registryFile: registryFile,
 		fileMode:     fileMode,
+		storeConfig:  storeConfig,
 		done:         make(chan struct{}),
 		states:       file.NewStates(),
 		Channel:      make(chan []file.State, 1),
 		flushTimeout: flushTimeout,
 		out:          out,
 		wg:           sync.WaitGroup{},
+		updated:      map[string]file.State{},
+		removed:      map[string]struct{}{},
 	}
 	err := r.Init()
 
@@ -91,29 +98,8 @@ func (r *Registrar) Init() error {
 		return fmt.Errorf("Failed to created registry file dir %s: %v", registryPath, err)
 	}
 
-	// Check if files exists
-	fileInfo, err := os.Lstat(r.registryFile)
-	if os.IsNotExist(err) {
-		logp.Info("No registry file found under: %s. Creating a new registry file.", r.registryFile)
-		// No registry exists yet, write empty state to check if registry can be written
-		return r.writeRegistry()
-	}
-	if err != nil {
-		return err
-	}
-
-	// Check if regular file, no dir, no symlink
-	if !fileInfo.Mode().IsRegular() {
-		// Special error message for directory
-		if fileInfo.IsDir() {
-			return fmt.Errorf("Registry file path must be a file. %s is a directory.", r.registryFile)
-		}
-		return fmt.Errorf("Registry file path is not a regular file: %s", r.registryFile)
-	}
-
-	logp.Debug("registrar", "Registry file set to: %s", r.registryFile)
-
-	return nil
+	r.store, err = OpenStore(r.registryFile, r.fileMode, r.storeConfig)
+	return err
 }
 
 // GetStates return the registrar states
@@ -124,19 +110,15 @@ func (r *Registrar) GetStates() []file.State {
 // loadStates fetches the previous reading state from the configure RegistryFile file
 // The default file is `registry` in the data path.
 func (r *Registrar) loadStates() error {
-	f, err := os.Open(r.registryFile)
-	if err != nil {
-		return err
-	}
-
-	defer f.Close()
-
 	logp.Info("Loading registrar data from %s", r.registryFile)
 
-	states, err := readStatesFrom(f)
+	states, err := r.store.Load()
 	if err != nil {
 		return err
 	}
+
+	states = fixStates(states)
+	states = resetStates(states)
 	r.states.SetStates(states)
 	logp.Info("States Loaded from registrar: %+v", len(states))
 
@@ -144,14 +126,23 @@ func (r *Registrar) loadStates() error {
 }
 
 func readStatesFrom(in io.Reader) ([]file.State, error) {
+	states, err := decodeStates(in)
+	if err != nil {
+		return nil, err
+	}
+
+	states = fixStates(states)
+	states = resetStates(states)
+	return states, nil
+}
+
+// decodeStates reads the states from a JSON registry file as they were written.
+func decodeStates(in io.Reader) ([]file.State, error) {
 	states := []file.State{}
 	decoder := json.NewDecoder(in)
 	if err := decoder.Decode(&states); err != nil {
 		return nil, fmt.Errorf("Error decoding states: %s", err)
 	}
-
-	states = fixStates(states)
-	states = resetStates(states)
 	return states, nil
 }
 
@@ -202,6 +193,10 @@ func fixState(st *file.State) {
 // The st state is overwritten with the updated fields.
 func mergeStates(st, other *file.State) {
 	st.Finished = st.Finished || other.Finished
+	st.Completed = st.Completed || other.Completed
+	if st.Compression == "" {
+		st.Compression = other.Compression
+	}
 	if st.Offset < other.Offset { // always select the higher offset
 		st.Offset = other.Offset
 	}
@@ -213,6 +208,7 @@ func mergeStates(st, other *file.State) {
 		st.Source = other.Source
 		st.Timestamp = other.Timestamp
 		st.TTL = other.TTL
+		st.Cursor = other.Cursor
 		st.FileStateOS = other.FileStateOS
 
 		metaOld, metaNew = st.Meta, other.Meta
@@ -329,7 +325,11 @@ func (r *Registrar) gcStates() {
 	}
 
 	beforeCount := r.states.Count()
-	cleanedStates, pendingClean := r.states.Cleanup()
+	cleanedStates, pendingClean := r.states.CleanupWith(func(state file.State) {
+		id := state.ID()
+		delete(r.updated, id)
+		r.removed[id] = struct{}{}
+	})
 	statesCleanup.Add(int64(cleanedStates))
 
 	logp.Debug("registrar",
@@ -348,6 +348,12 @@ func (r *Registrar) processEventStates(states []file.State) {
 	for i := range states {
 		r.states.UpdateWithTs(states[i], ts)
 		statesUpdate.Add(1)
+
+		state := states[i]
+		state.Timestamp = ts
+		id := state.ID()
+		r.updated[id] = state
+		delete(r.removed, id)
 	}
 }
 
@@ -356,6 +362,7 @@ func (r *Registrar) Stop() {
 	logp.Info("Stopping Registrar")
 	close(r.done)
 	r.wg.Wait()
+	r.store.Close()
 }
 
 func (r *Registrar) flushRegistry() {
@@ -369,28 +376,37 @@ func (r *Registrar) flushRegistry() {
 	r.bufferedStateUpdates = 0
 }
 
-// writeRegistry writes the new json registry file to disk.
+// writeRegistry persists the state changes to the registry store.
 func (r *Registrar) writeRegistry() error {
 	// First clean up states
 	r.gcStates()
-	states := r.states.GetStates()
-	statesCurrent.Set(int64(len(states)))
+	count := r.states.Count()
+	statesCurrent.Set(int64(count))
 
 	registryWrites.Inc()
 
-	tempfile, err := writeTmpFile(r.registryFile, r.fileMode, states)
-	if err != nil {
-		registryFails.Inc()
-		return err
+	changes := Changes{
+		Updated: make([]file.State, 0, len(r.updated)),
+		Removed: make([]string, 0, len(r.removed)),
+		All:     r.states.GetStates,
+	}
+	for _, state := range r.updated {
+		changes.Updated = append(changes.Updated, state)
+	}
+	for id := range r.removed {
+		changes.Removed = append(changes.Removed, id)
 	}
 
-	err = helper.SafeFileRotate(r.registryFile, tempfile)
-	if err != nil {
+	// Changes are kept on failure, so they are written with the next flush
+	if err := r.store.Write(changes); err != nil {
 		registryFails.Inc()
 		return err
 	}
+	r.updated = map[string]file.State{}
+	r.removed = map[string]struct{}{}
 
-	logp.Debug("registrar", "Registry file updated. %d states written.", len(states))
+	logp.Debug("registrar", "Registry updated. %d states, %d updates and %d removals written.",
+		count, len(changes.Updated), len(changes.Removed))
 	registrySuccess.Inc()
 
 	return nil
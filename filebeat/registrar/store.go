@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/beats/filebeat/input/file"
+)
+
+// Registry store backends.
+const (
+	// BackendJSON rewrites the complete registry as JSON file on every flush.
+	BackendJSON = "json"
+
+	// BackendLog appends the changed states to a log file and writes a
+	// checkpoint of all states once the log file is large enough.
+	BackendLog = "log"
+)
+
+// StoreConfig selects and configures the backend persisting the registry.
+type StoreConfig struct {
+	Backend        string
+	CheckpointSize int64
+}
+
+// Store persists the registry states.
+type Store interface {
+	// Load reads all states from the store, as they were written.
+	Load() ([]file.State, error)
+
+	// Write persists the changes since the last successful write.
+	Write(changes Changes) error
+
+	// Close releases the resources of the store.
+	Close() error
+}
+
+// Changes describes the state updates to be persisted by a Store.
+type Changes struct {
+	// Updated contains the states updated since the last write.
+	Updated []file.State
+
+	// Removed contains the IDs of the states removed since the last write.
+	Removed []string
+
+	// All returns the complete list of current states, for stores
+	// rewriting the complete registry.
+	All func() []file.State
+}
+
+// OpenStore opens the registry store for the registry file. The registry file
+// is created if it doesn't exist yet.
+func OpenStore(registryFile string, fileMode os.FileMode, config StoreConfig) (Store, error) {
+	switch config.Backend {
+	case "", BackendJSON:
+		return openJSONStore(registryFile, fileMode)
+	case BackendLog:
+		return openLogStore(registryFile, fileMode, config.CheckpointSize)
+	default:
+		return nil, fmt.Errorf("unknown registry backend '%s'", config.Backend)
+	}
+}
+
+// LoadStates reads the states of the registry file as they were written,
+// without creating or modifying any file. The states of the JSON registry
+// file are returned if the log backend has not written a checkpoint yet.
+func LoadStates(registryFile string, config StoreConfig) ([]file.State, error) {
+	var store Store
+	switch config.Backend {
+	case "", BackendJSON:
+		store = &jsonStore{path: registryFile}
+	case BackendLog:
+		logStore := newLogStore(registryFile, 0, config.CheckpointSize)
+		if _, err := os.Stat(logStore.checkpointFile); os.IsNotExist(err) {
+			store = &jsonStore{path: registryFile}
+		} else {
+			store = logStore
+		}
+	default:
+		return nil, fmt.Errorf("unknown registry backend '%s'", config.Backend)
+	}
+
+	defer store.Close()
+	return store.Load()
+}
+
+// checkRegularFile returns an error if path exists and is no regular file.
+// The first return value is false if the file does not exist.
+func checkRegularFile(path string) (bool, error) {
+	fileInfo, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Check if regular file, no dir, no symlink
+	if !fileInfo.Mode().IsRegular() {
+		// Special error message for directory
+		if fileInfo.IsDir() {
+			return true, fmt.Errorf("Registry file path must be a file. %s is a directory.", path)
+		}
+		return true, fmt.Errorf("Registry file path is not a regular file: %s", path)
+	}
+	return true, nil
+}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"os"
+
+	"github.com/elastic/beats/filebeat/input/file"
+	helper "github.com/elastic/beats/libbeat/common/file"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// jsonStore keeps the registry as a single JSON file, which is rewritten on
+// every write.
+type jsonStore struct {
+	path     string
+	fileMode os.FileMode
+}
+
+func openJSONStore(path string, fileMode os.FileMode) (*jsonStore, error) {
+	s := &jsonStore{path: path, fileMode: fileMode}
+
+	exists, err := checkRegularFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		logp.Info("No registry file found under: %s. Creating a new registry file.", path)
+		// No registry exists yet, write empty state to check if registry can be written
+		if err := s.writeStates(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	logp.Debug("registrar", "Registry file set to: %s", path)
+	return s, nil
+}
+
+func (s *jsonStore) Load() ([]file.State, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return decodeStates(f)
+}
+
+func (s *jsonStore) Write(changes Changes) error {
+	return s.writeStates(changes.All())
+}
+
+func (s *jsonStore) writeStates(states []file.State) error {
+	if states == nil {
+		states = []file.State{}
+	}
+
+	tempfile, err := writeTmpFile(s.path, s.fileMode, states)
+	if err != nil {
+		return err
+	}
+
+	return helper.SafeFileRotate(s.path, tempfile)
+}
+
+func (s *jsonStore) Close() error {
+	return nil
+}
@@ -0,0 +1,356 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/beats/filebeat/input/file"
+	helper "github.com/elastic/beats/libbeat/common/file"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// DefaultCheckpointSize is the size of the log file which triggers a new
+// checkpoint of the log backend.
+const DefaultCheckpointSize = 10 * 1024 * 1024
+
+// Operations of log entries.
+const (
+	opSet    = "set"
+	opRemove = "remove"
+)
+
+// logStore keeps the registry as a checkpoint file containing all states and
+// an append-only log file with the changes since the checkpoint. Each entry of
+// the log file is one JSON document per line. Entries and checkpoint carry a
+// sequence number, so log entries already contained in the checkpoint are
+// skipped when loading the registry.
+//
+// If no checkpoint exists yet, the states are loaded from the JSON registry
+// file, so switching from the json backend keeps the reading state.
+type logStore struct {
+	registryFile   string
+	checkpointFile string
+	logFile        string
+	fileMode       os.FileMode
+	checkpointSize int64
+
+	loaded  bool
+	log     *os.File
+	logSize int64 // size of the valid entries in the log file
+	seq     uint64
+}
+
+type logEntry struct {
+	Op    string      `json:"op"`
+	Seq   uint64      `json:"seq"`
+	ID    string      `json:"id,omitempty"`
+	State *file.State `json:"state,omitempty"`
+}
+
+type checkpoint struct {
+	Seq    uint64       `json:"seq"`
+	States []file.State `json:"states"`
+}
+
+func newLogStore(registryFile string, fileMode os.FileMode, checkpointSize int64) *logStore {
+	if checkpointSize <= 0 {
+		checkpointSize = DefaultCheckpointSize
+	}
+
+	return &logStore{
+		registryFile:   registryFile,
+		checkpointFile: registryFile + ".checkpoint",
+		logFile:        registryFile + ".log",
+		fileMode:       fileMode,
+		checkpointSize: checkpointSize,
+	}
+}
+
+func openLogStore(registryFile string, fileMode os.FileMode, checkpointSize int64) (*logStore, error) {
+	s := newLogStore(registryFile, fileMode, checkpointSize)
+
+	exists, err := checkRegularFile(s.checkpointFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkRegularFile(s.logFile); err != nil {
+		return nil, err
+	}
+	if exists {
+		logp.Debug("registrar", "Registry checkpoint file set to: %s", s.checkpointFile)
+		return s, nil
+	}
+
+	// Migrate the JSON registry, or create an empty checkpoint to check if the
+	// registry can be written.
+	var states []file.State
+	legacy, err := checkRegularFile(registryFile)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		logp.Info("Migrating registry file %s to checkpoint file %s", registryFile, s.checkpointFile)
+		states, err = (&jsonStore{path: registryFile}).Load()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		logp.Info("No registry file found under: %s. Creating a new registry checkpoint.", s.checkpointFile)
+	}
+
+	if err := s.writeCheckpoint(states); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *logStore) Load() ([]file.State, error) {
+	f, err := os.Open(s.checkpointFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cp checkpoint
+	if err := json.NewDecoder(f).Decode(&cp); err != nil {
+		return nil, fmt.Errorf("Error decoding registry checkpoint: %s", err)
+	}
+
+	states := cp.States
+	idx := make(map[string]int, len(states))
+	for i := range states {
+		idx[states[i].ID()] = i
+	}
+	s.seq = cp.Seq
+
+	removed := false
+	err = s.replay(cp.Seq, func(entry logEntry) {
+		switch entry.Op {
+		case opSet:
+			id := entry.State.ID()
+			if i, exists := idx[id]; exists {
+				states[i] = *entry.State
+			} else {
+				idx[id] = len(states)
+				states = append(states, *entry.State)
+			}
+		case opRemove:
+			if i, exists := idx[entry.ID]; exists {
+				delete(idx, entry.ID)
+				states[i] = file.State{}
+				removed = true
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.loaded = true
+
+	if removed {
+		current := states[:0]
+		for _, state := range states {
+			if !state.IsEmpty() {
+				current = append(current, state)
+			}
+		}
+		states = current
+	}
+	return states, nil
+}
+
+// replay calls fn for all entries of the log file newer than the checkpoint.
+// An incomplete entry at the end of the file, written during a crash, is
+// ignored and overwritten by the next write.
+func (s *logStore) replay(checkpointSeq uint64, fn func(logEntry)) error {
+	f, err := os.Open(s.logFile)
+	if os.IsNotExist(err) {
+		s.logSize = 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) > 0 {
+				logp.Warn("Ignoring incomplete entry at the end of the registry log %s", s.logFile)
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		var entry logEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			logp.Warn("Ignoring registry log %s after invalid entry at offset %d: %s", s.logFile, offset, err)
+			break
+		}
+		offset += int64(len(line))
+
+		if entry.Seq <= checkpointSeq {
+			continue
+		}
+		if entry.Op == opSet && entry.State == nil {
+			continue
+		}
+		if entry.Seq > s.seq {
+			s.seq = entry.Seq
+		}
+		fn(entry)
+	}
+
+	s.logSize = offset
+	return nil
+}
+
+func (s *logStore) Write(changes Changes) error {
+	// The sequence number and the end of the log are known after loading
+	if !s.loaded {
+		if _, err := s.Load(); err != nil {
+			return err
+		}
+	}
+
+	if s.logSize >= s.checkpointSize {
+		return s.writeCheckpoint(changes.All())
+	}
+
+	if len(changes.Updated) == 0 && len(changes.Removed) == 0 {
+		return nil
+	}
+
+	if err := s.openLog(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	seq := s.seq
+	for _, id := range changes.Removed {
+		seq++
+		if err := encoder.Encode(logEntry{Op: opRemove, Seq: seq, ID: id}); err != nil {
+			return err
+		}
+	}
+	for i := range changes.Updated {
+		seq++
+		if err := encoder.Encode(logEntry{Op: opSet, Seq: seq, State: &changes.Updated[i]}); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.log.Write(buf.Bytes())
+	if err == nil {
+		err = s.log.Sync()
+	}
+	if err != nil {
+		// Drop the partially written entries, so the next write appends to
+		// the last valid entry.
+		s.closeLog()
+		return fmt.Errorf("failed to append to registry log %s: %v", s.logFile, err)
+	}
+
+	s.logSize += int64(n)
+	s.seq = seq
+	return nil
+}
+
+// openLog opens the log file for appending after the last valid entry.
+func (s *logStore) openLog() error {
+	if s.log != nil {
+		return nil
+	}
+
+	f, err := os.OpenFile(s.logFile, os.O_WRONLY|os.O_CREATE, s.fileMode)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(s.logSize); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Seek(s.logSize, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+
+	s.log = f
+	return nil
+}
+
+func (s *logStore) closeLog() {
+	if s.log != nil {
+		s.log.Close()
+		s.log = nil
+	}
+}
+
+// writeCheckpoint writes all states to the checkpoint file and truncates the
+// log file. Until the log file is truncated, its entries are skipped on load
+// based on the sequence number of the checkpoint.
+func (s *logStore) writeCheckpoint(states []file.State) error {
+	if states == nil {
+		states = []file.State{}
+	}
+
+	logp.Debug("registrar", "Write registry checkpoint: %s", s.checkpointFile)
+
+	tempfile := s.checkpointFile + ".new"
+	f, err := os.OpenFile(tempfile, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_SYNC, s.fileMode)
+	if err != nil {
+		return err
+	}
+
+	err = json.NewEncoder(f).Encode(checkpoint{Seq: s.seq, States: states})
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := helper.SafeFileRotate(s.checkpointFile, tempfile); err != nil {
+		return err
+	}
+
+	s.closeLog()
+	s.logSize = 0
+	if err := os.Truncate(s.logFile, 0); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *logStore) Close() error {
+	s.closeLog()
+	return nil
+}
@@ -0,0 +1,289 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package registrar
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/input/file"
+	helper "github.com/elastic/beats/libbeat/common/file"
+)
+
+func testState(source string, inode uint64, offset int64) file.State {
+	return file.State{
+		Source:      source,
+		Offset:      offset,
+		Timestamp:   time.Date(2018, time.July, 16, 10, 45, 01, 0, time.UTC),
+		TTL:         -1,
+		Type:        "log",
+		FileStateOS: helper.StateOS{Inode: inode, Device: 1},
+	}
+}
+
+func offsets(states []file.State) map[string]int64 {
+	m := map[string]int64{}
+	for _, state := range states {
+		m[state.Source] = state.Offset
+	}
+	return m
+}
+
+func allStates(states ...file.State) func() []file.State {
+	return func() []file.State { return states }
+}
+
+func tempRegistry(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "filebeat-registry")
+	require.NoError(t, err)
+	return filepath.Join(dir, "registry"), func() { os.RemoveAll(dir) }
+}
+
+func TestJSONStore(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	store, err := OpenStore(registryFile, 0600, StoreConfig{Backend: BackendJSON})
+	require.NoError(t, err)
+
+	states, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	a, b := testState("a.log", 1, 10), testState("b.log", 2, 20)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a}, All: allStates(a, b)}))
+	require.NoError(t, store.Close())
+
+	f, err := os.Open(registryFile)
+	require.NoError(t, err)
+	defer f.Close()
+	states, err = decodeStates(f)
+	require.NoError(t, err)
+	assert.Equal(t, []file.State{a, b}, states)
+}
+
+func TestLogStore(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendLog}
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+
+	a, b, c := testState("a.log", 1, 10), testState("b.log", 2, 20), testState("c.log", 3, 30)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a, b, c}}))
+
+	a.Offset, c.Offset = 15, 35
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a, c}, Removed: []string{b.ID()}}))
+	require.NoError(t, store.Close())
+
+	store, err = OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	defer store.Close()
+
+	states, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 15, "c.log": 35}, offsets(states))
+
+	// Entries are appended after reopening
+	b.Offset = 25
+	require.NoError(t, store.Write(Changes{Updated: []file.State{b}}))
+
+	states, err = LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 15, "b.log": 25, "c.log": 35}, offsets(states))
+}
+
+func TestLogStoreCheckpoint(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendLog, CheckpointSize: 1}
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	defer store.Close()
+
+	a, b := testState("a.log", 1, 10), testState("b.log", 2, 20)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a, b}}))
+
+	info, err := os.Stat(registryFile + ".log")
+	require.NoError(t, err)
+	assert.NotZero(t, info.Size())
+
+	// The log exceeds the checkpoint size, all states are written to the checkpoint
+	a.Offset = 15
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a}, All: allStates(a, b)}))
+
+	info, err = os.Stat(registryFile + ".log")
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	b.Offset = 25
+	require.NoError(t, store.Write(Changes{Updated: []file.State{b}}))
+
+	states, err := LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 15, "b.log": 25}, offsets(states))
+}
+
+func TestLogStoreSkipsEntriesInCheckpoint(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendLog}
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+
+	a := testState("a.log", 1, 10)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a}}))
+	log, err := ioutil.ReadFile(registryFile + ".log")
+	require.NoError(t, err)
+
+	// Simulate a crash after writing the checkpoint, before truncating the log
+	a.Offset = 15
+	require.NoError(t, store.(*logStore).writeCheckpoint([]file.State{a}))
+	require.NoError(t, store.Close())
+	require.NoError(t, ioutil.WriteFile(registryFile+".log", log, 0600))
+
+	states, err := LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 15}, offsets(states))
+}
+
+func TestLogStoreIncompleteEntry(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	config := StoreConfig{Backend: BackendLog}
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+
+	a := testState("a.log", 1, 10)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{a}}))
+	require.NoError(t, store.Close())
+
+	// Entry written partially during a crash
+	f, err := os.OpenFile(registryFile+".log", os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"set","seq":2,"state":{"source":"a.lo`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	store, err = OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	defer store.Close()
+
+	states, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 10}, offsets(states))
+
+	// The incomplete entry is overwritten
+	b := testState("b.log", 2, 20)
+	require.NoError(t, store.Write(Changes{Updated: []file.State{b}}))
+
+	states, err = LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 10, "b.log": 20}, offsets(states))
+}
+
+func TestLogStoreMigratesJSONRegistry(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	a, b := testState("a.log", 1, 10), testState("b.log", 2, 20)
+	jsonStore, err := OpenStore(registryFile, 0600, StoreConfig{Backend: BackendJSON})
+	require.NoError(t, err)
+	require.NoError(t, jsonStore.Write(Changes{All: allStates(a, b)}))
+
+	// Without checkpoint, the JSON registry is read
+	config := StoreConfig{Backend: BackendLog}
+	states, err := LoadStates(registryFile, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 10, "b.log": 20}, offsets(states))
+
+	store, err := OpenStore(registryFile, 0600, config)
+	require.NoError(t, err)
+	defer store.Close()
+
+	states, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a.log": 10, "b.log": 20}, offsets(states))
+}
+
+func TestOpenStoreUnknownBackend(t *testing.T) {
+	registryFile, cleanup := tempRegistry(t)
+	defer cleanup()
+
+	_, err := OpenStore(registryFile, 0600, StoreConfig{Backend: "sqlite"})
+	assert.Error(t, err)
+}
+
+// recordingStore records the changes written by the registrar
+type recordingStore struct {
+	changes []Changes
+}
+
+func (s *recordingStore) Load() ([]file.State, error) { return nil, nil }
+func (s *recordingStore) Close() error                { return nil }
+func (s *recordingStore) Write(changes Changes) error {
+	s.changes = append(s.changes, changes)
+	return nil
+}
+
+func TestRegistrarWritesChanges(t *testing.T) {
+	store := &recordingStore{}
+	r := &Registrar{
+		states:  file.NewStates(),
+		store:   store,
+		updated: map[string]file.State{},
+		removed: map[string]struct{}{},
+	}
+
+	a, b := testState("a.log", 1, 10), testState("b.log", 2, 20)
+	a.Finished, b.Finished = true, true
+	r.onEvents([]file.State{a, b})
+	a.Offset = 15
+	r.onEvents([]file.State{a})
+	require.NoError(t, r.writeRegistry())
+
+	require.Len(t, store.changes, 1)
+	updated := store.changes[0].Updated
+	sort.Slice(updated, func(i, j int) bool { return updated[i].Source < updated[j].Source })
+	assert.Equal(t, map[string]int64{"a.log": 15, "b.log": 20}, offsets(updated))
+	assert.Empty(t, store.changes[0].Removed)
+	assert.Len(t, store.changes[0].All(), 2)
+
+	// Removing a state
+	b.TTL = 0
+	r.onEvents([]file.State{b})
+	require.NoError(t, r.writeRegistry())
+
+	require.Len(t, store.changes, 2)
+	assert.Empty(t, store.changes[1].Updated)
+	assert.Equal(t, []string{b.ID()}, store.changes[1].Removed)
+	assert.Len(t, store.changes[1].All(), 1)
+}
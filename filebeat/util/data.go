@@ -24,6 +24,12 @@ import (
 	"github.com/elastic/beats/filebeat/input/file"
 )
 
+// ACKer is implemented by the private data of events from inputs that need
+// to know when their events have been acknowledged by the outputs.
+type ACKer interface {
+	ACK()
+}
+
 type Data struct {
 	Event beat.Event
 	state file.State
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/common/cfgwarn"
@@ -44,6 +45,8 @@ func Start(cfg *common.Config) {
 		mux.HandleFunc("/state", stateHandler)
 		mux.HandleFunc("/stats", statsHandler)
 		mux.HandleFunc("/dataset", datasetHandler)
+		mux.HandleFunc("/inputs", inputsHandler)
+		mux.HandleFunc("/inputs/", inputsHandler)
 
 		url := config.Host + ":" + strconv.Itoa(config.Port)
 		logp.Info("Metrics endpoint listening on: %s", url)
@@ -94,11 +97,48 @@ func datasetHandler(w http.ResponseWriter, r *http.Request) {
 	print(w, data, r.URL)
 }
 
+// inputsHandler reports the metrics of the running inputs. The path below
+// /inputs selects the metrics of a single input, e.g. /inputs/<id>/files.
+func inputsHandler(w http.ResponseWriter, r *http.Request) {
+	data := monitoring.CollectStructSnapshot(monitoring.GetNamespace("inputs").GetRegistry(), monitoring.Full, false)
+
+	data, found := selectPath(data, strings.TrimPrefix(r.URL.Path, "/inputs"))
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	print(w, data, r.URL)
+}
+
+// selectPath returns the metrics below the given slash separated path. A
+// missing input is not found. Namespaces without metrics are omitted from
+// snapshots, so missing keys below an input are reported as empty.
+func selectPath(data map[string]interface{}, path string) (map[string]interface{}, bool) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return data, true
+	}
+
+	for i, key := range strings.Split(path, "/") {
+		sub, ok := data[key].(map[string]interface{})
+		if !ok {
+			if i == 0 {
+				return nil, false
+			}
+			return map[string]interface{}{}, true
+		}
+		data = sub
+	}
+	return data, true
+}
+
 func print(w http.ResponseWriter, data common.MapStr, u *url.URL) {
 	query := u.Query()
 	if _, ok := query["pretty"]; ok {
-		fmt.Fprintf(w, data.StringToPrint())
+		fmt.Fprint(w, data.StringToPrint())
 	} else {
-		fmt.Fprintf(w, data.String())
+		fmt.Fprint(w, data.String())
 	}
 }
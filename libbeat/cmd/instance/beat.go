@@ -69,12 +69,19 @@ import (
 	_ "github.com/elastic/beats/libbeat/processors/actions"
 	_ "github.com/elastic/beats/libbeat/processors/add_cloud_metadata"
 	_ "github.com/elastic/beats/libbeat/processors/add_docker_metadata"
+	_ "github.com/elastic/beats/libbeat/processors/add_geoip"
 	_ "github.com/elastic/beats/libbeat/processors/add_host_metadata"
 	_ "github.com/elastic/beats/libbeat/processors/add_kubernetes_metadata"
 	_ "github.com/elastic/beats/libbeat/processors/add_locale"
 	_ "github.com/elastic/beats/libbeat/processors/add_process_metadata"
+	_ "github.com/elastic/beats/libbeat/processors/communityid"
 	_ "github.com/elastic/beats/libbeat/processors/dissect"
 	_ "github.com/elastic/beats/libbeat/processors/dns"
+	_ "github.com/elastic/beats/libbeat/processors/fingerprint"
+	_ "github.com/elastic/beats/libbeat/processors/grok"
+	_ "github.com/elastic/beats/libbeat/processors/registered_domain"
+	_ "github.com/elastic/beats/libbeat/processors/script"
+	_ "github.com/elastic/beats/libbeat/processors/translate"
 
 	// Register autodiscover providers
 	_ "github.com/elastic/beats/libbeat/autodiscover/providers/docker"
@@ -344,7 +351,7 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 		settings := report.Settings{
 			DefaultUsername: settings.Monitoring.DefaultUsername,
 		}
-		reporter, err := report.New(b.Info, settings, b.Config.Monitoring, b.Config.Output)
+		reporter, err := report.New(b.Info, settings, b.Config.Monitoring, *b.primaryOutput())
 		if err != nil {
 			return err
 		}
@@ -426,7 +433,7 @@ func (b *Beat) Setup(bt beat.Creator, template, dashboards, machineLearning, pip
 		}
 
 		if template {
-			outCfg := b.Config.Output
+			outCfg := b.primaryOutput()
 
 			if outCfg.Name() != "elasticsearch" {
 				return fmt.Errorf("Template loading requested but the Elasticsearch output is not configured/enabled")
@@ -473,7 +480,7 @@ func (b *Beat) Setup(bt beat.Creator, template, dashboards, machineLearning, pip
 		}
 
 		if pipelines && b.OverwritePipelinesCallback != nil {
-			esConfig := b.Config.Output.Config()
+			esConfig := b.primaryOutput().Config()
 			err = b.OverwritePipelinesCallback(esConfig)
 			if err != nil {
 				return err
@@ -537,6 +544,11 @@ func (b *Beat) configure() error {
 	}
 
 	b.Beat.Config = &b.Config.BeatConfig
+	if !b.Config.Output.IsSet() && len(b.Config.Pipeline.Outputs) > 0 {
+		// Beats use the Elasticsearch output of the beat config for setup
+		// tasks, like loading Ingest Node pipelines.
+		b.Beat.Config = &beat.BeatConfig{Output: *b.primaryOutput()}
+	}
 
 	err = cfgwarn.CheckRemoved5xSettings(cfg, "queue_size", "bulk_queue_size")
 	if err != nil {
@@ -664,8 +676,8 @@ func (b *Beat) loadDashboards(ctx context.Context, force bool) error {
 	if b.Config.Dashboards.Enabled() {
 		var esConfig *common.Config
 
-		if b.Config.Output.Name() == "elasticsearch" {
-			esConfig = b.Config.Output.Config()
+		if b.primaryOutput().Name() == "elasticsearch" {
+			esConfig = b.primaryOutput().Config()
 		}
 		err := dashboards.ImportDashboards(ctx, b.Info.Beat, b.Info.Hostname, paths.Resolve(paths.Home, ""),
 			b.Config.Kibana, esConfig, b.Config.Dashboards, nil)
@@ -693,13 +705,13 @@ func (b *Beat) registerTemplateLoading() error {
 	}
 
 	// Loads template by default if esOutput is enabled
-	if b.Config.Output.Name() == "elasticsearch" {
+	if b.primaryOutput().Name() == "elasticsearch" {
 
 		// Get ES Index name for comparison
 		esCfg := struct {
 			Index string `config:"index"`
 		}{}
-		err := b.Config.Output.Config().Unpack(&esCfg)
+		err := b.primaryOutput().Config().Unpack(&esCfg)
 		if err != nil {
 			return err
 		}
@@ -723,6 +735,20 @@ func (b *Beat) registerTemplateLoading() error {
 	return nil
 }
 
+// primaryOutput returns the output used for setup and monitoring. If multiple
+// outputs are configured, the first Elasticsearch output is used.
+func (b *Beat) primaryOutput() *common.ConfigNamespace {
+	if b.Config.Output.IsSet() {
+		return &b.Config.Output
+	}
+	for i := range b.Config.Pipeline.Outputs {
+		if out := &b.Config.Pipeline.Outputs[i].Output; out.Name() == "elasticsearch" {
+			return out
+		}
+	}
+	return &b.Config.Output
+}
+
 // Build and return a callback to load index template into ES
 func (b *Beat) templateLoadingCallback() (func(esClient *elasticsearch.Client) error, error) {
 	callback := func(esClient *elasticsearch.Client) error {
@@ -0,0 +1,260 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue/spool"
+)
+
+const defaultDrainBatchSize = 2048
+
+var (
+	// drainRetryWait is the duration to wait before retrying to publish events.
+	drainRetryWait = time.Second
+
+	// drainMaxReconnectWait is the maximum duration to wait between attempts
+	// to reconnect to the output.
+	drainMaxReconnectWait = time.Minute
+)
+
+// GenDrainCmd generates the command for sending the pending events to the
+// configured output or to a file. Events are removed from the spool file once
+// the output has ACKed them.
+func GenDrainCmd(name, beatVersion string) *cobra.Command {
+	var (
+		flags queueFlags
+		file  string
+	)
+	command := &cobra.Command{
+		Use:   "drain",
+		Short: "Send pending events of the spool queue file to the configured output or a file",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			b, err := initBeat(name, beatVersion)
+			if err != nil {
+				return err
+			}
+
+			inspector, err := openInspector(b, flags, false)
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+
+			var count int
+			if file != "" {
+				count, err = drainToFile(b, inspector, file)
+			} else {
+				count, err = drainToOutput(b, inspector, flags.output)
+			}
+			fmt.Printf("Drained %v events\n", count)
+			return err
+		}),
+	}
+	flags.register(command)
+	command.Flags().StringVar(&file, "file", "", "Write events as NDJSON to the file instead of sending them to the output")
+	return command
+}
+
+func drainToFile(b *instance.Beat, inspector *spool.Inspector, path string) (int, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	total := 0
+	for {
+		out := bufio.NewWriter(f)
+		n, err := writeEvents(out, b.Info, inspector, defaultDrainBatchSize)
+		if err == nil {
+			err = out.Flush()
+		}
+		if err == nil {
+			err = f.Sync()
+		}
+		if err != nil || n == 0 {
+			return total, err
+		}
+
+		if err := inspector.ACK(uint(n)); err != nil {
+			return total, err
+		}
+		total += n
+	}
+}
+
+func drainToOutput(b *instance.Beat, inspector *spool.Inspector, name string) (int, error) {
+	_, outCfg, err := outputConfig(b, name)
+	if err != nil {
+		return 0, err
+	}
+	if !outCfg.IsSet() {
+		return 0, fmt.Errorf("no output configured")
+	}
+
+	group, err := outputs.Load(b.Info, nil, outCfg.Name(), outCfg.Config())
+	if err != nil {
+		return 0, fmt.Errorf("error initializing output: %v", err)
+	}
+
+	client, err := connectClient(group.Clients)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	batchSize := group.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultDrainBatchSize
+	}
+
+	total := 0
+	for {
+		var events []publisher.Event
+		n, err := inspector.Read(batchSize, func(event publisher.Event) error {
+			events = append(events, event)
+			return nil
+		})
+		if err != nil || n == 0 {
+			return total, err
+		}
+
+		if err := publish(client, events, group.Retry); err != nil {
+			return total, err
+		}
+		if err := inspector.ACK(uint(n)); err != nil {
+			return total, err
+		}
+		total += n
+	}
+}
+
+// connectClient returns the first client a connection can be established
+// with. All other clients are closed.
+func connectClient(clients []outputs.Client) (outputs.Client, error) {
+	var (
+		client outputs.Client
+		err    error
+	)
+	for _, c := range clients {
+		if client != nil {
+			c.Close()
+			continue
+		}
+
+		if conn, ok := c.(outputs.Connectable); ok {
+			if err = conn.Connect(); err != nil {
+				c.Close()
+				continue
+			}
+		}
+		client = c
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("failed to connect to the output: %v", err)
+	}
+	return client, nil
+}
+
+// publish sends the events and waits for the output to process them. Events
+// returned by the output are retried up to maxRetries times. Negative
+// maxRetries retries until all events have been processed.
+func publish(client outputs.Client, events []publisher.Event, maxRetries int) error {
+	reconnect := false
+	for attempt := 0; len(events) > 0; attempt++ {
+		if attempt > 0 {
+			if maxRetries >= 0 && attempt > maxRetries {
+				return fmt.Errorf("failed to publish %v events after %v attempts", len(events), attempt)
+			}
+			time.Sleep(drainRetryWait)
+		}
+
+		// Network clients close their connection when publishing fails, so
+		// they must connect again before the events are retried.
+		if reconnect {
+			if conn, ok := client.(outputs.Connectable); ok {
+				reconnectClient(conn)
+			}
+			reconnect = false
+		}
+
+		batch := newDrainBatch(events)
+		if err := client.Publish(batch); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to publish events: %v\n", err)
+			reconnect = true
+		}
+		<-batch.done
+		events = batch.retry
+	}
+	return nil
+}
+
+// reconnectClient connects the client again. Like the publisher pipeline, it
+// keeps trying with exponential backoff until the connection is established.
+func reconnectClient(conn outputs.Connectable) {
+	backoff := common.NewBackoff(nil, drainRetryWait, drainMaxReconnectWait)
+	for attempt := 1; ; attempt++ {
+		err := conn.Connect()
+		if err == nil {
+			return
+		}
+
+		fmt.Fprintf(os.Stderr, "Failed to reconnect to the output with %v reconnect attempt(s): %v\n", attempt, err)
+		backoff.Wait()
+	}
+}
+
+// drainBatch implements publisher.Batch. The batch is done, once the output
+// signals the events have been processed or must be retried.
+type drainBatch struct {
+	events []publisher.Event
+	retry  []publisher.Event
+	done   chan struct{}
+}
+
+func newDrainBatch(events []publisher.Event) *drainBatch {
+	return &drainBatch{events: events, done: make(chan struct{})}
+}
+
+func (b *drainBatch) Events() []publisher.Event { return b.events }
+
+func (b *drainBatch) ACK()  { close(b.done) }
+func (b *drainBatch) Drop() { close(b.done) }
+
+func (b *drainBatch) Retry()     { b.RetryEvents(b.events) }
+func (b *drainBatch) Cancelled() { b.RetryEvents(b.events) }
+
+func (b *drainBatch) CancelledEvents(events []publisher.Event) { b.RetryEvents(events) }
+
+func (b *drainBatch) RetryEvents(events []publisher.Event) {
+	b.retry = events
+	close(b.done)
+}
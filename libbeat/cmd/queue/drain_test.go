@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+type mockClient struct {
+	connectErr error
+	publish    func(publisher.Batch) error
+	published  [][]publisher.Event
+	connects   int
+	closed     bool
+}
+
+func (c *mockClient) Connect() error {
+	c.connects++
+	if c.connectErr != nil {
+		return c.connectErr
+	}
+	c.closed = false
+	return nil
+}
+
+func (c *mockClient) Close() error   { c.closed = true; return nil }
+func (c *mockClient) String() string { return "mock" }
+
+func (c *mockClient) Publish(batch publisher.Batch) error {
+	if c.closed {
+		batch.Retry()
+		return errors.New("connection closed")
+	}
+	c.published = append(c.published, batch.Events())
+	return c.publish(batch)
+}
+
+func TestPublishRetry(t *testing.T) {
+	drainRetryWait = 0
+	events := make([]publisher.Event, 3)
+
+	client := &mockClient{}
+	client.publish = func(batch publisher.Batch) error {
+		if len(client.published) == 1 {
+			batch.RetryEvents(batch.Events()[1:])
+		} else {
+			batch.ACK()
+		}
+		return nil
+	}
+
+	assert.NoError(t, publish(client, events, 3))
+	if assert.Len(t, client.published, 2) {
+		assert.Len(t, client.published[1], 2)
+	}
+}
+
+func TestPublishRetryLimit(t *testing.T) {
+	drainRetryWait = 0
+	events := make([]publisher.Event, 3)
+
+	client := &mockClient{publish: func(batch publisher.Batch) error {
+		batch.Retry()
+		return nil
+	}}
+
+	assert.Error(t, publish(client, events, 2))
+	assert.Len(t, client.published, 3)
+}
+
+func TestPublishReconnectsAfterError(t *testing.T) {
+	drainRetryWait = 0
+	events := make([]publisher.Event, 3)
+
+	client := &mockClient{}
+	client.publish = func(batch publisher.Batch) error {
+		if len(client.published) == 1 {
+			batch.Retry()
+			return errors.New("connection reset")
+		}
+		batch.ACK()
+		return nil
+	}
+
+	// Network outputs close the client when publishing fails, so the
+	// events can only be published after connecting again.
+	wrapped := outputs.WithBackoff(client, 0, 0)
+
+	assert.NoError(t, publish(wrapped, events, 1))
+	assert.Len(t, client.published, 2)
+	assert.Equal(t, 1, client.connects)
+	assert.False(t, client.closed)
+}
+
+func TestReconnectClientRetries(t *testing.T) {
+	drainRetryWait = 0
+	drainMaxReconnectWait = 0
+
+	attempts := 0
+	client := &mockClient{connectErr: errors.New("connection refused")}
+	conn := connectFunc(func() error {
+		attempts++
+		if attempts < 3 {
+			return client.Connect()
+		}
+		return nil
+	})
+
+	reconnectClient(conn)
+	assert.Equal(t, 3, attempts)
+}
+
+type connectFunc func() error
+
+func (f connectFunc) Connect() error { return f() }
+
+func TestConnectClient(t *testing.T) {
+	failing := &mockClient{connectErr: errors.New("oops")}
+	first := &mockClient{}
+	second := &mockClient{}
+
+	client, err := connectClient([]outputs.Client{failing, first, second})
+	assert.NoError(t, err)
+	assert.Equal(t, first, client)
+	assert.True(t, failing.closed)
+	assert.False(t, first.closed)
+	assert.True(t, second.closed)
+
+	_, err = connectClient([]outputs.Client{failing})
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue/spool"
+)
+
+// GenDumpCmd generates the command for printing the pending events as NDJSON.
+func GenDumpCmd(name, beatVersion string) *cobra.Command {
+	var (
+		flags queueFlags
+		limit int
+	)
+	command := &cobra.Command{
+		Use:   "dump",
+		Short: "Print pending events of the spool queue file as NDJSON to stdout",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			b, err := initBeat(name, beatVersion)
+			if err != nil {
+				return err
+			}
+
+			inspector, err := openInspector(b, flags, true)
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+
+			out := bufio.NewWriter(os.Stdout)
+			defer out.Flush()
+
+			_, err = writeEvents(out, b.Info, inspector, limit)
+			return err
+		}),
+	}
+	flags.register(command)
+	command.Flags().IntVar(&limit, "limit", 0, "Maximum number of events to print, 0 prints all pending events")
+	return command
+}
+
+// writeEvents writes up to limit events as NDJSON, using the same encoding
+// as the file and console outputs.
+func writeEvents(w io.Writer, info beat.Info, inspector *spool.Inspector, limit int) (int, error) {
+	codec := json.New(false, true, info.Version)
+	return inspector.Read(limit, func(event publisher.Event) error {
+		serialized, err := codec.Encode(info.Beat, &event.Content)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %v", err)
+		}
+
+		if _, err := w.Write(serialized); err != nil {
+			return err
+		}
+		_, err = w.Write([]byte("\n"))
+		return err
+	})
+}
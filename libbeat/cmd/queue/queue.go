@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package queue
+
+import (
+	"fmt"
+	"os"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/publisher/queue/spool"
+)
+
+// queueFlags selects the spool file to be opened.
+type queueFlags struct {
+	output string
+	path   string
+}
+
+func (f *queueFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.output, "output", "", "Name of the output in 'outputs', whose queue is opened")
+	cmd.Flags().StringVar(&f.path, "path", "", "Path of the spool file, overwrites the queue.spool.file.path setting")
+}
+
+// GenInfoCmd generates the command for reporting the state of the spool file.
+func GenInfoCmd(name, beatVersion string) *cobra.Command {
+	var flags queueFlags
+	command := &cobra.Command{
+		Use:   "info",
+		Short: "Show pages, pending and ACKed events of the spool queue file",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			b, err := initBeat(name, beatVersion)
+			if err != nil {
+				return err
+			}
+
+			inspector, err := openInspector(b, flags, true)
+			if err != nil {
+				return err
+			}
+			defer inspector.Close()
+
+			stats, err := inspector.Stats()
+			if err != nil {
+				return fmt.Errorf("failed to read spool file: %v", err)
+			}
+
+			fmt.Printf("path:          %v\n", stats.Path)
+			fmt.Printf("file size:     %v\n", humanize.IBytes(uint64(stats.FileSize)))
+			fmt.Printf("page size:     %v\n", humanize.IBytes(uint64(stats.PageSize)))
+			fmt.Printf("data pages:    %v (%v)\n", stats.DataPages,
+				humanize.IBytes(stats.DataPages*uint64(stats.PageSize)))
+			fmt.Printf("compression:   %v\n", stats.Compression)
+			fmt.Printf("encrypted:     %v\n", stats.Encrypted)
+			fmt.Printf("pending:       %v events (%v)\n", stats.Pending, humanize.IBytes(stats.PendingBytes))
+			fmt.Printf("acked:         %v entries\n", stats.ACKed)
+			return nil
+		}),
+	}
+	flags.register(command)
+	return command
+}
+
+func initBeat(name, beatVersion string) (*instance.Beat, error) {
+	b, err := instance.NewBeat(name, "", beatVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing beat: %s", err)
+	}
+
+	if err = b.Init(); err != nil {
+		return nil, fmt.Errorf("error initializing beat: %s", err)
+	}
+	return b, nil
+}
+
+// outputConfig returns the queue and output settings of the single output,
+// or of the named output in the `outputs` list.
+func outputConfig(b *instance.Beat, output string) (queue, out common.ConfigNamespace, err error) {
+	pipeline := b.Config.Pipeline
+	if output == "" {
+		if !b.Config.Output.IsSet() && len(pipeline.Outputs) > 0 {
+			return queue, out, fmt.Errorf("multiple outputs are configured, select an output with --output")
+		}
+		return pipeline.Queue, b.Config.Output, nil
+	}
+
+	for _, cfg := range pipeline.Outputs {
+		if cfg.Name == output {
+			return cfg.Queue, cfg.Output, nil
+		}
+	}
+	return queue, out, fmt.Errorf("no output named '%v' configured", output)
+}
+
+func openInspector(b *instance.Beat, flags queueFlags, readonly bool) (*spool.Inspector, error) {
+	queue, _, err := outputConfig(b, flags.output)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := common.NewConfig()
+	if queue.IsSet() {
+		if queue.Name() != "spool" {
+			return nil, fmt.Errorf("the configured queue type is '%v', not spool", queue.Name())
+		}
+		cfg = queue.Config()
+	} else if flags.path == "" {
+		return nil, fmt.Errorf("no spool queue configured, use --path to select a spool file")
+	}
+
+	if flags.path != "" {
+		if err := cfg.SetString("file.path", -1, flags.path); err != nil {
+			return nil, err
+		}
+	}
+
+	inspector, err := spool.OpenInspector(cfg, readonly)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("spool file does not exist: %v", err)
+		}
+		return nil, err
+	}
+	return inspector, nil
+}
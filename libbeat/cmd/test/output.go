@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/outputs"
 	"github.com/elastic/beats/libbeat/testing"
 )
@@ -45,21 +46,31 @@ func GenTestOutputCmd(name, beatVersion string) *cobra.Command {
 				os.Exit(1)
 			}
 
-			output, err := outputs.Load(b.Info, nil, b.Config.Output.Name(), b.Config.Output.Config())
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error initializing output: %s\n", err)
-				os.Exit(1)
+			configs := []common.ConfigNamespace{b.Config.Output}
+			if !b.Config.Output.IsSet() {
+				configs = configs[:0]
+				for _, out := range b.Config.Pipeline.Outputs {
+					configs = append(configs, out.Output)
+				}
 			}
 
-			for _, client := range output.Clients {
-				tClient, ok := client.(testing.Testable)
-				if !ok {
-					fmt.Printf("%s output doesn't support testing\n", b.Config.Output.Name())
+			for _, outCfg := range configs {
+				output, err := outputs.Load(b.Info, nil, outCfg.Name(), outCfg.Config())
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error initializing output: %s\n", err)
 					os.Exit(1)
 				}
 
-				// Perform test:
-				tClient.Test(testing.NewConsoleDriver(os.Stdout))
+				for _, client := range output.Clients {
+					tClient, ok := client.(testing.Testable)
+					if !ok {
+						fmt.Printf("%s output doesn't support testing\n", outCfg.Name())
+						os.Exit(1)
+					}
+
+					// Perform test:
+					tClient.Test(testing.NewConsoleDriver(os.Stdout))
+				}
 			}
 		},
 	}
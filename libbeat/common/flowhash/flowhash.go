@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package flowhash computes hashes identifying network flows, so that flows
+// can be correlated with the data of other network monitoring tools.
+package flowhash
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"strings"
+)
+
+// IANA protocol numbers of the transport protocols with ports.
+const (
+	ICMP   uint8 = 1
+	TCP    uint8 = 6
+	UDP    uint8 = 17
+	ICMPv6 uint8 = 58
+	SCTP   uint8 = 132
+)
+
+var protocolNumbers = map[string]uint8{
+	"icmp":      ICMP,
+	"tcp":       TCP,
+	"udp":       UDP,
+	"ipv6-icmp": ICMPv6,
+	"icmpv6":    ICMPv6,
+	"sctp":      SCTP,
+}
+
+// ProtocolNumber returns the protocol number for a transport protocol name,
+// like "tcp" or "udp".
+func ProtocolNumber(name string) (uint8, bool) {
+	n, found := protocolNumbers[strings.ToLower(name)]
+	return n, found
+}
+
+// Flow identifies a network flow. For ICMP and ICMPv6 flows, the ICMP type and
+// code are used instead of the ports.
+type Flow struct {
+	SourceIP        net.IP
+	DestinationIP   net.IP
+	SourcePort      uint16
+	DestinationPort uint16
+	Protocol        uint8
+	ICMP            struct {
+		Type uint8
+		Code uint8
+	}
+}
+
+// CommunityID computes Community ID v1 flow hashes, as specified in
+// https://github.com/corelight/community-id-spec.
+type CommunityID struct {
+	seed [2]byte
+}
+
+// NewCommunityID returns a Community ID hasher using the given seed. Tools
+// that are correlated must use the same seed.
+func NewCommunityID(seed uint16) CommunityID {
+	var c CommunityID
+	binary.BigEndian.PutUint16(c.seed[:], seed)
+	return c
+}
+
+// Hash returns the Community ID of the flow. The hash is the same for both
+// directions of the flow. An empty string is returned if the flow has no
+// valid source or destination IP.
+func (c CommunityID) Hash(f Flow) string {
+	src, dst := f.SourceIP.To4(), f.DestinationIP.To4()
+	if src == nil || dst == nil {
+		src, dst = f.SourceIP.To16(), f.DestinationIP.To16()
+		if src == nil || dst == nil {
+			return ""
+		}
+	}
+
+	srcPort, dstPort := f.SourcePort, f.DestinationPort
+	oneWay := false
+	switch f.Protocol {
+	case ICMP:
+		srcPort, dstPort, oneWay = icmpPorts(icmpCounterparts, f.ICMP.Type, f.ICMP.Code)
+	case ICMPv6:
+		srcPort, dstPort, oneWay = icmpPorts(icmpv6Counterparts, f.ICMP.Type, f.ICMP.Code)
+	}
+
+	// Order the endpoints, so that both directions of a flow get the same
+	// hash.
+	if !oneWay {
+		if cmp := bytes.Compare(src, dst); cmp > 0 || (cmp == 0 && srcPort > dstPort) {
+			src, dst = dst, src
+			srcPort, dstPort = dstPort, srcPort
+		}
+	}
+
+	h := sha1.New()
+	h.Write(c.seed[:])
+	h.Write(src)
+	h.Write(dst)
+	h.Write([]byte{f.Protocol, 0})
+	switch f.Protocol {
+	case ICMP, TCP, UDP, ICMPv6, SCTP:
+		var ports [4]byte
+		binary.BigEndian.PutUint16(ports[:2], srcPort)
+		binary.BigEndian.PutUint16(ports[2:], dstPort)
+		h.Write(ports[:])
+	}
+
+	return "1:" + base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// icmpCounterparts maps ICMP request and reply types to each other.
+var icmpCounterparts = map[uint8]uint8{
+	0: 8, 8: 0, // Echo reply, echo request
+	9: 10, 10: 9, // Router advertisement, router solicitation
+	13: 14, 14: 13, // Timestamp, timestamp reply
+	15: 16, 16: 15, // Information request, information reply
+	17: 18, 18: 17, // Address mask request, address mask reply
+}
+
+// icmpv6Counterparts maps ICMPv6 request and reply types to each other.
+var icmpv6Counterparts = map[uint8]uint8{
+	128: 129, 129: 128, // Echo request, echo reply
+	130: 131, 131: 130, // Multicast listener query, report
+	133: 134, 134: 133, // Router solicitation, advertisement
+	135: 136, 136: 135, // Neighbor solicitation, advertisement
+	139: 140, 140: 139, // Who are you request, reply
+	144: 145, 145: 144, // Home agent address discovery request, reply
+}
+
+// icmpPorts returns the values used as ports for an ICMP message. Messages
+// with a counterpart use the type of the counterpart as destination port, so
+// that requests and replies get the same hash. Other messages are one-way and
+// use the code as destination port.
+func icmpPorts(counterparts map[uint8]uint8, typ, code uint8) (src, dst uint16, oneWay bool) {
+	if other, found := counterparts[typ]; found {
+		return uint16(typ), uint16(other), false
+	}
+	return uint16(typ), uint16(code), true
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package flowhash
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommunityID(t *testing.T) {
+	var tests = []struct {
+		src, dst         string
+		proto            uint8
+		srcPort, dstPort uint16
+		seed             uint16
+		expected         string
+	}{
+		{"128.232.110.120", "66.35.250.204", TCP, 34855, 80, 0, "1:LQU9qZlK+B5F3KDmev6m5PMibrg="},
+		{"66.35.250.204", "128.232.110.120", TCP, 80, 34855, 0, "1:LQU9qZlK+B5F3KDmev6m5PMibrg="},
+		{"128.232.110.120", "66.35.250.204", TCP, 34855, 80, 1, "1:3V71V58M3Ksw/yuFALMcW0LAHvc="},
+		{"3ffe:507:0:1:200:86ff:fe05:80da", "3ffe:507:0:1:260:97ff:fe07:69ea", UDP, 1024, 53, 0, "1:INqmIiLffei3abJ5CF2olEHTMSY="},
+		{"10.0.0.1", "10.0.0.1", UDP, 53, 1024, 0, "1:K8UZyjeAJnFCD/O9e/qnJG6Cf4o="},
+		{"10.0.0.1", "10.0.0.1", UDP, 1024, 53, 0, "1:K8UZyjeAJnFCD/O9e/qnJG6Cf4o="},
+		{"10.0.0.1", "10.0.0.2", 47, 0, 0, 0, "1:+KlEHDT0vJgzs/eNmzHq0aSpRYw="},
+	}
+
+	for _, test := range tests {
+		f := Flow{
+			SourceIP:        net.ParseIP(test.src),
+			DestinationIP:   net.ParseIP(test.dst),
+			SourcePort:      test.srcPort,
+			DestinationPort: test.dstPort,
+			Protocol:        test.proto,
+		}
+		assert.Equal(t, test.expected, NewCommunityID(test.seed).Hash(f), "%+v", test)
+	}
+}
+
+func TestCommunityIDICMP(t *testing.T) {
+	var tests = []struct {
+		src, dst  string
+		proto     uint8
+		typ, code uint8
+		expected  string
+	}{
+		// Echo request and reply get the same hash.
+		{"192.168.0.89", "192.168.0.1", ICMP, 8, 0, "1:X0snYXpgwiv9TZtqg64sgzUn6Dk="},
+		{"192.168.0.1", "192.168.0.89", ICMP, 0, 0, "1:X0snYXpgwiv9TZtqg64sgzUn6Dk="},
+		// Destination unreachable is one-way.
+		{"192.168.0.89", "192.168.0.1", ICMP, 3, 1, "1:LXCfhMUFbYXe+CEMrQuZCbiLY3o="},
+		{"fe80::200:86ff:fe05:80da", "fe80::260:97ff:fe07:69ea", ICMPv6, 135, 0, "1:dGHyGvjMfljg6Bppwm3bg0LO8TY="},
+	}
+
+	for _, test := range tests {
+		f := Flow{
+			SourceIP:      net.ParseIP(test.src),
+			DestinationIP: net.ParseIP(test.dst),
+			Protocol:      test.proto,
+		}
+		f.ICMP.Type, f.ICMP.Code = test.typ, test.code
+		assert.Equal(t, test.expected, NewCommunityID(0).Hash(f), "%+v", test)
+	}
+}
+
+func TestCommunityIDInvalidFlow(t *testing.T) {
+	assert.Empty(t, NewCommunityID(0).Hash(Flow{SourceIP: net.ParseIP("10.0.0.1"), Protocol: TCP}))
+}
+
+func TestProtocolNumber(t *testing.T) {
+	n, found := ProtocolNumber("TCP")
+	assert.True(t, found)
+	assert.Equal(t, TCP, n)
+
+	n, found = ProtocolNumber("ipv6-icmp")
+	assert.True(t, found)
+	assert.Equal(t, ICMPv6, n)
+
+	_, found = ProtocolNumber("gre")
+	assert.False(t, found)
+}
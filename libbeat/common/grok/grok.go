@@ -0,0 +1,298 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package grok implements grok expressions as used by the Elasticsearch
+// ingest node and Logstash. A grok expression is a regular expression that
+// can reference named patterns using the %{SYNTAX:SEMANTIC:TYPE} notation.
+//
+// The regular expression engine supports the Oniguruma features the standard
+// pattern library depends on (look-behind, atomic groups, possessive
+// quantifiers).
+package grok
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dlclark/regexp2"
+	"github.com/pkg/errors"
+)
+
+var patternRef = regexp.MustCompile(`%{(\w+)(?::([^:}]+)(?::(\w+))?)?}`)
+
+// Grok is a compiled grok expression.
+type Grok struct {
+	pattern string
+	re      *regexp2.Regexp
+	fields  []field
+}
+
+// field maps a generated regexp group to the field name and type given in
+// the grok expression.
+type field struct {
+	group string
+	name  string
+	conv  converter
+}
+
+type converter func(string) (interface{}, error)
+
+var converters = map[string]converter{
+	"string": func(s string) (interface{}, error) { return s, nil },
+	"int": func(s string) (interface{}, error) {
+		i, err := strconv.ParseInt(s, 10, 32)
+		return int(i), err
+	},
+	"long": func(s string) (interface{}, error) {
+		return strconv.ParseInt(s, 10, 64)
+	},
+	"float": func(s string) (interface{}, error) {
+		f, err := strconv.ParseFloat(s, 32)
+		return float32(f), err
+	},
+	"double": func(s string) (interface{}, error) {
+		return strconv.ParseFloat(s, 64)
+	},
+	"boolean": func(s string) (interface{}, error) {
+		return strconv.ParseBool(s)
+	},
+}
+
+// Compile expands and compiles a grok expression. Pattern references are
+// resolved using definitions first and the standard pattern library second.
+// definitions can be nil.
+func Compile(pattern string, definitions map[string]string) (*Grok, error) {
+	c := compiler{definitions: definitions}
+	expanded, err := c.expand(pattern, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexp2.Compile(expanded, regexp2.ExplicitCapture)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile grok pattern '%v'", pattern)
+	}
+
+	return &Grok{pattern: pattern, re: re, fields: c.fields}, nil
+}
+
+// MustCompile is like Compile but panics if the expression cannot be
+// compiled.
+func MustCompile(pattern string, definitions map[string]string) *Grok {
+	g, err := Compile(pattern, definitions)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// SetMatchTimeout limits the time a single match may take. Some patterns
+// can backtrack catastrophically on unexpected input. By default there is
+// no limit.
+func (g *Grok) SetMatchTimeout(d time.Duration) {
+	g.re.MatchTimeout = d
+}
+
+// String returns the original grok expression.
+func (g *Grok) String() string {
+	return g.pattern
+}
+
+// Match matches text against the expression. It returns the captured
+// fields, or nil if text does not match. Field names can contain dots.
+// If the same field name is captured by multiple groups, the first group
+// that participated in the match wins.
+func (g *Grok) Match(text string) (map[string]interface{}, error) {
+	m, err := g.re.FindStringMatch(text)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+
+	captures := make(map[string]interface{}, len(g.fields))
+	for _, f := range g.fields {
+		if _, exists := captures[f.name]; exists {
+			continue
+		}
+
+		group := m.GroupByName(f.group)
+		if group == nil || len(group.Captures) == 0 {
+			continue
+		}
+
+		value := group.String()
+		if f.conv == nil {
+			captures[f.name] = value
+			continue
+		}
+
+		v, err := f.conv(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert value '%v' of field '%v'", value, f.name)
+		}
+		captures[f.name] = v
+	}
+	return captures, nil
+}
+
+// MatchString reports whether text matches the expression.
+func (g *Grok) MatchString(text string) (bool, error) {
+	return g.re.MatchString(text)
+}
+
+type compiler struct {
+	definitions map[string]string
+	fields      []field
+}
+
+func (c *compiler) lookup(name string) (string, bool) {
+	if p, found := c.definitions[name]; found {
+		return p, true
+	}
+	p, found := builtinPatterns[name]
+	return p, found
+}
+
+func (c *compiler) addField(name, typ string) (string, error) {
+	f := field{
+		group: fmt.Sprintf("g%d", len(c.fields)),
+		name:  name,
+	}
+
+	if typ != "" {
+		conv, found := converters[typ]
+		if !found {
+			return "", errors.Errorf("unsupported type '%v' for field '%v'", typ, name)
+		}
+		f.conv = conv
+	}
+
+	c.fields = append(c.fields, f)
+	return f.group, nil
+}
+
+// expand replaces pattern references and named groups in pattern. stack
+// contains the names of the patterns being expanded to detect recursion.
+func (c *compiler) expand(pattern string, stack []string) (string, error) {
+	pattern, err := c.renameGroups(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		buf  strings.Builder
+		last int
+	)
+	for _, loc := range patternRef.FindAllStringSubmatchIndex(pattern, -1) {
+		buf.WriteString(pattern[last:loc[0]])
+		last = loc[1]
+
+		name := pattern[loc[2]:loc[3]]
+		var semantic, typ string
+		if loc[4] >= 0 {
+			semantic = pattern[loc[4]:loc[5]]
+		}
+		if loc[6] >= 0 {
+			typ = pattern[loc[6]:loc[7]]
+		}
+
+		for _, s := range stack {
+			if s == name {
+				return "", errors.Errorf("circular reference in pattern '%v'", name)
+			}
+		}
+
+		definition, found := c.lookup(name)
+		if !found {
+			return "", errors.Errorf("pattern '%v' not defined", name)
+		}
+
+		expanded, err := c.expand(definition, append(stack, name))
+		if err != nil {
+			return "", err
+		}
+
+		if semantic == "" {
+			buf.WriteString("(?:")
+		} else {
+			group, err := c.addField(semantic, typ)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString("(?<" + group + ">")
+		}
+		buf.WriteString(expanded)
+		buf.WriteString(")")
+	}
+	buf.WriteString(pattern[last:])
+	return buf.String(), nil
+}
+
+// renameGroups replaces the names of inline named groups (?<name>...) and
+// (?'name'...) with generated names. Field names like 'url.original' are
+// not valid group names.
+func (c *compiler) renameGroups(pattern string) (string, error) {
+	if !strings.Contains(pattern, "(?<") && !strings.Contains(pattern, "(?'") {
+		return pattern, nil
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		if ch == '\\' && i+1 < len(pattern) {
+			buf.WriteByte(ch)
+			buf.WriteByte(pattern[i+1])
+			i++
+			continue
+		}
+
+		if i+3 >= len(pattern) || !strings.HasPrefix(pattern[i:], "(?") {
+			buf.WriteByte(ch)
+			continue
+		}
+
+		var terminator byte
+		switch pattern[i+2] {
+		case '<':
+			terminator = '>'
+		case '\'':
+			terminator = '\''
+		}
+		if terminator == 0 || pattern[i+3] == '=' || pattern[i+3] == '!' {
+			buf.WriteByte(ch)
+			continue
+		}
+
+		end := strings.IndexByte(pattern[i+3:], terminator)
+		if end < 0 {
+			return "", errors.Errorf("unterminated group name in '%v'", pattern)
+		}
+
+		group, err := c.addField(pattern[i+3:i+3+end], "")
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString("(?<" + group + ">")
+		i += 3 + end
+	}
+	return buf.String(), nil
+}
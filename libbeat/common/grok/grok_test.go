@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinPatternsCompile(t *testing.T) {
+	for name := range builtinPatterns {
+		_, err := Compile("%{"+name+"}", nil)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		definitions map[string]string
+		text        string
+		expected    map[string]interface{}
+	}{
+		{
+			pattern: `%{IP:client.ip} %{WORD:method} %{URIPATHPARAM:url} %{NUMBER:bytes:int} %{NUMBER:duration:double}`,
+			text:    "55.3.244.1 GET /index.html 15824 0.043",
+			expected: map[string]interface{}{
+				"client.ip": "55.3.244.1",
+				"method":    "GET",
+				"url":       "/index.html",
+				"bytes":     15824,
+				"duration":  0.043,
+			},
+		},
+		{
+			pattern: `%{COMBINEDAPACHELOG}`,
+			text:    `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "-" "Mozilla/4.08"`,
+			expected: map[string]interface{}{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"-"`,
+				"agent":       `"Mozilla/4.08"`,
+			},
+		},
+		{
+			pattern:     `%{SYSLOGTIMESTAMP:timestamp} %{PROGRAM:process.name}: %{GREEDYDATA:message}`,
+			definitions: map[string]string{"PROGRAM": `[\w-]+`},
+			text:        "Dec 12 18:59:34 sshd: Accepted key",
+			expected: map[string]interface{}{
+				"timestamp":    "Dec 12 18:59:34",
+				"process.name": "sshd",
+				"message":      "Accepted key",
+			},
+		},
+		{
+			pattern: `(?<user.name>[a-z]+)@(?<host.name>%{HOSTNAME})`,
+			text:    "root@localhost",
+			expected: map[string]interface{}{
+				"user.name": "root",
+				"host.name": "localhost",
+			},
+		},
+		{
+			pattern: `%{WORD:log.level}: (?'error.message'.*)`,
+			text:    "ERROR: disk full",
+			expected: map[string]interface{}{
+				"log.level":     "ERROR",
+				"error.message": "disk full",
+			},
+		},
+		{
+			pattern: `(?:%{NUMBER:value:long}|%{WORD:value})`,
+			text:    "abc",
+			expected: map[string]interface{}{
+				"value": "abc",
+			},
+		},
+		{
+			pattern: `(?:%{NUMBER:value:long}|%{WORD:value})`,
+			text:    "42",
+			expected: map[string]interface{}{
+				"value": int64(42),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		g, err := Compile(test.pattern, test.definitions)
+		require.NoError(t, err, test.pattern)
+
+		captures, err := g.Match(test.text)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, captures, test.pattern)
+	}
+}
+
+func TestNoMatch(t *testing.T) {
+	g := MustCompile(`^%{IP:ip}$`, nil)
+
+	captures, err := g.Match("not an ip")
+	assert.NoError(t, err)
+	assert.Nil(t, captures)
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := map[string]struct {
+		pattern     string
+		definitions map[string]string
+	}{
+		"undefined pattern": {pattern: `%{DOES_NOT_EXIST}`},
+		"unsupported type":  {pattern: `%{NUMBER:n:decimal}`},
+		"recursion": {
+			pattern:     `%{A}`,
+			definitions: map[string]string{"A": `a%{B}`, "B": `b%{A}`},
+		},
+		"invalid regexp": {pattern: `%{WORD:a}(`},
+	}
+
+	for name, test := range tests {
+		_, err := Compile(test.pattern, test.definitions)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestConversionError(t *testing.T) {
+	g := MustCompile(`%{WORD:n:int}`, nil)
+
+	_, err := g.Match("abc")
+	assert.Error(t, err)
+}
+
+func TestDefinitionsOverrideBuiltins(t *testing.T) {
+	g := MustCompile(`^%{WORD:w}$`, map[string]string{"WORD": `[0-9]+`})
+
+	captures, err := g.Match("123")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"w": "123"}, captures)
+
+	captures, err = g.Match("abc")
+	require.NoError(t, err)
+	assert.Nil(t, captures)
+}
@@ -17,7 +17,14 @@
 
 package kafka
 
-import "github.com/Shopify/sarama"
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// Version is a Kafka version.
+type Version string
 
 // TODO: remove me.
 // Compat version overwrite for missing versions in sarama
@@ -78,3 +85,27 @@ func parseKafkaVersion(s string) sarama.KafkaVersion {
 	}
 	return v
 }
+
+// Validate checks the version is supported.
+func (v Version) Validate() error {
+	if _, ok := kafkaVersions[string(v)]; !ok {
+		return fmt.Errorf("unknown/unsupported kafka version '%v'", v)
+	}
+	return nil
+}
+
+// Unpack sets and validates the version.
+func (v *Version) Unpack(s string) error {
+	tmp := Version(s)
+	if err := tmp.Validate(); err != nil {
+		return err
+	}
+	*v = tmp
+	return nil
+}
+
+// Get returns the sarama version.
+func (v Version) Get() (sarama.KafkaVersion, bool) {
+	version, ok := kafkaVersions[string(v)]
+	return version, ok
+}
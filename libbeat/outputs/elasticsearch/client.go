@@ -42,10 +42,11 @@ type Client struct {
 	Connection
 	tlsConfig *transport.TLSConfig
 
-	index    outil.Selector
-	pipeline *outil.Selector
-	params   map[string]string
-	timeout  time.Duration
+	index      outil.Selector
+	pipeline   *outil.Selector
+	documentID string
+	params     map[string]string
+	timeout    time.Duration
 
 	// buffered bulk requests
 	bulkRequ *bulkRequest
@@ -58,6 +59,8 @@ type Client struct {
 	proxyURL         *url.URL
 
 	observer outputs.Observer
+
+	deadLetter *deadLetter
 }
 
 // ClientSettings contains the settings for a client.
@@ -71,9 +74,11 @@ type ClientSettings struct {
 	Headers            map[string]string
 	Index              outil.Selector
 	Pipeline           *outil.Selector
+	DocumentID         string
 	Timeout            time.Duration
 	CompressionLevel   int
 	Observer           outputs.Observer
+	DeadLetter         *deadLetter
 }
 
 type connectCallback func(client *Client) error
@@ -113,6 +118,9 @@ type bulkResultStats struct {
 	fails        int // number of failed events (can be retried)
 	nonIndexable int // number of failed events (not indexable -> must be dropped)
 	tooMany      int // number of events receiving HTTP 429 Too Many Requests
+
+	// events that were not indexable, for storing them as dead letters
+	rejected []rejectedEvent
 }
 
 var (
@@ -210,17 +218,19 @@ func NewClient(
 			},
 			encoder: encoder,
 		},
-		tlsConfig: s.TLS,
-		index:     s.Index,
-		pipeline:  pipeline,
-		params:    params,
-		timeout:   s.Timeout,
+		tlsConfig:  s.TLS,
+		index:      s.Index,
+		pipeline:   pipeline,
+		documentID: s.DocumentID,
+		params:     params,
+		timeout:    s.Timeout,
 
 		bulkRequ: bulkRequ,
 
 		compressionLevel: compression,
 		proxyURL:         s.Proxy,
 		observer:         s.Observer,
+		deadLetter:       s.DeadLetter,
 	}
 
 	client.Connection.onConnectCallback = func() error {
@@ -253,6 +263,7 @@ func (client *Client) Clone() *Client {
 			URL:              client.URL,
 			Index:            client.index,
 			Pipeline:         client.pipeline,
+			DocumentID:       client.documentID,
 			Proxy:            client.proxyURL,
 			TLS:              client.tlsConfig,
 			Username:         client.Username,
@@ -261,6 +272,7 @@ func (client *Client) Clone() *Client {
 			Headers:          client.Headers,
 			Timeout:          client.http.Timeout,
 			CompressionLevel: client.compressionLevel,
+			DeadLetter:       client.deadLetter,
 		},
 		nil, // XXX: do not pass connection callback?
 	)
@@ -302,7 +314,7 @@ func (client *Client) publishEvents(
 	// events slice
 
 	origCount := len(data)
-	data = bulkEncodePublishRequest(body, client.index, client.pipeline, data)
+	data = bulkEncodePublishRequest(body, client.index, client.pipeline, client.documentID, data)
 	newCount := len(data)
 	if st != nil && origCount > newCount {
 		st.Dropped(origCount - newCount)
@@ -334,16 +346,25 @@ func (client *Client) publishEvents(
 		failedEvents, stats = bulkCollectPublishFails(&client.json, data)
 	}
 
+	deadLetters := 0
+	if len(stats.rejected) > 0 && client.deadLetter != nil {
+		retry, stored := client.storeDeadLetters(stats.rejected)
+		failedEvents = append(failedEvents, retry...)
+		stats.nonIndexable -= stored + len(retry)
+		deadLetters = stored
+	}
+
 	failed := len(failedEvents)
 	if st := client.observer; st != nil {
 		dropped := stats.nonIndexable
 		duplicates := stats.duplicates
-		acked := len(data) - failed - dropped - duplicates
+		acked := len(data) - failed - dropped - duplicates - deadLetters
 
 		st.Acked(acked)
 		st.Failed(failed)
 		st.Dropped(dropped)
 		st.Duplicate(duplicates)
+		st.DeadLetter(deadLetters)
 		st.ErrTooMany(stats.tooMany)
 	}
 
@@ -362,12 +383,13 @@ func bulkEncodePublishRequest(
 	body bulkWriter,
 	index outil.Selector,
 	pipeline *outil.Selector,
+	documentID string,
 	data []publisher.Event,
 ) []publisher.Event {
 	okEvents := data[:0]
 	for i := range data {
 		event := &data[i].Content
-		meta, err := createEventBulkMeta(index, pipeline, event)
+		meta, err := createEventBulkMeta(index, pipeline, documentID, event)
 		if err != nil {
 			logp.Err("Failed to encode event meta data: %s", err)
 			continue
@@ -384,6 +406,7 @@ func bulkEncodePublishRequest(
 func createEventBulkMeta(
 	indexSel outil.Selector,
 	pipelineSel *outil.Selector,
+	documentID string,
 	event *beat.Event,
 ) (interface{}, error) {
 	pipeline, err := getPipeline(event, pipelineSel)
@@ -398,15 +421,9 @@ func createEventBulkMeta(
 		return nil, err
 	}
 
-	var id string
-	if m := event.Meta; m != nil {
-		if tmp := m["id"]; tmp != nil {
-			if s, ok := tmp.(string); ok {
-				id = s
-			} else {
-				logp.Err("Event ID '%v' is no string value", id)
-			}
-		}
+	id, err := getDocumentID(event, documentID)
+	if err != nil {
+		logp.Err("Failed to select event ID: %v", err)
 	}
 
 	meta := bulkEventMeta{
@@ -422,6 +439,33 @@ func createEventBulkMeta(
 	return bulkIndexAction{meta}, nil
 }
 
+// getDocumentID returns the ID set in the event metadata. If no ID is set,
+// the value of the configured document ID field is used.
+func getDocumentID(event *beat.Event, field string) (string, error) {
+	if m := event.Meta; m != nil {
+		if tmp := m["id"]; tmp != nil {
+			if s, ok := tmp.(string); ok {
+				return s, nil
+			}
+			return "", fmt.Errorf("event ID '%v' is no string value", tmp)
+		}
+	}
+
+	if field == "" {
+		return "", nil
+	}
+
+	tmp, err := event.GetValue(field)
+	if err != nil {
+		// events without the field are indexed with a generated ID
+		return "", nil
+	}
+	if s, ok := tmp.(string); ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("document ID field '%v' is no string value", field)
+}
+
 func getPipeline(event *beat.Event, pipelineSel *outil.Selector) (string, error) {
 	if event.Meta != nil {
 		if pipeline, exists := event.Meta["pipeline"]; exists {
@@ -524,6 +568,11 @@ func bulkCollectPublishFails(
 				// hard failure, don't collect
 				logp.Warn("Cannot index event %#v (status=%v): %s", data[i], status, msg)
 				stats.nonIndexable++
+				stats.rejected = append(stats.rejected, rejectedEvent{
+					event:  data[i],
+					status: status,
+					reason: string(msg),
+				})
 				continue
 			}
 		}
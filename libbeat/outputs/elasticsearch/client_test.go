@@ -228,6 +228,43 @@ func TestGetIndexOverwrite(t *testing.T) {
 	assert.Equal(t, expected, index)
 }
 
+func TestGetDocumentID(t *testing.T) {
+	fields := common.MapStr{
+		"fingerprint": "abc",
+		"count":       1,
+	}
+
+	tests := []struct {
+		name     string
+		meta     common.MapStr
+		field    string
+		expected string
+		err      bool
+	}{
+		{name: "no id"},
+		{name: "metadata id", meta: common.MapStr{"id": "meta"}, field: "fingerprint", expected: "meta"},
+		{name: "document id field", field: "fingerprint", expected: "abc"},
+		{name: "missing field", field: "missing"},
+		{name: "no string", field: "count", err: true},
+	}
+
+	for _, test := range tests {
+		event := &beat.Event{Meta: test.meta, Fields: fields}
+		id, err := getDocumentID(event, test.field)
+		if test.err {
+			assert.Error(t, err, test.name)
+			continue
+		}
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, id, test.name)
+	}
+
+	meta, err := createEventBulkMeta(outil.MakeSelector(outil.ConstSelectorExpr("test")), nil,
+		"fingerprint", &beat.Event{Fields: fields})
+	assert.NoError(t, err)
+	assert.Equal(t, bulkCreateAction{bulkEventMeta{Index: "test", DocType: eventType, ID: "abc"}}, meta)
+}
+
 func BenchmarkCollectPublishFailsNone(b *testing.B) {
 	response := []byte(`
     { "items": [
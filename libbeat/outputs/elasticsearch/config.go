@@ -20,6 +20,7 @@ package elasticsearch
 import (
 	"time"
 
+	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
 )
 
@@ -39,6 +40,8 @@ type elasticsearchConfig struct {
 	MaxRetries       int               `config:"max_retries"`
 	Timeout          time.Duration     `config:"timeout"`
 	Backoff          Backoff           `config:"backoff"`
+	DeadLetter       *common.Config    `config:"dead_letter"`
+	DocumentID       string            `config:"document_id"`
 }
 
 type Backoff struct {
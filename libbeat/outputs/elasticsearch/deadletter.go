@@ -0,0 +1,237 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/fileout"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+// deadLetterConfig configures where events rejected by Elasticsearch are
+// stored, instead of being dropped. Either index or file must be set.
+type deadLetterConfig struct {
+	Index string         `config:"index"`
+	File  *common.Config `config:"file"`
+}
+
+func (c *deadLetterConfig) Validate() error {
+	if c.Index != "" && c.File != nil {
+		return errors.New("dead_letter.index and dead_letter.file can not be used together")
+	}
+	if c.Index == "" && c.File == nil {
+		return errors.New("dead_letter requires either index or file to be set")
+	}
+	return nil
+}
+
+// rejectedEvent is an event Elasticsearch refused to index, with the status
+// and error of the bulk item.
+type rejectedEvent struct {
+	event  publisher.Event
+	status int
+	reason string
+}
+
+// deadLetter stores rejected events either in a separate index, using the
+// client that received the rejection, or in rotating files.
+type deadLetter struct {
+	index outil.Selector
+	file  *deadLetterFile
+}
+
+// deadLetterFile serializes the writes of all clients of the output to the
+// file output client.
+type deadLetterFile struct {
+	sync.Mutex
+	client   outputs.Client
+	observer *deadLetterObserver
+}
+
+// deadLetterObserver collects the events the file output failed to write.
+type deadLetterObserver struct {
+	outputs.Observer
+	dropped int
+}
+
+func (o *deadLetterObserver) Dropped(n int) { o.dropped += n }
+
+// deadLetterBatch passes the dead letter documents to the file output, which
+// doesn't retry failed writes.
+type deadLetterBatch struct {
+	events []publisher.Event
+}
+
+func (b *deadLetterBatch) Events() []publisher.Event                { return b.events }
+func (b *deadLetterBatch) ACK()                                     {}
+func (b *deadLetterBatch) Drop()                                    {}
+func (b *deadLetterBatch) Retry()                                   {}
+func (b *deadLetterBatch) RetryEvents(events []publisher.Event)     {}
+func (b *deadLetterBatch) Cancelled()                               {}
+func (b *deadLetterBatch) CancelledEvents(events []publisher.Event) {}
+
+func newDeadLetter(info beat.Info, cfg *common.Config) (*deadLetter, error) {
+	config := deadLetterConfig{}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Index != "" {
+		index, err := outil.BuildSelectorFromConfig(cfg, outil.Settings{
+			Key:              "index",
+			EnableSingleOnly: true,
+			FailEmpty:        true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &deadLetter{index: index}, nil
+	}
+
+	if !config.File.HasField("path") {
+		config.File.SetString("path", -1, paths.Resolve(paths.Data, "dead_letter"))
+	}
+	if !config.File.HasField("filename") {
+		config.File.SetString("filename", -1, info.Beat+"-dead-letter")
+	}
+
+	observer := &deadLetterObserver{Observer: outputs.NewNilObserver()}
+	client, err := fileout.NewClient(info, observer, config.File)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetter{file: &deadLetterFile{client: client, observer: observer}}, nil
+}
+
+// storeDeadLetters stores the rejected events in the dead letter destination.
+// It returns the events to be retried, because the destination is not
+// available right now, and the number of events stored.
+func (client *Client) storeDeadLetters(rejected []rejectedEvent) ([]publisher.Event, int) {
+	now := time.Now()
+
+	docs := make([]publisher.Event, 0, len(rejected))
+	for i := range rejected {
+		r := &rejected[i]
+		index, _ := getIndex(&r.event.Content, client.index)
+		doc, err := makeDeadLetterEvent(&r.event.Content, index, r.status, r.reason, now)
+		if err != nil {
+			logp.Err("Failed to create dead letter event: %s", err)
+			continue
+		}
+		// Remember the rejected event the document belongs to.
+		doc.Private = i
+		docs = append(docs, publisher.Event{Content: doc, Flags: r.event.Flags})
+	}
+	if len(docs) == 0 {
+		return nil, 0
+	}
+
+	if f := client.deadLetter.file; f != nil {
+		f.Lock()
+		defer f.Unlock()
+
+		f.observer.dropped = 0
+		if err := f.client.Publish(&deadLetterBatch{events: docs}); err != nil {
+			logp.Err("Failed to write dead letter events: %s", err)
+			return nil, 0
+		}
+		return nil, len(docs) - f.observer.dropped
+	}
+
+	body := client.encoder
+	body.Reset()
+	docs = bulkEncodePublishRequest(body, client.deadLetter.index, nil, "", docs)
+	if len(docs) == 0 {
+		return nil, 0
+	}
+
+	requ := client.bulkRequ
+	requ.Reset(body)
+	status, result, err := client.sendBulkRequest(requ)
+	if err != nil || status != 200 {
+		logp.Err("Failed to index dead letter events (status=%v): %v", status, err)
+		return originalEvents(rejected, docs), 0
+	}
+
+	client.json.init(result.raw)
+	failed, stats := bulkCollectPublishFails(&client.json, docs)
+	return originalEvents(rejected, failed), stats.acked + stats.duplicates
+}
+
+// originalEvents returns the rejected events of the dead letter documents,
+// so that they are sent to their original index again on retry.
+func originalEvents(rejected []rejectedEvent, docs []publisher.Event) []publisher.Event {
+	events := make([]publisher.Event, 0, len(docs))
+	for _, doc := range docs {
+		events = append(events, rejected[doc.Content.Private.(int)].event)
+	}
+	return events
+}
+
+// makeDeadLetterEvent creates the document stored for a rejected event. The
+// original event is stored as JSON string in the message field, so that it
+// can't cause mapping conflicts again.
+func makeDeadLetterEvent(
+	event *beat.Event,
+	index string,
+	status int,
+	reason string,
+	now time.Time,
+) (beat.Event, error) {
+	original := common.MapStr{"@timestamp": common.Time(event.Timestamp)}
+	for k, v := range event.Fields {
+		original[k] = v
+	}
+	message, err := json.Marshal(original)
+	if err != nil {
+		return beat.Event{}, err
+	}
+
+	cause := common.MapStr{"reason": reason}
+	var details struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(reason), &details); err == nil && details.Type != "" {
+		cause = common.MapStr{"type": details.Type, "reason": details.Reason}
+	}
+
+	fields := common.MapStr{
+		"message": string(message),
+		"dead_letter": common.MapStr{
+			"index":  index,
+			"status": status,
+			"error":  cause,
+		},
+	}
+	// Keep the beat metadata, it's commonly used in index names.
+	if info, err := event.Fields.GetValue("beat"); err == nil {
+		fields["beat"] = info
+	}
+	return beat.Event{Timestamp: now, Fields: fields}, nil
+}
@@ -0,0 +1,276 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	_ "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+)
+
+type countingObserver struct {
+	outputs.Observer
+	acked, failed, dropped, deadLetter int
+}
+
+func (o *countingObserver) Acked(n int)      { o.acked += n }
+func (o *countingObserver) Failed(n int)     { o.failed += n }
+func (o *countingObserver) Dropped(n int)    { o.dropped += n }
+func (o *countingObserver) DeadLetter(n int) { o.deadLetter += n }
+
+const rejectedResponse = `{"items": [
+  {"index": {"status": 201}},
+  {"index": {"status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse [count]"}}}
+]}`
+
+func TestDeadLetterConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		err    bool
+	}{
+		"index": {
+			config: map[string]interface{}{"index": "dead-letter"},
+		},
+		"file": {
+			config: map[string]interface{}{"file.path": "/tmp"},
+		},
+		"none": {
+			config: map[string]interface{}{},
+			err:    true,
+		},
+		"both": {
+			config: map[string]interface{}{"index": "dead-letter", "file.path": "/tmp"},
+			err:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := deadLetterConfig{}
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMakeDeadLetterEvent(t *testing.T) {
+	ts := time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC)
+	now := ts.Add(time.Minute)
+	event := beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"beat":  common.MapStr{"name": "test", "version": "6.4.0"},
+			"count": "abc",
+		},
+	}
+
+	reason := `{"type":"mapper_parsing_exception","reason":"failed to parse [count]"}`
+	doc, err := makeDeadLetterEvent(&event, "test-2018.08.01", 400, reason, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, now, doc.Timestamp)
+	assert.Equal(t, common.MapStr{"name": "test", "version": "6.4.0"}, doc.Fields["beat"])
+	assert.Equal(t, common.MapStr{
+		"index":  "test-2018.08.01",
+		"status": 400,
+		"error": common.MapStr{
+			"type":   "mapper_parsing_exception",
+			"reason": "failed to parse [count]",
+		},
+	}, doc.Fields["dead_letter"])
+
+	var original map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(doc.Fields["message"].(string)), &original))
+	assert.Equal(t, "2018-08-01T10:00:00.000Z", original["@timestamp"])
+	assert.Equal(t, "abc", original["count"])
+
+	// Unstructured errors are stored as reason.
+	doc, err = makeDeadLetterEvent(&event, "test", 400, "bad request", now)
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{"reason": "bad request"}, doc.Fields["dead_letter"].(common.MapStr)["error"])
+}
+
+func TestDeadLetterIndex(t *testing.T) {
+	var bulks []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bulks = append(bulks, string(body))
+		if len(bulks) == 1 {
+			w.Write([]byte(rejectedResponse))
+			return
+		}
+		w.Write([]byte(`{"items": [{"index": {"status": 201}}]}`))
+	}))
+	defer ts.Close()
+
+	deadLetter, err := newDeadLetter(beat.Info{Beat: "test"},
+		common.MustNewConfigFrom(map[string]interface{}{"index": "dead-letter"}))
+	require.NoError(t, err)
+
+	observer := &countingObserver{Observer: outputs.NewNilObserver()}
+	client, err := NewClient(ClientSettings{
+		URL:        ts.URL,
+		Index:      outil.MakeSelector(outil.ConstSelectorExpr("test")),
+		Observer:   observer,
+		DeadLetter: deadLetter,
+	}, nil)
+	require.NoError(t, err)
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"count": 1}},
+		beat.Event{Fields: common.MapStr{"count": "abc"}},
+	)
+	require.NoError(t, client.Publish(batch))
+
+	require.Len(t, bulks, 2)
+	assert.Contains(t, bulks[1], `"_index":"dead-letter"`)
+	assert.Contains(t, bulks[1], `"mapper_parsing_exception"`)
+	assert.Contains(t, bulks[1], `\"count\":\"abc\"`)
+
+	assert.Equal(t, 1, observer.acked)
+	assert.Equal(t, 1, observer.deadLetter)
+	assert.Equal(t, 0, observer.dropped)
+	assert.Equal(t, 0, observer.failed)
+}
+
+func TestDeadLetterIndexRetry(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Write([]byte(rejectedResponse))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	deadLetter, err := newDeadLetter(beat.Info{Beat: "test"},
+		common.MustNewConfigFrom(map[string]interface{}{"index": "dead-letter"}))
+	require.NoError(t, err)
+
+	observer := &countingObserver{Observer: outputs.NewNilObserver()}
+	client, err := NewClient(ClientSettings{
+		URL:        ts.URL,
+		Index:      outil.MakeSelector(outil.ConstSelectorExpr("test")),
+		Observer:   observer,
+		DeadLetter: deadLetter,
+	}, nil)
+	require.NoError(t, err)
+
+	rejected := beat.Event{Fields: common.MapStr{"count": "abc"}}
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"count": 1}}, rejected)
+	client.Publish(batch)
+
+	// The original event is retried, if the dead letter index is not available.
+	signals := batch.Signals
+	require.Len(t, signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, signals[0].Tag)
+	require.Len(t, signals[0].Events, 1)
+	assert.Equal(t, rejected, signals[0].Events[0].Content)
+
+	assert.Equal(t, 1, observer.acked)
+	assert.Equal(t, 1, observer.failed)
+	assert.Equal(t, 0, observer.deadLetter)
+	assert.Equal(t, 0, observer.dropped)
+}
+
+func TestDeadLetterFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(rejectedResponse))
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "dead-letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	deadLetter, err := newDeadLetter(beat.Info{Beat: "test"},
+		common.MustNewConfigFrom(map[string]interface{}{"file.path": dir}))
+	require.NoError(t, err)
+
+	observer := &countingObserver{Observer: outputs.NewNilObserver()}
+	client, err := NewClient(ClientSettings{
+		URL:        ts.URL,
+		Index:      outil.MakeSelector(outil.ConstSelectorExpr("test")),
+		Observer:   observer,
+		DeadLetter: deadLetter,
+	}, nil)
+	require.NoError(t, err)
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"count": 1}},
+		beat.Event{Fields: common.MapStr{"count": "abc"}},
+	)
+	require.NoError(t, client.Publish(batch))
+
+	assert.Equal(t, 1, observer.acked)
+	assert.Equal(t, 1, observer.deadLetter)
+	assert.Equal(t, 0, observer.dropped)
+
+	f, err := os.Open(filepath.Join(dir, "test-dead-letter"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.Len(t, lines, 1)
+
+	var doc struct {
+		Message    string `json:"message"`
+		DeadLetter struct {
+			Index  string `json:"index"`
+			Status int    `json:"status"`
+			Error  struct {
+				Type string `json:"type"`
+			} `json:"error"`
+		} `json:"dead_letter"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &doc))
+	assert.Equal(t, "test", doc.DeadLetter.Index)
+	assert.Equal(t, 400, doc.DeadLetter.Status)
+	assert.Equal(t, "mapper_parsing_exception", doc.DeadLetter.Error.Type)
+	assert.True(t, strings.Contains(doc.Message, `"count":"abc"`))
+}
@@ -162,6 +162,14 @@ func makeES(
 		params = nil
 	}
 
+	var deadLetter *deadLetter
+	if config.DeadLetter.Enabled() {
+		deadLetter, err = newDeadLetter(beat, config.DeadLetter)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+	}
+
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		esURL, err := common.MakeURL(config.Protocol, config.Path, host, 9200)
@@ -175,6 +183,7 @@ func makeES(
 			URL:              esURL,
 			Index:            index,
 			Pipeline:         pipeline,
+			DocumentID:       config.DocumentID,
 			Proxy:            proxyURL,
 			TLS:              tlsConfig,
 			Username:         config.Username,
@@ -185,6 +194,7 @@ func makeES(
 			CompressionLevel: config.CompressionLevel,
 			Observer:         observer,
 			EscapeHTML:       config.EscapeHTML,
+			DeadLetter:       deadLetter,
 		}, &connectCallbackRegistry)
 		if err != nil {
 			return outputs.Fail(err)
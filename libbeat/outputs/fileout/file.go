@@ -48,23 +48,38 @@ func makeFileout(
 	observer outputs.Observer,
 	cfg *common.Config,
 ) (outputs.Group, error) {
-	config := defaultConfig
-	if err := cfg.Unpack(&config); err != nil {
+	fo, err := NewClient(beat, observer, cfg)
+	if err != nil {
 		return outputs.Fail(err)
 	}
 
 	// disable bulk support in publisher pipeline
 	cfg.SetInt("bulk_max_size", -1, -1)
 
+	return outputs.Success(-1, 0, fo)
+}
+
+// NewClient creates a client writing events to rotating files. It allows
+// other outputs to store events locally, using the settings of the file
+// output.
+func NewClient(
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *common.Config,
+) (outputs.Client, error) {
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
 	fo := &fileOutput{
 		beat:     beat,
 		observer: observer,
 	}
 	if err := fo.init(beat, config); err != nil {
-		return outputs.Fail(err)
+		return nil, err
 	}
-
-	return outputs.Success(-1, 0, fo)
+	return fo, nil
 }
 
 func (out *fileOutput) init(beat beat.Info, c config) error {
@@ -0,0 +1,295 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+// maxErrorBodySize limits how much of a failed response is logged.
+const maxErrorBodySize = 1024
+
+type clientSettings struct {
+	URL              string
+	Method           string
+	Headers          map[string]string
+	Username         string
+	Password         string
+	Proxy            *url.URL
+	TLS              *transport.TLSConfig
+	Timeout          time.Duration
+	CompressionLevel int
+	BatchFormat      string
+	Index            string
+	Codec            codec.Codec
+	Observer         outputs.Observer
+}
+
+type client struct {
+	url         string
+	method      string
+	headers     map[string]string
+	username    string
+	password    string
+	compression int
+	batchFormat string
+	index       string
+	codec       codec.Codec
+	observer    outputs.Observer
+
+	http *http.Client
+	body bytes.Buffer
+}
+
+func newClient(s clientSettings) (*client, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse http URL: %v", err)
+	}
+	if u.User != nil {
+		s.Username = u.User.Username()
+		s.Password, _ = u.User.Password()
+		u.User = nil
+
+		// Re-write URL without credentials.
+		s.URL = u.String()
+	}
+
+	logp.Info("HTTP output url: %s", s.URL)
+
+	proxy := http.ProxyFromEnvironment
+	if s.Proxy != nil {
+		proxy = http.ProxyURL(s.Proxy)
+	}
+
+	var dialer, tlsDialer transport.Dialer
+	dialer = transport.NetDialer(s.Timeout)
+	tlsDialer, err = transport.TLSDialer(dialer, s.TLS, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if st := s.Observer; st != nil {
+		dialer = transport.StatsDialer(dialer, st)
+		tlsDialer = transport.StatsDialer(tlsDialer, st)
+	}
+
+	return &client{
+		url:         s.URL,
+		method:      s.Method,
+		headers:     s.Headers,
+		username:    s.Username,
+		password:    s.Password,
+		compression: s.CompressionLevel,
+		batchFormat: s.BatchFormat,
+		index:       s.Index,
+		codec:       s.Codec,
+		observer:    s.Observer,
+		http: &http.Client{
+			Transport: &http.Transport{
+				Dial:    dialer.Dial,
+				DialTLS: tlsDialer.Dial,
+				Proxy:   proxy,
+			},
+			Timeout: s.Timeout,
+		},
+	}, nil
+}
+
+// Connect is a no-op, connections are established per request.
+func (c *client) Connect() error {
+	return nil
+}
+
+func (c *client) Close() error {
+	if t, ok := c.http.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	rest, err := c.publishEvents(events)
+	if len(rest) == 0 {
+		batch.ACK()
+	} else {
+		batch.RetryEvents(rest)
+	}
+	return err
+}
+
+// publishEvents sends the events in one request. It returns the events to
+// be retried if the request failed with a transient error.
+func (c *client) publishEvents(data []publisher.Event) ([]publisher.Event, error) {
+	st := c.observer
+	if st != nil {
+		st.NewBatch(len(data))
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	origCount := len(data)
+	data = c.encodeEvents(data)
+	if st != nil && origCount > len(data) {
+		st.Dropped(origCount - len(data))
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	begin := time.Now()
+	status, body, err := c.send()
+	if err != nil {
+		logp.Err("Failed to send events to %v: %v", c.url, err)
+		if st != nil {
+			st.Failed(len(data))
+		}
+		return data, err
+	}
+
+	switch {
+	case status < 300:
+		debugf("%d events have been published to %v in %v.", len(data), c.url, time.Now().Sub(begin))
+		if st != nil {
+			st.Acked(len(data))
+		}
+		return nil, nil
+
+	case status == http.StatusTooManyRequests || status >= 500:
+		if st != nil {
+			st.Failed(len(data))
+			if status == http.StatusTooManyRequests {
+				st.ErrTooMany(len(data))
+			}
+		}
+		return data, fmt.Errorf("temporary failure sending events to %v (status=%v): %s", c.url, status, body)
+
+	default:
+		// The endpoint rejected the events, sending them again won't succeed.
+		logp.Err("Dropping %d events rejected by %v (status=%v): %s", len(data), c.url, status, body)
+		if st != nil {
+			st.Dropped(len(data))
+		}
+		return nil, nil
+	}
+}
+
+// encodeEvents writes the events into the request body and returns the
+// events successfully encoded.
+func (c *client) encodeEvents(data []publisher.Event) []publisher.Event {
+	c.body.Reset()
+
+	var w io.Writer = &c.body
+	var gz *gzip.Writer
+	if c.compression > 0 {
+		gz, _ = gzip.NewWriterLevel(&c.body, c.compression)
+		w = gz
+	}
+
+	okEvents := data[:0]
+	for i := range data {
+		serialized, err := c.codec.Encode(c.index, &data[i].Content)
+		if err != nil {
+			logp.Err("Failed to encode event: %v", err)
+			continue
+		}
+
+		switch {
+		case c.batchFormat == batchFormatNDJSON:
+		case len(okEvents) == 0:
+			w.Write([]byte("["))
+		default:
+			w.Write([]byte(","))
+		}
+		w.Write(serialized)
+		if c.batchFormat == batchFormatNDJSON {
+			w.Write([]byte("\n"))
+		}
+		okEvents = append(okEvents, data[i])
+	}
+	if c.batchFormat == batchFormatJSONArray && len(okEvents) > 0 {
+		w.Write([]byte("]"))
+	}
+
+	if gz != nil {
+		gz.Close()
+	}
+	return okEvents
+}
+
+// send sends the request body and returns the status code and the start of
+// the response body.
+func (c *client) send() (int, []byte, error) {
+	req, err := http.NewRequest(c.method, c.url, bytes.NewReader(c.body.Bytes()))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to create request")
+	}
+
+	if c.batchFormat == batchFormatNDJSON {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.compression > 0 {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read the rest of the body, so the connection can be reused.
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+	}
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to read response")
+	}
+	return resp.StatusCode, body, nil
+}
+
+func (c *client) String() string {
+	return "http(" + c.url + ")"
+}
@@ -0,0 +1,255 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+)
+
+type request struct {
+	method  string
+	header  http.Header
+	user    string
+	events  []common.MapStr
+	rawBody string
+}
+
+func newTestServer(t *testing.T, status int) (*httptest.Server, <-chan request) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+		raw, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+
+		var events []common.MapStr
+		if r.Header.Get("Content-Type") == "application/x-ndjson" {
+			for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\n") {
+				var event common.MapStr
+				require.NoError(t, json.Unmarshal([]byte(line), &event))
+				events = append(events, event)
+			}
+		} else {
+			require.NoError(t, json.Unmarshal(raw, &events))
+		}
+
+		user, _, _ := r.BasicAuth()
+		requests <- request{method: r.Method, header: r.Header, user: user, events: events, rawBody: string(raw)}
+		w.WriteHeader(status)
+	}))
+	return server, requests
+}
+
+func newTestClient(t *testing.T, url string, settings clientSettings) *client {
+	settings.URL = url
+	if settings.Method == "" {
+		settings.Method = "POST"
+	}
+	if settings.BatchFormat == "" {
+		settings.BatchFormat = batchFormatJSONArray
+	}
+	settings.Index = "test"
+	settings.Codec = jsoncodec.New(false, true, "1.2.3")
+	settings.Timeout = 5 * time.Second
+	settings.Observer = outputs.NewNilObserver()
+
+	c, err := newClient(settings)
+	require.NoError(t, err)
+	return c
+}
+
+func testEvents() []beat.Event {
+	ts := time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC)
+	return []beat.Event{
+		{Timestamp: ts, Fields: common.MapStr{"message": "first"}},
+		{Timestamp: ts, Fields: common.MapStr{"message": "second"}},
+	}
+}
+
+func TestPublishJSONArray(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusOK)
+	defer server.Close()
+
+	c := newTestClient(t, server.URL+"/ingest", clientSettings{
+		Username: "beats",
+		Password: "secret",
+		Headers:  map[string]string{"X-Api-Key": "abc"},
+	})
+	defer c.Close()
+
+	batch := outest.NewBatch(testEvents()...)
+	require.NoError(t, c.Publish(batch))
+
+	req := <-requests
+	assert.Equal(t, "POST", req.method)
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "abc", req.header.Get("X-Api-Key"))
+	assert.Equal(t, "beats", req.user)
+	if assert.Len(t, req.events, 2) {
+		assert.Equal(t, "first", req.events[0]["message"])
+		assert.Equal(t, "second", req.events[1]["message"])
+		assert.Equal(t, "2018-08-01T10:00:00.000Z", req.events[0]["@timestamp"])
+	}
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishNDJSONGzip(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusAccepted)
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, clientSettings{
+		Method:           "PUT",
+		BatchFormat:      batchFormatNDJSON,
+		CompressionLevel: 5,
+	})
+	defer c.Close()
+
+	batch := outest.NewBatch(testEvents()...)
+	require.NoError(t, c.Publish(batch))
+
+	req := <-requests
+	assert.Equal(t, "PUT", req.method)
+	assert.Equal(t, "gzip", req.header.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-ndjson", req.header.Get("Content-Type"))
+	assert.Equal(t,
+		`{"@timestamp":"2018-08-01T10:00:00.000Z","@metadata":{"beat":"test","type":"doc","version":"1.2.3"},"message":"first"}`+"\n"+
+			`{"@timestamp":"2018-08-01T10:00:00.000Z","@metadata":{"beat":"test","type":"doc","version":"1.2.3"},"message":"second"}`+"\n",
+		req.rawBody)
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishRetriesTemporaryFailures(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		server, requests := newTestServer(t, status)
+
+		c := newTestClient(t, server.URL, clientSettings{})
+		batch := outest.NewBatch(testEvents()...)
+		assert.Error(t, c.Publish(batch), "status %v", status)
+		<-requests
+
+		if assert.Len(t, batch.Signals, 1) {
+			assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+			assert.Len(t, batch.Signals[0].Events, 2)
+		}
+
+		c.Close()
+		server.Close()
+	}
+}
+
+func TestPublishDropsRejectedEvents(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusBadRequest)
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, clientSettings{})
+	defer c.Close()
+
+	batch := outest.NewBatch(testEvents()...)
+	assert.NoError(t, c.Publish(batch))
+	<-requests
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishConnectionError(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK)
+	url := server.URL
+	server.Close()
+
+	c := newTestClient(t, url, clientSettings{})
+	defer c.Close()
+
+	batch := outest.NewBatch(testEvents()...)
+	assert.Error(t, c.Publish(batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"method": "put", "batch_format": "ndjson"}, true},
+		{map[string]interface{}{"batch_format": "ndjson", "codec.format.string": "%{[message]}"}, true},
+		{map[string]interface{}{"method": "GET"}, false},
+		{map[string]interface{}{"batch_format": "xml"}, false},
+		{map[string]interface{}{"codec.format.string": "%{[message]}"}, false},
+		{map[string]interface{}{"compression_level": 10}, false},
+	}
+
+	for _, test := range tests {
+		cfg, err := common.NewConfigFrom(test.config)
+		require.NoError(t, err)
+
+		config := defaultConfig
+		err = cfg.Unpack(&config)
+		if test.valid {
+			assert.NoError(t, err, "%v", test.config)
+		} else {
+			assert.Error(t, err, "%v", test.config)
+		}
+	}
+}
+
+func TestMakeURL(t *testing.T) {
+	tests := []struct {
+		protocol, path, host string
+		tls                  bool
+		expected             string
+	}{
+		{"", "", "localhost", false, "http://localhost:80"},
+		{"", "/events", "collector:8080", false, "http://collector:8080/events"},
+		{"", "", "collector", true, "https://collector:443"},
+		{"", "", "https://collector", false, "https://collector:443"},
+	}
+
+	for _, test := range tests {
+		url, err := makeURL(test.protocol, test.path, test.host, test.tls)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, url)
+	}
+}
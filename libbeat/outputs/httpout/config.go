@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+)
+
+// Supported framings of the events in the request body.
+const (
+	batchFormatJSONArray = "json_array"
+	batchFormatNDJSON    = "ndjson"
+)
+
+type httpConfig struct {
+	Protocol         string            `config:"protocol"`
+	Path             string            `config:"path"`
+	Method           string            `config:"method"`
+	Headers          map[string]string `config:"headers"`
+	Username         string            `config:"username"`
+	Password         string            `config:"password"`
+	ProxyURL         string            `config:"proxy_url"`
+	LoadBalance      bool              `config:"loadbalance"`
+	CompressionLevel int               `config:"compression_level" validate:"min=0, max=9"`
+	BatchFormat      string            `config:"batch_format"`
+	Codec            codec.Config      `config:"codec"`
+	TLS              *tlscommon.Config `config:"ssl"`
+	BulkMaxSize      int               `config:"bulk_max_size"`
+	MaxRetries       int               `config:"max_retries"`
+	Timeout          time.Duration     `config:"timeout"`
+	Backoff          backoff           `config:"backoff"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+var defaultConfig = httpConfig{
+	Method:           "POST",
+	LoadBalance:      true,
+	CompressionLevel: 0,
+	BatchFormat:      batchFormatJSONArray,
+	BulkMaxSize:      50,
+	MaxRetries:       3,
+	Timeout:          90 * time.Second,
+	Backoff: backoff{
+		Init: 1 * time.Second,
+		Max:  60 * time.Second,
+	},
+}
+
+func (c *httpConfig) Validate() error {
+	switch strings.ToUpper(c.Method) {
+	case "POST", "PUT", "PATCH":
+	default:
+		return fmt.Errorf("http method %v not supported, use POST, PUT or PATCH", c.Method)
+	}
+
+	switch c.BatchFormat {
+	case batchFormatJSONArray:
+		if name := c.Codec.Namespace.Name(); name != "" && name != "json" {
+			return fmt.Errorf("batch format %v requires the json codec", c.BatchFormat)
+		}
+	case batchFormatNDJSON:
+	default:
+		return fmt.Errorf("batch format %v not supported, use %v or %v",
+			c.BatchFormat, batchFormatJSONArray, batchFormatNDJSON)
+	}
+
+	if c.ProxyURL != "" {
+		if _, err := parseProxyURL(c.ProxyURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -34,6 +34,7 @@ type Stats struct {
 	duplicates *monitoring.Uint // events sent and waiting for ACK/fail from output
 	dropped    *monitoring.Uint // total number of invalid events dropped by the output
 	tooMany    *monitoring.Uint // total number of too many requests replies from output
+	deadLetter *monitoring.Uint // total number of rejected events stored in the dead letter destination
 
 	//
 	// Output network connection stats
@@ -58,6 +59,7 @@ func NewStats(reg *monitoring.Registry) *Stats {
 		duplicates: monitoring.NewUint(reg, "events.duplicates"),
 		active:     monitoring.NewUint(reg, "events.active"),
 		tooMany:    monitoring.NewUint(reg, "events.toomany"),
+		deadLetter: monitoring.NewUint(reg, "events.dead_letter"),
 
 		writeBytes:  monitoring.NewUint(reg, "write.bytes"),
 		writeErrors: monitoring.NewUint(reg, "write.errors"),
@@ -119,6 +121,16 @@ func (s *Stats) Cancelled(n int) {
 	}
 }
 
+// DeadLetter updates the active and dead letter event metrics. Outputs
+// report events they could not publish, but stored in a dead letter
+// destination instead.
+func (s *Stats) DeadLetter(n int) {
+	if s != nil {
+		s.deadLetter.Add(uint64(n))
+		s.active.Sub(uint64(n))
+	}
+}
+
 // ErrTooMany updates the number of Too Many Requests responses reported by the output.
 func (s *Stats) ErrTooMany(n int) {
 	if s != nil {
@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+type client struct {
+	*transport.Client
+	observer outputs.Observer
+	network  string
+	framing  string
+	timeout  time.Duration
+	format   *formatter
+
+	msg bytes.Buffer
+	buf bytes.Buffer
+}
+
+func newClient(
+	conn *transport.Client,
+	observer outputs.Observer,
+	config *syslogConfig,
+	format *formatter,
+) *client {
+	return &client{
+		Client:   conn,
+		observer: observer,
+		network:  config.Network,
+		framing:  config.Framing,
+		timeout:  config.Timeout,
+		format:   format,
+	}
+}
+
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	rest, err := c.publishEvents(events)
+	if len(rest) == 0 {
+		batch.ACK()
+	} else {
+		batch.RetryEvents(rest)
+	}
+	return err
+}
+
+// publishEvents writes the events to the connection. It returns the events
+// to be retried if writing failed.
+func (c *client) publishEvents(data []publisher.Event) ([]publisher.Event, error) {
+	st := c.observer
+	if st != nil {
+		st.NewBatch(len(data))
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if c.network == "udp" {
+		return c.publishDatagrams(data)
+	}
+
+	// All messages of the batch are framed into one buffer and written
+	// at once.
+	c.buf.Reset()
+	okEvents := data[:0]
+	for i := range data {
+		if err := c.formatEvent(&data[i]); err != nil {
+			continue
+		}
+
+		if c.framing == framingOctetCounting {
+			c.buf.WriteString(strconv.Itoa(c.msg.Len()))
+			c.buf.WriteByte(' ')
+			c.buf.Write(c.msg.Bytes())
+		} else {
+			c.buf.Write(c.msg.Bytes())
+			c.buf.WriteByte('\n')
+		}
+		okEvents = append(okEvents, data[i])
+	}
+
+	if st != nil && len(okEvents) < len(data) {
+		st.Dropped(len(data) - len(okEvents))
+	}
+	if len(okEvents) == 0 {
+		return nil, nil
+	}
+
+	if err := c.write(c.buf.Bytes()); err != nil {
+		logp.Err("Failed to send events to %v: %v", c.Host(), err)
+		if st != nil {
+			st.Failed(len(okEvents))
+		}
+		return okEvents, err
+	}
+
+	debugf("%d events have been published to %v.", len(okEvents), c.Host())
+	if st != nil {
+		st.Acked(len(okEvents))
+	}
+	return nil, nil
+}
+
+// publishDatagrams sends every message in its own datagram, without framing.
+func (c *client) publishDatagrams(data []publisher.Event) ([]publisher.Event, error) {
+	st := c.observer
+
+	acked, dropped := 0, 0
+	for i := range data {
+		if err := c.formatEvent(&data[i]); err != nil {
+			dropped++
+			continue
+		}
+
+		if err := c.write(c.msg.Bytes()); err != nil {
+			logp.Err("Failed to send events to %v: %v", c.Host(), err)
+			if st != nil {
+				st.Acked(acked)
+				st.Dropped(dropped)
+				st.Failed(len(data) - i)
+			}
+			return data[i:], err
+		}
+		acked++
+	}
+
+	if st != nil {
+		st.Acked(acked)
+		st.Dropped(dropped)
+	}
+	return nil, nil
+}
+
+// formatEvent writes the syslog message of the event into c.msg.
+func (c *client) formatEvent(event *publisher.Event) error {
+	c.msg.Reset()
+	err := c.format.Format(&c.msg, &event.Content)
+	if err != nil {
+		logp.Err("Failed to encode event: %v", err)
+	}
+	return err
+}
+
+func (c *client) write(b []byte) error {
+	if c.timeout > 0 {
+		if err := c.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+			return err
+		}
+	}
+	_, err := c.Write(b)
+	return err
+}
+
+func (c *client) String() string {
+	return "syslog(" + c.Host() + ")"
+}
@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec/format"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+func newTestClient(t *testing.T, addr string, settings map[string]interface{}) *client {
+	config := defaultConfig
+	require.NoError(t, common.MustNewConfigFrom(settings).Unpack(&config))
+
+	conn, err := transport.NewClient(&transport.Config{Timeout: config.Timeout}, config.Network, addr, config.Port)
+	require.NoError(t, err)
+
+	f := newFormatter(&config, "test", format.New(fmtstr.MustCompileEvent("%{[message]}")))
+	return newClient(conn, outputs.NewNilObserver(), &config, f)
+}
+
+func testEvents(messages ...string) []beat.Event {
+	var events []beat.Event
+	for _, msg := range messages {
+		events = append(events, beat.Event{
+			Timestamp: time.Date(2018, 8, 1, 10, 0, 0, 0, time.UTC),
+			Fields: common.MapStr{
+				"beat":    common.MapStr{"name": "test", "hostname": "host"},
+				"message": msg,
+			},
+		})
+	}
+	return events
+}
+
+// readOctetCounted reads one octet-counted frame.
+func readOctetCounted(r *bufio.Reader) (string, error) {
+	length, err := r.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		return "", err
+	}
+	msg := make([]byte, n)
+	_, err = r.Read(msg)
+	return string(msg), err
+}
+
+func TestPublishTCP(t *testing.T) {
+	for _, framing := range []string{framingOctetCounting, framingNewline} {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+
+		received := make(chan string, 10)
+		go func() {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			r := bufio.NewReader(conn)
+			for {
+				var msg string
+				if framing == framingNewline {
+					msg, err = r.ReadString('\n')
+					msg = strings.TrimSuffix(msg, "\n")
+				} else {
+					msg, err = readOctetCounted(r)
+				}
+				if err != nil {
+					return
+				}
+				received <- msg
+			}
+		}()
+
+		c := newTestClient(t, l.Addr().String(), map[string]interface{}{"framing": framing})
+		require.NoError(t, c.Connect())
+
+		batch := outest.NewBatch(testEvents("first message", "second\nmessage")...)
+		require.NoError(t, c.Publish(batch))
+		assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+		prefix := "<14>1 2018-08-01T10:00:00.000Z host test - - - "
+		assert.Equal(t, prefix+"first message", <-received, framing)
+		if framing == framingOctetCounting {
+			assert.Equal(t, prefix+"second\nmessage", <-received, framing)
+		} else {
+			assert.Equal(t, prefix+"second", <-received, framing)
+		}
+		c.Close()
+	}
+}
+
+func TestPublishUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	c := newTestClient(t, conn.LocalAddr().String(), map[string]interface{}{
+		"network": "udp",
+		"format":  "rfc3164",
+	})
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	batch := outest.NewBatch(testEvents("first", "second")...)
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 1024)
+	for _, expected := range []string{"first", "second"} {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(buf[:n]), " host test: "+expected), string(buf[:n]))
+	}
+}
+
+func TestPublishRetryOnError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	c := newTestClient(t, addr, map[string]interface{}{})
+
+	// Not connected, so all events are retried.
+	batch := outest.NewBatch(testEvents("first", "second")...)
+	assert.Error(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 2)
+}
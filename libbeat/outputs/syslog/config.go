@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+type syslogConfig struct {
+	Network     string                `config:"network"`
+	Port        int                   `config:"port"`
+	Format      string                `config:"format"`
+	Framing     string                `config:"framing"`
+	LoadBalance bool                  `config:"loadbalance"`
+	BulkMaxSize int                   `config:"bulk_max_size"`
+	MaxRetries  int                   `config:"max_retries" validate:"min=-1"`
+	Timeout     time.Duration         `config:"timeout"`
+	TLS         *tlscommon.Config     `config:"ssl"`
+	Proxy       transport.ProxyConfig `config:",inline"`
+	Backoff     backoff               `config:"backoff"`
+	Codec       codec.Config          `config:"codec"`
+
+	Facility *fmtstr.EventFormatString `config:"facility"`
+	Severity *fmtstr.EventFormatString `config:"severity"`
+	Hostname *fmtstr.EventFormatString `config:"hostname"`
+	AppName  *fmtstr.EventFormatString `config:"app_name"`
+	ProcID   *fmtstr.EventFormatString `config:"proc_id"`
+	MsgID    *fmtstr.EventFormatString `config:"msg_id"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+const (
+	formatRFC5424 = "rfc5424"
+	formatRFC3164 = "rfc3164"
+
+	framingOctetCounting = "octet_counting"
+	framingNewline       = "newline"
+)
+
+var defaultConfig = syslogConfig{
+	Network:     "tcp",
+	Port:        514,
+	Format:      formatRFC5424,
+	Framing:     framingOctetCounting,
+	LoadBalance: false,
+	BulkMaxSize: 2048,
+	MaxRetries:  3,
+	Timeout:     30 * time.Second,
+	Backoff: backoff{
+		Init: 1 * time.Second,
+		Max:  60 * time.Second,
+	},
+}
+
+// Defaults of the header fields, used when the setting is missing.
+var (
+	defaultFacility = fmtstr.MustCompileEvent("user")
+	defaultSeverity = fmtstr.MustCompileEvent("informational")
+	defaultHostname = fmtstr.MustCompileEvent("%{[beat.hostname]}")
+	defaultAppName  = fmtstr.MustCompileEvent("%{[beat.name]}")
+)
+
+func (c *syslogConfig) Validate() error {
+	switch c.Network {
+	case "tcp":
+	case "udp":
+		if c.TLS.IsEnabled() {
+			return fmt.Errorf("ssl is not supported with network udp")
+		}
+		if c.Proxy.URL != "" {
+			return fmt.Errorf("proxy_url is not supported with network udp")
+		}
+	default:
+		return fmt.Errorf("network %v not supported, use tcp or udp", c.Network)
+	}
+
+	switch c.Format {
+	case formatRFC5424, formatRFC3164:
+	default:
+		return fmt.Errorf("format %v not supported, use %v or %v", c.Format, formatRFC5424, formatRFC3164)
+	}
+
+	switch c.Framing {
+	case framingOctetCounting, framingNewline:
+	default:
+		return fmt.Errorf("framing %v not supported, use %v or %v", c.Framing, framingOctetCounting, framingNewline)
+	}
+
+	// Constant facility and severity values are checked on startup.
+	if c.Facility != nil && c.Facility.IsConst() {
+		name, _ := c.Facility.Run(&beat.Event{})
+		if _, err := parseFacility(name); err != nil {
+			return err
+		}
+	}
+	if c.Severity != nil && c.Severity.IsConst() {
+		name, _ := c.Severity.Run(&beat.Event{})
+		if _, err := parseSeverity(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
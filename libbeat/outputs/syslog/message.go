@@ -0,0 +1,230 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+)
+
+const (
+	// Used when the facility or severity of an event cannot be resolved.
+	fallbackFacility = 1 // user
+	fallbackSeverity = 6 // informational
+
+	nilValue = "-"
+
+	rfc5424Timestamp = "2006-01-02T15:04:05.000Z07:00"
+	rfc3164Timestamp = time.Stamp
+)
+
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"ntp":      12,
+	"security": 13,
+	"console":  14,
+	"clock":    15,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+var severities = map[string]int{
+	"emergency":     0,
+	"emerg":         0,
+	"panic":         0,
+	"alert":         1,
+	"critical":      2,
+	"crit":          2,
+	"fatal":         2,
+	"error":         3,
+	"err":           3,
+	"warning":       4,
+	"warn":          4,
+	"notice":        5,
+	"informational": 6,
+	"info":          6,
+	"debug":         7,
+	"trace":         7,
+}
+
+// parseFacility resolves a facility name or number.
+func parseFacility(s string) (int, error) {
+	return parseCode(s, facilities, 23, "facility")
+}
+
+// parseSeverity resolves a severity name, log level or number.
+func parseSeverity(s string) (int, error) {
+	return parseCode(s, severities, 7, "severity")
+}
+
+func parseCode(s string, names map[string]int, max int, kind string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if code, found := names[s]; found {
+		return code, nil
+	}
+	if code, err := strconv.Atoi(s); err == nil && code >= 0 && code <= max {
+		return code, nil
+	}
+	return 0, fmt.Errorf("invalid syslog %v '%v'", kind, s)
+}
+
+// formatter creates the syslog message of an event. The event is encoded by
+// the codec into the MSG part of the message.
+type formatter struct {
+	format   string
+	index    string
+	codec    codec.Codec
+	facility *fmtstr.EventFormatString
+	severity *fmtstr.EventFormatString
+	hostname *fmtstr.EventFormatString
+	appName  *fmtstr.EventFormatString
+	procID   *fmtstr.EventFormatString
+	msgID    *fmtstr.EventFormatString
+}
+
+func newFormatter(config *syslogConfig, index string, codec codec.Codec) *formatter {
+	return &formatter{
+		format:   config.Format,
+		index:    index,
+		codec:    codec,
+		facility: orDefault(config.Facility, defaultFacility),
+		severity: orDefault(config.Severity, defaultSeverity),
+		hostname: orDefault(config.Hostname, defaultHostname),
+		appName:  orDefault(config.AppName, defaultAppName),
+		procID:   config.ProcID,
+		msgID:    config.MsgID,
+	}
+}
+
+// Format appends the syslog message of the event to buf.
+func (f *formatter) Format(buf *bytes.Buffer, event *beat.Event) error {
+	msg, err := f.codec.Encode(f.index, event)
+	if err != nil {
+		return err
+	}
+
+	facility, err := parseFacility(evalString(f.facility, event))
+	if err != nil {
+		debugf("Using facility %v: %v", fallbackFacility, err)
+		facility = fallbackFacility
+	}
+	severity, err := parseSeverity(evalString(f.severity, event))
+	if err != nil {
+		debugf("Using severity %v: %v", fallbackSeverity, err)
+		severity = fallbackSeverity
+	}
+
+	fmt.Fprintf(buf, "<%d>", facility*8+severity)
+
+	hostname := header(evalString(f.hostname, event), 255)
+	appName := header(evalString(f.appName, event), 48)
+	procID := header(evalString(f.procID, event), 128)
+
+	if f.format == formatRFC3164 {
+		buf.WriteString(event.Timestamp.Local().Format(rfc3164Timestamp))
+		buf.WriteByte(' ')
+		buf.WriteString(hostname)
+		buf.WriteByte(' ')
+		if appName != nilValue {
+			buf.WriteString(appName)
+			if procID != nilValue {
+				buf.WriteString("[" + procID + "]")
+			}
+			buf.WriteString(": ")
+		}
+		buf.Write(msg)
+		return nil
+	}
+
+	buf.WriteString("1 ")
+	if event.Timestamp.IsZero() {
+		buf.WriteString(nilValue)
+	} else {
+		buf.WriteString(event.Timestamp.UTC().Format(rfc5424Timestamp))
+	}
+	for _, field := range []string{hostname, appName, procID, header(evalString(f.msgID, event), 32)} {
+		buf.WriteByte(' ')
+		buf.WriteString(field)
+	}
+
+	// No structured data.
+	buf.WriteString(" - ")
+	buf.Write(msg)
+	return nil
+}
+
+func orDefault(fs, def *fmtstr.EventFormatString) *fmtstr.EventFormatString {
+	if fs == nil {
+		return def
+	}
+	return fs
+}
+
+func evalString(fs *fmtstr.EventFormatString, event *beat.Event) string {
+	if fs == nil {
+		return ""
+	}
+	s, err := fs.Run(event)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// header converts a value into a header field. Header fields cannot contain
+// spaces or non-printable characters, and an empty value is written as '-'.
+func header(s string, maxLen int) string {
+	if s == "" {
+		return nilValue
+	}
+
+	b := []byte(s)
+	if len(b) > maxLen {
+		b = b[:maxLen]
+	}
+	for i, c := range b {
+		if c < 33 || c > 126 {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/outputs/codec/format"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+)
+
+func testEvent() *beat.Event {
+	return &beat.Event{
+		Timestamp: time.Date(2018, 8, 1, 10, 2, 3, 456000000, time.UTC),
+		Fields: common.MapStr{
+			"beat":    common.MapStr{"name": "filebeat", "hostname": "web 01"},
+			"level":   "error",
+			"message": "connection refused",
+		},
+	}
+}
+
+func testFormatter(t *testing.T, settings map[string]interface{}) *formatter {
+	config := defaultConfig
+	require.NoError(t, common.MustNewConfigFrom(settings).Unpack(&config))
+	return newFormatter(&config, "filebeat", format.New(fmtstr.MustCompileEvent("%{[message]}")))
+}
+
+func formatEvent(t *testing.T, f *formatter, event *beat.Event) string {
+	var buf bytes.Buffer
+	require.NoError(t, f.Format(&buf, event))
+	return buf.String()
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	f := testFormatter(t, map[string]interface{}{
+		"facility": "local3",
+		"severity": "%{[level]}",
+		"proc_id":  "1234",
+	})
+
+	assert.Equal(t,
+		"<155>1 2018-08-01T10:02:03.456Z web_01 filebeat 1234 - - connection refused",
+		formatEvent(t, f, testEvent()))
+}
+
+func TestFormatRFC3164(t *testing.T) {
+	f := testFormatter(t, map[string]interface{}{
+		"format":   "rfc3164",
+		"facility": "daemon",
+		"severity": "warning",
+		"proc_id":  "42",
+	})
+
+	event := testEvent()
+	expected := "<28>" + event.Timestamp.Local().Format(time.Stamp) +
+		" web_01 filebeat[42]: connection refused"
+	assert.Equal(t, expected, formatEvent(t, f, event))
+}
+
+func TestFormatDefaults(t *testing.T) {
+	f := testFormatter(t, map[string]interface{}{
+		"severity": "%{[missing]}",
+		"app_name": "%{[missing]}",
+	})
+
+	// Missing values fall back to the default severity and the nil value.
+	assert.Equal(t,
+		"<14>1 2018-08-01T10:02:03.456Z web_01 - - - - connection refused",
+		formatEvent(t, f, testEvent()))
+}
+
+func TestFormatJSONMessage(t *testing.T) {
+	config := defaultConfig
+	f := newFormatter(&config, "filebeat", jsoncodec.New(false, true, "1.2.3"))
+
+	msg := formatEvent(t, f, testEvent())
+	assert.Contains(t, msg, ` filebeat - - - {"@timestamp":"2018-08-01T10:02:03.456Z",`)
+	assert.Contains(t, msg, `"message":"connection refused"`)
+}
+
+func TestParseFacilityAndSeverity(t *testing.T) {
+	facility, err := parseFacility("LOCAL7")
+	assert.NoError(t, err)
+	assert.Equal(t, 23, facility)
+
+	facility, err = parseFacility("4")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, facility)
+
+	severity, err := parseSeverity("warn")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, severity)
+
+	for _, invalid := range []string{"", "24", "-1", "foo"} {
+		_, err := parseFacility(invalid)
+		assert.Error(t, err, invalid)
+	}
+	for _, invalid := range []string{"", "8", "foo"} {
+		_, err := parseSeverity(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"invalid network":  {"network": "unix"},
+		"invalid format":   {"format": "rfc1234"},
+		"invalid framing":  {"framing": "nul"},
+		"invalid facility": {"facility": "local9"},
+		"invalid severity": {"severity": "loud"},
+		"udp with proxy":   {"network": "udp", "proxy_url": "socks5://localhost:1080"},
+	}
+
+	for name, settings := range tests {
+		config := defaultConfig
+		err := common.MustNewConfigFrom(settings).Unpack(&config)
+		assert.Error(t, err, name)
+	}
+
+	config := defaultConfig
+	err := common.MustNewConfigFrom(map[string]interface{}{
+		"network":  "udp",
+		"severity": "%{[level]}",
+	}).Unpack(&config)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,351 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/joeshaw/multierror"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type convertFields struct {
+	config convertFieldsConfig
+}
+
+type convertFieldsConfig struct {
+	Fields        []convertField `config:"fields"`
+	IgnoreMissing bool           `config:"ignore_missing"`
+	FailOnError   bool           `config:"fail_on_error"`
+}
+
+type convertField struct {
+	From string   `config:"from" validate:"required"`
+	To   string   `config:"to"`
+	Type dataType `config:"type"`
+}
+
+func (f *convertField) Validate() error {
+	if f.Type == unset {
+		return fmt.Errorf("missing type for field '%v'", f.From)
+	}
+	return nil
+}
+
+type dataType uint8
+
+const (
+	unset dataType = iota
+	integer
+	long
+	float
+	double
+	boolean
+	ip
+	str
+)
+
+var dataTypeNames = map[dataType]string{
+	integer: "integer",
+	long:    "long",
+	float:   "float",
+	double:  "double",
+	boolean: "boolean",
+	ip:      "ip",
+	str:     "string",
+}
+
+func (t dataType) String() string {
+	return dataTypeNames[t]
+}
+
+func (t *dataType) Unpack(v string) error {
+	for typ, name := range dataTypeNames {
+		if strings.ToLower(v) == name {
+			*t = typ
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid conversion type '%v' (valid types are: integer, long, float, double, boolean, ip, string)", v)
+}
+
+func init() {
+	processors.RegisterPlugin("convert",
+		configChecked(newConvertFields,
+			requireFields("fields"),
+			allowedFields("fields", "ignore_missing", "fail_on_error", "when")))
+}
+
+func newConvertFields(c *common.Config) (processors.Processor, error) {
+	config := convertFieldsConfig{
+		IgnoreMissing: false,
+		FailOnError:   true,
+	}
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the convert configuration: %s", err)
+	}
+
+	return &convertFields{config: config}, nil
+}
+
+// Run converts the configured fields. Conversion failures are recorded in
+// the error field of the event. If fail_on_error is set, the event is
+// reverted on failure. Otherwise the remaining fields are converted and all
+// failures are recorded.
+func (f *convertFields) Run(event *beat.Event) (*beat.Event, error) {
+	var backup common.MapStr
+	// Creates a copy of the event to revert in case of failure
+	if f.config.FailOnError {
+		backup = event.Fields.Clone()
+	}
+
+	var errs multierror.Errors
+	for _, field := range f.config.Fields {
+		err := f.convertField(field, event.Fields)
+		if err == nil {
+			continue
+		}
+
+		if f.config.FailOnError {
+			logp.Debug("convert", "Failed to convert fields, revert to old event: %s", err)
+			event.Fields = backup
+			setConvertError(event.Fields, multierror.Errors{err})
+			return event, err
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		setConvertError(event.Fields, errs)
+	}
+	return event, nil
+}
+
+// setConvertError writes the conversion failures to the error field of the
+// event. The message of an error recorded before, for example by another
+// processor, is kept in front of the conversion failures.
+func setConvertError(fields common.MapStr, errs multierror.Errors) {
+	errField := common.MapStr{"type": "convert"}
+	switch prev := fields["error"].(type) {
+	case common.MapStr:
+		errField = prev
+	case map[string]interface{}:
+		errField = prev
+	case string:
+		errField["message"] = prev
+	}
+
+	if prev, ok := errField["message"].(string); ok {
+		errs = append(multierror.Errors{errors.New(prev)}, errs...)
+	}
+
+	message := errs[0].Error()
+	if len(errs) > 1 {
+		message = errs.Err().Error()
+	}
+	errField["message"] = message
+	fields["error"] = errField
+}
+
+func (f *convertFields) convertField(field convertField, fields common.MapStr) error {
+	value, err := fields.GetValue(field.From)
+	if err != nil {
+		// Ignore ErrKeyNotFound errors
+		if f.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return fmt.Errorf("could not fetch value for key: %s, Error: %s", field.From, err)
+	}
+
+	converted, err := convertValue(value, field.Type)
+	if err != nil {
+		return fmt.Errorf("unable to convert value of field '%s' to %v: %v", field.From, field.Type, err)
+	}
+
+	to := field.To
+	if to == "" {
+		to = field.From
+	}
+	if _, err := fields.Put(to, converted); err != nil {
+		return fmt.Errorf("could not put value: %s: %v, %+v", to, converted, err)
+	}
+	return nil
+}
+
+func convertValue(value interface{}, typ dataType) (interface{}, error) {
+	switch typ {
+	case integer:
+		i, err := toInt(value, 32)
+		return int32(i), err
+	case long:
+		return toInt(value, 64)
+	case float:
+		f, err := toFloat(value, 32)
+		return float32(f), err
+	case double:
+		return toFloat(value, 64)
+	case boolean:
+		return toBool(value)
+	case ip:
+		return toIP(value)
+	case str:
+		return toString(value)
+	}
+	return nil, fmt.Errorf("unsupported type %v", typ)
+}
+
+func toInt(value interface{}, bitSize int) (int64, error) {
+	var (
+		i   int64
+		err error
+	)
+	switch v := value.(type) {
+	case string:
+		i, err = strconv.ParseInt(strings.TrimSpace(v), 10, bitSize)
+		return i, err
+	case int:
+		i = int64(v)
+	case int8:
+		i = int64(v)
+	case int16:
+		i = int64(v)
+	case int32:
+		i = int64(v)
+	case int64:
+		i = v
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, errors.New("value out of range")
+		}
+		i = int64(v)
+	case uint8:
+		i = int64(v)
+	case uint16:
+		i = int64(v)
+	case uint32:
+		i = int64(v)
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, errors.New("value out of range")
+		}
+		i = int64(v)
+	case float32:
+		return floatToInt(float64(v), bitSize)
+	case float64:
+		return floatToInt(v, bitSize)
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if bitSize == 32 && (i > math.MaxInt32 || i < math.MinInt32) {
+		return 0, errors.New("value out of range")
+	}
+	return i, nil
+}
+
+// floatToInt converts floats without fractional part only, so to not lose
+// information.
+func floatToInt(f float64, bitSize int) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("value %v has a fractional part", f)
+	}
+
+	max, min := float64(math.MaxInt64), float64(math.MinInt64)
+	if bitSize == 32 {
+		max, min = math.MaxInt32, math.MinInt32
+	}
+	if f > max || f < min {
+		return 0, errors.New("value out of range")
+	}
+	return int64(f), nil
+}
+
+func toFloat(value interface{}, bitSize int) (float64, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), bitSize)
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case bool:
+		return 0, fmt.Errorf("unsupported value type %T", value)
+	}
+
+	i, err := toInt(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(i), nil
+}
+
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	}
+	return false, fmt.Errorf("unsupported value type %T", value)
+}
+
+func toIP(value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+
+	parsed := net.ParseIP(strings.TrimSpace(s))
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address '%v'", s)
+	}
+	return parsed.String(), nil
+}
+
+func toString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case common.MapStr, map[string]interface{}, []interface{}:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+	return fmt.Sprint(value), nil
+}
+
+func (f *convertFields) String() string {
+	return "convert=" + fmt.Sprintf("%+v", f.config.Fields)
+}
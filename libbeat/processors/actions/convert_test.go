@@ -0,0 +1,267 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestConvertRun(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      common.MapStr
+		input       common.MapStr
+		output      common.MapStr
+		error       bool
+	}{
+		{
+			description: "convert in place",
+			config: common.MapStr{"fields": []common.MapStr{
+				{"from": "status", "type": "integer"},
+				{"from": "bytes", "type": "long"},
+				{"from": "duration", "type": "double"},
+				{"from": "ratio", "type": "float"},
+				{"from": "success", "type": "boolean"},
+				{"from": "client", "type": "ip"},
+				{"from": "code", "type": "string"},
+			}},
+			input: common.MapStr{
+				"status":   "200",
+				"bytes":    " 4294967296",
+				"duration": "1.5",
+				"ratio":    "0.25",
+				"success":  "true",
+				"client":   "2001:0db8::0001",
+				"code":     404,
+			},
+			output: common.MapStr{
+				"status":   int32(200),
+				"bytes":    int64(4294967296),
+				"duration": 1.5,
+				"ratio":    float32(0.25),
+				"success":  true,
+				"client":   "2001:db8::1",
+				"code":     "404",
+			},
+		},
+		{
+			description: "convert to new field",
+			config: common.MapStr{"fields": []common.MapStr{
+				{"from": "http.status", "to": "http.response.status_code", "type": "long"},
+			}},
+			input: common.MapStr{"http": common.MapStr{"status": "404"}},
+			output: common.MapStr{"http": common.MapStr{
+				"status":   "404",
+				"response": common.MapStr{"status_code": int64(404)},
+			}},
+		},
+		{
+			description: "ignore missing field",
+			config: common.MapStr{
+				"fields":         []common.MapStr{{"from": "missing", "type": "long"}},
+				"ignore_missing": true,
+			},
+			input:  common.MapStr{"a": "1"},
+			output: common.MapStr{"a": "1"},
+		},
+		{
+			description: "missing field",
+			config: common.MapStr{
+				"fields": []common.MapStr{{"from": "missing", "type": "long"}},
+			},
+			input: common.MapStr{"a": "1"},
+			output: common.MapStr{
+				"a": "1",
+				"error": common.MapStr{
+					"message": "could not fetch value for key: missing, Error: key not found",
+					"type":    "convert",
+				},
+			},
+			error: true,
+		},
+		{
+			description: "revert event on failure",
+			config: common.MapStr{"fields": []common.MapStr{
+				{"from": "a", "type": "long"},
+				{"from": "b", "type": "integer"},
+			}},
+			input: common.MapStr{"a": "1", "b": "2.5"},
+			output: common.MapStr{
+				"a": "1",
+				"b": "2.5",
+				"error": common.MapStr{
+					"message": "unable to convert value of field 'b' to integer: strconv.ParseInt: parsing \"2.5\": invalid syntax",
+					"type":    "convert",
+				},
+			},
+			error: true,
+		},
+		{
+			description: "continue on failure",
+			config: common.MapStr{
+				"fields": []common.MapStr{
+					{"from": "a", "type": "ip"},
+					{"from": "b", "type": "long"},
+				},
+				"fail_on_error": false,
+			},
+			input: common.MapStr{"a": "localhost", "b": "2"},
+			output: common.MapStr{
+				"a": "localhost",
+				"b": int64(2),
+				"error": common.MapStr{
+					"message": "unable to convert value of field 'a' to ip: invalid IP address 'localhost'",
+					"type":    "convert",
+				},
+			},
+		},
+		{
+			description: "record all failures",
+			config: common.MapStr{
+				"fields": []common.MapStr{
+					{"from": "a", "type": "ip"},
+					{"from": "b", "type": "long"},
+					{"from": "c", "type": "boolean"},
+				},
+				"fail_on_error": false,
+			},
+			input: common.MapStr{"a": "localhost", "b": "2", "c": "maybe"},
+			output: common.MapStr{
+				"a": "localhost",
+				"b": int64(2),
+				"c": "maybe",
+				"error": common.MapStr{
+					"message": "2 errors: " +
+						"unable to convert value of field 'a' to ip: invalid IP address 'localhost'; " +
+						"unable to convert value of field 'c' to boolean: strconv.ParseBool: parsing \"maybe\": invalid syntax",
+					"type": "convert",
+				},
+			},
+		},
+		{
+			description: "keep existing error",
+			config: common.MapStr{
+				"fields":        []common.MapStr{{"from": "a", "type": "ip"}},
+				"fail_on_error": false,
+			},
+			input: common.MapStr{
+				"a":     "localhost",
+				"error": common.MapStr{"message": "failed to parse line", "type": "json"},
+			},
+			output: common.MapStr{
+				"a": "localhost",
+				"error": common.MapStr{
+					"message": "2 errors: failed to parse line; " +
+						"unable to convert value of field 'a' to ip: invalid IP address 'localhost'",
+					"type": "json",
+				},
+			},
+		},
+		{
+			description: "keep existing error on revert",
+			config: common.MapStr{
+				"fields": []common.MapStr{{"from": "a", "type": "ip"}},
+			},
+			input: common.MapStr{
+				"a":     "localhost",
+				"error": "failed to parse line",
+			},
+			output: common.MapStr{
+				"a": "localhost",
+				"error": common.MapStr{
+					"message": "2 errors: failed to parse line; " +
+						"unable to convert value of field 'a' to ip: invalid IP address 'localhost'",
+					"type": "convert",
+				},
+			},
+			error: true,
+		},
+	}
+
+	for _, test := range tests {
+		cfg, err := common.NewConfigFrom(test.config)
+		require.NoError(t, err)
+
+		p, err := newConvertFields(cfg)
+		require.NoError(t, err, test.description)
+
+		event, err := p.Run(&beat.Event{Fields: test.input})
+		if test.error {
+			assert.Error(t, err, test.description)
+		} else {
+			assert.NoError(t, err, test.description)
+		}
+		assert.Equal(t, test.output, event.Fields, test.description)
+	}
+}
+
+func TestConvertValue(t *testing.T) {
+	var tests = []struct {
+		value    interface{}
+		typ      dataType
+		expected interface{}
+		error    bool
+	}{
+		{value: "2147483648", typ: integer, error: true},
+		{value: int64(-5), typ: integer, expected: int32(-5)},
+		{value: 3.0, typ: long, expected: int64(3)},
+		{value: 3.5, typ: long, error: true},
+		{value: uint64(1 << 63), typ: long, error: true},
+		{value: true, typ: long, expected: int64(1)},
+		{value: 2, typ: double, expected: 2.0},
+		{value: "abc", typ: double, error: true},
+		{value: "F", typ: boolean, expected: false},
+		{value: 1, typ: boolean, error: true},
+		{value: "10.0.0.1", typ: ip, expected: "10.0.0.1"},
+		{value: 1.25, typ: str, expected: "1.25"},
+		{value: true, typ: str, expected: "true"},
+		{value: common.MapStr{}, typ: str, error: true},
+	}
+
+	for _, test := range tests {
+		actual, err := convertValue(test.value, test.typ)
+		if test.error {
+			assert.Error(t, err, "%v to %v", test.value, test.typ)
+			continue
+		}
+		if assert.NoError(t, err, "%v to %v", test.value, test.typ) {
+			assert.Equal(t, test.expected, actual, "%v to %v", test.value, test.typ)
+		}
+	}
+}
+
+func TestConvertConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"no fields":    {},
+		"no type":      {"fields": []common.MapStr{{"from": "a"}}},
+		"invalid type": {"fields": []common.MapStr{{"from": "a", "type": "date"}}},
+		"no from":      {"fields": []common.MapStr{{"type": "long"}}},
+	} {
+		cfg, err := common.NewConfigFrom(config)
+		require.NoError(t, err)
+
+		_, err = configChecked(newConvertFields, requireFields("fields"))(cfg)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_geoip
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+// result holds the fields found for an IP address. Addresses not found in a
+// database are cached as well, with nil fields.
+type result struct {
+	geo common.MapStr
+	as  common.MapStr
+}
+
+type cacheEntry struct {
+	key   string
+	value result
+}
+
+// lruCache caches lookup results. When the cache is full, the least recently
+// used entry is evicted.
+type lruCache struct {
+	sync.Mutex
+	maxSize int
+	entries map[string]*list.Element
+	order   *list.List // Front is the most recently used entry.
+	stats   cacheStats
+}
+
+type cacheStats struct {
+	Hit   *monitoring.Int
+	Miss  *monitoring.Int
+	Evict *monitoring.Int
+}
+
+func newCacheStats(reg *monitoring.Registry) cacheStats {
+	return cacheStats{
+		Hit:   monitoring.NewInt(reg, "hits"),
+		Miss:  monitoring.NewInt(reg, "misses"),
+		Evict: monitoring.NewInt(reg, "evictions"),
+	}
+}
+
+func newLRUCache(maxSize int, stats cacheStats) *lruCache {
+	return &lruCache{
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+		stats:   stats,
+	}
+}
+
+func (c *lruCache) get(key string) (result, bool) {
+	if c.maxSize == 0 {
+		return result{}, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	elem, found := c.entries[key]
+	if !found {
+		c.stats.Miss.Inc()
+		return result{}, false
+	}
+	c.stats.Hit.Inc()
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).value, true
+}
+
+func (c *lruCache) set(key string, value result) {
+	if c.maxSize == 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if elem, found := c.entries[key]; found {
+		elem.Value.(*cacheEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	if c.order.Len() >= c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.stats.Evict.Inc()
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+}
+
+func (c *lruCache) len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_geoip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func TestLRUCache(t *testing.T) {
+	stats := newCacheStats(monitoring.NewRegistry())
+	c := newLRUCache(2, stats)
+
+	a := result{geo: common.MapStr{"city_name": "a"}}
+	b := result{geo: common.MapStr{"city_name": "b"}}
+
+	c.set("a", a)
+	c.set("b", b)
+
+	// Accessing a makes b the least recently used entry.
+	res, found := c.get("a")
+	assert.True(t, found)
+	assert.Equal(t, a, res)
+
+	c.set("c", result{})
+	assert.Equal(t, 2, c.len())
+
+	_, found = c.get("b")
+	assert.False(t, found)
+	_, found = c.get("a")
+	assert.True(t, found)
+	res, found = c.get("c")
+	assert.True(t, found)
+	assert.Equal(t, result{}, res)
+
+	// Updating an entry does not evict anything.
+	c.set("c", b)
+	assert.Equal(t, 2, c.len())
+	res, _ = c.get("c")
+	assert.Equal(t, b, res)
+
+	assert.EqualValues(t, 4, stats.Hit.Get())
+	assert.EqualValues(t, 1, stats.Miss.Get())
+	assert.EqualValues(t, 1, stats.Evict.Get())
+}
+
+func TestLRUCacheDisabled(t *testing.T) {
+	c := newLRUCache(0, newCacheStats(monitoring.NewRegistry()))
+	c.set("a", result{})
+
+	_, found := c.get("a")
+	assert.False(t, found)
+	assert.Equal(t, 0, c.len())
+}
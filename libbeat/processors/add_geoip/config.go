@@ -0,0 +1,49 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_geoip
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type config struct {
+	Field           string        `config:"field" validate:"required"`
+	TargetField     string        `config:"target_field"`
+	ASNTargetField  string        `config:"asn_target_field"`
+	Database        string        `config:"database"`
+	ASNDatabase     string        `config:"asn_database"`
+	IgnoreMissing   bool          `config:"ignore_missing"`
+	RefreshInterval time.Duration `config:"refresh_interval" validate:"min=0"`
+	CacheSize       int           `config:"cache.size" validate:"min=0"`
+}
+
+var defaultConfig = config{
+	TargetField:     "geo",
+	ASNTargetField:  "as",
+	RefreshInterval: time.Minute,
+	CacheSize:       10000,
+}
+
+func (c *config) Validate() error {
+	if c.Database == "" && c.ASNDatabase == "" {
+		return errors.New("either database or asn_database must be set")
+	}
+	return nil
+}
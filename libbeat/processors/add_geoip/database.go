@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_geoip
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/oschwald/geoip2-golang"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// database is a MaxMind database loaded into memory. The file is read instead
+// of being memory mapped, so that a reloaded database can replace it while
+// lookups are still in flight.
+type database struct {
+	path    string
+	modTime time.Time
+	size    int64
+	lookup  func(ip net.IP) (common.MapStr, error)
+}
+
+func openDatabase(path string, asn bool) (*database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open GeoIP database")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open GeoIP database")
+	}
+	reader, err := geoip2.FromBytes(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open GeoIP database %v", path)
+	}
+
+	db := &database{path: path, modTime: info.ModTime(), size: info.Size()}
+
+	dbType := reader.Metadata().DatabaseType
+	switch {
+	case asn && strings.HasSuffix(dbType, "ASN"):
+		db.lookup = func(ip net.IP) (common.MapStr, error) {
+			record, err := reader.ASN(ip)
+			if err != nil {
+				return nil, err
+			}
+			return asnFields(record), nil
+		}
+	case !asn && (strings.HasSuffix(dbType, "City") || strings.HasSuffix(dbType, "Enterprise")):
+		db.lookup = func(ip net.IP) (common.MapStr, error) {
+			record, err := reader.City(ip)
+			if err != nil {
+				return nil, err
+			}
+			return cityFields(record), nil
+		}
+	case !asn && strings.HasSuffix(dbType, "Country"):
+		db.lookup = func(ip net.IP) (common.MapStr, error) {
+			record, err := reader.Country(ip)
+			if err != nil {
+				return nil, err
+			}
+			return countryFields(record), nil
+		}
+	default:
+		return nil, errors.Errorf("unsupported database type '%v' of GeoIP database %v", dbType, path)
+	}
+	return db, nil
+}
+
+// changed reports if the database file has been modified since it was loaded.
+func (db *database) changed() (bool, error) {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return false, err
+	}
+	return !info.ModTime().Equal(db.modTime) || info.Size() != db.size, nil
+}
+
+// putString adds the value only if it is not empty.
+func putString(m common.MapStr, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func cityFields(city *geoip2.City) common.MapStr {
+	m := common.MapStr{}
+	putString(m, "continent_name", city.Continent.Names["en"])
+	putString(m, "country_iso_code", city.Country.IsoCode)
+	putString(m, "country_name", city.Country.Names["en"])
+	putString(m, "city_name", city.City.Names["en"])
+	if len(city.Subdivisions) > 0 {
+		sub := city.Subdivisions[0]
+		putString(m, "region_name", sub.Names["en"])
+		if sub.IsoCode != "" && city.Country.IsoCode != "" {
+			m["region_iso_code"] = city.Country.IsoCode + "-" + sub.IsoCode
+		}
+	}
+	if city.Location.Latitude != 0 || city.Location.Longitude != 0 {
+		m["location"] = common.MapStr{
+			"lat": city.Location.Latitude,
+			"lon": city.Location.Longitude,
+		}
+	}
+	return m
+}
+
+func countryFields(country *geoip2.Country) common.MapStr {
+	m := common.MapStr{}
+	putString(m, "continent_name", country.Continent.Names["en"])
+	putString(m, "country_iso_code", country.Country.IsoCode)
+	putString(m, "country_name", country.Country.Names["en"])
+	return m
+}
+
+func asnFields(asn *geoip2.ASN) common.MapStr {
+	m := common.MapStr{}
+	if asn.AutonomousSystemNumber != 0 {
+		m["number"] = int64(asn.AutonomousSystemNumber)
+	}
+	if asn.AutonomousSystemOrganization != "" {
+		m["organization"] = common.MapStr{"name": asn.AutonomousSystemOrganization}
+	}
+	return m
+}
@@ -0,0 +1,240 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_geoip
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.add_geoip"
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin("add_geoip", newGeoIP)
+}
+
+type addGeoIP struct {
+	config
+	log   *logp.Logger
+	stats cacheStats
+
+	mu    sync.RWMutex
+	state *lookupState
+
+	reloadMu  sync.Mutex
+	nextCheck atomic.Int64
+}
+
+// lookupState holds the databases and the cache of their results. On reload
+// the state is replaced as a whole, so that results of the old databases are
+// never served from the cache.
+type lookupState struct {
+	geo, asn *database
+	cache    *lruCache
+}
+
+func newGeoIP(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the add_geoip configuration")
+	}
+	if c.Database != "" {
+		c.Database = paths.Resolve(paths.Config, c.Database)
+	}
+	if c.ASNDatabase != "" {
+		c.ASNDatabase = paths.Resolve(paths.Config, c.ASNDatabase)
+	}
+
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger(logName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	p := &addGeoIP{
+		config: c,
+		log:    log,
+		stats:  newCacheStats(reg.NewRegistry("cache")),
+	}
+
+	state := &lookupState{cache: newLRUCache(c.CacheSize, p.stats)}
+	var err error
+	if c.Database != "" {
+		if state.geo, err = openDatabase(c.Database, false); err != nil {
+			return nil, err
+		}
+	}
+	if c.ASNDatabase != "" {
+		if state.asn, err = openDatabase(c.ASNDatabase, true); err != nil {
+			return nil, err
+		}
+	}
+	p.state = state
+	p.nextCheck.Store(time.Now().Add(c.RefreshInterval).UnixNano())
+
+	return p, nil
+}
+
+func (p *addGeoIP) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return event, nil
+		}
+		return event, errors.Wrapf(err, "failed to get IP address from field '%v'", p.Field)
+	}
+
+	var ip net.IP
+	switch val := v.(type) {
+	case string:
+		ip = net.ParseIP(val)
+	case net.IP:
+		ip = val
+	}
+	if ip == nil {
+		return event, errors.Errorf("value '%v' of field '%v' is not an IP address", v, p.Field)
+	}
+
+	res, err := p.current().lookup(ip)
+	if err != nil {
+		return event, errors.Wrapf(err, "GeoIP lookup of '%v' failed", ip)
+	}
+
+	if len(res.geo) > 0 {
+		if _, err := event.PutValue(p.TargetField, res.geo.Clone()); err != nil {
+			return event, err
+		}
+	}
+	if len(res.as) > 0 {
+		if _, err := event.PutValue(p.ASNTargetField, res.as.Clone()); err != nil {
+			return event, err
+		}
+	}
+	return event, nil
+}
+
+func (p *addGeoIP) String() string {
+	return fmt.Sprintf("add_geoip=[field=%v, target_field=%v, asn_target_field=%v, database=%v, asn_database=%v]",
+		p.Field, p.TargetField, p.ASNTargetField, p.Database, p.ASNDatabase)
+}
+
+func (s *lookupState) lookup(ip net.IP) (result, error) {
+	key := ip.String()
+	if res, found := s.cache.get(key); found {
+		return res, nil
+	}
+
+	var res result
+	var err error
+	if s.geo != nil {
+		if res.geo, err = s.geo.lookup(ip); err != nil {
+			return res, err
+		}
+	}
+	if s.asn != nil {
+		if res.as, err = s.asn.lookup(ip); err != nil {
+			return res, err
+		}
+	}
+
+	s.cache.set(key, res)
+	return res, nil
+}
+
+func (p *addGeoIP) current() *lookupState {
+	if p.RefreshInterval > 0 {
+		p.checkReload()
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.state
+}
+
+// checkReload reloads the databases if their files have been changed. The
+// files are checked at most once per refresh interval. If a database can not
+// be loaded, the current databases are kept.
+func (p *addGeoIP) checkReload() {
+	now := time.Now()
+	if now.UnixNano() < p.nextCheck.Load() {
+		return
+	}
+
+	p.reloadMu.Lock()
+	defer p.reloadMu.Unlock()
+	if now.UnixNano() < p.nextCheck.Load() {
+		return
+	}
+	p.nextCheck.Store(now.Add(p.RefreshInterval).UnixNano())
+
+	p.mu.RLock()
+	old := p.state
+	p.mu.RUnlock()
+
+	geo, geoChanged, err := p.reloadDatabase(old.geo, false)
+	if err != nil {
+		return
+	}
+	asn, asnChanged, err := p.reloadDatabase(old.asn, true)
+	if err != nil || (!geoChanged && !asnChanged) {
+		return
+	}
+
+	p.mu.Lock()
+	p.state = &lookupState{geo: geo, asn: asn, cache: newLRUCache(p.CacheSize, p.stats)}
+	p.mu.Unlock()
+}
+
+func (p *addGeoIP) reloadDatabase(db *database, asn bool) (*database, bool, error) {
+	if db == nil {
+		return nil, false, nil
+	}
+
+	changed, err := db.changed()
+	if err != nil {
+		p.log.Warnf("Failed to check GeoIP database %v for changes: %v", db.path, err)
+		return nil, false, err
+	}
+	if !changed {
+		return db, false, nil
+	}
+
+	newDB, err := openDatabase(db.path, asn)
+	if err != nil {
+		p.log.Errorf("Failed to reload GeoIP database, keeping the current one: %v", err)
+		return nil, false, err
+	}
+	p.log.Infof("Reloaded GeoIP database %v", db.path)
+	return newDB, true, nil
+}
@@ -0,0 +1,217 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_geoip
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const (
+	cityDB    = "testdata/GeoLite2-City-Test.mmdb"
+	countryDB = "testdata/GeoLite2-Country-Test.mmdb"
+	asnDB     = "testdata/GeoLite2-ASN-Test.mmdb"
+)
+
+var linkoping = common.MapStr{
+	"continent_name":   "Europe",
+	"country_iso_code": "SE",
+	"country_name":     "Sweden",
+	"region_name":      "Östergötland County",
+	"region_iso_code":  "SE-E",
+	"city_name":        "Linköping",
+	"location":         common.MapStr{"lat": 58.4167, "lon": 15.6167},
+}
+
+func newTestGeoIP(t *testing.T, config common.MapStr) processors.Processor {
+	cfg, err := common.NewConfigFrom(config)
+	require.NoError(t, err)
+
+	p, err := newGeoIP(cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func TestGeoIP(t *testing.T) {
+	p := newTestGeoIP(t, common.MapStr{
+		"field":            "source.ip",
+		"target_field":     "source.geo",
+		"asn_target_field": "source.as",
+		"database":         cityDB,
+		"asn_database":     asnDB,
+	})
+
+	var tests = []struct {
+		ip  interface{}
+		geo interface{}
+		as  interface{}
+	}{
+		{
+			ip:  "89.160.20.112",
+			geo: linkoping,
+			as: common.MapStr{
+				"number":       int64(29518),
+				"organization": common.MapStr{"name": "Bredband2 AB"},
+			},
+		},
+		{
+			ip: "2a02:cf40::1",
+			geo: common.MapStr{
+				"continent_name":   "North America",
+				"country_iso_code": "US",
+				"country_name":     "United States",
+				"location":         common.MapStr{"lat": 37.751, "lon": -97.822},
+			},
+		},
+		{
+			ip:  net.ParseIP("89.160.20.1"),
+			geo: linkoping,
+			as: common.MapStr{
+				"number":       int64(29518),
+				"organization": common.MapStr{"name": "Bredband2 AB"},
+			},
+		},
+		{
+			ip: "10.0.0.1",
+		},
+	}
+
+	for _, test := range tests {
+		// Run twice, the second lookup is served from the cache.
+		for i := 0; i < 2; i++ {
+			event, err := p.Run(&beat.Event{Fields: common.MapStr{"source": common.MapStr{"ip": test.ip}}})
+			require.NoError(t, err, "%v", test.ip)
+
+			geo, _ := event.GetValue("source.geo")
+			as, _ := event.GetValue("source.as")
+			assert.Equal(t, test.geo, geo, "%v", test.ip)
+			assert.Equal(t, test.as, as, "%v", test.ip)
+		}
+	}
+
+	stats := p.(*addGeoIP).stats
+	assert.EqualValues(t, 4, stats.Hit.Get())
+	assert.EqualValues(t, 4, stats.Miss.Get())
+}
+
+func TestGeoIPCountry(t *testing.T) {
+	p := newTestGeoIP(t, common.MapStr{"field": "ip", "database": countryDB})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"ip": "89.160.20.112"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"continent_name":   "Europe",
+		"country_iso_code": "SE",
+		"country_name":     "Sweden",
+	}, event.Fields["geo"])
+	assert.NotContains(t, event.Fields, "as")
+}
+
+func TestGeoIPResultIsCopied(t *testing.T) {
+	p := newTestGeoIP(t, common.MapStr{"field": "ip", "database": cityDB})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"ip": "89.160.20.112"}})
+	require.NoError(t, err)
+	event.PutValue("geo.city_name", "modified")
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"ip": "89.160.20.112"}})
+	require.NoError(t, err)
+	assert.Equal(t, linkoping, event.Fields["geo"])
+}
+
+func TestGeoIPInvalidValues(t *testing.T) {
+	config := common.MapStr{"field": "ip", "asn_database": asnDB}
+	p := newTestGeoIP(t, config)
+
+	for _, fields := range []common.MapStr{
+		{},
+		{"ip": "not an ip"},
+		{"ip": 42},
+	} {
+		_, err := p.Run(&beat.Event{Fields: fields})
+		assert.Error(t, err, "%v", fields)
+	}
+
+	config["ignore_missing"] = true
+	event, err := newTestGeoIP(t, config).Run(&beat.Event{Fields: common.MapStr{}})
+	assert.NoError(t, err)
+	assert.Equal(t, common.MapStr{}, event.Fields)
+}
+
+func TestGeoIPConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"no database":        {"field": "ip"},
+		"no field":           {"database": cityDB},
+		"missing database":   {"field": "ip", "database": "testdata/missing.mmdb"},
+		"asn as geo":         {"field": "ip", "database": asnDB},
+		"city as asn":        {"field": "ip", "asn_database": cityDB},
+		"invalid cache size": {"field": "ip", "database": cityDB, "cache.size": -1},
+	} {
+		cfg, err := common.NewConfigFrom(config)
+		require.NoError(t, err)
+
+		_, err = newGeoIP(cfg)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGeoIPReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "add_geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "GeoLite2.mmdb")
+	copyFile := func(src string) {
+		data, err := ioutil.ReadFile(src)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	}
+	copyFile(countryDB)
+
+	p := newTestGeoIP(t, common.MapStr{
+		"field":            "ip",
+		"database":         path,
+		"refresh_interval": time.Nanosecond,
+	})
+	cityName := func() interface{} {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"ip": "89.160.20.112"}})
+		require.NoError(t, err)
+		v, _ := event.GetValue("geo.city_name")
+		return v
+	}
+	assert.Nil(t, cityName())
+
+	// The reloaded database is used, cached results of the old one are dropped.
+	copyFile(cityDB)
+	assert.Equal(t, "Linköping", cityName())
+
+	// Invalid files are not loaded, the current database is kept.
+	require.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0600))
+	assert.Equal(t, "Linköping", cityName())
+}
@@ -0,0 +1,205 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package communityid
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/flowhash"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func init() {
+	processors.RegisterPlugin("community_id", newCommunityID)
+}
+
+type communityID struct {
+	config
+	hasher flowhash.CommunityID
+}
+
+func newCommunityID(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the community_id configuration")
+	}
+	if c.Target == "" {
+		return nil, errors.New("target must not be empty")
+	}
+
+	return &communityID{config: c, hasher: flowhash.NewCommunityID(c.Seed)}, nil
+}
+
+// Run adds the Community ID to the event. Events without the fields required
+// to identify the flow are not changed.
+func (p *communityID) Run(event *beat.Event) (*beat.Event, error) {
+	flow, ok := p.buildFlow(event)
+	if !ok {
+		return event, nil
+	}
+
+	id := p.hasher.Hash(flow)
+	if id == "" {
+		return event, nil
+	}
+
+	if _, err := event.PutValue(p.Target, id); err != nil {
+		return event, errors.Wrapf(err, "failed to set field '%v'", p.Target)
+	}
+	return event, nil
+}
+
+func (p *communityID) String() string {
+	return fmt.Sprintf("community_id=[target=%v, seed=%v]", p.Target, p.Seed)
+}
+
+func (p *communityID) buildFlow(event *beat.Event) (flowhash.Flow, bool) {
+	var flow flowhash.Flow
+
+	var ok bool
+	if flow.SourceIP, ok = getIP(event, p.Fields.SourceIP); !ok {
+		return flow, false
+	}
+	if flow.DestinationIP, ok = getIP(event, p.Fields.DestinationIP); !ok {
+		return flow, false
+	}
+	if flow.Protocol, ok = getProtocol(event, p.Fields.Transport); !ok {
+		return flow, false
+	}
+
+	switch flow.Protocol {
+	case flowhash.TCP, flowhash.UDP, flowhash.SCTP:
+		if flow.SourcePort, ok = getUint16(event, p.Fields.SourcePort); !ok {
+			return flow, false
+		}
+		if flow.DestinationPort, ok = getUint16(event, p.Fields.DestinationPort); !ok {
+			return flow, false
+		}
+	case flowhash.ICMP, flowhash.ICMPv6:
+		typ, ok := getUint16(event, p.Fields.ICMPType)
+		if !ok || typ > math.MaxUint8 {
+			return flow, false
+		}
+		code, ok := getUint16(event, p.Fields.ICMPCode)
+		if !ok || code > math.MaxUint8 {
+			return flow, false
+		}
+		flow.ICMP.Type, flow.ICMP.Code = uint8(typ), uint8(code)
+	}
+
+	return flow, true
+}
+
+func getIP(event *beat.Event, field string) (net.IP, bool) {
+	v, err := event.GetValue(field)
+	if err != nil {
+		return nil, false
+	}
+
+	switch ip := v.(type) {
+	case string:
+		parsed := net.ParseIP(ip)
+		return parsed, parsed != nil
+	case net.IP:
+		return ip, len(ip) > 0
+	}
+	return nil, false
+}
+
+// getProtocol returns the protocol number of the transport field, which can
+// be a protocol name or an IANA protocol number.
+func getProtocol(event *beat.Event, field string) (uint8, bool) {
+	v, err := event.GetValue(field)
+	if err != nil {
+		return 0, false
+	}
+
+	if name, ok := v.(string); ok {
+		if n, found := flowhash.ProtocolNumber(name); found {
+			return n, true
+		}
+	}
+
+	n, ok := toUint16(v)
+	if !ok || n > math.MaxUint8 {
+		return 0, false
+	}
+	return uint8(n), true
+}
+
+func getUint16(event *beat.Event, field string) (uint16, bool) {
+	v, err := event.GetValue(field)
+	if err != nil {
+		return 0, false
+	}
+	return toUint16(v)
+}
+
+func toUint16(v interface{}) (uint16, bool) {
+	var n int64
+	switch val := v.(type) {
+	case int:
+		n = int64(val)
+	case int8:
+		n = int64(val)
+	case int16:
+		n = int64(val)
+	case int32:
+		n = int64(val)
+	case int64:
+		n = val
+	case uint:
+		n = int64(val)
+	case uint8:
+		n = int64(val)
+	case uint16:
+		n = int64(val)
+	case uint32:
+		n = int64(val)
+	case uint64:
+		if val > math.MaxUint16 {
+			return 0, false
+		}
+		n = int64(val)
+	case float64:
+		if val != math.Trunc(val) {
+			return 0, false
+		}
+		n = int64(val)
+	case string:
+		i, err := strconv.ParseUint(val, 10, 16)
+		if err != nil {
+			return 0, false
+		}
+		n = int64(i)
+	default:
+		return 0, false
+	}
+
+	if n < 0 || n > math.MaxUint16 {
+		return 0, false
+	}
+	return uint16(n), true
+}
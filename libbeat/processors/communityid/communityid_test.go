@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package communityid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func newTestCommunityID(t *testing.T, config common.MapStr) *communityID {
+	cfg, err := common.NewConfigFrom(config)
+	require.NoError(t, err)
+
+	p, err := newCommunityID(cfg)
+	require.NoError(t, err)
+	return p.(*communityID)
+}
+
+func TestRun(t *testing.T) {
+	var tests = []struct {
+		description string
+		fields      common.MapStr
+		expected    interface{}
+	}{
+		{
+			description: "tcp",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "128.232.110.120", "port": 34855},
+				"destination": common.MapStr{"ip": "66.35.250.204", "port": uint16(80)},
+				"network":     common.MapStr{"transport": "tcp"},
+			},
+			expected: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			description: "reverse direction with protocol number",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "66.35.250.204", "port": "80"},
+				"destination": common.MapStr{"ip": "128.232.110.120", "port": int64(34855)},
+				"network":     common.MapStr{"transport": 6},
+			},
+			expected: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			description: "icmp",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "192.168.0.89"},
+				"destination": common.MapStr{"ip": "192.168.0.1"},
+				"network":     common.MapStr{"transport": "icmp"},
+				"icmp":        common.MapStr{"type": 8, "code": 0},
+			},
+			expected: "1:X0snYXpgwiv9TZtqg64sgzUn6Dk=",
+		},
+		{
+			description: "other protocol",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "10.0.0.1"},
+				"destination": common.MapStr{"ip": "10.0.0.2"},
+				"network":     common.MapStr{"transport": 47},
+			},
+			expected: "1:+KlEHDT0vJgzs/eNmzHq0aSpRYw=",
+		},
+		{
+			description: "missing port",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "128.232.110.120", "port": 34855},
+				"destination": common.MapStr{"ip": "66.35.250.204"},
+				"network":     common.MapStr{"transport": "tcp"},
+			},
+		},
+		{
+			description: "invalid ip",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "localhost", "port": 34855},
+				"destination": common.MapStr{"ip": "66.35.250.204", "port": 80},
+				"network":     common.MapStr{"transport": "tcp"},
+			},
+		},
+		{
+			description: "unknown transport",
+			fields: common.MapStr{
+				"source":      common.MapStr{"ip": "128.232.110.120", "port": 34855},
+				"destination": common.MapStr{"ip": "66.35.250.204", "port": 80},
+				"network":     common.MapStr{"transport": "quic"},
+			},
+		},
+	}
+
+	p := newTestCommunityID(t, common.MapStr{})
+	for _, test := range tests {
+		event, err := p.Run(&beat.Event{Fields: test.fields})
+		require.NoError(t, err, test.description)
+
+		id, _ := event.GetValue("network.community_id")
+		assert.Equal(t, test.expected, id, test.description)
+	}
+}
+
+func TestRunCustomFieldsAndSeed(t *testing.T) {
+	p := newTestCommunityID(t, common.MapStr{
+		"fields": common.MapStr{
+			"source_ip":        "client_ip",
+			"source_port":      "client_port",
+			"destination_ip":   "ip",
+			"destination_port": "port",
+			"transport":        "transport",
+		},
+		"target": "community_id",
+		"seed":   1,
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{
+		"client_ip":   "128.232.110.120",
+		"client_port": 34855,
+		"ip":          "66.35.250.204",
+		"port":        80,
+		"transport":   "tcp",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "1:3V71V58M3Ksw/yuFALMcW0LAHvc=", event.Fields["community_id"])
+}
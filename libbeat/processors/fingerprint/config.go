@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/pkg/errors"
+	"github.com/spaolacci/murmur3"
+)
+
+// Config defines the configuration options for the fingerprint processor.
+type Config struct {
+	Fields        []string `config:"fields" validate:"required"` // Fields to compute the fingerprint from.
+	TargetField   string   `config:"target_field"`               // Field the fingerprint is written to.
+	Method        Method   `config:"method"`                     // Hash function.
+	Encoding      Encoding `config:"encoding"`                   // Encoding of the hash value.
+	Key           string   `config:"key"`                        // Optional key, the hash is computed as HMAC if set.
+	IgnoreMissing bool     `config:"ignore_missing"`             // Skip missing fields instead of failing.
+}
+
+// Method selects the hash function used to compute the fingerprint.
+type Method uint8
+
+// List of supported hash functions.
+const (
+	MethodSHA256 Method = iota
+	MethodSHA1
+	MethodXXHash
+	MethodMurmur3
+)
+
+var methodNames = map[Method]string{
+	MethodSHA256:  "sha256",
+	MethodSHA1:    "sha1",
+	MethodXXHash:  "xxhash",
+	MethodMurmur3: "murmur3",
+}
+
+// String returns the hash function name.
+func (m Method) String() string {
+	return methodNames[m]
+}
+
+// Unpack unpacks a string to a Method.
+func (m *Method) Unpack(v string) error {
+	for method, name := range methodNames {
+		if strings.ToLower(v) == name {
+			*m = method
+			return nil
+		}
+	}
+	return errors.Errorf("invalid fingerprint method '%v' (valid values are: sha1, sha256, xxhash, murmur3)", v)
+}
+
+func (m Method) newHash() func() hash.Hash {
+	switch m {
+	case MethodSHA1:
+		return sha1.New
+	case MethodXXHash:
+		return func() hash.Hash { return xxhash.New64() }
+	case MethodMurmur3:
+		return func() hash.Hash { return murmur3.New128() }
+	default:
+		return sha256.New
+	}
+}
+
+// Encoding selects how the hash value is encoded into the target field.
+type Encoding uint8
+
+// List of supported encodings.
+const (
+	EncodingHex Encoding = iota
+	EncodingBase64
+)
+
+// Unpack unpacks a string to an Encoding.
+func (e *Encoding) Unpack(v string) error {
+	switch strings.ToLower(v) {
+	case "hex":
+		*e = EncodingHex
+	case "base64":
+		*e = EncodingBase64
+	default:
+		return errors.Errorf("invalid fingerprint encoding '%v' (valid values are: hex, base64)", v)
+	}
+	return nil
+}
+
+func (e Encoding) encode(b []byte) string {
+	if e == EncodingBase64 {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Validate validates the data contained in the config.
+func (c *Config) Validate() error {
+	if len(c.Fields) == 0 {
+		return errors.New("no fields configured")
+	}
+	if c.TargetField == "" {
+		return errors.New("target_field must not be empty")
+	}
+	return nil
+}
+
+var defaultConfig = Config{
+	TargetField: "fingerprint",
+	Method:      MethodSHA256,
+	Encoding:    EncodingHex,
+}
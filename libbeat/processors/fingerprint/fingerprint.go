@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func init() {
+	processors.RegisterPlugin("fingerprint", newFingerprint)
+}
+
+type fingerprint struct {
+	config  Config
+	fields  []string
+	newHash func() hash.Hash
+}
+
+func newFingerprint(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the fingerprint configuration")
+	}
+
+	// Sort the fields, so the fingerprint does not depend on the configured
+	// order.
+	fields := append([]string(nil), c.Fields...)
+	sort.Strings(fields)
+
+	newHash := c.Method.newHash()
+	if c.Key != "" {
+		key := []byte(c.Key)
+		method := newHash
+		newHash = func() hash.Hash { return hmac.New(method, key) }
+	}
+
+	return &fingerprint{config: c, fields: fields, newHash: newHash}, nil
+}
+
+func (p *fingerprint) Run(event *beat.Event) (*beat.Event, error) {
+	h := p.newHash()
+
+	found := false
+	for _, field := range p.fields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			if p.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+				continue
+			}
+			return event, errors.Wrapf(err, "failed to compute fingerprint, field '%v'", field)
+		}
+
+		if err := writeField(h, field, v); err != nil {
+			return event, errors.Wrapf(err, "failed to compute fingerprint, field '%v'", field)
+		}
+		found = true
+	}
+
+	if !found {
+		return event, nil
+	}
+
+	if _, err := event.PutValue(p.config.TargetField, p.config.Encoding.encode(h.Sum(nil))); err != nil {
+		return event, errors.Wrapf(err, "failed to set fingerprint in field '%v'", p.config.TargetField)
+	}
+	return event, nil
+}
+
+// writeField writes the field name and value as `|name|value|` to the hash.
+// Timestamps are written in RFC3339 format with nanoseconds in UTC. Objects and
+// arrays are written in JSON, with object keys being sorted.
+func writeField(w io.Writer, name string, v interface{}) error {
+	var value string
+	switch x := v.(type) {
+	case string:
+		value = x
+	case time.Time:
+		value = x.UTC().Format(time.RFC3339Nano)
+	case common.Time:
+		value = time.Time(x).UTC().Format(time.RFC3339Nano)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		value = fmt.Sprint(x)
+	default:
+		b, err := json.Marshal(x)
+		if err != nil {
+			return err
+		}
+		value = string(b)
+	}
+
+	_, err := fmt.Fprintf(w, "|%v|%v|", name, value)
+	return err
+}
+
+func (p *fingerprint) String() string {
+	return fmt.Sprintf("fingerprint=[fields=%v, target_field=%v, method=%v]",
+		p.fields, p.config.TargetField, p.config.Method)
+}
@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func newTestFingerprint(t testing.TB, cfg map[string]interface{}) processors.Processor {
+	c, err := common.NewConfigFrom(cfg)
+	require.NoError(t, err)
+
+	p, err := newFingerprint(c)
+	require.NoError(t, err)
+	return p
+}
+
+func runFingerprint(t testing.TB, p processors.Processor, fields common.MapStr) (common.MapStr, error) {
+	event, err := p.Run(&beat.Event{Fields: fields})
+	require.NotNil(t, event)
+	return event.Fields, err
+}
+
+func TestFingerprintMethods(t *testing.T) {
+	fields := common.MapStr{
+		"message": "hello world",
+		"host":    "web-1",
+		"other":   "ignored",
+	}
+
+	tests := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{
+			config:   map[string]interface{}{},
+			expected: "bf9c6dcde47b3368adde78830750552a57f1d50be97dedbbefb8b7eefb54f45e",
+		},
+		{
+			config:   map[string]interface{}{"method": "sha1"},
+			expected: "cfb827e657293f1acb535f3829bd68b47944d533",
+		},
+		{
+			config:   map[string]interface{}{"key": "secret"},
+			expected: "0cb35c60e5a9c3e8e8753e92be8aa40b7f77414cb41c26d766d31ef8e6f94484",
+		},
+		{
+			config:   map[string]interface{}{"method": "sha1", "encoding": "base64"},
+			expected: "z7gn5lcpPxrLU184Kb1otHlE1TM",
+		},
+	}
+
+	for _, test := range tests {
+		cfg := map[string]interface{}{"fields": []string{"message", "host"}}
+		for k, v := range test.config {
+			cfg[k] = v
+		}
+
+		actual, err := runFingerprint(t, newTestFingerprint(t, cfg), fields.Clone())
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, actual["fingerprint"], "%v", test.config)
+	}
+}
+
+func TestFingerprintNonCryptographic(t *testing.T) {
+	for method, length := range map[string]int{"xxhash": 16, "murmur3": 32} {
+		cfg := map[string]interface{}{
+			"fields":       []string{"message"},
+			"method":       method,
+			"target_field": "event.id",
+		}
+
+		a, err := runFingerprint(t, newTestFingerprint(t, cfg), common.MapStr{"message": "a"})
+		require.NoError(t, err)
+		b, err := runFingerprint(t, newTestFingerprint(t, cfg), common.MapStr{"message": "b"})
+		require.NoError(t, err)
+
+		idA, _ := a.GetValue("event.id")
+		idB, _ := b.GetValue("event.id")
+		assert.Len(t, idA, length, method)
+		assert.NotEqual(t, idA, idB, method)
+	}
+}
+
+func TestFingerprintValues(t *testing.T) {
+	cfg := map[string]interface{}{"fields": []string{"nested", "@timestamp"}}
+	p := newTestFingerprint(t, cfg)
+
+	event, err := p.Run(&beat.Event{
+		Timestamp: time.Date(2018, 11, 1, 10, 0, 0, 500000000, time.UTC),
+		Fields: common.MapStr{
+			"nested": common.MapStr{"b": []string{"x", "y"}, "a": 1},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "4115e8d5916fbc12d6bafd2cafa627d5c925f44d1b12f227ea378186e83f70ca", event.Fields["fingerprint"])
+}
+
+func TestFingerprintMissingField(t *testing.T) {
+	cfg := map[string]interface{}{"fields": []string{"message", "host"}}
+
+	fields, err := runFingerprint(t, newTestFingerprint(t, cfg), common.MapStr{"message": "hello world"})
+	assert.Error(t, err)
+	assert.NotContains(t, fields, "fingerprint")
+
+	cfg["ignore_missing"] = true
+	fields, err = runFingerprint(t, newTestFingerprint(t, cfg), common.MapStr{"message": "hello world"})
+	assert.NoError(t, err)
+	assert.Contains(t, fields, "fingerprint")
+
+	fields, err = runFingerprint(t, newTestFingerprint(t, cfg), common.MapStr{})
+	assert.NoError(t, err)
+	assert.NotContains(t, fields, "fingerprint")
+}
+
+func TestFingerprintConfig(t *testing.T) {
+	for name, cfg := range map[string]map[string]interface{}{
+		"no fields":        {},
+		"invalid method":   {"fields": []string{"message"}, "method": "md5"},
+		"invalid encoding": {"fields": []string{"message"}, "encoding": "base32"},
+		"empty target":     {"fields": []string{"message"}, "target_field": ""},
+	} {
+		c, err := common.NewConfigFrom(cfg)
+		require.NoError(t, err)
+
+		_, err = newFingerprint(c)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	libgrok "github.com/elastic/beats/libbeat/common/grok"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type processor struct {
+	config   config
+	patterns []*libgrok.Grok
+}
+
+func init() {
+	processors.RegisterPlugin("grok", newProcessor)
+}
+
+func newProcessor(c *common.Config) (processors.Processor, error) {
+	config := defaultConfig
+	if err := c.Unpack(&config); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the grok configuration")
+	}
+
+	p := &processor{config: config}
+	for _, pattern := range config.Patterns {
+		g, err := libgrok.Compile(pattern, config.PatternDefinitions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid grok pattern '%v'", pattern)
+		}
+		if config.Timeout > 0 {
+			g.SetMatchTimeout(config.Timeout)
+		}
+		p.patterns = append(p.patterns, g)
+	}
+	return p, nil
+}
+
+// Run matches the configured field against the patterns in order and adds
+// the fields captured by the first matching pattern to the event. If no
+// pattern matches, the event is tagged and not modified otherwise.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.config.Field)
+	if err != nil {
+		if p.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return event, nil
+		}
+		return p.fail(event, errors.Wrapf(err, "could not fetch value for field '%s'", p.config.Field))
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return p.fail(event, fmt.Errorf("field is not a string, value: `%v`, field: `%s`", v, p.config.Field))
+	}
+
+	for _, g := range p.patterns {
+		m, err := g.Match(s)
+		if err != nil {
+			return p.fail(event, err)
+		}
+		if m != nil {
+			return p.mapper(event, m)
+		}
+	}
+	return p.fail(event, fmt.Errorf("no grok pattern matched the value of field `%s`", p.config.Field))
+}
+
+func (p *processor) mapper(event *beat.Event, m map[string]interface{}) (*beat.Event, error) {
+	copy := event.Fields.Clone()
+
+	prefix := ""
+	if p.config.TargetPrefix != "" {
+		prefix = p.config.TargetPrefix + "."
+	}
+	for k, v := range m {
+		prefixKey := prefix + k
+		if !p.config.OverwriteKeys {
+			if _, err := event.GetValue(prefixKey); err != common.ErrKeyNotFound {
+				event.Fields = copy
+				if err != nil {
+					return p.fail(event, errors.Wrapf(err, "cannot override existing key with `%s`", prefixKey))
+				}
+				return p.fail(event, fmt.Errorf("cannot override existing key with `%s`", prefixKey))
+			}
+		}
+		if _, err := event.PutValue(prefixKey, v); err != nil {
+			event.Fields = copy
+			return p.fail(event, errors.Wrapf(err, "cannot set key `%s`", prefixKey))
+		}
+	}
+	return event, nil
+}
+
+// fail tags the event as not parsed and returns the error.
+func (p *processor) fail(event *beat.Event, err error) (*beat.Event, error) {
+	if tagErr := common.AddTags(event.Fields, p.config.TagOnFailure); tagErr != nil {
+		return event, errors.Wrapf(err, "failed to add tags to the event (%v)", tagErr)
+	}
+	return event, err
+}
+
+func (p *processor) String() string {
+	return "grok=[field=" + p.config.Field +
+		", patterns=[" + strings.Join(p.config.Patterns, ", ") + "]" +
+		", target_prefix=" + p.config.TargetPrefix + "]"
+}
@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestProcessor(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        map[string]interface{}
+		fields   common.MapStr
+		expected common.MapStr
+		err      bool
+	}{
+		{
+			name: "default field/target root",
+			c: map[string]interface{}{
+				"patterns": []string{"%{IP:client.ip} %{WORD:http.method} %{URIPATHPARAM:url}"},
+			},
+			fields: common.MapStr{"message": "10.0.0.1 GET /index.html?a=1"},
+			expected: common.MapStr{
+				"message": "10.0.0.1 GET /index.html?a=1",
+				"client":  common.MapStr{"ip": "10.0.0.1"},
+				"http":    common.MapStr{"method": "GET"},
+				"url":     "/index.html?a=1",
+			},
+		},
+		{
+			name: "specific field/specific target",
+			c: map[string]interface{}{
+				"field":         "log",
+				"target_prefix": "parsed",
+				"patterns":      []string{"%{NUMBER:bytes:int} bytes"},
+			},
+			fields: common.MapStr{"log": "512 bytes"},
+			expected: common.MapStr{
+				"log":    "512 bytes",
+				"parsed": common.MapStr{"bytes": 512},
+			},
+		},
+		{
+			name: "fallback patterns and custom definitions",
+			c: map[string]interface{}{
+				"patterns": []string{
+					"%{USER:user} logged in",
+					"%{SESSION:session} expired",
+				},
+				"pattern_definitions": map[string]string{"SESSION": `[a-f0-9]{8}`},
+			},
+			fields: common.MapStr{"message": "deadbeef expired"},
+			expected: common.MapStr{
+				"message": "deadbeef expired",
+				"session": "deadbeef",
+			},
+		},
+		{
+			name: "no match adds tag",
+			c: map[string]interface{}{
+				"patterns": []string{"%{IP:client.ip}"},
+			},
+			fields: common.MapStr{"message": "no address here"},
+			expected: common.MapStr{
+				"message": "no address here",
+				"tags":    []string{"_grokparsefailure"},
+			},
+			err: true,
+		},
+		{
+			name: "custom failure tag",
+			c: map[string]interface{}{
+				"patterns":       []string{"%{IP:client.ip}"},
+				"tag_on_failure": []string{"grok_failed"},
+			},
+			fields: common.MapStr{"message": "no address here", "tags": []string{"web"}},
+			expected: common.MapStr{
+				"message": "no address here",
+				"tags":    []string{"web", "grok_failed"},
+			},
+			err: true,
+		},
+		{
+			name: "existing keys are not overwritten",
+			c: map[string]interface{}{
+				"patterns": []string{"%{WORD:level} %{GREEDYDATA:message}"},
+			},
+			fields: common.MapStr{"message": "INFO started"},
+			expected: common.MapStr{
+				"message": "INFO started",
+				"tags":    []string{"_grokparsefailure"},
+			},
+			err: true,
+		},
+		{
+			name: "overwrite keys",
+			c: map[string]interface{}{
+				"patterns":       []string{"%{WORD:level} %{GREEDYDATA:message}"},
+				"overwrite_keys": true,
+			},
+			fields: common.MapStr{"message": "INFO started"},
+			expected: common.MapStr{
+				"message": "started",
+				"level":   "INFO",
+			},
+		},
+		{
+			name: "missing field",
+			c: map[string]interface{}{
+				"patterns": []string{"%{WORD:level}"},
+			},
+			fields: common.MapStr{},
+			expected: common.MapStr{
+				"tags": []string{"_grokparsefailure"},
+			},
+			err: true,
+		},
+		{
+			name: "ignore missing field",
+			c: map[string]interface{}{
+				"patterns":       []string{"%{WORD:level}"},
+				"ignore_missing": true,
+			},
+			fields:   common.MapStr{},
+			expected: common.MapStr{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := common.NewConfigFrom(test.c)
+			require.NoError(t, err)
+
+			p, err := newProcessor(c)
+			require.NoError(t, err)
+
+			event, err := p.Run(&beat.Event{Fields: test.fields})
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, event.Fields)
+		})
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"missing patterns":   {"field": "message"},
+		"undefined pattern":  {"patterns": []string{"%{NOT_A_PATTERN:x}"}},
+		"invalid expression": {"patterns": []string{"(unclosed"}},
+	}
+
+	for name, config := range tests {
+		c, err := common.NewConfigFrom(config)
+		require.NoError(t, err)
+
+		_, err = newProcessor(c)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registered_domain
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func init() {
+	processors.RegisterPlugin("registered_domain", newRegisteredDomain)
+}
+
+type processor struct {
+	config
+}
+
+func newRegisteredDomain(cfg *common.Config) (processors.Processor, error) {
+	var c config
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the registered_domain configuration")
+	}
+
+	return &processor{config: c}, nil
+}
+
+// Run splits the domain name of the field into the registered domain, the
+// subdomain and the effective top-level domain, using the embedded public
+// suffix list.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return event, nil
+		}
+		return event, errors.Wrapf(err, "failed to get domain from field '%v'", p.Field)
+	}
+
+	if err := p.split(event, v); err != nil && !p.IgnoreFailure {
+		return event, err
+	}
+	return event, nil
+}
+
+func (p *processor) split(event *beat.Event, v interface{}) error {
+	value, ok := v.(string)
+	if !ok {
+		return errors.Errorf("field '%v' is not a string (value=%v)", p.Field, v)
+	}
+
+	domain, err := domainName(value)
+	if err != nil {
+		return errors.Wrapf(err, "invalid domain in field '%v'", p.Field)
+	}
+
+	registered, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get registered domain of '%v'", domain)
+	}
+
+	fields := map[string]string{
+		p.TargetField: registered,
+	}
+	if p.TargetETLDField != "" {
+		etld, _ := publicsuffix.PublicSuffix(registered)
+		fields[p.TargetETLDField] = etld
+	}
+	if p.TargetSubdomainField != "" && domain != registered {
+		fields[p.TargetSubdomainField] = strings.TrimSuffix(domain, "."+registered)
+	}
+
+	for field, value := range fields {
+		if _, err := event.PutValue(field, value); err != nil {
+			return errors.Wrapf(err, "failed to set field '%v'", field)
+		}
+	}
+	return nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("registered_domain=[field=%v, target_field=%v, target_subdomain_field=%v, target_etld_field=%v]",
+		p.Field, p.TargetField, p.TargetSubdomainField, p.TargetETLDField)
+}
+
+// domainName returns the normalized domain name of a value, which can be a
+// domain name, a fully qualified domain name with a trailing dot, a host and
+// port as used in HTTP host headers, or a URL.
+func domainName(value string) (string, error) {
+	host := strings.TrimSpace(value)
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return "", err
+		}
+		host = u.Hostname()
+	} else if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return "", errors.New("empty domain name")
+	}
+	if net.ParseIP(host) != nil {
+		return "", errors.Errorf("'%v' is an IP address", host)
+	}
+	return host, nil
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registered_domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func newTestProcessor(t *testing.T, config common.MapStr) *processor {
+	cfg, err := common.NewConfigFrom(config)
+	require.NoError(t, err)
+
+	p, err := newRegisteredDomain(cfg)
+	require.NoError(t, err)
+	return p.(*processor)
+}
+
+func TestRun(t *testing.T) {
+	p := newTestProcessor(t, common.MapStr{
+		"field":                  "domain",
+		"target_field":           "registered_domain",
+		"target_subdomain_field": "subdomain",
+		"target_etld_field":      "top_level_domain",
+	})
+
+	var tests = []struct {
+		domain     string
+		registered string
+		subdomain  string
+		etld       string
+		error      bool
+	}{
+		{domain: "www.example.com", registered: "example.com", subdomain: "www", etld: "com"},
+		{domain: "example.com", registered: "example.com", etld: "com"},
+		{domain: "a.b.c.example.co.uk", registered: "example.co.uk", subdomain: "a.b.c", etld: "co.uk"},
+		{domain: "WWW.Example.COM.", registered: "example.com", subdomain: "www", etld: "com"},
+		{domain: "www.example.com:8080", registered: "example.com", subdomain: "www", etld: "com"},
+		{domain: "https://user@docs.elastic.co:443/guide?q=1", registered: "elastic.co", subdomain: "docs", etld: "co"},
+		{domain: "foo.bar.blogspot.com", registered: "bar.blogspot.com", subdomain: "foo", etld: "blogspot.com"},
+		{domain: "co.uk", error: true},
+		{domain: "192.168.0.1", error: true},
+		{domain: "[2001:db8::1]:53", error: true},
+		{domain: "", error: true},
+	}
+
+	for _, test := range tests {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"domain": test.domain}})
+		if test.error {
+			assert.Error(t, err, test.domain)
+			assert.Equal(t, common.MapStr{"domain": test.domain}, event.Fields, test.domain)
+			continue
+		}
+		if !assert.NoError(t, err, test.domain) {
+			continue
+		}
+
+		expected := common.MapStr{
+			"domain":            test.domain,
+			"registered_domain": test.registered,
+			"top_level_domain":  test.etld,
+		}
+		if test.subdomain != "" {
+			expected["subdomain"] = test.subdomain
+		}
+		assert.Equal(t, expected, event.Fields, test.domain)
+	}
+}
+
+func TestRunTargetFieldOnly(t *testing.T) {
+	p := newTestProcessor(t, common.MapStr{
+		"field":        "dns.question.name",
+		"target_field": "dns.question.registered_domain",
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{
+		"dns": common.MapStr{"question": common.MapStr{"name": "www.elastic.co."}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"dns": common.MapStr{"question": common.MapStr{
+			"name":              "www.elastic.co.",
+			"registered_domain": "elastic.co",
+		}},
+	}, event.Fields)
+}
+
+func TestRunIgnore(t *testing.T) {
+	config := common.MapStr{"field": "domain", "target_field": "registered_domain"}
+
+	p := newTestProcessor(t, config)
+	_, err := p.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.Error(t, err)
+	_, err = p.Run(&beat.Event{Fields: common.MapStr{"domain": 42}})
+	assert.Error(t, err)
+
+	config["ignore_missing"] = true
+	config["ignore_failure"] = true
+	p = newTestProcessor(t, config)
+	for _, fields := range []common.MapStr{{}, {"domain": 42}, {"domain": "com"}} {
+		event, err := p.Run(&beat.Event{Fields: fields.Clone()})
+		assert.NoError(t, err)
+		assert.Equal(t, fields, event.Fields)
+	}
+}
@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package javascript
+
+import (
+	"github.com/dop251/goja"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// jsEvent is the Javascript view of a beat.Event. It is passed to the
+// process function. The same object is reused for every event processed by
+// a session.
+//
+// The following methods are available to scripts:
+//
+//	Get(key)             Returns the value of the field or null.
+//	Put(key, value)      Sets a field and returns the old value (or null).
+//	Delete(key)          Removes a field, returns true on success.
+//	Rename(from, to)     Renames a field, fails if the target exists.
+//	Tag(tag)             Appends the tag to the tags field if not present.
+//	AppendTo(key, value) Appends a string value to a string or string array field.
+//	Cancel()             Drops the event.
+//	IsCancelled()        Returns true if the event was dropped.
+type jsEvent struct {
+	vm        *goja.Runtime
+	obj       *goja.Object
+	inner     *beat.Event
+	cancelled bool
+}
+
+func newJSEvent(vm *goja.Runtime) *jsEvent {
+	e := &jsEvent{vm: vm, obj: vm.NewObject()}
+	e.obj.Set("Get", e.get)
+	e.obj.Set("Put", e.put)
+	e.obj.Set("Delete", e.delete)
+	e.obj.Set("Rename", e.rename)
+	e.obj.Set("Tag", e.tag)
+	e.obj.Set("AppendTo", e.appendTo)
+	e.obj.Set("Cancel", e.cancel)
+	e.obj.Set("IsCancelled", e.isCancelled)
+	return e
+}
+
+// reset points the Javascript object to a new event.
+func (e *jsEvent) reset(b *beat.Event) {
+	e.inner = b
+	e.cancelled = false
+}
+
+func (e *jsEvent) object() *goja.Object {
+	return e.obj
+}
+
+func (e *jsEvent) throw(err error) {
+	panic(e.vm.NewGoError(err))
+}
+
+func (e *jsEvent) get(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) == 0 {
+		return e.vm.ToValue(e.inner.Fields)
+	}
+
+	v, err := e.inner.GetValue(call.Argument(0).String())
+	if err != nil {
+		return goja.Null()
+	}
+	return e.vm.ToValue(v)
+}
+
+func (e *jsEvent) put(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 2 {
+		e.throw(errors.New("Put requires two arguments (key and value)"))
+	}
+
+	key := call.Argument(0).String()
+	value := normalizeValue(call.Argument(1).Export())
+
+	old, err := e.inner.PutValue(key, value)
+	if err != nil {
+		e.throw(err)
+	}
+	return e.vm.ToValue(old)
+}
+
+func (e *jsEvent) delete(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		e.throw(errors.New("Delete requires one argument (key)"))
+	}
+
+	if err := e.inner.Delete(call.Argument(0).String()); err != nil {
+		return e.vm.ToValue(false)
+	}
+	return e.vm.ToValue(true)
+}
+
+func (e *jsEvent) rename(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 2 {
+		e.throw(errors.New("Rename requires two arguments (from and to)"))
+	}
+	from := call.Argument(0).String()
+	to := call.Argument(1).String()
+
+	if has, _ := e.inner.Fields.HasKey(to); has {
+		return e.vm.ToValue(false)
+	}
+
+	v, err := e.inner.GetValue(from)
+	if err != nil {
+		return e.vm.ToValue(false)
+	}
+
+	if _, err = e.inner.PutValue(to, v); err != nil {
+		return e.vm.ToValue(false)
+	}
+
+	if err = e.inner.Delete(from); err != nil {
+		e.inner.Delete(to)
+		return e.vm.ToValue(false)
+	}
+	return e.vm.ToValue(true)
+}
+
+func (e *jsEvent) tag(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 1 {
+		e.throw(errors.New("Tag requires one argument (tag)"))
+	}
+
+	tag := call.Argument(0).String()
+	if tags, ok := e.inner.Fields["tags"].([]string); ok {
+		for _, t := range tags {
+			if t == tag {
+				return goja.Undefined()
+			}
+		}
+	}
+
+	if err := common.AddTags(e.inner.Fields, []string{tag}); err != nil {
+		e.throw(err)
+	}
+	return goja.Undefined()
+}
+
+func (e *jsEvent) appendTo(call goja.FunctionCall) goja.Value {
+	if len(call.Arguments) != 2 {
+		e.throw(errors.New("AppendTo requires two arguments (key and value)"))
+	}
+	key := call.Argument(0).String()
+	value := call.Argument(1).String()
+
+	old, err := e.inner.GetValue(key)
+	if err != nil {
+		e.inner.PutValue(key, value)
+		return goja.Undefined()
+	}
+
+	switch v := old.(type) {
+	case string:
+		if v != value {
+			e.inner.PutValue(key, []string{v, value})
+		}
+	case []string:
+		for _, s := range v {
+			if s == value {
+				return goja.Undefined()
+			}
+		}
+		e.inner.PutValue(key, append(v, value))
+	case []interface{}:
+		for _, s := range v {
+			if s == value {
+				return goja.Undefined()
+			}
+		}
+		e.inner.PutValue(key, append(v, value))
+	default:
+		e.throw(errors.Errorf("unexpected type %T for %v found", old, key))
+	}
+	return goja.Undefined()
+}
+
+func (e *jsEvent) cancel(call goja.FunctionCall) goja.Value {
+	e.cancelled = true
+	return goja.Undefined()
+}
+
+func (e *jsEvent) isCancelled(call goja.FunctionCall) goja.Value {
+	return e.vm.ToValue(e.cancelled)
+}
+
+// normalizeValue converts the objects exported from the Javascript runtime
+// into common.MapStr so that nested keys can be accessed with dotted paths.
+func normalizeValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(common.MapStr, len(val))
+		for k, x := range val {
+			m[k] = normalizeValue(x)
+		}
+		return m
+	case []interface{}:
+		for i, x := range val {
+			val[i] = normalizeValue(x)
+		}
+		return val
+	default:
+		return v
+	}
+}
@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package javascript
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.javascript"
+
+type jsProcessor struct {
+	Config
+	sessionPool *sessionPool
+	sourceFile  string // Source file when loaded from file.
+}
+
+// New constructs a new Javascript processor.
+func New(c *common.Config) (processors.Processor, error) {
+	conf := defaultConfig()
+	if err := c.Unpack(&conf); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the javascript configuration")
+	}
+
+	return NewFromConfig(conf)
+}
+
+// NewFromConfig constructs a new Javascript processor from the given config
+// object. It loads the sources, compiles them, and validates the entry point.
+func NewFromConfig(c Config) (processors.Processor, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	var sourceFile string
+	var programs []*goja.Program
+	var err error
+	switch {
+	case c.Source != "":
+		sourceFile = "inline.js"
+		programs, err = compile([]string{sourceFile}, []string{c.Source})
+	case c.File != "":
+		sourceFile = c.File
+		programs, err = loadSources(c.File)
+	case len(c.Files) > 0:
+		sourceFile = strings.Join(c.Files, ",")
+		programs, err = loadSources(c.Files...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log := logp.NewLogger(logName)
+	if c.Tag != "" {
+		log = log.With("instance_id", c.Tag)
+	}
+
+	pool, err := newSessionPool(programs, c, log)
+	if err != nil {
+		return nil, annotateError(c.Tag, err)
+	}
+
+	return &jsProcessor{
+		Config:      c,
+		sessionPool: pool,
+		sourceFile:  sourceFile,
+	}, nil
+}
+
+// loadSources loads javascript source from files.
+func loadSources(files ...string) ([]*goja.Program, error) {
+	var names, sources []string
+	for _, f := range files {
+		path := paths.Resolve(paths.Config, f)
+		code, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read javascript file %v", path)
+		}
+		names = append(names, filepath.Base(path))
+		sources = append(sources, string(code))
+	}
+	return compile(names, sources)
+}
+
+// compile compiles each named source into a program. The programs are
+// executed in order when a new session is created.
+func compile(names, sources []string) ([]*goja.Program, error) {
+	programs := make([]*goja.Program, 0, len(sources))
+	for i, src := range sources {
+		p, err := goja.Compile(names[i], src, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile javascript %v", names[i])
+		}
+		programs = append(programs, p)
+	}
+	return programs, nil
+}
+
+func annotateError(id string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if id != "" {
+		return errors.Wrapf(err, "failed in processor.javascript with id=%v", id)
+	}
+	return errors.Wrap(err, "failed in processor.javascript")
+}
+
+// Run executes the processor on the given event. It invokes the
+// process function defined in the Javascript source.
+func (p *jsProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	s := p.sessionPool.Get()
+	defer p.sessionPool.Put(s)
+
+	out, err := s.runProcessFunc(event)
+	return out, annotateError(p.Tag, err)
+}
+
+func (p *jsProcessor) String() string {
+	return fmt.Sprintf("script=[type=javascript, id=%v, sources=%v]", p.Tag, p.sourceFile)
+}
@@ -0,0 +1,284 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package javascript
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func newTestProcessor(t *testing.T, c map[string]interface{}) processors.Processor {
+	t.Helper()
+
+	p, err := New(common.MustNewConfigFrom(c))
+	require.NoError(t, err)
+	return p
+}
+
+func testEvent() *beat.Event {
+	return &beat.Event{
+		Timestamp: time.Now(),
+		Fields: common.MapStr{
+			"message": "hello world",
+			"source":  "/var/log/messages",
+			"http": common.MapStr{
+				"status": 200,
+			},
+		},
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"no source": {},
+		"source and file": {
+			"source": "function process(evt) {}",
+			"file":   "test.js",
+		},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(common.MustNewConfigFrom(c))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMissingProcessFunction(t *testing.T) {
+	_, err := New(common.MustNewConfigFrom(map[string]interface{}{
+		"source": "function foo(evt) {}",
+	}))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "process function not found")
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	_, err := New(common.MustNewConfigFrom(map[string]interface{}{
+		"source": "function process(evt) {",
+	}))
+	assert.Error(t, err)
+}
+
+func TestEventAPI(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"source": `
+			function process(evt) {
+				evt.Put("host.name", "localhost");
+				evt.Put("nested", {"a": 1, "b": ["x", "y"]});
+				evt.Put("status_copy", evt.Get("http.status"));
+				evt.Rename("source", "log.file.path");
+				evt.Delete("message");
+				evt.Tag("js");
+				evt.Tag("js");
+				evt.AppendTo("labels", "first");
+				evt.AppendTo("labels", "second");
+				if (evt.Get("does.not.exist") !== null) {
+					throw "expected null for missing key";
+				}
+			}
+		`,
+	})
+
+	evt, err := p.Run(testEvent())
+	require.NoError(t, err)
+	require.NotNil(t, evt)
+
+	expected := common.MapStr{
+		"host": common.MapStr{"name": "localhost"},
+		"nested": common.MapStr{
+			"a": int64(1),
+			"b": []interface{}{"x", "y"},
+		},
+		"status_copy": int64(200),
+		"log": common.MapStr{
+			"file": common.MapStr{"path": "/var/log/messages"},
+		},
+		"http":   common.MapStr{"status": 200},
+		"tags":   []string{"js"},
+		"labels": []string{"first", "second"},
+	}
+	assert.Equal(t, expected, evt.Fields)
+
+	v, err := evt.GetValue("nested.a")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, v)
+}
+
+func TestRenameExistingTarget(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"source": `
+			function process(evt) {
+				if (evt.Rename("message", "source")) {
+					throw "rename should fail when the target exists";
+				}
+			}
+		`,
+	})
+
+	evt, err := p.Run(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", evt.Fields["message"])
+	assert.Equal(t, "/var/log/messages", evt.Fields["source"])
+}
+
+func TestCancel(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"source": `
+			function process(evt) {
+				if (evt.Get("http.status") === 200) {
+					evt.Cancel();
+				}
+			}
+		`,
+	})
+
+	evt, err := p.Run(testEvent())
+	assert.NoError(t, err)
+	assert.Nil(t, evt)
+}
+
+func TestRegisterParams(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"source": `
+			var field;
+			function register(params) {
+				field = params.field;
+			}
+			function process(evt) {
+				evt.Put(field, true);
+			}
+		`,
+		"params": map[string]interface{}{
+			"field": "processed",
+		},
+	})
+
+	evt, err := p.Run(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, true, evt.Fields["processed"])
+}
+
+func TestTestFunctionFailure(t *testing.T) {
+	_, err := New(common.MustNewConfigFrom(map[string]interface{}{
+		"source": `
+			function process(evt) {}
+			function test() {
+				throw "test failed";
+			}
+		`,
+	}))
+	assert.Error(t, err)
+}
+
+func TestTagOnException(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"source": `
+			function process(evt) {
+				evt.Put("before", true);
+				throw "oops";
+			}
+		`,
+	})
+
+	evt, err := p.Run(testEvent())
+	assert.Error(t, err)
+	require.NotNil(t, evt)
+	assert.Equal(t, []string{"_js_exception"}, evt.Fields["tags"])
+	assert.Equal(t, true, evt.Fields["before"])
+}
+
+func TestTimeout(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"source": `
+			function process(evt) {
+				if (evt.Get("loop")) {
+					while (true) {}
+				}
+			}
+		`,
+		"timeout": "50ms",
+	})
+
+	evt := testEvent()
+	evt.Fields["loop"] = true
+	_, err := p.Run(evt)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), timeoutError)
+	}
+
+	// The processor must still be usable.
+	out, err := p.Run(testEvent())
+	assert.NoError(t, err)
+	assert.NotNil(t, out)
+}
+
+func TestSourceFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "script")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lib := filepath.Join(dir, "lib.js")
+	main := filepath.Join(dir, "main.js")
+	require.NoError(t, ioutil.WriteFile(lib, []byte(`var suffix = "!";`), 0644))
+	require.NoError(t, ioutil.WriteFile(main, []byte(`
+		function process(evt) {
+			evt.Put("message", evt.Get("message") + suffix);
+		}
+	`), 0644))
+
+	p := newTestProcessor(t, map[string]interface{}{
+		"files": []string{lib, main},
+	})
+
+	evt, err := p.Run(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "hello world!", evt.Fields["message"])
+}
+
+func BenchmarkProcessor(b *testing.B) {
+	p, err := New(common.MustNewConfigFrom(map[string]interface{}{
+		"source": `
+			function process(evt) {
+				evt.Put("host.name", "localhost");
+				evt.Rename("source", "log.file.path");
+			}
+		`,
+	}))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.Run(testEvent()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
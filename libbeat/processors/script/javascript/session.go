@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package javascript
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+const (
+	entryPointFunction = "process"
+	registerFunction   = "register"
+	testFunction       = "test"
+
+	timeoutError = "javascript processor execution timeout"
+)
+
+// session is a Javascript runtime with the compiled programs loaded. A
+// goja.Runtime is not safe for concurrent use so each session must only be
+// used by one goroutine at a time.
+type session struct {
+	vm             *goja.Runtime
+	log            *logp.Logger
+	processFunc    goja.Callable
+	evt            *jsEvent
+	timeout        time.Duration
+	tagOnException string
+
+	// broken is set when the runtime was interrupted (or might still receive
+	// an interrupt), in which case the session must not be reused.
+	broken bool
+}
+
+func newSession(programs []*goja.Program, conf Config, test bool, log *logp.Logger) (*session, error) {
+	s := &session{
+		vm:             goja.New(),
+		log:            log,
+		timeout:        conf.Timeout,
+		tagOnException: conf.TagOnException,
+	}
+	s.evt = newJSEvent(s.vm)
+
+	for _, p := range programs {
+		if _, err := s.vm.RunProgram(p); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate that the process function is defined.
+	processFunc, ok := goja.AssertFunction(s.vm.Get(entryPointFunction))
+	if !ok {
+		return nil, errors.Errorf("%v function not found", entryPointFunction)
+	}
+	s.processFunc = processFunc
+
+	if err := s.callOptionalFunc(registerFunction, s.paramsValue(conf.Params)); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %v function", registerFunction)
+	}
+
+	if test {
+		if err := s.callOptionalFunc(testFunction); err != nil {
+			return nil, errors.Wrapf(err, "failed in %v function", testFunction)
+		}
+	}
+
+	return s, nil
+}
+
+// callOptionalFunc invokes the named global function if the script defines
+// it. It is not an error if the function does not exist.
+func (s *session) callOptionalFunc(name string, args ...goja.Value) error {
+	v := s.vm.Get(name)
+	if v == nil || goja.IsUndefined(v) {
+		return nil
+	}
+
+	fn, ok := goja.AssertFunction(v)
+	if !ok {
+		return errors.Errorf("%v is defined but it is not a function", name)
+	}
+
+	_, err := fn(goja.Undefined(), args...)
+	return err
+}
+
+func (s *session) paramsValue(params map[string]interface{}) goja.Value {
+	obj := s.vm.NewObject()
+	for k, v := range params {
+		obj.Set(k, v)
+	}
+	return obj
+}
+
+// runProcessFunc executes the process function with the given event. The
+// returned event is nil if the script cancelled (dropped) the event.
+func (s *session) runProcessFunc(in *beat.Event) (out *beat.Event, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Errorw("The javascript processor caused an unexpected "+
+				"panic while processing an event. Recovering, but please "+
+				"report this.", "panic", r)
+			s.broken = true
+			out, err = in, errors.Errorf("unexpected panic in javascript processor: %v", r)
+		}
+	}()
+
+	s.evt.reset(in)
+	defer s.evt.reset(nil)
+
+	if s.timeout > 0 {
+		timer := time.AfterFunc(s.timeout, func() {
+			s.vm.Interrupt(timeoutError)
+		})
+		defer func() {
+			if !timer.Stop() {
+				s.broken = true
+			}
+		}()
+	}
+
+	if _, err = s.processFunc(goja.Undefined(), s.evt.object()); err != nil {
+		if _, interrupted := err.(*goja.InterruptedError); interrupted {
+			s.broken = true
+		}
+		if s.tagOnException != "" {
+			common.AddTags(in.Fields, []string{s.tagOnException})
+		}
+		err = errors.Wrapf(err, "failed in %v function", entryPointFunction)
+	}
+
+	if s.evt.cancelled {
+		return nil, err
+	}
+	return in, err
+}
+
+// sessionPool holds ready to use sessions. Sessions are created on demand
+// from the compiled programs.
+type sessionPool struct {
+	New  func() *session
+	pool sync.Pool
+}
+
+// newSessionPool validates the programs by creating a first session and
+// running the optional test function. It returns a pool that creates new
+// sessions as needed.
+func newSessionPool(programs []*goja.Program, conf Config, log *logp.Logger) (*sessionPool, error) {
+	s, err := newSession(programs, conf, true, log)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &sessionPool{}
+	p.New = func() *session {
+		s, err := newSession(programs, conf, false, log)
+		if err != nil {
+			// The programs were validated when creating the first session.
+			panic(errors.Wrap(err, "failed to create new javascript session"))
+		}
+		return s
+	}
+	p.pool.Put(s)
+	return p, nil
+}
+
+func (p *sessionPool) Get() *session {
+	if s, ok := p.pool.Get().(*session); ok {
+		return s
+	}
+	return p.New()
+}
+
+func (p *sessionPool) Put(s *session) {
+	if s == nil || s.broken {
+		return
+	}
+	p.pool.Put(s)
+}
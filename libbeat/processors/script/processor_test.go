@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package script
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func TestUnsupportedLang(t *testing.T) {
+	_, err := New(common.MustNewConfigFrom(map[string]interface{}{
+		"lang":   "lua",
+		"source": "function process(evt) {}",
+	}))
+	assert.Error(t, err)
+}
+
+func TestScriptWithCondition(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"lang": "javascript",
+		"source": `
+			function process(evt) {
+				evt.Put("processed", true);
+			}
+		`,
+		"when.equals.type": "apache",
+	})
+	require.NoError(t, err)
+
+	procs, err := processors.New(processors.PluginConfig{{"script": cfg}})
+	require.NoError(t, err)
+
+	apache := procs.Run(&beat.Event{Fields: common.MapStr{"type": "apache"}})
+	assert.Equal(t, true, apache.Fields["processed"])
+
+	nginx := procs.Run(&beat.Event{Fields: common.MapStr{"type": "nginx"}})
+	assert.NotContains(t, nginx.Fields, "processed")
+}
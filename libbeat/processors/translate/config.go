@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type config struct {
+	Field            string           `config:"field" validate:"required"`
+	TargetField      string           `config:"target_field" validate:"required"`
+	Dictionary       []entry          `config:"dictionary"`
+	DictionaryPath   string           `config:"dictionary_path"`
+	DictionaryFormat dictionaryFormat `config:"dictionary_format"`
+	Match            matchType        `config:"match"`
+	Default          interface{}      `config:"default"`
+	IgnoreMissing    bool             `config:"ignore_missing"`
+	RefreshInterval  time.Duration    `config:"refresh_interval" validate:"min=0"`
+}
+
+// entry is a single key-value pair of an inline dictionary. Inline
+// dictionaries are configured as list, because keys like host names or IP
+// addresses contain dots, which would otherwise be split into nested objects.
+type entry struct {
+	Key   string      `config:"key" validate:"required"`
+	Value interface{} `config:"value" validate:"required"`
+}
+
+var defaultConfig = config{
+	RefreshInterval: 30 * time.Second,
+}
+
+func (c *config) Validate() error {
+	if len(c.Dictionary) == 0 && c.DictionaryPath == "" {
+		return errors.New("either dictionary or dictionary_path must be set")
+	}
+
+	if c.DictionaryPath != "" && c.DictionaryFormat == formatAuto {
+		format, err := formatFromPath(c.DictionaryPath)
+		if err != nil {
+			return err
+		}
+		c.DictionaryFormat = format
+	}
+	return nil
+}
+
+type dictionaryFormat uint8
+
+const (
+	formatAuto dictionaryFormat = iota
+	formatCSV
+	formatYAML
+	formatJSON
+)
+
+var formatNames = map[dictionaryFormat]string{
+	formatAuto: "auto",
+	formatCSV:  "csv",
+	formatYAML: "yaml",
+	formatJSON: "json",
+}
+
+func (f dictionaryFormat) String() string {
+	return formatNames[f]
+}
+
+func (f *dictionaryFormat) Unpack(s string) error {
+	switch strings.ToLower(s) {
+	case "", "auto":
+		*f = formatAuto
+	case "csv":
+		*f = formatCSV
+	case "yaml", "yml":
+		*f = formatYAML
+	case "json":
+		*f = formatJSON
+	default:
+		return errors.Errorf("invalid dictionary_format '%v' (valid values are: csv, yaml, json)", s)
+	}
+	return nil
+}
+
+func formatFromPath(path string) (dictionaryFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return formatCSV, nil
+	case ".yml", ".yaml":
+		return formatYAML, nil
+	case ".json":
+		return formatJSON, nil
+	default:
+		return formatAuto, errors.Errorf("can not detect format of dictionary file '%v', "+
+			"set dictionary_format to csv, yaml or json", path)
+	}
+}
+
+type matchType uint8
+
+const (
+	matchExact matchType = iota
+	matchCIDR
+)
+
+func (m matchType) String() string {
+	if m == matchCIDR {
+		return "cidr"
+	}
+	return "exact"
+}
+
+func (m *matchType) Unpack(s string) error {
+	switch strings.ToLower(s) {
+	case "", "exact":
+		*m = matchExact
+	case "cidr":
+		*m = matchCIDR
+	default:
+		return errors.Errorf("invalid match type '%v' (valid values are: exact, cidr)", s)
+	}
+	return nil
+}
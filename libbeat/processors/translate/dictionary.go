@@ -0,0 +1,291 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// dictionary maps lookup keys to the values written to the event.
+type dictionary interface {
+	lookup(key string) (interface{}, bool)
+	len() int
+}
+
+// newDictionary builds the dictionary from the inline entries and the
+// dictionary file. Entries from the file take precedence.
+func newDictionary(c *config) (dictionary, error) {
+	entries := map[string]interface{}{}
+	for _, e := range c.Dictionary {
+		v, err := normalize(e.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for key '%v'", e.Key)
+		}
+		entries[e.Key] = v
+	}
+
+	if c.DictionaryPath != "" {
+		if err := loadFile(c.DictionaryPath, c.DictionaryFormat, entries); err != nil {
+			return nil, errors.Wrapf(err, "failed to load dictionary file '%v'", c.DictionaryPath)
+		}
+	}
+
+	if c.Match == matchCIDR {
+		return newCIDRDictionary(entries)
+	}
+	return exactDictionary(entries), nil
+}
+
+func loadFile(path string, format dictionaryFormat, to map[string]interface{}) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case formatCSV:
+		return readCSV(bytes.NewReader(contents), to)
+	case formatJSON:
+		var m map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(contents))
+		dec.UseNumber()
+		if err := dec.Decode(&m); err != nil {
+			return err
+		}
+		return addEntries(m, to)
+	default:
+		var m map[string]interface{}
+		if err := yaml.Unmarshal(contents, &m); err != nil {
+			return err
+		}
+		return addEntries(m, to)
+	}
+}
+
+func addEntries(from, to map[string]interface{}) error {
+	for k, v := range from {
+		v, err := normalize(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for key '%v'", k)
+		}
+		to[k] = v
+	}
+	return nil
+}
+
+// readCSV reads a CSV file with a header row. The first column holds the
+// keys. If the file has two columns, the second column is the value.
+// Otherwise the value is an object with the remaining columns, named by the
+// header.
+func readCSV(in io.Reader, to map[string]interface{}) error {
+	r := csv.NewReader(in)
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(header) < 2 {
+		return errors.New("CSV dictionary must have at least two columns")
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(header) == 2 {
+			to[record[0]] = record[1]
+			continue
+		}
+
+		obj := common.MapStr{}
+		for i, name := range header[1:] {
+			obj[name] = record[i+1]
+		}
+		to[record[0]] = obj
+	}
+}
+
+// normalize converts nested objects as returned by the config, YAML and JSON
+// decoders to common.MapStr.
+func normalize(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(common.MapStr, len(val))
+		for k, v := range val {
+			nv, err := normalize(v)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = nv
+		}
+		return m, nil
+	case map[interface{}]interface{}:
+		m := make(common.MapStr, len(val))
+		for k, v := range val {
+			nv, err := normalize(v)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = nv
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, v := range val {
+			nv, err := normalize(v)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = nv
+		}
+		return s, nil
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i, nil
+		}
+		return val.Float64()
+	default:
+		return v, nil
+	}
+}
+
+type exactDictionary map[string]interface{}
+
+func (d exactDictionary) lookup(key string) (interface{}, bool) {
+	v, found := d[key]
+	return v, found
+}
+
+func (d exactDictionary) len() int { return len(d) }
+
+// cidrDictionary matches IP addresses against network keys. The longest
+// matching prefix wins.
+type cidrDictionary struct {
+	v4, v6 prefixTable
+	count  int
+}
+
+// prefixTable holds the networks of one address family, grouped by prefix
+// length. Lookups mask the address with every prefix length in use, starting
+// with the longest one.
+type prefixTable struct {
+	lengths []int
+	nets    map[int]map[string]interface{}
+}
+
+func newCIDRDictionary(entries map[string]interface{}) (*cidrDictionary, error) {
+	d := &cidrDictionary{count: len(entries)}
+	for key, value := range entries {
+		ip, ipNet, err := parseNetwork(key)
+		if err != nil {
+			return nil, err
+		}
+
+		ones, _ := ipNet.Mask.Size()
+		if v4 := ip.To4(); v4 != nil {
+			d.v4.add(ones, v4.Mask(ipNet.Mask), value)
+		} else {
+			d.v6.add(ones, ip.Mask(ipNet.Mask), value)
+		}
+	}
+	return d, nil
+}
+
+// parseNetwork parses a key in CIDR notation. Plain IP addresses are
+// accepted as single host networks.
+func parseNetwork(key string) (net.IP, *net.IPNet, error) {
+	key = strings.TrimSpace(key)
+	if !strings.Contains(key, "/") {
+		ip := net.ParseIP(key)
+		if ip == nil {
+			return nil, nil, errors.Errorf("invalid IP address or network '%v'", key)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			bits = 8 * net.IPv4len
+		}
+		return ip, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	ip, ipNet, err := net.ParseCIDR(key)
+	if err != nil {
+		return nil, nil, errors.Errorf("invalid IP address or network '%v'", key)
+	}
+	return ip, ipNet, nil
+}
+
+func (d *cidrDictionary) lookup(key string) (interface{}, bool) {
+	ip := net.ParseIP(strings.TrimSpace(key))
+	if ip == nil {
+		return nil, false
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return d.v4.lookup(v4)
+	}
+	return d.v6.lookup(ip)
+}
+
+func (d *cidrDictionary) len() int { return d.count }
+
+func (t *prefixTable) add(ones int, network net.IP, value interface{}) {
+	if t.nets == nil {
+		t.nets = map[int]map[string]interface{}{}
+	}
+
+	nets, exists := t.nets[ones]
+	if !exists {
+		nets = map[string]interface{}{}
+		t.nets[ones] = nets
+		t.lengths = append(t.lengths, ones)
+		sort.Sort(sort.Reverse(sort.IntSlice(t.lengths)))
+	}
+	nets[string(network)] = value
+}
+
+func (t *prefixTable) lookup(ip net.IP) (interface{}, bool) {
+	bits := 8 * len(ip)
+	for _, ones := range t.lengths {
+		if v, found := t.nets[ones][string(ip.Mask(net.CIDRMask(ones, bits)))]; found {
+			return v, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,203 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.translate"
+
+func init() {
+	processors.RegisterPlugin("translate", newTranslate)
+}
+
+type translate struct {
+	config
+	log *logp.Logger
+
+	mu   sync.RWMutex
+	dict dictionary
+
+	// State of the dictionary file, used to detect changes.
+	reloadMu  sync.Mutex
+	nextCheck atomic.Int64
+	modTime   time.Time
+	size      int64
+}
+
+func newTranslate(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the translate configuration")
+	}
+
+	p := &translate{config: c, log: logp.NewLogger(logName)}
+	if c.Default != nil {
+		def, err := normalize(c.Default)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid default value of the translate processor")
+		}
+		p.Default = def
+	}
+
+	if c.DictionaryPath != "" {
+		info, err := os.Stat(c.DictionaryPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the translate dictionary")
+		}
+		p.modTime, p.size = info.ModTime(), info.Size()
+		p.nextCheck.Store(time.Now().Add(c.RefreshInterval).UnixNano())
+	}
+
+	dict, err := newDictionary(&p.config)
+	if err != nil {
+		return nil, err
+	}
+	p.dict = dict
+
+	return p, nil
+}
+
+func (p *translate) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return event, nil
+		}
+		return event, errors.Wrapf(err, "failed to translate field '%v'", p.Field)
+	}
+
+	key, err := lookupKey(v)
+	if err != nil {
+		return event, errors.Wrapf(err, "failed to translate field '%v'", p.Field)
+	}
+
+	value, found := p.dictionary().lookup(key)
+	if !found {
+		if p.Default == nil {
+			return event, nil
+		}
+		value = p.Default
+	}
+
+	// Objects and lists are shared by all events, so later processors must
+	// not be able to modify them.
+	if _, err := event.PutValue(p.TargetField, deepCopy(value)); err != nil {
+		return event, errors.Wrapf(err, "failed to set field '%v'", p.TargetField)
+	}
+	return event, nil
+}
+
+func (p *translate) String() string {
+	return fmt.Sprintf("translate=[field=%v, target_field=%v, dictionary_path=%v, match=%v, entries=%v]",
+		p.Field, p.TargetField, p.DictionaryPath, p.Match, p.dictionary().len())
+}
+
+// deepCopy copies the objects and lists of a dictionary value.
+func deepCopy(v interface{}) interface{} {
+	switch val := v.(type) {
+	case common.MapStr:
+		m := make(common.MapStr, len(val))
+		for k, v := range val {
+			m[k] = deepCopy(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, v := range val {
+			s[i] = deepCopy(v)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// lookupKey converts the field value to the dictionary key. Numbers and
+// booleans are looked up by their string representation, so numeric codes can
+// be translated.
+func lookupKey(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(val), nil
+	default:
+		return "", errors.Errorf("unsupported value type %T", v)
+	}
+}
+
+func (p *translate) dictionary() dictionary {
+	if p.DictionaryPath != "" && p.RefreshInterval > 0 {
+		p.checkReload()
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.dict
+}
+
+// checkReload reloads the dictionary file if it has been changed since the
+// last check. The file is checked at most once per refresh interval. If the
+// file can not be loaded, the current dictionary is kept.
+func (p *translate) checkReload() {
+	now := time.Now()
+	if now.UnixNano() < p.nextCheck.Load() {
+		return
+	}
+
+	p.reloadMu.Lock()
+	defer p.reloadMu.Unlock()
+	if now.UnixNano() < p.nextCheck.Load() {
+		return
+	}
+	p.nextCheck.Store(now.Add(p.RefreshInterval).UnixNano())
+
+	info, err := os.Stat(p.DictionaryPath)
+	if err != nil {
+		p.log.Warnf("Failed to check the dictionary file for changes: %v", err)
+		return
+	}
+	if info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return
+	}
+
+	dict, err := newDictionary(&p.config)
+	if err != nil {
+		p.log.Errorf("Failed to reload the dictionary, keeping the current one: %v", err)
+		return
+	}
+	p.modTime, p.size = info.ModTime(), info.Size()
+
+	p.mu.Lock()
+	p.dict = dict
+	p.mu.Unlock()
+	p.log.Infof("Reloaded dictionary file %v with %v entries", p.DictionaryPath, dict.len())
+}
@@ -0,0 +1,325 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func newTestTranslate(t *testing.T, config common.MapStr) processors.Processor {
+	cfg, err := common.NewConfigFrom(config)
+	require.NoError(t, err)
+
+	p, err := newTranslate(cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "translate")
+	require.NoError(t, err)
+	return dir
+}
+
+func TestTranslateInline(t *testing.T) {
+	p := newTestTranslate(t, common.MapStr{
+		"field":        "error.code",
+		"target_field": "error.message",
+		"dictionary": []common.MapStr{
+			{"key": "404", "value": "not found"},
+			{"key": "web-01.example.com", "value": common.MapStr{"name": "web", "tier": 1}},
+		},
+		"default": "unknown",
+	})
+
+	var tests = []struct {
+		description string
+		input       common.MapStr
+		output      common.MapStr
+	}{
+		{
+			description: "string value",
+			input:       common.MapStr{"error": common.MapStr{"code": "404"}},
+			output:      common.MapStr{"error": common.MapStr{"code": "404", "message": "not found"}},
+		},
+		{
+			description: "numeric value",
+			input:       common.MapStr{"error": common.MapStr{"code": 404}},
+			output:      common.MapStr{"error": common.MapStr{"code": 404, "message": "not found"}},
+		},
+		{
+			description: "key with dots and object value",
+			input:       common.MapStr{"error": common.MapStr{"code": "web-01.example.com"}},
+			output: common.MapStr{"error": common.MapStr{
+				"code":    "web-01.example.com",
+				"message": common.MapStr{"name": "web", "tier": uint64(1)},
+			}},
+		},
+		{
+			description: "default value",
+			input:       common.MapStr{"error": common.MapStr{"code": "500"}},
+			output:      common.MapStr{"error": common.MapStr{"code": "500", "message": "unknown"}},
+		},
+	}
+
+	for _, test := range tests {
+		event, err := p.Run(&beat.Event{Fields: test.input})
+		if assert.NoError(t, err, test.description) {
+			assert.Equal(t, test.output, event.Fields, test.description)
+		}
+	}
+}
+
+func TestTranslateObjectValueIsCopied(t *testing.T) {
+	p := newTestTranslate(t, common.MapStr{
+		"field":        "host",
+		"target_field": "owner",
+		"dictionary":   []common.MapStr{{"key": "a", "value": common.MapStr{"team": "x"}}},
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"host": "a"}})
+	require.NoError(t, err)
+	event.Fields.Put("owner.team", "modified")
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"host": "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{"team": "x"}, event.Fields["owner"])
+}
+
+func TestTranslateDefaultObjectIsCopied(t *testing.T) {
+	p := newTestTranslate(t, common.MapStr{
+		"field":        "host",
+		"target_field": "owner",
+		"dictionary":   []common.MapStr{{"key": "a", "value": "x"}},
+		"default": common.MapStr{
+			"team": common.MapStr{"name": "unknown"},
+			"tags": []string{"unassigned"},
+		},
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"host": "b"}})
+	require.NoError(t, err)
+	event.Fields.Put("owner.team.name", "modified")
+	event.Fields.Delete("owner.team")
+	event.Fields["owner"].(common.MapStr)["tags"].([]interface{})[0] = "modified"
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"host": "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"team": common.MapStr{"name": "unknown"},
+		"tags": []interface{}{"unassigned"},
+	}, event.Fields["owner"])
+}
+
+func TestTranslateListValueIsCopied(t *testing.T) {
+	p := newTestTranslate(t, common.MapStr{
+		"field":        "host",
+		"target_field": "owner",
+		"dictionary": []common.MapStr{
+			{"key": "a", "value": []common.MapStr{{"team": "x"}}},
+		},
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"host": "a"}})
+	require.NoError(t, err)
+	owners := event.Fields["owner"].([]interface{})
+	owners[0].(common.MapStr)["team"] = "modified"
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"host": "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{common.MapStr{"team": "x"}}, event.Fields["owner"])
+}
+
+func TestTranslateMissingField(t *testing.T) {
+	config := common.MapStr{
+		"field":        "host",
+		"target_field": "owner",
+		"dictionary":   []common.MapStr{{"key": "a", "value": "x"}},
+	}
+
+	_, err := newTestTranslate(t, config).Run(&beat.Event{Fields: common.MapStr{}})
+	assert.Error(t, err)
+
+	config["ignore_missing"] = true
+	event, err := newTestTranslate(t, config).Run(&beat.Event{Fields: common.MapStr{}})
+	assert.NoError(t, err)
+	assert.Equal(t, common.MapStr{}, event.Fields)
+}
+
+func TestTranslateNoMatch(t *testing.T) {
+	p := newTestTranslate(t, common.MapStr{
+		"field":        "host",
+		"target_field": "owner",
+		"dictionary":   []common.MapStr{{"key": "a", "value": "x"}},
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"host": "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, common.MapStr{"host": "b"}, event.Fields)
+}
+
+func TestTranslateFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	var tests = []struct {
+		name     string
+		contents string
+		expected interface{}
+	}{
+		{
+			name:     "hosts.csv",
+			contents: "host,team\n# comment\nweb-01,frontend\ndb-01,storage\n",
+			expected: "storage",
+		},
+		{
+			name:     "owners.csv",
+			contents: "host,team,tier\nweb-01,frontend,2\ndb-01, storage,1\n",
+			expected: common.MapStr{"team": "storage", "tier": "1"},
+		},
+		{
+			name:     "hosts.yml",
+			contents: "web-01: frontend\ndb-01:\n  team: storage\n  tags: [a, b]\n",
+			expected: common.MapStr{"team": "storage", "tags": []interface{}{"a", "b"}},
+		},
+		{
+			name:     "hosts.json",
+			contents: `{"web-01": "frontend", "db-01": {"team": "storage", "tier": 1, "weight": 0.5}}`,
+			expected: common.MapStr{"team": "storage", "tier": int64(1), "weight": 0.5},
+		},
+	}
+
+	for _, test := range tests {
+		p := newTestTranslate(t, common.MapStr{
+			"field":           "host",
+			"target_field":    "owner",
+			"dictionary_path": writeFile(t, dir, test.name, test.contents),
+		})
+
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"host": "db-01"}})
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.expected, event.Fields["owner"], test.name)
+		}
+	}
+}
+
+func TestTranslateCIDR(t *testing.T) {
+	p := newTestTranslate(t, common.MapStr{
+		"field":        "source.ip",
+		"target_field": "source.network",
+		"match":        "cidr",
+		"dictionary": []common.MapStr{
+			{"key": "10.0.0.0/8", "value": "internal"},
+			{"key": "10.10.0.0/16", "value": "datacenter"},
+			{"key": "10.10.1.1", "value": "gateway"},
+			{"key": "2001:db8::/32", "value": "documentation"},
+		},
+	})
+
+	var tests = map[string]interface{}{
+		"10.1.2.3":         "internal",
+		"10.10.2.3":        "datacenter",
+		"10.10.1.1":        "gateway",
+		"2001:db8::1":      "documentation",
+		"::ffff:10.10.1.1": "gateway",
+		"192.168.0.1":      nil,
+		"not an ip":        nil,
+	}
+
+	for ip, expected := range tests {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"source": common.MapStr{"ip": ip}}})
+		require.NoError(t, err, ip)
+
+		network, _ := event.GetValue("source.network")
+		assert.Equal(t, expected, network, ip)
+	}
+}
+
+func TestTranslateReload(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := writeFile(t, dir, "hosts.csv", "host,team\na,x\n")
+	p := newTestTranslate(t, common.MapStr{
+		"field":            "host",
+		"target_field":     "team",
+		"dictionary_path":  path,
+		"refresh_interval": time.Nanosecond,
+	})
+
+	lookup := func() interface{} {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"host": "a"}})
+		require.NoError(t, err)
+		return event.Fields["team"]
+	}
+	assert.Equal(t, "x", lookup())
+
+	writeFile(t, dir, "hosts.csv", "host,team\na,changed\n")
+	assert.Equal(t, "changed", lookup())
+
+	// Invalid files are not loaded, the last dictionary is kept.
+	writeFile(t, dir, "hosts.csv", "host\na\n")
+	assert.Equal(t, "changed", lookup())
+}
+
+func TestTranslateConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"no dictionary": {"field": "a", "target_field": "b"},
+		"no field": {
+			"target_field": "b",
+			"dictionary":   []common.MapStr{{"key": "a", "value": "x"}},
+		},
+		"invalid match": {
+			"field": "a", "target_field": "b", "match": "prefix",
+			"dictionary": []common.MapStr{{"key": "a", "value": "x"}},
+		},
+		"invalid network": {
+			"field": "a", "target_field": "b", "match": "cidr",
+			"dictionary": []common.MapStr{{"key": "10.0.0.0/33", "value": "x"}},
+		},
+		"unknown file format": {
+			"field": "a", "target_field": "b", "dictionary_path": "hosts.txt",
+		},
+		"missing file": {
+			"field": "a", "target_field": "b", "dictionary_path": "does-not-exist.csv",
+		},
+	} {
+		cfg, err := common.NewConfigFrom(config)
+		require.NoError(t, err)
+
+		_, err = newTranslate(cfg)
+		assert.Error(t, err, name)
+	}
+}
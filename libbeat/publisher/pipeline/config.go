@@ -23,6 +23,7 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/conditions"
 	"github.com/elastic/beats/libbeat/processors"
 )
 
@@ -34,6 +35,42 @@ type Config struct {
 
 	// Event queue
 	Queue common.ConfigNamespace `config:"queue"`
+
+	// Multiple named outputs, used instead of the single output.
+	Outputs []OutputConfig `config:"outputs"`
+}
+
+// OutputConfig configures one of multiple outputs. Each output has its own
+// queue, which is fed with all events matching the optional condition.
+type OutputConfig struct {
+	Name   string                 `config:"name" validate:"required"`
+	Output common.ConfigNamespace `config:"output"`
+	Queue  common.ConfigNamespace `config:"queue"`
+	When   *conditions.Config     `config:"when"`
+
+	// Blocking makes the pipeline wait for the output its queue to accept
+	// events. If not set, events are dropped if the output queue is full.
+	Blocking bool `config:"blocking"`
+}
+
+// Validate checks the configured outputs have unique names.
+func (c *Config) Validate() error {
+	names := map[string]bool{}
+	for _, out := range c.Outputs {
+		if names[out.Name] {
+			return fmt.Errorf("output name '%v' is not unique", out.Name)
+		}
+		names[out.Name] = true
+	}
+	return nil
+}
+
+// Validate checks the output type is configured.
+func (c *OutputConfig) Validate() error {
+	if !c.Output.IsSet() {
+		return fmt.Errorf("no output configured for '%v'", c.Name)
+	}
+	return nil
 }
 
 // validateClientConfig checks a ClientConfig can be used with (*Pipeline).ConnectWith.
@@ -98,7 +98,15 @@ func Load(
 		return nil, err
 	}
 
-	out, err := loadOutput(beatInfo, monitors, outcfg)
+	var out outputs.Group
+	if len(config.Outputs) > 0 {
+		if outcfg.IsSet() {
+			return nil, errors.New("output and outputs can not be configured at the same time")
+		}
+		out, err = loadRouter(beatInfo, monitors, config.Outputs)
+	} else {
+		out, err = loadOutput(beatInfo, monitors, outcfg)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,313 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/conditions"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue"
+)
+
+// router is the output client of a pipeline with multiple outputs. It
+// forwards the events of each batch to the queues of all outputs with a
+// matching condition. The batch is ACKed once all outputs are done with their
+// share of events.
+type router struct {
+	logger   *logp.Logger
+	branches []*branch
+}
+
+// branch is one named output of the router with its own queue, retryer and
+// output workers.
+type branch struct {
+	name      string
+	condition conditions.Condition
+	blocking  bool
+
+	queue    queue.Queue
+	producer queue.Producer
+	output   *outputController
+
+	dropped *monitoring.Uint
+
+	// batches with events published to a blocking branch, waiting for ACK
+	mutex   sync.Mutex
+	pending []*branchBatch
+}
+
+// branchBatch tracks the events of a routed batch published to one branch.
+type branchBatch struct {
+	batch     *routedBatch
+	published int
+	acked     int
+	sealed    bool // all events of the batch have been published
+}
+
+// routedBatch is a batch from the pipeline queue, waiting for all branches
+// to finish.
+type routedBatch struct {
+	batch   publisher.Batch
+	pending atomic.Int
+}
+
+type nilEventer struct{}
+
+func (nilEventer) OnACK(int) {}
+
+func loadRouter(
+	beatInfo beat.Info,
+	monitors Monitors,
+	configs []OutputConfig,
+) (outputs.Group, error) {
+	log := monitors.Logger
+	if log == nil {
+		log = logp.L()
+	}
+
+	if publishDisabled {
+		return outputs.Group{}, nil
+	}
+
+	var metrics, telemetry *monitoring.Registry
+	if monitors.Metrics != nil {
+		metrics = monitors.Metrics.NewRegistry("outputs")
+	}
+	if monitors.Telemetry != nil {
+		telemetry = monitors.Telemetry.NewRegistry("outputs")
+	}
+
+	r := &router{logger: log}
+	for _, config := range configs {
+		b, err := newBranch(beatInfo, log, metrics, config)
+		if err != nil {
+			r.Close()
+			return outputs.Fail(fmt.Errorf("error initializing output '%v': %v", config.Name, err))
+		}
+		r.branches = append(r.branches, b)
+
+		if telemetry != nil {
+			monitoring.NewString(telemetry, config.Name).Set(config.Output.Name())
+		}
+	}
+
+	return outputs.Success(-1, 0, r)
+}
+
+func newBranch(
+	beatInfo beat.Info,
+	log *logp.Logger,
+	metrics *monitoring.Registry,
+	config OutputConfig,
+) (*branch, error) {
+	b := &branch{
+		name:     config.Name,
+		blocking: config.Blocking,
+	}
+
+	if config.When != nil {
+		cond, err := conditions.NewCondition(config.When)
+		if err != nil {
+			return nil, err
+		}
+		b.condition = cond
+	}
+
+	var stats outputs.Observer
+	if metrics != nil {
+		reg := metrics.NewRegistry(config.Name)
+		monitoring.NewString(reg, "type").Set(config.Output.Name())
+		stats = outputs.NewStats(reg)
+		b.dropped = monitoring.NewUint(reg, "queue.dropped")
+	}
+
+	out, err := outputs.Load(beatInfo, stats, config.Output.Name(), config.Output.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	queueBuilder, err := createQueueBuilder(config.Queue, Monitors{Logger: log})
+	if err != nil {
+		closeClients(out.Clients)
+		return nil, err
+	}
+	b.queue, err = queueBuilder(nilEventer{})
+	if err != nil {
+		closeClients(out.Clients)
+		return nil, err
+	}
+
+	producerCfg := queue.ProducerConfig{}
+	if b.blocking {
+		producerCfg.ACK = b.onACK
+	}
+	b.producer = b.queue.Producer(producerCfg)
+	b.output = newOutputController(log.Named(config.Name), nilObserver, b.queue)
+	b.output.Set(out)
+	return b, nil
+}
+
+func closeClients(clients []outputs.Client) {
+	for _, client := range clients {
+		client.Close()
+	}
+}
+
+func (r *router) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+
+	rb := &routedBatch{batch: batch}
+	rb.pending.Store(len(r.branches))
+	for _, b := range r.branches {
+		b.publish(rb, events)
+	}
+	return nil
+}
+
+func (r *router) Close() error {
+	for _, b := range r.branches {
+		b.close()
+	}
+	return nil
+}
+
+func (r *router) String() string {
+	names := make([]string, len(r.branches))
+	for i, b := range r.branches {
+		names[i] = b.name
+	}
+	return fmt.Sprintf("router%v", names)
+}
+
+// publish forwards the events matching the branch condition to the branch
+// queue. A blocking branch finishes its share of the batch once the output
+// has ACKed the events. A non-blocking branch only hands the events over to
+// its queue, dropping them if the queue is full, so a slow output can not
+// stall the other outputs.
+func (b *branch) publish(rb *routedBatch, events []publisher.Event) {
+	if !b.blocking {
+		for i := range events {
+			if !b.matches(&events[i]) {
+				continue
+			}
+			if !b.producer.TryPublish(events[i]) && b.dropped != nil {
+				b.dropped.Inc()
+			}
+		}
+		rb.done()
+		return
+	}
+
+	var bb *branchBatch
+	for i := range events {
+		if !b.matches(&events[i]) {
+			continue
+		}
+
+		b.mutex.Lock()
+		if bb == nil {
+			bb = &branchBatch{batch: rb}
+			b.pending = append(b.pending, bb)
+		}
+		bb.published++
+		b.mutex.Unlock()
+
+		if !b.producer.Publish(events[i]) {
+			// queue has been closed
+			b.mutex.Lock()
+			bb.published--
+			b.mutex.Unlock()
+		}
+	}
+
+	if bb == nil {
+		rb.done()
+		return
+	}
+
+	b.mutex.Lock()
+	bb.sealed = true
+	b.collect()
+	b.mutex.Unlock()
+}
+
+func (b *branch) matches(event *publisher.Event) bool {
+	return b.condition == nil || b.condition.Check(&event.Content)
+}
+
+// onACK is called by the queue of a blocking branch with the number of events
+// ACKed by the output, in publishing order.
+func (b *branch) onACK(n int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for n > 0 && len(b.pending) > 0 {
+		bb := b.pending[0]
+		count := bb.published - bb.acked
+		if count > n {
+			count = n
+		}
+		if count == 0 {
+			break
+		}
+		bb.acked += count
+		n -= count
+		b.collect()
+	}
+}
+
+// collect removes the completed batches from the head of the pending list.
+// The mutex must be held.
+func (b *branch) collect() {
+	for len(b.pending) > 0 {
+		bb := b.pending[0]
+		if !bb.sealed || bb.acked < bb.published {
+			return
+		}
+		b.pending[0] = nil
+		b.pending = b.pending[1:]
+		bb.batch.done()
+	}
+}
+
+func (b *branch) close() {
+	if b.producer != nil {
+		b.producer.Cancel()
+	}
+	if b.output != nil {
+		b.output.Close()
+	}
+	if b.queue != nil {
+		if err := b.queue.Close(); err != nil {
+			logp.Err("Failed to close queue of output '%v': %v", b.name, err)
+		}
+	}
+}
+
+func (rb *routedBatch) done() {
+	if rb.pending.Dec() == 0 {
+		rb.batch.ACK()
+	}
+}
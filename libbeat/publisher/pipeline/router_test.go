@@ -0,0 +1,300 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/publisher"
+	_ "github.com/elastic/beats/libbeat/publisher/queue/memqueue"
+)
+
+// routerTestClient collects the published events. If hold is set, batches
+// are only ACKed once release is called.
+type routerTestClient struct {
+	mutex    sync.Mutex
+	events   []beat.Event
+	hold     bool
+	held     []publisher.Batch
+	released bool
+}
+
+var routerTestClients = struct {
+	sync.Mutex
+	clients map[string]*routerTestClient
+}{clients: map[string]*routerTestClient{}}
+
+func init() {
+	outputs.RegisterType("routertest", func(
+		_ beat.Info,
+		_ outputs.Observer,
+		cfg *common.Config,
+	) (outputs.Group, error) {
+		config := struct {
+			ID   string `config:"id"`
+			Hold bool   `config:"hold"`
+		}{}
+		if err := cfg.Unpack(&config); err != nil {
+			return outputs.Fail(err)
+		}
+
+		client := &routerTestClient{hold: config.Hold}
+		routerTestClients.Lock()
+		routerTestClients.clients[config.ID] = client
+		routerTestClients.Unlock()
+		return outputs.Success(-1, 0, client)
+	})
+}
+
+func getRouterTestClient(id string) *routerTestClient {
+	routerTestClients.Lock()
+	defer routerTestClients.Unlock()
+	return routerTestClients.clients[id]
+}
+
+func (c *routerTestClient) Publish(batch publisher.Batch) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, event := range batch.Events() {
+		c.events = append(c.events, event.Content)
+	}
+	if c.hold && !c.released {
+		c.held = append(c.held, batch)
+		return nil
+	}
+	batch.ACK()
+	return nil
+}
+
+func (c *routerTestClient) release() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.released = true
+	for _, batch := range c.held {
+		batch.ACK()
+	}
+	c.held = nil
+}
+
+func (c *routerTestClient) count() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.events)
+}
+
+func (c *routerTestClient) Close() error   { return nil }
+func (c *routerTestClient) String() string { return "routertest" }
+
+func loadRouterPipeline(t *testing.T, metrics *monitoring.Registry, outputs ...map[string]interface{}) *Pipeline {
+	var config Config
+	err := common.MustNewConfigFrom(map[string]interface{}{
+		"queue.mem.flush.min_events": 0,
+		"outputs":                    outputs,
+	}).Unpack(&config)
+	require.NoError(t, err)
+
+	p, err := Load(beat.Info{}, Monitors{Metrics: metrics}, config, common.ConfigNamespace{})
+	require.NoError(t, err)
+	return p
+}
+
+type ackCounter struct {
+	mutex sync.Mutex
+	acked int
+}
+
+func (c *ackCounter) add(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.acked += n
+}
+
+func (c *ackCounter) get() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.acked
+}
+
+func publishRouterEvents(t *testing.T, p *Pipeline, acks *ackCounter, types ...string) {
+	client, err := p.ConnectWith(beat.ClientConfig{ACKCount: acks.add})
+	require.NoError(t, err)
+	for _, typ := range types {
+		client.Publish(beat.Event{
+			Timestamp: time.Now(),
+			Fields:    common.MapStr{"type": typ},
+		})
+	}
+}
+
+func waitFor(t *testing.T, msg string, fn func() bool) {
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		if fn() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal(msg)
+}
+
+func TestRouterConditions(t *testing.T) {
+	p := loadRouterPipeline(t, nil,
+		map[string]interface{}{"name": "all", "output.routertest.id": "cond-all", "blocking": true},
+		map[string]interface{}{"name": "a", "output.routertest.id": "cond-a", "blocking": true, "when.equals.type": "a"},
+	)
+	defer p.Close()
+
+	acks := &ackCounter{}
+	publishRouterEvents(t, p, acks, "a", "b", "a")
+
+	waitFor(t, "events not ACKed", func() bool { return acks.get() == 3 })
+
+	all, a := getRouterTestClient("cond-all"), getRouterTestClient("cond-a")
+	assert.Equal(t, 3, all.count())
+	assert.Equal(t, 2, a.count())
+	for _, event := range a.events {
+		assert.Equal(t, "a", event.Fields["type"])
+	}
+}
+
+func TestRouterBlockingOutputWaitsForACK(t *testing.T) {
+	p := loadRouterPipeline(t, nil,
+		map[string]interface{}{"name": "fast", "output.routertest.id": "blocking-fast", "blocking": true},
+		map[string]interface{}{"name": "slow", "output.routertest.id": "blocking-slow", "blocking": true, "output.routertest.hold": true},
+	)
+	defer p.Close()
+
+	acks := &ackCounter{}
+	publishRouterEvents(t, p, acks, "a", "b", "c")
+
+	fast, slow := getRouterTestClient("blocking-fast"), getRouterTestClient("blocking-slow")
+	waitFor(t, "events not published", func() bool { return fast.count() == 3 && slow.count() == 3 })
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, acks.get())
+
+	slow.release()
+	waitFor(t, "events not ACKed", func() bool { return acks.get() == 3 })
+}
+
+func TestRouterNonBlockingOutputDoesNotStall(t *testing.T) {
+	metrics := monitoring.NewRegistry()
+	p := loadRouterPipeline(t, metrics,
+		map[string]interface{}{"name": "fast", "output.routertest.id": "nonblocking-fast", "blocking": true},
+		map[string]interface{}{
+			"name":                       "slow",
+			"output.routertest.id":       "nonblocking-slow",
+			"output.routertest.hold":     true,
+			"queue.mem.events":           32,
+			"queue.mem.flush.min_events": 0,
+		},
+	)
+	defer p.Close()
+
+	types := make([]string, 200)
+	for i := range types {
+		types[i] = "a"
+	}
+
+	acks := &ackCounter{}
+	publishRouterEvents(t, p, acks, types...)
+
+	fast := getRouterTestClient("nonblocking-fast")
+	waitFor(t, "events not ACKed", func() bool { return acks.get() == len(types) })
+	assert.Equal(t, len(types), fast.count())
+
+	slow := getRouterTestClient("nonblocking-slow")
+	assert.True(t, slow.count() < len(types))
+
+	dropped := metrics.Get("outputs.slow.queue.dropped").(*monitoring.Uint).Get()
+	assert.True(t, dropped > 0)
+}
+
+func TestRouterConfig(t *testing.T) {
+	tests := map[string]struct {
+		outputs []map[string]interface{}
+		err     bool
+	}{
+		"valid": {
+			outputs: []map[string]interface{}{
+				{"name": "a", "output.console.pretty": true},
+				{"name": "b", "output.console.pretty": false},
+			},
+		},
+		"duplicate names": {
+			outputs: []map[string]interface{}{
+				{"name": "a", "output.console.pretty": true},
+				{"name": "a", "output.console.pretty": false},
+			},
+			err: true,
+		},
+		"missing name": {
+			outputs: []map[string]interface{}{
+				{"output.console.pretty": true},
+			},
+			err: true,
+		},
+		"missing output": {
+			outputs: []map[string]interface{}{
+				{"name": "a"},
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var config Config
+			err := common.MustNewConfigFrom(map[string]interface{}{
+				"outputs": test.outputs,
+			}).Unpack(&config)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRouterOutputAndOutputsExclusive(t *testing.T) {
+	var config Config
+	err := common.MustNewConfigFrom(map[string]interface{}{
+		"outputs": []map[string]interface{}{
+			{"name": "a", "output.routertest.id": "exclusive"},
+		},
+	}).Unpack(&config)
+	require.NoError(t, err)
+
+	var output common.ConfigNamespace
+	err = common.MustNewConfigFrom(map[string]interface{}{"routertest.id": "exclusive"}).Unpack(&output)
+	require.NoError(t, err)
+
+	_, err = Load(beat.Info{}, Monitors{}, config, output)
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ package spool
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -34,13 +35,20 @@ import (
 )
 
 type encoder struct {
-	buf    bytes.Buffer
-	folder *gotype.Iterator
-	codec  codecID
+	buf       bytes.Buffer
+	folder    *gotype.Iterator
+	codec     codecID
+	transform *recordTransform
+
+	// events added to the current block, each prefixed with its size
+	block       []byte
+	blockEvents uint
+	record      []byte
 }
 
 type decoder struct {
-	buf []byte
+	buf       []byte
+	transform *recordTransform
 
 	json     *json.Parser
 	cborl    *cborl.Parser
@@ -68,7 +76,7 @@ const (
 	flagGuaranteed uint8 = 1 << 0
 )
 
-func newEncoder(codec codecID) (*encoder, error) {
+func newEncoder(codec codecID, transform *recordTransform) (*encoder, error) {
 	switch codec {
 	case codecJSON, codecCBORL, codecUBJSON:
 		break
@@ -76,7 +84,7 @@ func newEncoder(codec codecID) (*encoder, error) {
 		return nil, fmt.Errorf("unknown codec type '%v'", codec)
 	}
 
-	e := &encoder{codec: codec}
+	e := &encoder{codec: codec, transform: transform}
 	e.reset()
 	return e, nil
 }
@@ -104,7 +112,63 @@ func (e *encoder) reset() {
 	e.folder = folder
 }
 
+// encode serializes a single event into a record. The returned buffer is only
+// valid until the next call to encode.
 func (e *encoder) encode(event *publisher.Event) ([]byte, error) {
+	if err := e.fold(event); err != nil {
+		return nil, err
+	}
+
+	if e.transform == nil {
+		return e.buf.Bytes(), nil
+	}
+	return e.transform.encode(e.buf.Bytes())
+}
+
+// addToBlock serializes an event and adds it to the current block. The size of
+// the block in bytes is returned.
+func (e *encoder) addToBlock(event *publisher.Event) (int, error) {
+	if err := e.fold(event); err != nil {
+		return 0, err
+	}
+
+	contents := e.buf.Bytes()[1:]
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64(len(contents)))
+	e.block = append(append(e.block, tmp[:n]...), contents...)
+	e.blockEvents++
+	return len(e.block), nil
+}
+
+// closeBlock returns the record holding all events added to the current block,
+// and the number of events in the block. The transformations are applied to
+// the block as a whole. The block is only reset if the record has been
+// created. No record is returned if the block is empty.
+func (e *encoder) closeBlock() ([]byte, uint, error) {
+	if e.blockEvents == 0 {
+		return nil, 0, nil
+	}
+
+	var hdr [1 + binary.MaxVarintLen64]byte
+	hdr[0] = byte(e.codec) | recordBlock
+	n := 1 + binary.PutUvarint(hdr[1:], uint64(e.blockEvents))
+	e.record = append(append(e.record[:0], hdr[:n]...), e.block...)
+
+	record := e.record
+	if e.transform != nil {
+		var err error
+		if record, err = e.transform.encode(record); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	count := e.blockEvents
+	e.block = e.block[:0]
+	e.blockEvents = 0
+	return record, count, nil
+}
+
+func (e *encoder) fold(event *publisher.Event) error {
 	e.buf.Reset()
 	e.buf.WriteByte(byte(e.codec))
 
@@ -121,14 +185,12 @@ func (e *encoder) encode(event *publisher.Event) ([]byte, error) {
 	})
 	if err != nil {
 		e.reset()
-		return nil, err
 	}
-
-	return e.buf.Bytes(), nil
+	return err
 }
 
-func newDecoder() *decoder {
-	d := &decoder{}
+func newDecoder(transform *recordTransform) *decoder {
+	d := &decoder{transform: transform}
 	d.reset()
 	return d
 }
@@ -155,12 +217,56 @@ func (d *decoder) Buffer(n int) []byte {
 	return d.buf
 }
 
-func (d *decoder) Decode() (publisher.Event, error) {
+// Decode decodes the record in the read buffer and appends its events to
+// events. On error no event is appended.
+func (d *decoder) Decode(events []publisher.Event) ([]publisher.Event, error) {
+	record := d.buf
+	if d.transform != nil {
+		var err error
+		if record, err = d.transform.decode(record); err != nil {
+			return events, err
+		}
+	}
+	if len(record) == 0 {
+		return events, errInvalidRecord
+	}
+
+	codec := codecID(record[0] &^ recordBlock)
+	if record[0]&recordBlock == 0 {
+		event, err := d.decodeEvent(codec, record[1:])
+		if err != nil {
+			return events, err
+		}
+		return append(events, event), nil
+	}
+
+	count, n := binary.Uvarint(record[1:])
+	if n <= 0 {
+		return events, errInvalidRecord
+	}
+
+	L := len(events)
+	contents := record[1+n:]
+	for i := uint64(0); i < count; i++ {
+		sz, n := binary.Uvarint(contents)
+		if n <= 0 || sz > uint64(len(contents)-n) {
+			return events[:L], errInvalidRecord
+		}
+
+		event, err := d.decodeEvent(codec, contents[n:n+int(sz)])
+		if err != nil {
+			return events[:L], err
+		}
+		events = append(events, event)
+		contents = contents[n+int(sz):]
+	}
+	return events, nil
+}
+
+func (d *decoder) decodeEvent(codec codecID, contents []byte) (publisher.Event, error) {
 	var (
-		to       entry
-		err      error
-		codec    = codecID(d.buf[0])
-		contents = d.buf[1:]
+		to  entry
+		err error
 	)
 
 	d.unfolder.SetTarget(&to)
@@ -18,6 +18,7 @@
 package spool
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
@@ -28,12 +29,14 @@ import (
 	"github.com/joeshaw/multierror"
 
 	"github.com/elastic/beats/libbeat/common/cfgtype"
+	"github.com/elastic/beats/libbeat/paths"
 )
 
 type config struct {
-	File  pathConfig  `config:"file"`
-	Write writeConfig `config:"write"`
-	Read  readConfig  `config:"read"`
+	File       pathConfig       `config:"file"`
+	Write      writeConfig      `config:"write"`
+	Read       readConfig       `config:"read"`
+	Encryption encryptionConfig `config:"encryption"`
 }
 
 type pathConfig struct {
@@ -49,12 +52,18 @@ type writeConfig struct {
 	FlushEvents  time.Duration    `config:"flush.events"`
 	FlushTimeout time.Duration    `config:"flush.timeout"`
 	Codec        codecID          `config:"codec"`
+	Compression  compressionID    `config:"compression"`
 }
 
 type readConfig struct {
 	FlushTimeout time.Duration `config:"flush.timeout"`
 }
 
+type encryptionConfig struct {
+	// Base64 encoded AES key. The key should be stored in the keystore.
+	Key string `config:"key"`
+}
+
 func defaultConfig() config {
 	return config{
 		File: pathConfig{
@@ -76,6 +85,13 @@ func defaultConfig() config {
 	}
 }
 
+func (c *pathConfig) path() string {
+	if c.Path == "" {
+		return paths.Resolve(paths.Data, "spool.dat")
+	}
+	return c.Path
+}
+
 func (c *pathConfig) Validate() error {
 	var errs multierror.Errors
 
@@ -112,6 +128,29 @@ func (c *readConfig) Validate() error {
 	return nil
 }
 
+func (c *encryptionConfig) Validate() error {
+	_, err := c.key()
+	return err
+}
+
+// key decodes the configured key. It returns nil if no key is configured.
+func (c *encryptionConfig) key() ([]byte, error) {
+	if c.Key == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %v", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("encryption key must be 16, 24 or 32 bytes long, got %v bytes", len(key))
+	}
+}
+
 func (c *codecID) Unpack(value string) error {
 	ids := map[string]codecID{
 		"json":   codecJSON,
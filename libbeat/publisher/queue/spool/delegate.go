@@ -0,0 +1,250 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spool
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/go-txfile"
+	"github.com/elastic/go-txfile/pq"
+)
+
+// spoolDelegate is the queue delegate of a spool file. In addition to the
+// queue root, the file root page stores a header with the compression and
+// encryption settings the file has been written with.
+type spoolDelegate struct {
+	file   *txfile.File
+	root   txfile.PageID
+	offset uintptr
+	header fileHeader
+}
+
+// fileHeader is stored at the beginning of the root page, the queue root
+// follows at offset fileHeaderSize. The header starts with the magic bytes,
+// followed by the version, compression and encryption flag bytes, a reserved
+// byte, the 8 bytes key check value and 16 reserved bytes.
+type fileHeader struct {
+	compression compressionID
+	encrypted   bool
+	keyCheck    [8]byte
+}
+
+const (
+	fileHeaderSize    = 32
+	fileHeaderVersion = 1
+)
+
+var fileHeaderMagic = []byte("BSPQ")
+
+// newSpoolDelegate initializes the root page of new files. Files created
+// without a header are migrated, by copying the queue root into a new root
+// page with a header for uncompressed and unencrypted events.
+func newSpoolDelegate(f *txfile.File) (*spoolDelegate, error) {
+	tx, err := f.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+
+	var (
+		queueRoot [pq.SzRoot]byte
+		old       *txfile.Page
+	)
+
+	root := tx.Root()
+	if root == 0 {
+		queueRoot = pq.MakeRoot()
+	} else {
+		page, err := tx.Page(root)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := page.Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.HasPrefix(buf, fileHeaderMagic) {
+			header, err := readFileHeader(buf)
+			if err != nil {
+				return nil, err
+			}
+			return &spoolDelegate{file: f, root: root, offset: fileHeaderSize, header: header}, nil
+		}
+
+		copy(queueRoot[:], buf)
+		old = page
+	}
+
+	page, err := tx.Alloc()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, fileHeaderSize+pq.SzRoot)
+	writeFileHeader(buf, fileHeader{})
+	copy(buf[fileHeaderSize:], queueRoot[:])
+	if err := page.SetBytes(buf); err != nil {
+		return nil, err
+	}
+	tx.SetRoot(page.ID())
+
+	if old != nil {
+		if err := old.Free(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &spoolDelegate{file: f, root: page.ID(), offset: fileHeaderSize}, nil
+}
+
+// loadSpoolDelegate reads the root page of an existing file, without
+// modifying the file. The queue root of files without header starts at the
+// beginning of the root page.
+func loadSpoolDelegate(f *txfile.File) (*spoolDelegate, error) {
+	tx, err := f.BeginReadonly()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+
+	root := tx.Root()
+	if root == 0 {
+		return nil, errors.New("spool file has not been initialized")
+	}
+
+	page, err := tx.Page(root)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := page.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(buf, fileHeaderMagic) {
+		return &spoolDelegate{file: f, root: root}, nil
+	}
+
+	header, err := readFileHeader(buf)
+	if err != nil {
+		return nil, err
+	}
+	return &spoolDelegate{file: f, root: root, offset: fileHeaderSize, header: header}, nil
+}
+
+func readFileHeader(buf []byte) (fileHeader, error) {
+	var h fileHeader
+	if len(buf) < fileHeaderSize {
+		return h, fmt.Errorf("invalid spool file header")
+	}
+	if v := buf[4]; v != fileHeaderVersion {
+		return h, fmt.Errorf("unsupported spool file version %v", v)
+	}
+
+	h.compression = compressionID(buf[5])
+	h.encrypted = buf[6] != 0
+	copy(h.keyCheck[:], buf[8:16])
+	return h, nil
+}
+
+func writeFileHeader(buf []byte, h fileHeader) {
+	copy(buf, fileHeaderMagic)
+	buf[4] = fileHeaderVersion
+	buf[5] = byte(h.compression)
+	buf[6] = 0
+	if h.encrypted {
+		buf[6] = 1
+	}
+	copy(buf[8:16], h.keyCheck[:])
+}
+
+// updateHeader stores the settings new events are written with.
+func (d *spoolDelegate) updateHeader(h fileHeader) error {
+	tx, err := d.file.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Close()
+
+	page, err := tx.Page(d.root)
+	if err != nil {
+		return err
+	}
+	if err := page.Load(); err != nil {
+		return err
+	}
+	buf, err := page.Bytes()
+	if err != nil {
+		return err
+	}
+	writeFileHeader(buf, h)
+	if err := page.MarkDirty(); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	d.header = h
+	return nil
+}
+
+// PageSize returns the files page size.
+func (d *spoolDelegate) PageSize() int {
+	return d.file.PageSize()
+}
+
+// Root returns the queue root, following the file header.
+func (d *spoolDelegate) Root() (txfile.PageID, uintptr) {
+	return d.root, d.offset
+}
+
+func (d *spoolDelegate) Offset(id txfile.PageID, offset uintptr) uintptr {
+	return d.file.Offset(id, offset)
+}
+
+func (d *spoolDelegate) SplitOffset(offset uintptr) (txfile.PageID, uintptr) {
+	return d.file.SplitOffset(offset)
+}
+
+// BeginWrite creates a new transaction for flushing the write buffers to disk.
+func (d *spoolDelegate) BeginWrite() (*txfile.Tx, error) {
+	return d.file.BeginWith(txfile.TxOptions{
+		WALLimit: 3,
+	})
+}
+
+// BeginRead returns a readonly transaction.
+func (d *spoolDelegate) BeginRead() (*txfile.Tx, error) {
+	return d.file.BeginReadonly()
+}
+
+// BeginCleanup creates a new write transaction configured for cleaning up used
+// events/pages only.
+func (d *spoolDelegate) BeginCleanup() (*txfile.Tx, error) {
+	return d.file.BeginWith(txfile.TxOptions{
+		EnableOverflowArea: true,
+		WALLimit:           3,
+	})
+}
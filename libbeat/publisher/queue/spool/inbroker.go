@@ -59,6 +59,14 @@ type inBroker struct {
 
 	bufferedEvents uint // number of buffered events
 
+	// If compression or encryption is configured, events are grouped into
+	// blocks, each block being written as a single queue entry. A block is
+	// written once it reaches maxBlockSize or before the write buffer is
+	// flushed. The number of events in the written, but not yet flushed,
+	// blocks is kept, so to report flushed events.
+	blocks      bool
+	blockCounts []uint
+
 	// flush settings
 	timer       *timer
 	flushEvents uint
@@ -69,6 +77,9 @@ type inBroker struct {
 const (
 	inSigChannelSize   = 3
 	inEventChannelSize = 20
+
+	// maximum size of a block of serialized events before compression
+	maxBlockSize = 64 * 1024
 )
 
 func newInBroker(
@@ -76,10 +87,11 @@ func newInBroker(
 	eventer queue.Eventer,
 	qu *pq.Queue,
 	codec codecID,
+	transform *recordTransform,
 	flushTimeout time.Duration,
 	flushEvents uint,
 ) (*inBroker, error) {
-	enc, err := newEncoder(codec)
+	enc, err := newEncoder(codec, transform)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +119,7 @@ func newInBroker(
 		clientStates:   clientStates{},
 		pending:        nil,
 		bufferedEvents: 0,
+		blocks:         transform.active(),
 
 		// internal
 		timer:       newTimer(flushTimeout),
@@ -126,7 +139,18 @@ func (b *inBroker) Producer(cfg queue.ProducerConfig) queue.Producer {
 // onFlush is run whenever the queue flushes it's write buffer. The callback is
 // run in the same go-routine as the Flush was executed from.
 // Only the (*inBroker).eventLoop triggers a flush.
-func (b *inBroker) onFlush(n uint) {
+// The number of flushed queue entries is reported. If events are grouped into
+// blocks, the number of entries is converted into the number of events.
+func (b *inBroker) onFlush(entries uint) {
+	n := entries
+	if b.blocks {
+		n = 0
+		for _, count := range b.blockCounts[:entries] {
+			n += count
+		}
+		b.blockCounts = b.blockCounts[entries:]
+	}
+
 	if n == 0 {
 		return
 	}
@@ -246,6 +270,9 @@ func (b *inBroker) eventloopShutdown() {
 			return
 		}
 	}
+	if b.writeBlock() != nil {
+		return
+	}
 	w.Flush()
 }
 
@@ -482,7 +509,13 @@ func (b *inBroker) handleCancel(req *producerCancelRequest) {
 }
 
 func (b *inBroker) encodeEvent(req *pushRequest) ([]byte, clientState, error) {
-	buf, err := b.enc.encode(&req.event)
+	var buf []byte
+	var err error
+	if b.blocks {
+		_, err = b.enc.addToBlock(&req.event)
+	} else {
+		buf, err = b.enc.encode(&req.event)
+	}
 	if err != nil {
 		return nil, clientState{}, err
 	}
@@ -511,7 +544,12 @@ func (b *inBroker) addEvent(buf []byte, st clientState) error {
 	count := b.clientStates.Add(st)
 	log.Debug("  add event -> active:", count)
 
-	err := b.writeEvent(buf)
+	var err error
+	if !b.blocks {
+		err = b.writeEvent(buf)
+	} else if len(b.enc.block) >= maxBlockSize {
+		err = b.writeBlock()
+	}
 	log.Debugf("  inbroker write -> events=%v, err=%+v ", b.bufferedEvents, err)
 
 	return err
@@ -537,8 +575,22 @@ func (b *inBroker) writeEvent(buf []byte) error {
 	return err
 }
 
+// writeBlock writes the current block of events to the queue.
+func (b *inBroker) writeBlock() error {
+	buf, count, err := b.enc.closeBlock()
+	if err != nil || count == 0 {
+		return err
+	}
+
+	b.blockCounts = append(b.blockCounts, count)
+	return b.writeEvent(buf)
+}
+
 func (b *inBroker) flushBuffer() error {
-	err := b.writer.Flush()
+	err := b.writeBlock()
+	if err == nil {
+		err = b.writer.Flush()
+	}
 	if err != nil {
 		log := b.ctx.logger
 		log.Errorf("Spool flush failed with: %+v", err)
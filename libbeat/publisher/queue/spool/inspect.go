@@ -0,0 +1,389 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/go-txfile"
+	"github.com/elastic/go-txfile/pq"
+)
+
+// Inspector provides offline access to the events stored in a spool file.
+// The file is locked while being inspected, so to not interfere with a Beat
+// using the same spool file.
+type Inspector struct {
+	path     string
+	file     *txfile.File
+	delegate *spoolDelegate
+	queue    *pq.Queue
+	decoder  *decoder
+	readonly bool
+
+	// Events of the last entry read, that have not been passed to Read's
+	// callback yet. Entries hold a block of events, if compression or
+	// encryption has been enabled.
+	events []publisher.Event
+
+	// The number of events in the entries read, but not yet ACKed, and the
+	// number of events read but not yet ACKed. ACKed events of partially
+	// ACKed entries are counted in acked.
+	entries []uint
+	unacked uint
+	acked   uint
+}
+
+// FileStats reports the state of a spool file.
+type FileStats struct {
+	Path     string
+	FileSize int64
+	PageSize int
+
+	// DataPages is the number of pages holding events.
+	DataPages uint64
+
+	Compression string
+	Encrypted   bool
+
+	// Pending is the number of events not yet ACKed by the outputs. ACKed
+	// events are removed from the file once all events in a page are ACKed.
+	// ACKed reports the total number of queue entries ACKed. An entry holds a
+	// single event, or a block of events if compression or encryption is
+	// enabled.
+	Pending      uint64
+	PendingBytes uint64
+	ACKed        uint64
+}
+
+// lockTimeout is the maximum duration to wait for the file lock.
+var lockTimeout = 2 * time.Second
+
+// queueState holds the event ids and the number of data pages as stored in
+// the queue root by go-txfile/pq. The root starts with the version, followed
+// by the head, tail and read positions and the data page count. A position
+// stores the file offset and the id of an event.
+type queueState struct {
+	tail, read uint64
+	dataPages  uint64
+}
+
+// OpenInspector opens the spool file configured in the queue.spool settings.
+// If readonly is set, the file is not modified. Events can only be ACKed, if
+// the file is not opened in readonly mode.
+func OpenInspector(cfg *common.Config, readonly bool) (*Inspector, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	key, err := config.Encryption.key()
+	if err != nil {
+		return nil, err
+	}
+
+	return openInspector(config.File.path(), key, readonly)
+}
+
+func openInspector(path string, key []byte, readonly bool) (*Inspector, error) {
+	// txfile creates missing files
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	f, err := openLocked(path, txfile.Options{Readonly: readonly})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open spool file at path '%s'", path)
+	}
+
+	ok := false
+	defer ifNotOK(&ok, ignoreErr(f.Close))
+
+	var delegate *spoolDelegate
+	if readonly {
+		delegate, err = loadSpoolDelegate(f)
+	} else {
+		delegate, err = newSpoolDelegate(f)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read spool file at path '%s'", path)
+	}
+
+	transform, err := inspectorTransform(delegate.header, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not read spool file at path '%s'", path)
+	}
+
+	queue, err := pq.New(delegate, pq.Settings{})
+	if err != nil {
+		transform.close()
+		return nil, err
+	}
+
+	ok = true
+	return &Inspector{
+		path:     path,
+		file:     f,
+		delegate: delegate,
+		queue:    queue,
+		decoder:  newDecoder(transform),
+		readonly: readonly,
+	}, nil
+}
+
+// openLocked opens the file, unless it is locked by another process.
+// txfile.Open blocks until the file lock can be acquired. If opening takes
+// longer than lockTimeout, the file is closed once opened.
+func openLocked(path string, opts txfile.Options) (*txfile.File, error) {
+	type result struct {
+		file *txfile.File
+		err  error
+	}
+
+	opened := make(chan result)
+	timeout := make(chan struct{})
+	go func() {
+		f, err := txfile.Open(path, 0600, opts)
+		select {
+		case opened <- result{f, err}:
+		case <-timeout:
+			if err == nil {
+				f.Close()
+			}
+		}
+	}()
+
+	select {
+	case res := <-opened:
+		return res.file, res.err
+	case <-time.After(lockTimeout):
+		close(timeout)
+		return nil, errors.New("file is in use by another process")
+	}
+}
+
+// inspectorTransform creates the transform for decoding the events in a file
+// with the given header. Compressed events are always decoded, but encrypted
+// events require the key the file has been written with.
+func inspectorTransform(header fileHeader, key []byte) (*recordTransform, error) {
+	if !header.encrypted {
+		key = nil
+	} else if key == nil {
+		return nil, errors.New("events are encrypted, but no encryption key is configured")
+	} else if keyCheck(key) != header.keyCheck {
+		return nil, errors.New("events have been encrypted with a different key")
+	}
+	return newRecordTransform(compressionNone, key)
+}
+
+// Close closes the spool file.
+func (i *Inspector) Close() error {
+	err := i.queue.Close()
+	i.file.Close()
+	i.decoder.transform.close()
+	return err
+}
+
+// Stats reads the current state of the spool file. The pending events are
+// read, in order to compute their total size.
+func (i *Inspector) Stats() (FileStats, error) {
+	info, err := os.Stat(i.path)
+	if err != nil {
+		return FileStats{}, err
+	}
+
+	state, err := i.readQueueState()
+	if err != nil {
+		return FileStats{}, err
+	}
+
+	pending, pendingBytes, err := i.pending()
+	if err != nil {
+		return FileStats{}, err
+	}
+
+	header := i.delegate.header
+	return FileStats{
+		Path:         i.path,
+		FileSize:     info.Size(),
+		PageSize:     i.file.PageSize(),
+		DataPages:    state.dataPages,
+		Compression:  header.compression.String(),
+		Encrypted:    header.encrypted,
+		Pending:      pending,
+		PendingBytes: pendingBytes,
+		ACKed:        state.read,
+	}, nil
+}
+
+// Read decodes up to limit pending events and passes them to fn in queue
+// order. All pending events are read if limit is 0. Subsequent calls continue
+// reading after the last event read. Read returns the number of events passed
+// to fn.
+func (i *Inspector) Read(limit int, fn func(publisher.Event) error) (int, error) {
+	reader := i.queue.Reader()
+	if err := reader.Begin(); err != nil {
+		return 0, err
+	}
+	defer reader.Done()
+
+	count := 0
+	for limit <= 0 || count < limit {
+		if len(i.events) == 0 {
+			sz, err := reader.Next()
+			if err != nil {
+				return count, err
+			}
+			if sz <= 0 {
+				break
+			}
+
+			buf := i.decoder.Buffer(sz)
+			if _, err := reader.Read(buf); err != nil {
+				return count, err
+			}
+
+			i.events, err = i.decoder.Decode(i.events[:0])
+			if err != nil {
+				return count, fmt.Errorf("failed to decode event: %v", err)
+			}
+			i.entries = append(i.entries, uint(len(i.events)))
+			continue
+		}
+
+		event := i.events[0]
+		i.events = i.events[1:]
+		if err := fn(event); err != nil {
+			return count, err
+		}
+		i.unacked++
+		count++
+	}
+	return count, nil
+}
+
+// ACK removes the n oldest pending events from the spool file. The file must
+// not have been opened in readonly mode. Only events passed to Read's callback
+// can be ACKed. A block of events is removed, once all its events are ACKed.
+func (i *Inspector) ACK(n uint) error {
+	if i.readonly {
+		return errors.New("spool file is opened in readonly mode")
+	}
+	if n > i.unacked {
+		return fmt.Errorf("can not ACK %v events, only %v events have been read", n, i.unacked)
+	}
+
+	acked := i.acked + n
+	entries := 0
+	for entries < len(i.entries) && i.entries[entries] <= acked {
+		acked -= i.entries[entries]
+		entries++
+	}
+
+	if err := i.queue.ACK(uint(entries)); err != nil {
+		return err
+	}
+	i.entries = i.entries[entries:]
+	i.unacked -= n
+	i.acked = acked
+	return nil
+}
+
+// pending counts the pending events and sums up the sizes of all pending
+// entries. Only the record headers are read, so to count the events without
+// decoding. A second queue instance is used, so to not interfere with the
+// state of the inspector's reader.
+func (i *Inspector) pending() (events, bytes uint64, err error) {
+	queue, err := pq.New(i.delegate, pq.Settings{})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer queue.Close()
+
+	reader := queue.Reader()
+	if err := reader.Begin(); err != nil {
+		return 0, 0, err
+	}
+	defer reader.Done()
+
+	var hdr [1 + binary.MaxVarintLen64]byte
+	for {
+		sz, err := reader.Next()
+		if err != nil {
+			return 0, 0, err
+		}
+		if sz <= 0 {
+			return events, bytes, nil
+		}
+		bytes += uint64(sz)
+
+		n, err := reader.Read(hdr[:])
+		if err != nil {
+			return 0, 0, err
+		}
+		count, err := recordEvents(hdr[:n])
+		if err != nil {
+			return 0, 0, err
+		}
+		events += count
+	}
+}
+
+func (i *Inspector) readQueueState() (queueState, error) {
+	tx, err := i.file.BeginReadonly()
+	if err != nil {
+		return queueState{}, err
+	}
+	defer tx.Close()
+
+	id, off := i.delegate.Root()
+	page, err := tx.Page(id)
+	if err != nil {
+		return queueState{}, err
+	}
+	buf, err := page.Bytes()
+	if err != nil {
+		return queueState{}, err
+	}
+	return parseQueueState(buf[off : int(off)+pq.SzRoot]), nil
+}
+
+func parseQueueState(buf []byte) queueState {
+	pos := func(off int) (offset, id uint64) {
+		return binary.LittleEndian.Uint64(buf[off:]), binary.LittleEndian.Uint64(buf[off+8:])
+	}
+
+	_, head := pos(4)
+	_, tail := pos(20)
+	readOffset, read := pos(36)
+	if readOffset == 0 {
+		read = head
+	}
+
+	return queueState{
+		tail:      tail,
+		read:      read,
+		dataPages: binary.LittleEndian.Uint64(buf[52:]),
+	}
+}
@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/go-txfile/pq"
+	"github.com/elastic/go-txfile/txfiletest"
+)
+
+func TestInspectorStats(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	spool := openTestSpool(t, path, compressionLZ4, testKey)
+	publishTestEvents(t, spool, 10, 10)
+	assertTestEvents(t, spool, 4)
+
+	// the spool file is locked while in use
+	defer func(d time.Duration) { lockTimeout = d }(lockTimeout)
+	lockTimeout = 100 * time.Millisecond
+	_, err := openInspector(path, testKey, true)
+	assert.Error(t, err)
+	require.NoError(t, spool.Close())
+
+	inspector, err := openInspector(path, testKey, true)
+	require.NoError(t, err)
+	defer inspector.Close()
+
+	stats, err := inspector.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, path, stats.Path)
+	assert.Equal(t, 4096, stats.PageSize)
+	assert.Equal(t, "lz4", stats.Compression)
+	assert.True(t, stats.Encrypted)
+	assert.Equal(t, uint64(6), stats.Pending)
+	assert.Equal(t, uint64(4), stats.ACKed)
+	assert.True(t, stats.PendingBytes > 0)
+	assert.True(t, stats.DataPages > 0)
+
+	var messages []interface{}
+	n, err := inspector.Read(0, func(event publisher.Event) error {
+		messages = append(messages, event.Content.Fields["message"])
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	for i, msg := range messages {
+		assert.Equal(t, fmt.Sprintf("event %v", i+4), msg)
+	}
+
+	assert.Error(t, inspector.ACK(1))
+}
+
+func TestInspectorACK(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	spool := openTestSpool(t, path, compressionNone, nil)
+	publishTestEvents(t, spool, 5, 5)
+	require.NoError(t, spool.Close())
+
+	inspector, err := openInspector(path, nil, false)
+	require.NoError(t, err)
+
+	n, err := inspector.Read(3, func(publisher.Event) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.NoError(t, inspector.ACK(3))
+	require.NoError(t, inspector.Close())
+
+	spool = openTestSpool(t, path, compressionNone, nil)
+	defer spool.Close()
+	waitActive(t, spool, 2)
+}
+
+func TestInspectorBlock(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	// all events are written in a single block
+	settings := testSpoolSettings(compressionZSTD, nil)
+	settings.WriteFlushEvents = 10
+	spool, err := NewSpool(&testLogger{t}, path, settings)
+	require.NoError(t, err)
+	publishTestEvents(t, spool, 1, 10)
+	require.NoError(t, spool.Close())
+
+	inspector, err := openInspector(path, nil, false)
+	require.NoError(t, err)
+
+	stats, err := inspector.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), stats.Pending)
+
+	var messages []interface{}
+	collect := func(event publisher.Event) error {
+		messages = append(messages, event.Content.Fields["message"])
+		return nil
+	}
+
+	n, err := inspector.Read(4, collect)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.NoError(t, inspector.ACK(4))
+
+	n, err = inspector.Read(0, collect)
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+	for i, msg := range messages {
+		assert.Equal(t, fmt.Sprintf("event %v", i), msg)
+	}
+
+	assert.Error(t, inspector.ACK(7))
+	require.NoError(t, inspector.ACK(6))
+	require.NoError(t, inspector.Close())
+
+	spool = openTestSpool(t, path, compressionNone, nil)
+	defer spool.Close()
+	waitActive(t, spool, 0)
+}
+
+func TestInspectorEncryptionKey(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	spool := openTestSpool(t, path, compressionNone, testKey)
+	publishTestEvents(t, spool, 1, 1)
+	require.NoError(t, spool.Close())
+
+	for name, key := range map[string][]byte{"no key": nil, "other key": testOtherKey} {
+		_, err := openInspector(path, key, true)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParseQueueState(t *testing.T) {
+	// parseQueueState relies on the layout of the pq queue root
+	require.Equal(t, 60, pq.SzRoot)
+
+	root := pq.MakeRoot()
+	state := parseQueueState(root[:])
+	assert.Equal(t, queueState{}, state)
+}
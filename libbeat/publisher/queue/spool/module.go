@@ -22,7 +22,6 @@ import (
 	"github.com/elastic/beats/libbeat/common/cfgwarn"
 	"github.com/elastic/beats/libbeat/feature"
 	"github.com/elastic/beats/libbeat/logp"
-	"github.com/elastic/beats/libbeat/paths"
 	"github.com/elastic/beats/libbeat/publisher/queue"
 	"github.com/elastic/go-txfile"
 )
@@ -47,16 +46,18 @@ func create(eventer queue.Eventer, logp *logp.Logger, cfg *common.Config) (queue
 		return nil, err
 	}
 
-	path := config.File.Path
-	if path == "" {
-		path = paths.Resolve(paths.Data, "spool.dat")
-	}
+	path := config.File.path()
 
 	flushEvents := uint(0)
 	if count := config.Write.FlushEvents; count > 0 {
 		flushEvents = uint(count)
 	}
 
+	key, err := config.Encryption.key()
+	if err != nil {
+		return nil, err
+	}
+
 	var log logger = logp
 	if logp == nil {
 		log = defaultLogger()
@@ -70,6 +71,8 @@ func create(eventer queue.Eventer, logp *logp.Logger, cfg *common.Config) (queue
 		WriteFlushEvents:  flushEvents,
 		ReadFlushTimeout:  config.Read.FlushTimeout,
 		Codec:             config.Write.Codec,
+		Compression:       config.Write.Compression,
+		EncryptionKey:     key,
 		File: txfile.Options{
 			MaxSize:  uint64(config.File.MaxSize),
 			PageSize: uint32(config.File.PageSize),
@@ -42,8 +42,13 @@ type outBroker struct {
 	// queue state
 	queue     *pq.Queue
 	reader    *pq.Reader
-	available uint // number of available events. getRequests are only accepted if available > 0
+	available uint // number of available entries. getRequests are only accepted if available > 0
 	events    []publisher.Event
+
+	// Events of a block, that have been read from the queue, but not yet
+	// returned to the consumer. The queue entry holding the block is ACKed
+	// with the batch holding the last event of the block.
+	blockEvents []publisher.Event
 	required  int
 	total     int
 	active    getRequest
@@ -61,7 +66,7 @@ type chanList struct {
 type ackChan struct {
 	next  *ackChan
 	ch    chan batchAckMsg
-	total int // total number of events to ACK with this batch
+	total int // total number of queue entries to ACK with this batch
 }
 
 const (
@@ -81,7 +86,12 @@ var ackChanPool = sync.Pool{
 
 var errRetry = errors.New("retry")
 
-func newOutBroker(ctx *spoolCtx, qu *pq.Queue, flushTimeout time.Duration) (*outBroker, error) {
+func newOutBroker(
+	ctx *spoolCtx,
+	qu *pq.Queue,
+	transform *recordTransform,
+	flushTimeout time.Duration,
+) (*outBroker, error) {
 	reader := qu.Reader()
 	avail, err := reader.Available()
 	if err != nil {
@@ -112,7 +122,7 @@ func newOutBroker(ctx *spoolCtx, qu *pq.Queue, flushTimeout time.Duration) (*out
 
 		// internal
 		timer: newTimer(flushTimeout),
-		dec:   newDecoder(),
+		dec:   newDecoder(transform),
 	}
 
 	b.initState()
@@ -195,7 +205,7 @@ func (b *outBroker) initState() {
 	b.required = 0
 	b.total = 0
 	b.active = getRequest{}
-	if b.available == 0 {
+	if b.available == 0 && len(b.blockEvents) == 0 {
 		b.state = (*outBroker).stateWaitEvents
 	} else {
 		b.state = (*outBroker).stateActive
@@ -261,10 +271,10 @@ func (b *outBroker) stateActive() bool {
 		log.Debug("outbroker (stateActive): get request", required)
 
 		var err error
-		var total int
-		events, total, err = b.collectEvents(events, required)
+		var read, total int
+		events, read, total, err = b.collectEvents(events, required)
 		required -= len(events)
-		b.available -= uint(total)
+		b.available -= uint(read)
 
 		log.Debug("  outbroker (stateActive): events collected", len(events), total, err)
 
@@ -340,8 +350,8 @@ func (b *outBroker) stateWithTimer() bool {
 
 		L := len(b.events)
 		required := b.required
-		events, total, err := b.collectEvents(b.events, required)
-		b.available -= uint(total)
+		events, read, total, err := b.collectEvents(b.events, required)
+		b.available -= uint(read)
 		collected := len(events) - L
 		required -= collected
 		total += b.total
@@ -427,46 +437,81 @@ func (b *outBroker) returnError(
 	}
 }
 
+// collectEvents reads up to N events. The number of queue entries read and
+// the number of entries that can be ACKed once the events are ACKed is
+// returned. The two differ, if the events of a block are split between
+// batches.
 func (b *outBroker) collectEvents(
 	events []publisher.Event,
 	N int,
-) ([]publisher.Event, int, error) {
+) ([]publisher.Event, int, int, error) {
 	log := b.ctx.logger
 	reader := b.reader
 
+	read, total := 0, 0
+	if len(b.blockEvents) > 0 {
+		events, N = b.takeBlockEvents(events, N)
+		if len(b.blockEvents) > 0 {
+			return events, 0, 0, nil
+		}
+		total++
+	}
+
 	// ensure all read operations happen within same transaction
 	err := reader.Begin()
 	if err != nil {
-		return nil, 0, err
+		return events, read, total, err
 	}
 	defer reader.Done()
 
-	count := 0
 	for N > 0 {
 		sz, err := reader.Next()
 		if sz <= 0 || err != nil {
-			return events, count, err
+			return events, read, total, err
 		}
 
-		count++
+		read++
 
 		buf := b.dec.Buffer(sz)
 		_, err = reader.Read(buf)
 		if err != nil {
-			return events, count, err
+			return events, read, total, err
 		}
 
-		event, err := b.dec.Decode()
+		L := len(events)
+		events, err = b.dec.Decode(events)
 		if err != nil {
-			log.Debug("Failed to decode event from spool: %v", err)
+			log.Debugf("Failed to decode event from spool: %v", err)
+			total++
 			continue
 		}
 
-		events = append(events, event)
-		N--
+		if decoded := len(events) - L; decoded > N {
+			// keep the remaining events of the block for the next batch
+			b.blockEvents = append(b.blockEvents[:0], events[L+N:]...)
+			return events[:L+N], read, total, nil
+		}
+
+		total++
+		N -= len(events) - L
+	}
+
+	return events, read, total, nil
+}
+
+// takeBlockEvents moves up to N events of the current block to events.
+func (b *outBroker) takeBlockEvents(
+	events []publisher.Event,
+	N int,
+) ([]publisher.Event, int) {
+	n := len(b.blockEvents)
+	if n > N {
+		n = N
 	}
 
-	return events, count, nil
+	events = append(events, b.blockEvents[:n]...)
+	b.blockEvents = b.blockEvents[n:]
+	return events, N - n
 }
 
 func newACKChan(total int) *ackChan {
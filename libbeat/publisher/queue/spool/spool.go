@@ -44,6 +44,8 @@ type Spool struct {
 
 	queue *pq.Queue
 	file  *txfile.File
+
+	inTransform, outTransform *recordTransform
 }
 
 type spoolCtx struct {
@@ -71,6 +73,13 @@ type Settings struct {
 	ReadFlushTimeout  time.Duration
 
 	Codec codecID
+
+	// Compression and EncryptionKey configure the transformations applied to
+	// events written to the file. The key must be 16, 24 or 32 bytes long to
+	// select AES-128, AES-192 or AES-256. Events are not encrypted if the key
+	// is nil.
+	Compression   compressionID
+	EncryptionKey []byte
 }
 
 const minInFlushTimeout = 100 * time.Millisecond
@@ -110,14 +119,28 @@ func NewSpool(logger logger, path string, settings Settings) (*Spool, error) {
 	}
 	defer ifNotOK(&ok, ignoreErr(f.Close))
 
-	queueDelegate, err := pq.NewStandaloneDelegate(f)
+	queueDelegate, err := newSpoolDelegate(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "spool queue: failed to initialize file at path '%s'", path)
+	}
+
+	inTransform, err := newRecordTransform(settings.Compression, settings.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	defer ifNotOK(&ok, inTransform.close)
+
+	outTransform, err := newRecordTransform(settings.Compression, settings.EncryptionKey)
 	if err != nil {
 		return nil, err
 	}
+	defer ifNotOK(&ok, outTransform.close)
 
 	spool := &Spool{
-		inCtx:  inCtx,
-		outCtx: outCtx,
+		inCtx:        inCtx,
+		outCtx:       outCtx,
+		inTransform:  inTransform,
+		outTransform: outTransform,
 	}
 
 	queue, err := pq.New(queueDelegate, pq.Settings{
@@ -130,12 +153,16 @@ func NewSpool(logger logger, path string, settings Settings) (*Spool, error) {
 	}
 	defer ifNotOK(&ok, ignoreErr(queue.Close))
 
+	if err := checkFileHeader(logger, path, queueDelegate, queue, settings); err != nil {
+		return nil, err
+	}
+
 	inFlushTimeout := settings.WriteFlushTimeout
 	if inFlushTimeout < minInFlushTimeout {
 		inFlushTimeout = minInFlushTimeout
 	}
 	inBroker, err := newInBroker(inCtx, settings.Eventer, queue, settings.Codec,
-		inFlushTimeout, settings.WriteFlushEvents)
+		inTransform, inFlushTimeout, settings.WriteFlushEvents)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +171,7 @@ func NewSpool(logger logger, path string, settings Settings) (*Spool, error) {
 	if outFlushTimeout < minOutFlushTimeout {
 		outFlushTimeout = minOutFlushTimeout
 	}
-	outBroker, err := newOutBroker(outCtx, queue, outFlushTimeout)
+	outBroker, err := newOutBroker(outCtx, queue, outTransform, outFlushTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -169,9 +196,59 @@ func (s *Spool) Close() error {
 	// finally unmap and close file
 	s.file.Close()
 
+	s.inTransform.close()
+	s.outTransform.close()
+
 	return err
 }
 
+// checkFileHeader compares the settings the file has been written with to
+// the current settings. Events still in the queue must be readable with the
+// current encryption settings. If the settings differ, the header is updated
+// for new events.
+func checkFileHeader(
+	logger logger,
+	path string,
+	delegate *spoolDelegate,
+	queue *pq.Queue,
+	settings Settings,
+) error {
+	have := delegate.header
+	want := fileHeader{
+		compression: settings.Compression,
+		encrypted:   settings.EncryptionKey != nil,
+		keyCheck:    keyCheck(settings.EncryptionKey),
+	}
+	if have == want {
+		return nil
+	}
+
+	if have.encrypted != want.encrypted || have.keyCheck != want.keyCheck {
+		active, err := queue.Active()
+		if err != nil {
+			return err
+		}
+
+		if active > 0 {
+			var reason string
+			switch {
+			case have.encrypted && !want.encrypted:
+				reason = "events have been encrypted, but no encryption key is configured"
+			case !have.encrypted && want.encrypted:
+				reason = "events have not been encrypted, but encryption is configured"
+			default:
+				reason = "events have been encrypted with a different key"
+			}
+			return fmt.Errorf("spool queue: file at path '%s' contains %v events written with different settings: %v",
+				path, active, reason)
+		}
+	}
+
+	logger.Infof("Update settings of spool file '%s' (compression: %v, encrypted: %v)",
+		path, want.compression, want.encrypted)
+	return delegate.updateHeader(want)
+}
+
 // BufferConfig returns the queue initial buffer settings.
 func (s *Spool) BufferConfig() queue.BufferConfig {
 	return queue.BufferConfig{Events: -1}
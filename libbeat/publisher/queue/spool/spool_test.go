@@ -25,10 +25,16 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/publisher"
 	"github.com/elastic/beats/libbeat/publisher/queue"
 	"github.com/elastic/beats/libbeat/publisher/queue/queuetest"
 	"github.com/elastic/go-txfile"
+	"github.com/elastic/go-txfile/pq"
 	"github.com/elastic/go-txfile/txfiletest"
 )
 
@@ -72,15 +78,208 @@ func TestProduceConsumer(t *testing.T) {
 		}
 	}
 
-	testWith(makeTestQueue(
+	t.Run("default", testWith(makeTestQueue(
 		128*humanize.KiByte, 4*humanize.KiByte, 16*humanize.KiByte,
 		100*time.Millisecond,
-	))(t)
+	)))
+
+	t.Run("lz4", testWith(makeTestQueue(
+		128*humanize.KiByte, 4*humanize.KiByte, 16*humanize.KiByte,
+		100*time.Millisecond,
+		func(s *Settings) { s.Compression = compressionLZ4 },
+	)))
+
+	t.Run("zstd+encrypted", testWith(makeTestQueue(
+		128*humanize.KiByte, 4*humanize.KiByte, 16*humanize.KiByte,
+		100*time.Millisecond,
+		func(s *Settings) {
+			s.Compression = compressionZSTD
+			s.EncryptionKey = testKey
+		},
+	)))
+}
+
+func TestReopenWithDifferentSettings(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	// write events with encryption enabled, without consuming them
+	spool := openTestSpool(t, path, compressionLZ4, testKey)
+	publishTestEvents(t, spool, 10, 10)
+	require.NoError(t, spool.Close())
+
+	for name, key := range map[string][]byte{"no key": nil, "other key": testOtherKey} {
+		_, err := NewSpool(&testLogger{t}, path, testSpoolSettings(compressionLZ4, key))
+		assert.Error(t, err, name)
+	}
+
+	// compression can be changed with pending events
+	spool = openTestSpool(t, path, compressionNone, testKey)
+	assertTestEvents(t, spool, 10)
+	require.NoError(t, spool.Close())
+
+	// all events have been ACKed, so the key can be changed
+	spool = openTestSpool(t, path, compressionNone, testOtherKey)
+	publishTestEvents(t, spool, 5, 5)
+	require.NoError(t, spool.Close())
+
+	spool = openTestSpool(t, path, compressionZSTD, testOtherKey)
+	assertTestEvents(t, spool, 5)
+	require.NoError(t, spool.Close())
+}
+
+func TestOpenFileWithoutHeader(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	// write events using the queue root without spool file header
+	settings := testSpoolSettings(compressionNone, nil)
+	f, err := txfile.Open(path, settings.Mode, settings.File)
+	require.NoError(t, err)
+	delegate, err := pq.NewStandaloneDelegate(f)
+	require.NoError(t, err)
+	qu, err := pq.New(delegate, pq.Settings{WriteBuffer: settings.WriteBuffer})
+	require.NoError(t, err)
+	writer, err := qu.Writer()
+	require.NoError(t, err)
+	enc, err := newEncoder(codecCBORL, nil)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		buf, err := enc.encode(makeTestEvent(i))
+		require.NoError(t, err)
+		_, err = writer.Write(buf)
+		require.NoError(t, err)
+		require.NoError(t, writer.Next())
+	}
+	require.NoError(t, writer.Flush())
+	require.NoError(t, qu.Close())
+	require.NoError(t, f.Close())
+
+	_, err = NewSpool(&testLogger{t}, path, testSpoolSettings(compressionNone, testKey))
+	assert.Error(t, err)
+
+	spool := openTestSpool(t, path, compressionLZ4, nil)
+	assertTestEvents(t, spool, 3)
+	require.NoError(t, spool.Close())
+}
+
+func TestConsumeBlockInBatches(t *testing.T) {
+	path, cleanPath := txfiletest.SetupPath(t, "")
+	defer cleanPath()
+
+	// all events are written in a single block
+	settings := testSpoolSettings(compressionLZ4, testKey)
+	settings.WriteFlushEvents = 10
+	spool, err := NewSpool(&testLogger{t}, path, settings)
+	require.NoError(t, err)
+	publishTestEvents(t, spool, 1, 10)
+
+	consumer := spool.Consumer()
+	var batches []queue.Batch
+	var events []publisher.Event
+	for len(events) < 10 {
+		batch, err := consumer.Get(3)
+		require.NoError(t, err)
+		require.True(t, len(batch.Events()) <= 3)
+		events = append(events, batch.Events()...)
+		batches = append(batches, batch)
+	}
+	for i, event := range events {
+		assert.Equal(t, fmt.Sprintf("event %v", i), event.Content.Fields["message"])
+	}
+
+	// the block is removed once all its events are ACKed
+	for _, batch := range batches[:len(batches)-1] {
+		batch.ACK()
+	}
+	time.Sleep(100 * time.Millisecond)
+	waitActive(t, spool, 1)
+	batches[len(batches)-1].ACK()
+	waitActive(t, spool, 0)
+
+	require.NoError(t, consumer.Close())
+	require.NoError(t, spool.Close())
+}
+
+func testSpoolSettings(compression compressionID, key []byte) Settings {
+	return Settings{
+		Mode:              0600,
+		WriteBuffer:       16 * humanize.KiByte,
+		WriteFlushTimeout: 100 * time.Millisecond,
+		WriteFlushEvents:  1,
+		Codec:             codecCBORL,
+		Compression:       compression,
+		EncryptionKey:     key,
+		File: txfile.Options{
+			MaxSize:  128 * humanize.KiByte,
+			PageSize: 4 * humanize.KiByte,
+			Prealloc: true,
+		},
+	}
+}
+
+func openTestSpool(t *testing.T, path string, compression compressionID, key []byte) *Spool {
+	spool, err := NewSpool(&testLogger{t}, path, testSpoolSettings(compression, key))
+	require.NoError(t, err)
+	return spool
+}
+
+func makeTestEvent(i int) *publisher.Event {
+	return &publisher.Event{Content: beat.Event{
+		Timestamp: time.Now(),
+		Fields:    common.MapStr{"message": fmt.Sprintf("event %v", i)},
+	}}
+}
+
+// publishTestEvents publishes n events and waits for the given number of
+// queue entries to be written. Without compression and encryption, every
+// event is written as a single entry.
+func publishTestEvents(t *testing.T, spool *Spool, entries, n int) {
+	producer := spool.Producer(queue.ProducerConfig{})
+	for i := 0; i < n; i++ {
+		producer.Publish(*makeTestEvent(i))
+	}
+
+	// wait for the events to be written
+	waitActive(t, spool, entries)
+}
+
+func waitActive(t *testing.T, spool *Spool, n int) {
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if active, _ := spool.queue.Active(); int(active) == n {
+			return
+		}
+	}
+	t.Fatalf("expected %v active events", n)
+}
+
+func assertTestEvents(t *testing.T, spool *Spool, n int) {
+	active, err := spool.queue.Active()
+	require.NoError(t, err)
+
+	consumer := spool.Consumer()
+	defer consumer.Close()
+
+	var events []publisher.Event
+	for len(events) < n {
+		batch, err := consumer.Get(n - len(events))
+		require.NoError(t, err)
+		events = append(events, batch.Events()...)
+		batch.ACK()
+	}
+
+	for i, event := range events {
+		assert.Equal(t, fmt.Sprintf("event %v", i), event.Content.Fields["message"])
+	}
+
+	// wait for the ACK to be written
+	waitActive(t, spool, int(active)-n)
 }
 
 func makeTestQueue(
 	maxSize, pageSize, writeBuffer uint,
 	flushTimeout time.Duration,
+	opts ...func(*Settings),
 ) func(*testing.T) queue.Queue {
 	return func(t *testing.T) queue.Queue {
 		if debug {
@@ -95,7 +294,7 @@ func makeTestQueue(
 			}
 		}()
 
-		spool, err := NewSpool(&testLogger{t}, path, Settings{
+		settings := Settings{
 			WriteBuffer:       writeBuffer,
 			WriteFlushTimeout: flushTimeout,
 			Codec:             codecCBORL,
@@ -105,7 +304,12 @@ func makeTestQueue(
 				Prealloc: true,
 				Readonly: false,
 			},
-		})
+		}
+		for _, opt := range opts {
+			opt(&settings)
+		}
+
+		spool, err := NewSpool(&testLogger{t}, path, settings)
 		if err != nil {
 			t.Fatal(err)
 		}
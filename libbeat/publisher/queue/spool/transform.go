@@ -0,0 +1,341 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spool
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+)
+
+// recordTransform compresses and encrypts the records written to the queue
+// file. If a transformation is configured, the encoder groups the events into
+// blocks, so a whole block of events is compressed and encrypted at once (see
+// encoder.closeBlock).
+//
+// The upper bits of the codec byte in front of each record tell which
+// transformations have been applied, so records can be decoded independent of
+// the current settings. A block stores the number of events following the
+// codec byte. The codec byte and the event count form the record header,
+// which is neither compressed nor encrypted. Encrypted records store the nonce
+// in front of the ciphertext, with the record header being authenticated as
+// additional data.
+type recordTransform struct {
+	compression compressionID
+	aead        cipher.AEAD
+
+	zstdEnc *zstd.Encoder
+	zstdDec *zstd.Decoder
+
+	// reusable buffers
+	compressed, out, plain []byte
+}
+
+type compressionID uint8
+
+const (
+	// Note: Never change order. Compression IDs are stored in the file header.
+	compressionNone compressionID = iota
+	compressionLZ4
+	compressionZSTD
+)
+
+const (
+	recordCodecMask byte = 0x0f
+	recordLZ4       byte = 1 << 4
+	recordZSTD      byte = 1 << 5
+	recordBlock     byte = 1 << 6
+	recordEncrypted byte = 1 << 7
+
+	// bits kept in the codec byte of decoded records
+	recordFormatMask = recordCodecMask | recordBlock
+
+	// upper bound on the size of decompressed records, protecting from
+	// allocating huge buffers for corrupted lz4 records.
+	maxEventSize = 1 << 30
+)
+
+var (
+	errNoEncryptionKey = errors.New("event is encrypted, but no encryption key is configured")
+	errNotEncrypted    = errors.New("event is not encrypted, but encryption is configured")
+	errDecrypt         = errors.New("failed to decrypt event")
+	errInvalidRecord   = errors.New("invalid event record")
+)
+
+var compressionNames = map[compressionID]string{
+	compressionNone: "none",
+	compressionLZ4:  "lz4",
+	compressionZSTD: "zstd",
+}
+
+func (c *compressionID) Unpack(value string) error {
+	for id, name := range compressionNames {
+		if strings.ToLower(value) == name {
+			*c = id
+			return nil
+		}
+	}
+	return fmt.Errorf("compression '%v' not available", value)
+}
+
+func (c compressionID) String() string {
+	if name, exists := compressionNames[c]; exists {
+		return name
+	}
+	return fmt.Sprintf("compression(%d)", uint8(c))
+}
+
+func newRecordTransform(compression compressionID, key []byte) (*recordTransform, error) {
+	if _, exists := compressionNames[compression]; !exists {
+		return nil, fmt.Errorf("unknown compression type '%v'", compression)
+	}
+
+	t := &recordTransform{compression: compression}
+	if key != nil {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		t.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// keyCheck returns a value identifying the encryption key, without revealing
+// the key itself.
+func keyCheck(key []byte) [8]byte {
+	var check [8]byte
+	if key == nil {
+		return check
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("beats spool queue key check"))
+	copy(check[:], mac.Sum(nil))
+	return check
+}
+
+func (t *recordTransform) active() bool {
+	return t.compression != compressionNone || t.aead != nil
+}
+
+// recordHeaderLen returns the size of the record header, that is the codec
+// byte and the number of events in a block.
+func recordHeaderLen(record []byte) (int, error) {
+	if len(record) == 0 {
+		return 0, errInvalidRecord
+	}
+	if record[0]&recordBlock == 0 {
+		return 1, nil
+	}
+
+	_, n := binary.Uvarint(record[1:])
+	if n <= 0 {
+		return 0, errInvalidRecord
+	}
+	return 1 + n, nil
+}
+
+// recordEvents returns the number of events stored in a record, given the
+// record header.
+func recordEvents(record []byte) (uint64, error) {
+	if len(record) == 0 {
+		return 0, errInvalidRecord
+	}
+	if record[0]&recordBlock == 0 {
+		return 1, nil
+	}
+
+	count, n := binary.Uvarint(record[1:])
+	if n <= 0 {
+		return 0, errInvalidRecord
+	}
+	return count, nil
+}
+
+// encode transforms an encoded record, starting with the record header. The
+// returned buffer is only valid until the next call to encode.
+func (t *recordTransform) encode(record []byte) ([]byte, error) {
+	if !t.active() {
+		return record, nil
+	}
+
+	hdrLen, err := recordHeaderLen(record)
+	if err != nil {
+		return nil, err
+	}
+	hdr, count, payload := record[0], record[1:hdrLen], record[hdrLen:]
+
+	switch t.compression {
+	case compressionLZ4:
+		bound := binary.MaxVarintLen64 + lz4.CompressBlockBound(len(payload))
+		buf := growBuffer(t.compressed, bound)
+		n := binary.PutUvarint(buf, uint64(len(payload)))
+		sz, err := lz4.CompressBlock(payload, buf[n:], 0)
+		if err != nil {
+			return nil, err
+		}
+		t.compressed = buf
+
+		// sz is 0 if the event is not compressible
+		if sz > 0 && n+sz < len(payload) {
+			hdr |= recordLZ4
+			payload = buf[:n+sz]
+		}
+
+	case compressionZSTD:
+		if t.zstdEnc == nil {
+			enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			t.zstdEnc = enc
+		}
+
+		buf := t.zstdEnc.EncodeAll(payload, t.compressed[:0])
+		t.compressed = buf
+		if len(buf) < len(payload) {
+			hdr |= recordZSTD
+			payload = buf
+		}
+	}
+
+	if t.aead == nil {
+		t.out = append(append(append(t.out[:0], hdr), count...), payload...)
+		return t.out, nil
+	}
+
+	hdr |= recordEncrypted
+	nonceSize := t.aead.NonceSize()
+	out := growBuffer(t.out, hdrLen+nonceSize)
+	out[0] = hdr
+	copy(out[1:hdrLen], count)
+	nonce := out[hdrLen:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	t.out = t.aead.Seal(out, nonce, payload, out[:hdrLen])
+	return t.out, nil
+}
+
+// decode reverts the transformations applied to a record. The returned buffer
+// is only valid until the next call to decode.
+func (t *recordTransform) decode(record []byte) ([]byte, error) {
+	hdrLen, err := recordHeaderLen(record)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr, payload := record[0], record[hdrLen:]
+	if hdr&^recordFormatMask == 0 {
+		if t.aead != nil {
+			return nil, errNotEncrypted
+		}
+		return record, nil
+	}
+
+	if hdr&recordEncrypted != 0 {
+		if t.aead == nil {
+			return nil, errNoEncryptionKey
+		}
+
+		nonceSize := t.aead.NonceSize()
+		if len(payload) < nonceSize {
+			return nil, errInvalidRecord
+		}
+		plain, err := t.aead.Open(t.out[:0], payload[:nonceSize], payload[nonceSize:], record[:hdrLen])
+		if err != nil {
+			return nil, errDecrypt
+		}
+		t.out = plain
+		payload = plain
+	} else if t.aead != nil {
+		return nil, errNotEncrypted
+	}
+
+	switch hdr & (recordLZ4 | recordZSTD) {
+	case 0:
+
+	case recordLZ4:
+		size, n := binary.Uvarint(payload)
+		if n <= 0 || size > maxEventSize {
+			return nil, errInvalidRecord
+		}
+		buf := growBuffer(t.compressed, int(size))
+		sz, err := lz4.UncompressBlock(payload[n:], buf, 0)
+		if err != nil {
+			return nil, err
+		}
+		if sz != int(size) {
+			return nil, errInvalidRecord
+		}
+		t.compressed = buf
+		payload = buf[:sz]
+
+	case recordZSTD:
+		if t.zstdDec == nil {
+			dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			t.zstdDec = dec
+		}
+
+		buf, err := t.zstdDec.DecodeAll(payload, t.compressed[:0])
+		if err != nil {
+			return nil, err
+		}
+		t.compressed = buf
+		payload = buf
+
+	default:
+		return nil, errInvalidRecord
+	}
+
+	t.plain = append(append(t.plain[:0], hdr&recordFormatMask), record[1:hdrLen]...)
+	t.plain = append(t.plain, payload...)
+	return t.plain, nil
+}
+
+func (t *recordTransform) close() {
+	if t.zstdEnc != nil {
+		t.zstdEnc.Close()
+	}
+	if t.zstdDec != nil {
+		t.zstdDec.Close()
+	}
+}
+
+func growBuffer(buf []byte, n int) []byte {
+	if cap(buf) >= n {
+		return buf[:n]
+	}
+	return make([]byte, n)
+}
@@ -0,0 +1,253 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package spool
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+var (
+	testKey      = bytes.Repeat([]byte{1}, 32)
+	testOtherKey = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestRecordTransformRoundtrip(t *testing.T) {
+	compressible := append([]byte{byte(codecJSON)}, strings.Repeat(`{"message":"hello world"}`, 20)...)
+	small := []byte{byte(codecCBORL), 1, 2, 3}
+
+	for _, compression := range []compressionID{compressionNone, compressionLZ4, compressionZSTD} {
+		for _, key := range [][]byte{nil, testKey} {
+			name := compression.String()
+			if key != nil {
+				name += "/encrypted"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				enc, err := newRecordTransform(compression, key)
+				require.NoError(t, err)
+				dec, err := newRecordTransform(compression, key)
+				require.NoError(t, err)
+				defer enc.close()
+				defer dec.close()
+
+				for _, record := range [][]byte{compressible, small} {
+					encoded, err := enc.encode(record)
+					require.NoError(t, err)
+
+					if key != nil {
+						assert.NotEqual(t, byte(0), encoded[0]&recordEncrypted)
+						assert.False(t, bytes.Contains(encoded, []byte("hello")))
+					}
+					if compression != compressionNone && len(record) == len(compressible) {
+						assert.True(t, len(encoded) < len(record))
+					}
+
+					decoded, err := dec.decode(append([]byte(nil), encoded...))
+					require.NoError(t, err)
+					assert.Equal(t, record, decoded)
+				}
+			})
+		}
+	}
+}
+
+func TestBlockCompression(t *testing.T) {
+	// small log events, as published by filebeat
+	var events []publisher.Event
+	for i := 0; i < 200; i++ {
+		events = append(events, publisher.Event{Content: beat.Event{
+			Timestamp: time.Date(2018, 9, 1, 10, 0, i%60, 0, time.UTC),
+			Fields: common.MapStr{
+				"message": fmt.Sprintf("Sep  1 10:00:%02d web-%d nginx[812]: 10.0.0.%d GET /api/v1/items/%d 200", i%60, i%3, i%250, i),
+				"source":  "/var/log/syslog",
+				"offset":  i * 96,
+				"host":    common.MapStr{"name": fmt.Sprintf("web-%d", i%3)},
+			},
+		}})
+	}
+
+	for _, compression := range []compressionID{compressionLZ4, compressionZSTD} {
+		for _, key := range [][]byte{nil, testKey} {
+			name := compression.String()
+			if key != nil {
+				name += "/encrypted"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				transform, err := newRecordTransform(compression, key)
+				require.NoError(t, err)
+				defer transform.close()
+				enc, err := newEncoder(codecCBORL, transform)
+				require.NoError(t, err)
+				plain, err := newEncoder(codecCBORL, nil)
+				require.NoError(t, err)
+
+				plainSize, eventsSize := 0, 0
+				for i := range events {
+					buf, err := plain.encode(&events[i])
+					require.NoError(t, err)
+					plainSize += len(buf)
+
+					buf, err = enc.encode(&events[i])
+					require.NoError(t, err)
+					eventsSize += len(buf)
+
+					_, err = enc.addToBlock(&events[i])
+					require.NoError(t, err)
+				}
+
+				block, count, err := enc.closeBlock()
+				require.NoError(t, err)
+				assert.Equal(t, uint(len(events)), count)
+				t.Logf("plain: %v bytes, compressed events: %v bytes, compressed block: %v bytes",
+					plainSize, eventsSize, len(block))
+
+				// the block compresses to less than a third of the events
+				// size, and better than compressing every event on its own.
+				assert.True(t, 3*len(block) < plainSize)
+				assert.True(t, len(block) < eventsSize)
+
+				// the block is decoded into all events
+				dec := newDecoder(transform)
+				copy(dec.Buffer(len(block)), block)
+				decoded, err := dec.Decode(nil)
+				require.NoError(t, err)
+				require.Len(t, decoded, len(events))
+				for i, event := range decoded {
+					assert.Equal(t, events[i].Content.Fields["message"], event.Content.Fields["message"])
+				}
+			})
+		}
+	}
+}
+
+func TestRecordEvents(t *testing.T) {
+	enc, err := newEncoder(codecCBORL, nil)
+	require.NoError(t, err)
+
+	record, err := enc.encode(makeTestEvent(0))
+	require.NoError(t, err)
+	count, err := recordEvents(record)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	for i := 0; i < 300; i++ {
+		_, err := enc.addToBlock(makeTestEvent(i))
+		require.NoError(t, err)
+	}
+	record, _, err = enc.closeBlock()
+	require.NoError(t, err)
+	count, err = recordEvents(record)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(300), count)
+
+	_, err = recordEvents(nil)
+	assert.Error(t, err)
+}
+
+func TestRecordTransformDecodeCompressedWithoutSettings(t *testing.T) {
+	record := append([]byte{byte(codecJSON)}, strings.Repeat("abcd", 100)...)
+
+	enc, err := newRecordTransform(compressionZSTD, nil)
+	require.NoError(t, err)
+	defer enc.close()
+	encoded, err := enc.encode(record)
+	require.NoError(t, err)
+
+	dec, err := newRecordTransform(compressionNone, nil)
+	require.NoError(t, err)
+	defer dec.close()
+	decoded, err := dec.decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, record, decoded)
+}
+
+func TestRecordTransformEncryptionMismatch(t *testing.T) {
+	record := []byte{byte(codecJSON), '{', '}'}
+
+	plain, err := newRecordTransform(compressionNone, nil)
+	require.NoError(t, err)
+	encrypted, err := newRecordTransform(compressionNone, testKey)
+	require.NoError(t, err)
+	other, err := newRecordTransform(compressionNone, testOtherKey)
+	require.NoError(t, err)
+
+	encoded, err := encrypted.encode(record)
+	require.NoError(t, err)
+	encoded = append([]byte(nil), encoded...)
+
+	_, err = plain.decode(encoded)
+	assert.Equal(t, errNoEncryptionKey, err)
+
+	_, err = other.decode(encoded)
+	assert.Equal(t, errDecrypt, err)
+
+	_, err = encrypted.decode(record)
+	assert.Equal(t, errNotEncrypted, err)
+
+	// the codec byte is authenticated
+	tampered := append([]byte(nil), encoded...)
+	tampered[0] = (tampered[0] &^ recordCodecMask) | byte(codecCBORL)
+	_, err = encrypted.decode(tampered)
+	assert.Equal(t, errDecrypt, err)
+}
+
+func TestCompressionUnpack(t *testing.T) {
+	var c compressionID
+	require.NoError(t, c.Unpack("ZSTD"))
+	assert.Equal(t, compressionZSTD, c)
+	require.NoError(t, c.Unpack("lz4"))
+	assert.Equal(t, compressionLZ4, c)
+	assert.Error(t, c.Unpack("gzip"))
+}
+
+func TestEncryptionConfig(t *testing.T) {
+	tests := map[string]struct {
+		key string
+		err bool
+	}{
+		"empty":      {key: ""},
+		"aes128":     {key: "AAECAwQFBgcICQoLDA0ODw=="},
+		"aes256":     {key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+		"not base64": {key: "not base64!", err: true},
+		"wrong size": {key: "AAECAwQF", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := encryptionConfig{Key: test.key}
+			err := c.Validate()
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
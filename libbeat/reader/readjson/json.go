@@ -41,6 +41,13 @@ func NewJSONReader(r reader.Reader, cfg *Config) *JSONReader {
 	return &JSONReader{reader: r, cfg: cfg}
 }
 
+// Decode decodes the JSON text of a message that is not read through a
+// reader, it returns the new text and the decoded fields like the JSONReader.
+func Decode(text []byte, cfg *Config) ([]byte, common.MapStr) {
+	r := JSONReader{cfg: cfg}
+	return r.decode(text)
+}
+
 // decodeJSON unmarshals the text parameter into a MapStr and
 // returns the new text column if one was requested.
 func (r *JSONReader) decode(text []byte) ([]byte, common.MapStr) {
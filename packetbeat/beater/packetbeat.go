@@ -28,6 +28,7 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/flowhash"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/processors"
 	"github.com/elastic/beats/libbeat/service"
@@ -128,6 +129,7 @@ func (pb *packetbeat) init(b *beat.Beat) error {
 		b.Publisher,
 		pb.config.IgnoreOutgoing,
 		pb.config.Interfaces.File == "",
+		flowhash.NewCommunityID(pb.config.CommunityIDSeed),
 	)
 	if err != nil {
 		return err
@@ -185,7 +187,7 @@ func (pb *packetbeat) setupFlows() error {
 		return err
 	}
 
-	pb.flows, err = flows.NewFlows(client.PublishAll, config.Flows)
+	pb.flows, err = flows.NewFlows(client.PublishAll, config.Flows, flowhash.NewCommunityID(config.CommunityIDSeed))
 	if err != nil {
 		return err
 	}
@@ -33,6 +33,7 @@ type Config struct {
 	Procs           procs.ProcsConfig         `config:"procs"`
 	IgnoreOutgoing  bool                      `config:"ignore_outgoing"`
 	ShutdownTimeout time.Duration             `config:"shutdown_timeout"`
+	CommunityIDSeed uint16                    `config:"community_id_seed"`
 }
 
 type InterfacesConfig struct {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/flowhash"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/packetbeat/config"
 )
@@ -50,7 +51,7 @@ func TestFlowsCounting(t *testing.T) {
 	port1 := []byte{0, 1}
 	port2 := []byte{0, 2}
 
-	module, err := NewFlows(nil, &config.Flows{})
+	module, err := NewFlows(nil, &config.Flows{}, flowhash.NewCommunityID(0))
 	assert.NoError(t, err)
 
 	uint1, err := module.NewUint("uint1")
@@ -141,6 +142,7 @@ func TestFlowsCounting(t *testing.T) {
 	assert.Equal(t, uint16(256), source["port"])
 	assert.Equal(t, uint16(512), dest["port"])
 	assert.Equal(t, "tcp", event["transport"])
+	assert.Equal(t, common.MapStr{"community_id": "1:lov+6OShb6bXMyK0gZjGX3RabtQ="}, event["network"])
 
 	stat := source["stats"].(map[string]interface{})
 	assert.Equal(t, int64(-1), stat["int1"])
@@ -26,15 +26,17 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/flowhash"
 	"github.com/elastic/beats/packetbeat/procs"
 	"github.com/elastic/beats/packetbeat/protos/applayer"
 )
 
 type flowsProcessor struct {
-	spool    spool
-	table    *flowMetaTable
-	counters *counterReg
-	timeout  time.Duration
+	spool       spool
+	table       *flowMetaTable
+	counters    *counterReg
+	timeout     time.Duration
+	communityID flowhash.CommunityID
 }
 
 var (
@@ -47,6 +49,7 @@ func newFlowsWorker(
 	table *flowMetaTable,
 	counters *counterReg,
 	timeout, period time.Duration,
+	communityID flowhash.CommunityID,
 ) (*worker, error) {
 	oneSecond := 1 * time.Second
 
@@ -83,9 +86,10 @@ func newFlowsWorker(
 
 	defaultBatchSize := 1024
 	processor := &flowsProcessor{
-		table:    table,
-		counters: counters,
-		timeout:  timeout,
+		table:       table,
+		counters:    counters,
+		timeout:     timeout,
+		communityID: communityID,
 	}
 	processor.spool.init(pub, defaultBatchSize)
 
@@ -194,13 +198,14 @@ func (fw *flowsProcessor) report(
 	isOver bool,
 	intNames, uintNames, floatNames []string,
 ) {
-	event := createEvent(ts, flow, isOver, intNames, uintNames, floatNames)
+	event := createEvent(fw.communityID, ts, flow, isOver, intNames, uintNames, floatNames)
 
 	debugf("add event: %v", event)
 	fw.spool.publish(event)
 }
 
 func createEvent(
+	communityID flowhash.CommunityID,
 	ts time.Time, f *biFlow,
 	isOver bool,
 	intNames, uintNames, floatNames []string,
@@ -314,6 +319,10 @@ func createEvent(
 	fields["source"] = source
 	fields["dest"] = dest
 
+	if id := flowCommunityID(communityID, &f.id); id != "" {
+		fields["network"] = common.MapStr{"community_id": id}
+	}
+
 	// Set process information if it's available
 	if tuple.IPLength != 0 && tuple.SrcPort != 0 {
 		if cmdline := procs.ProcWatcher.FindProcessesTuple(&tuple, proto); cmdline != nil {
@@ -338,6 +347,34 @@ func createEvent(
 	}
 }
 
+// flowCommunityID returns the Community ID of a TCP or UDP flow, computed from
+// the innermost IP layer. ICMP flows are not hashed, as the flow ID does not
+// contain the ICMP type and code.
+func flowCommunityID(communityID flowhash.CommunityID, id *rawFlowID) string {
+	var flow flowhash.Flow
+	if src, dst, ok := id.IPv4Addr(); ok {
+		flow.SourceIP, flow.DestinationIP = net.IP(src), net.IP(dst)
+	} else if src, dst, ok := id.IPv6Addr(); ok {
+		flow.SourceIP, flow.DestinationIP = net.IP(src), net.IP(dst)
+	} else {
+		return ""
+	}
+
+	if src, dst, ok := id.TCPAddr(); ok {
+		flow.Protocol = flowhash.TCP
+		flow.SourcePort = binary.LittleEndian.Uint16(src)
+		flow.DestinationPort = binary.LittleEndian.Uint16(dst)
+	} else if src, dst, ok := id.UDPAddr(); ok {
+		flow.Protocol = flowhash.UDP
+		flow.SourcePort = binary.LittleEndian.Uint16(src)
+		flow.DestinationPort = binary.LittleEndian.Uint16(dst)
+	} else {
+		return ""
+	}
+
+	return communityID.Hash(flow)
+}
+
 func encodeStats(
 	stats *flowStats,
 	ints, uints, floats []string,
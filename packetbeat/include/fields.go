@@ -24,12 +24,12 @@ import (
 )
 
 func init() {
-	if err := asset.SetFields("packetbeat", "fields.yml", Asset); err != nil {
+	if err := asset.SetFields("packetbeat", "/tmp/pb_fields.yml", Asset); err != nil {
 		panic(err)
 	}
 }
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsfWt3Gzey4Pf8ChztnBP5LEn5Fd87Pju7q0hyojuWrEjyZDI799BgN0jiqhvoAGhRzO7+9z0oPBroRvMh0o6zV/6QiGR3VQEo1AOoxxDdkeVblPGy5OwbhBRVBXmLTtznnMhM0EpRzt6i//4Ngn+3cyIJmlJS5BJlnClMGcqxwghPeK2QmhNE2D0VnJWEKUQZWsxpNtc/WBBKYCZxpuEiLtC04Au0wBJluFK1IPnoG2QRvIU3hojhkrxFkoh7IiyQJHFAHjyN+BRIMe8gNcfK/J3D1wEJo28iJFlBCVPjXXFRRhXFai06/Q7NyHaICj6jGS7cy48b3X6wbjpOWq1Hdn6FcJ4LIuU2q2fen3JRYvUW5VxpYhhXuH/4uxOzYtjb0CMILtZScx6ht5gpm7VRIyoRRpXgD8sBUnMqzSbycOxmlfAeF3RGGS7slATDHfkX3nGBfry9vRro0SDygMuqIAN4PZgd8qAEzvQgp4KXCGs8UzqrBZ4UxMPScNCc4JyIAZosUU6muC4U+vT34TsuFljkJNd/fbIzpP99ZIVG0AxFjzCnUgPOB4gqhIsFXko0x3rk97ioyQBhluufSqyyOZEemKb6k1//TzAkxpmZLzsLsr16p5tw04zw9BJqNvqB8PMrRJmBCBLPLKd52SFUy4q8RTPBawcpFIAh0oJnAMf/4F8mfFxxylTwi12zt+h/F3o4370YoEJT9uf/GzzUw3ZuI5gROLSO/HAqHeOg22il8D2mRcQE+h9nxRLRKVryWjMBZQTh6IG5UpV8e3S0WCxGpMBS0WyU8aNZTXNyRNiR/U4SLLL5UVXUM8rkUYmlIuKolpTNhpTNiFRDWJjRXJXF/zKDuBI8I1Jy8e8IOKaiFSk0BZQF6mkPZHQJOIdv7GRWjg5k3vt3rQaBdPSez6TCcp5mtYoLtV50FXhJBHqN9NNuvSzKvUoveHEzkvyjmhDFM16gWmqRwUWHBi3wGFdIViSjU6q3upqThuFVVgF7SVmXxliIWL3OqxaZy2oDTaefcpMVCtXDSPYZcXixvPnp/QBdk5zKgV67648Xz/T/D7Qtc6DZKcMSwOkvvFgR5NeaCpLrqatJTOWelnYfWlID3M402IiEmKHLXO+89Wi0dYpZPoR9+nh0dv/sNrJNjR2LbX9j3BSxIAXBcgOMkk/VAgvi3ghtHG3bwf+7JsYoAEK1wc7QhBh1xsuSKkRzvQ0wkqTETNEM3RMhDZ3W24ANMSb3BBSVdTkO3mkf4Ex/eZB2PDZxO8CRoEqSYtrnQhxIhYUaK1qSg0j15liR9C6Nd84vv/zyy/DiYnh6evvjj28vLt7e3IxKWhT0H2059PL5i++Gz18MX76+ffH67fM3b59/N3r+Ly/+sYEooqU1s6ZUSIUqnN0R5WUlDFObPBNCGJKEtLngQKumP8wYSy4VEiTTVqhlepJvPeapNmbX2NEspxlWRGrzAxhQqxE9V+4TAzyggACe/n2KC2kpdUzrplALYYkwQ5QpIkqS6y1qSJVK/6ltnTadBV+Mab6OUkWExm84OkcTrOeEM835jBjFVBKF7Q5geWO8R9juC8zWoWJEwBL87f3xpTfqQTlThhhRCy7u7HK0wfNaETFej+SGZFxb5dviij1lXgvvsnbt5R7UV4JXRChKGjcO4KA5l2qNqV3ibDMz+caAvDg+8YPCElHLb7n2d6KdrPnXs3ZWC6G5D1jvmw4R3rVYQ0OzkOdX96/dKLcmJ4K5lrTx47yRg9fPR//y4rsBGv7L69HzFy8OdvZGaDU2I/4UerLwRuOPIKkEZbN4iEaVOF1XYEVVnRPYUwVnM/NJkgoLN3fY6OvEhJj9sOmKdXbFoxYuArkhTzk6v5rl8wStX8QIpFnQ/S4ire7fPGLLvflCW+7+zWNX7Y1ftTf72nT3b76mbbfpuqU23vbLt8vG+4oWMSDpK9h8gRe8bhHNcoEjzOpyQsT+dK623mR3YQJrYw1xHyb/QTKFFlTNHV8prl9QlJnpB8uuJFjWgpTh0SNKGCQhbYyosbWQxoorb/TGtOqJb/2wglxgEQ3LzSSfOivsm14iJktFPi8JgOGblhmoJ3F3IzBcin1agqcB3K/JHAzH+5/IJtTD/ipU0y4W4bZrFwvpLXjr6zUL163j12sUfsGN9xUZFUDMV7P5drMLv/j2+4rWMSDpd9+Cm5uGoRL++u3DkL8Ud+bik324qX0Y4qVZWa0/XT25uEI09+eO8NmcsAbr7e9y/InrWsAcvsEFuj25Ck9qae5vP+AupXP7cRvcN+56CRIFhHTuQmJTmgpDoR1B8lJg7WH6Yk7UnHSucbVQoGzCa5ajQ1JSZTedCWN51kya0IKv+1wTI/FshP6Gi5r4+ybK7FsjdMldJInfIBWXkk4KMoZ4kMiYpyz4wGvVOmBWWNVy9bC1zJvT2RwV5J4U9pXEtbGRjgu81Fs642VVKwKBLB4SUIdyUhGWS8SZu/SDy/EBmtjlFETWhbIRLiXBLNSYlJn3tahqrg0BQs+V89op+vDX4MOZEFwEn29MCFL76xMTQmS+jqa0JGrO1+ya4AL06J6IyZF5KTmpTUQSBAVRGVlJ9kW4vj384ex2gK4+3Oj/frw1YUGSI86emXCmm5/eh0CQRo0Ob87en53cDjzIj1enx7dnA3R69v5M/7+B0rl5je4nVl3h2zA694a54QVSwu0jyJQIiRRPjNrD04R/vH6PKqzmqK40sxlFKxWSBZZzdHj0zADwIQx06l+jEn06qiUR8ujFp0EE1VPXPPPJANLyRktLOeg8qJaVHlqxjJZF4UlhLtVbNgPjCk1pUdg4EFwU0QxoNdG+cdIDfYS00mhhjtpCatUsu2mKA+I030RT0DwbDlQ/ekeWQ7PNpeLCPd3sXvPWHWnfEf5aE7GMzjjuyHLBxQYbCV7VAhKjeV1iPUCcA1nmcjccJtUGiJ5zv2qTZtEk17tJW24FvSPo0w9nt8iyytjEPP0PTexflDYLDVQbFUNVyKFtOGaDafUL0YIAUasQYSbOwmsvusCljCZEkYcNomU0ixAw8QQuiSJCxsustSkWJoBBiwqtVvRAg+ejtb+dCzpVw+urk/bbzRtmXKrB3hoM44qsUTIXREo8IxbUFRhaE4KV0+dhnF0ta1g6H9xJtBRGpQcRSGq4pq4E8cGjAi+Aly3EMErRqto5KappXRjDWPB6UhA551xDaEI6BF40xsw1fGjHQXbNFoc/3I1AS0/khp3NLblAr5p+yuvF1pZ1HIKlcQCsHl5Q0WyFQ1xVBbWekQnA4qxYWrk6oQyLZQPfg+d1M/OCVIJIwlTkXqUZRBBZcSbJ3kdqwP7eQ40M4dDBCezhi+BrdBhYx/LZNpZxCB0JUpgAKp4KakpznJkxRcsN1MtCq6+s4NkdxLZoMag4v3P2X0EUWRVNpS03klHpLWcEETcSjiRkHCYMnlPspFT1uI9MDfvk6uPWVPXhMn4dXRPyAXF0safW5gV0yVVo/Uj6G2kbN11+tJINFYTN1HwAPrTzfcx3Ds/5FQqEn/bJTPx5ajbNFy4Ayl48dEetfYbHD9uw0x9r3DmTAWN13lwT4QX8hu+ItrCsbaJcgKXNZwDLD83oPWGNlGjgWAHmTRQfNXz98QIdCoKLobYhhiVnVHFB2ewZ+E4Zbnw9XEiO5vieGKcLlKJFP1R8aAnRPkjN3KQv5oSh08ub0FrzuF2UZE5lxu+JWK7byZngfienThf2MsXu8Kp1+qC4VuREauuUyrkZQsRsZvK3EEy9wyk4zvc6Fi3KtW9pBqHBk3zUZQsPaVP2oMAhqMR3mhG1WqQMcTUnzcxk2sDX2nJBiuLRM5Lz8pGTcs5WDML4XnC4lp45D+b0w0Vr9s4ZUkSUXjD9/Apd4ns6M4x/S0ttHh5fnafdzZxOp0QQlhE0IWqhLYlPOS9PzEK9BxxnLP+kXWX/YueJG4UF2PnOHtD2bWMBfG8+JWbmxNm5JmsR3nN63yecgANUFDas0/qRPYdgGsBI/7mBYA/iwjWFmnNyf7jNZ97qHrmcLXgKXgtdIqJswkmTKmViAqhNr2JGOxjf0QoegAm+lXYhQmCGF+ZcKovJPn/LAVVEx0D/Zs729cdPrbPLfrpG3UlzGDc4MXO0YW2HqVqw5oyPV0SYdDa5lIqUiAcJo4bwYO5EzRj4Hh1q9C74jW8Sbe+e/JzU2LD39cTYBx1bATvD4s8II8KG/FNpWLkd5/0/9VCkwmX1uEDv4Dl/iXRcz2qp0Ms3ao5ePn/xZoBevHz76ru3370avXr1crPZBZKMCvVx07BBBMm4yCHZ14+vnVSEZ2vc42MxoUpoT0Q/a2bLuqua3ysizEJhlsOHQLE1kmxZkU4EuZYO0TxyuKaxX5kP4y0OZLys0tI7Sou0yFoUkOBcdePYFjh1bXk+mn9xnlN7HaH9+jAnCfA0uY59MS9GmPnvE67oCrIa0iycUQdBxvMu9Nat0FroGkgXdJDyhXoO0TaCbtjE5ckXvM6DNHn9UTvD9zQH+1zhHCucVlsX9ldzpJNFr0q9Vo0Iwnk+hgfGDmSTL9irxfSjI3hr5MC2NzbJ1uzey0C9xRSO0JW9MXAWNBZEAxygWUYgAy6nM6pwwTOC26kZAW2USYVZRjbIgDAPovNTR5JWIqjE2Zyy9tZNYVivmTyOUK9vhsU+MA74zM+zejkqSU7rcjX2CwMiyjvcDLk1c2hB1XIcqLwmA1IOCZZq+GJNrttxAAiBRgyKNVBpyKGyUXMrWA5ko19VT4r9ZfiwOevZVzQtP3A+K4jZaf3YBZmtVbXX8My68dmNnvPsDvaP3emn7nMCuPnNRASgjBcFaVLhzW96z8o5F2psNMBbk1L0DUKYZXMuHL6h3+U951GeLLRVwrjVCUSMaL6bTPzI6K81aQAimqekukdXptTHVhhDvgBw3ik0BGhDYlLTQqHQae2SEgiDR1Jy4nHC/c4KXAWekKIbHRLZEmi1PbGGlnOYCYPHM62NYrUs+6P5lAByro2BgFGtox2LnoY39fdrOTOIoN2cL3dfkx+tW9FdjT1xuhEQCSbHIptTRTJViz2MIQKHDsloNkIP//pm/Ob1AGFRDlBVZQNU0ko+65LC5agqsNIm/W6UfLhBDpClISNMcTlA9aRmqh6gBWU5X/QQEXs8j6fBJwwncExxSYPDnseiMGDsIAXJ51gNUE4mFLMBmgpCJjJfM9o7IhgpdqPkNuFvfiuRAd0/D1EwsUG7aXzxeyohUOT8amij+IjsIoiD3R8xMIdmjkW+wII0yAb+vvLi+CSkwUmxu3qih2+uSK0s+2v4XQJt87s3wmOLugGKQkm2Wik3L60VfxHRaCshWPF8D8opmIHKBsCk4ifzUb2rYAwwXfEcfTw/7SLS/5UVzvY3qAZiF5n2//Y6gxpizxRuqto3Q2SgoRJXXUyYudoce0MXgEzj3Ke5FODNIstpFdo9GIxJvAaulTC4/LUKrpqPL3666twp6y9d+ZnMHl+5E5u0CLBQ0VabX5CqWA53PAYBWgESnIUgxRFm5ohngCQtaYGF/nKuVJXG6J21189fd5fHvNI69HmMtiMPCpGHqggCwoHKRHx1VmAphwlZtfm0vMO00GhsTCBATGAyP+8V1flpAg95yOaY7dMdchBXIBvu4RjMgrInYc0PnmmmmPlIWhQFbUlJ77voJ5wXBLMNPZ2pueDIOQQLZoJg1Qz96Nea1KkJyFvF53bC7UOEHNj1+MlDVtT7G72ngDWQUR9uXCs+zElB1J6wBwANUnOwXzO4ZUpp5OEC0660eBRy1RQ+hFhFzQUmkMJH8phtl5IinMm6JGKo8IY7+TwnTNEptWEJ9qwBgAzQPS5oDmEX7pLMJoloZmCkSPEhKeg9EcsWBdsKmFs/CUO9qWaM5HC5YREPvaZy+JDCs6Swg5P4YcZr1l2f7ehpglx8qKGdFuCRgT1ng0WbEPQbETyKO9D/GFkUy2FOsgILkpsXU0LaL+R+CXdg4SIc924owWvtoA3vyI6epw3rdACDwF8Ubx+c3e199+ScmIBr8lCRTCGc3TG+KEg+s/Fx0yBqNE1WwTPcdXn3tq0lYXnDTHZzh3Escxzn1lW1C2hRc1KmUiKnQyOldiP61Mg+V7iyV/DR6ZCUlepyyS7YAGICGXDrjhaZ26w2QsEJP9ls41Dc3c+5j1VFkYFoxc6u89ykFNnYOOKD3nzlr0qQe8prWSyRx2p4hcoIGBcIMwjo8ZVWu/KwLhStdrUTjpud5CGu2klYzGoX8Bqj3cax++ByzYJ7Xg8ZjC8zMZKXTkXKETox1+R8GsG6x0LPaZRwEc0TZjlWXOzI2c36eoBOFqZ2U2kLqu2G9NraTh6cV5JpRaMIU3uwmy/OL86a4LU+21l7VUfgEfXTQljG8zhNeld6HMjEDNhI0fWs+fjbC6cGDSqbxgCpM6ssqDLlJW/nPHE2rIiQVMIkHL6Aiq3hNy+fpdKOBeWCJqT65maHG7EDNUDP9db8c5IDBQSqU85STulWAz4OYogDuI2gT7ne1t3nO6K2efKK26MJxZNuUkVFOs/9URzVwPOHN2F5W5Qwhfc5x05ZrZxfHx61nyF7cClUu0sxh2VZ2by2LhaITN11Gk+0Y69tYoiUoinrClfV/tCE4fWAzYX4YCkxywUOTghP3HedY0L/C7p/ffRquwPDEBNaH+91HqRmNbneDQHNEUHeJBpYQP3Hj2FGVZqIDiEdlJvWJehqln6M67G2UxpWURBS0b2ljClJFCXoEJMI22xnXXcRT4smrrGLtsvFSczvNBBg3iUcoXLbG0FEl+Bt1FIJgstdcaNjg8emohugevOAV4ddHwkJ6ezNOjmhCKUuwO1zL6K/21xRNKuxwEwR8OSs5d881kqfMaPGIWRzxPD3/hngbebaevTHzF3V2sRkQ0NOpZYntXZDjduEM1Xjohv02CbJpOzsxIfHkNA5I6LJufPB6FFC0ITnS/e3WcNDbP+gEhW0pDYx7uV3by6+R5TZ95+Nkjs5TE/eZDK72Wg/vbfJQOaQKGAdSExzmz1pnkTJkWh7oRXLRvSlpJZlXgtv4Hi8NjEf0M4ASR8iD3vnW2kffxJyT0LuSch9PiGXrmFkKq88buefEoVpIQNTzSeaGLDbbumWLf+o5Y3EUV10zyVa4+eL/r2cmoFNZiFohrLJ8EN6WF2Oe2hC61iqQ9p1m5maawGNA9lfzZ0GlT2rFhNYEoVXEtc3aR3qTnhZcaj4P3Vr5SKb0iSsnsGQyDuybMfmpIntZ6okyR9YsfSzhqeKCEjG+aHgE1yM4XhHjrWHNHBFT4CMVjRtH9Wq00bqy5McVHdZS2+fItyJ3iuTjRHX6bBVHMwXwM1elZQ20iJ4fD3lGS/G7Wu2NPUrtlqH9BXbLeNFXTKJJLERyTZoz2WLY6hokdeZy4RbtRXDkVR3ZDm20D/vYK7+6kdBWU4ezOWsnsSksGuRiWeUzcbQMGTfHGPKHjXwTdlNU5XAJJeaDkpzXhe5Ni9cSbyfPp5d/3J09vezk4+3Z6ZYhB5u7cDZcwYlKLknAbvlZk2DwmXmHp1Ks5792maFXNpKydlLBs9nQcSMlzkw6KCZTIKZvFmZzUmJx53gnZi2jbThbTMpimvjMgTdb0ttphx7CdxkAjukdlncZSkZPJpH7nlxT/LVGnGNstmaLsj7N99MoPKg9h79suoVtfStJmuVNtkPTUZXbExQ53ZlnxSF20BimiM8nRpJa9CiQ0KbAo6a8IE5htWIB2haM7h/hyw/PJsJMtOSREN8tm6axYzsb1QATYtVI6oO3n28PLk9/3B5AI33jn/44frsh+Pbs4NBcwvrL0RXE9oKd91x8omfsqN4ulYTgUWvxbA1ER8YcSV4oXshzuZ+LsxWPsQSjmH0h8QyNpdfpMLxxX5M1KMk39X12dXx9dmuMs8RN6Z9k7L1xHXknsNhzZHz09WLKMiv4/25AYmN3Jw4PLkDvy/JT+5ATP2TO/DkDuzuDqDWWf/nlaY+XcxF+1oqky6B+fckWJ8E65NgRU+C9SsXrMkrDVlXFReqY8/3xPih9XF+namIGgNoVxgaLteVLTBmKsd4OhwTmlhwW9jP3XllvDQF+HB0L4YZ+nClHb+bxoFIjhbXaq65p9NKBW1+kZMKSraB67aylmzhMTXizdjjX1BJsjlmVJZ6GLXsyLe0bomS4jocuZpfU0djVvdNa6hXi6WMDsnOjxuaudA8WkvSc0O2wEILvvTt+IaxAFCy2OJ28AYm+p1nWS1MstHP5heQ91D0AzR0kqi4SfNWiw2tN1BVQ05BizOP3d0v3MQCfYJkhN7b8mZNyUqIokbUnDBen/1wfnN7dg1Xj7/HpV9HiDY1Efvd/jXHnRuivg0C+CH+FNK2NB36T5Ipek8gNDRxwoimvCj4IqqKBRGlllUYWRwJUvJ7kpsU8N6xBLVJ9jeJkCNOqxUHJ3GzohjrJvfeaZQa7Bc7rLZ8ndtb3KCAtkGEXAJqF9bTkfXTkfXTkfXTkfVnPLLu0f5Ra6KQlrXavzGPXP0EVy0GDPGmbP1tK9qoHaOFGbLvQ0GGUJPhuD0HwGID2wUKnErmkvtJZVi45KIpg13ipYW3rS3RqvkQz82mAYHBqOzYU5GVvTSUMoVla5simsJHEbIPw6qhRAUVHLciw2rW3TW1U9GuNIQpq9F9cRO1LAjOxxlnJisqawf6bjpXHTq7CjpAYtupWfqDIwkl6GxmkjzDbZGYVNTKbKDpayu0dajYqkMVbZTJtnOPC+0VQOoTmLndga4hHwB8dtoFgRwYS/6CCILuGF+4hgFmFL6Gs8+7wLnLxAXZSHJ0KCnLQOzVrCnB7teqibTwi/ls7fqBZ/Wl1m+O70HEN4m8eTjmNcROCp7dtUsbfNYFW8y5JO0MfihkavkejkmyORwabct8C0FVVNg2PaBtdv6pNewisxwcfo3LbnRaavOuXjfbOVZ4bKdoJYHdLOF+As8VtFZ0V6wwzXZbYImwvLOFMuGuQO8A68tGZQBS1O7bmwBp770HF+2MKbTQsybcGpL26knsgR6pSrXHG/zuBqqZEWtRXaYUJawux5r2WpC9mbUbKg/yUBFBoakGRpYG7ZeBHCVZ3XRs20gkuanf6zKHp4TbLTEWMxAo+5vVLb2FfrJ9yed5Vt2/DrI+T388ubp/3Un5NF9HGZ591ZodRLRVTbigWcF4+3TX/xPNXthW+Px0oH0YzHJeOh7MtB5h9oQtetOcdQ7MPUXUjNd0QDUn4FrLSMkz2r5S8VVcwnRU6fvF4BBWq3G4OW5NtNw23XU6E7IqCz+ejUu/8SwsRApc6QEa+8XSNCEzHDR6z36tqaTmCjBW8YIwssCFM4QSNLdvJx+xhDYZSpjWFvFKKO7bmaM5X8BPmj/d8hiLNAJHoRFeVSzRcIjsGQr0vJIKcYEmguNcf0jW5NNIx1tVn70NqmQ1neObHgM9dVlckavtkLV43zRCcyj95U04QZB1p1H5PLOGqBiWb7epXykkt5YyluhgyWtxEPbFT/GuRrfH0dgJDMfiB+hS1Iw7UkvS6QiFoPTWg0JSkcpV7ZpwrqQSuFrBz4IUeLnrMABIOJiEjLEXoTgLL9wiSId0REYImykwIG1j8X7WfURd41tP07cSXRyfeKIPTVM+teAphHbB2SPKqHYnLNS7bmcHfaf8UZKrCDRCH83tcgRJT9SHd+/OrvU+1x+OT/66qkoRr8bJwqRd8n01G81CIFw2H5s/xKnMqdKhgWFcTS2QHMjULM95tZ0+iMu/6debbeQP8YD/5oLXs3kKpy1p3XaQHrm2zhlyYJv+dZDgyDNcIEbUgos7dHimxTUjahCBea8fusXF3QARlaXmydy8d4htny6tSoO2s5NyC1cZb5414op2aybGTY6vp+FmKVqoCYGq6VC4Bwq5u2aMgw4wPp0S4ctoDlBOsoIyMtBkDRDDd/q3gmBJBjaIp31A0QSR2DbPYwtsXNDO9eLG99+pYVPpW7E1otHKuUY6ui3S9LPsgLKzR/JWM7ewt3VyjBY2qN+xlXfJEdKVx+mbDY4G1qAb1KEe7On5zcmHv51dPwMrsyj4ogMvVhjubVCEWA9T0awusAh1zYR446IvRMbqal/DZy9D7+pubbnd07zGRaTGzV3cHLO8sHFYHVirg168CffZls4xlhGeHp8foAkZsTcZHUhem8p6wnqDOEr8MNYO1NgJHkl/SwuexMHao8dS4gda1qVLLI/EjbOvegakGXpBi8JakjjLSNU3OAi6Wcdh+xQfgfCAklzcWgrF0tWq6nqA+t89Ybm73zChdqEggaKpAegR+hs8L1GJu7cG2ZxzE7+VkyllgXS3WEwoUjMr0lqB96QLLNjcLZqEaeLq4bgaT01oZgeYyU7HfhTQ6txqLhOR2hAFF3BQPo+s5m6v0CP6ehgi5yWmrG0u7pEXYjY36IxdaZontl2GDjC4BhBE8gJOyl0/T4nuKTY2lIEJBcpvoJNJ31iZHBtZty/JFA/IytHOwDEqbFeSgNQONEO6BRI2lG15h8mhGUZ2G3tJ2Wxsgx6TI01GUKwZ7XFkCGhmNO1cm6VWHNUMlxM6q02Z1I12OJQawayeYqhHY64/PA9HPVC9vOsAs23UbG0bPlXwslEHJhRDb8S8lkos4VqCC0VrCIYE8EkFbymcEC3o5ajli7uQETAkrt+doFd/fvldb/CrVjjjEsu2Lfp4zjMwkYa5ygPnTcFwlggvsRZ+D921yqC3/5hPp5KosSTZ3jShbbttINtpjYWF/Sk6svm2u/Z2Iijzh2vQAe+Ec5FTBnFjHxnVmwoX6FbjPPx4e9JnZgteqz1ZXnDkAOBWyYTGPrMdtM0r3XG6ldzIioFV27ewgwVbL+X0ZvjXN/8aPt4dzXbyjalqz6NJaaieRaFNXv/l7VWX/x4lsVsdrOPh7Fnrsqi76gqiGq9rDD7p2LLR/nb9I9yw9uE3skdK12c/fTy7uW28tB6vDCMYi2HH1IEkirykEbS/x02ofVUsDUFwhvVs4ExP+0AtE5dLLbVolmNpS0d5YmjbdofTgj6/pNMTuVmJVoucR65E4+03lyy2DW8Tl5YmA5ns6tBCgHXVX1we/7WpTxt25QYr3t44dkOGjleZGh746dnJ+/PLs8ZX4h1A/qICrv3n0WmvPY7Jnb6BcJ8Njing+uVz7o54AxtuYYqIe1wY9eZvieBMoUyFJNRM0SLaFAIzc6Hkmxxcn12e/Xx++QN0ke1z7AWZUDj1/f9jxN+fX56uG/KEczWe0oJ8RtfI7TvFG0sZA2aNuIl/+lZ//NaYSAnubg6SbVlzH/TkT3ThV+sQNK2GmQwvnS9vujfOlzfDrSoL52z7NoQ79b/SVsnp5Q2qcHanbcDGW/bdauz1TiX4TODSmMozwogwGQUtXWBS2ABuAIxKlPGKEt/4JxFh2X97sQH9Te1D699j1doQd5RBTTYToGhXPVnFAljMpP5RGd7dckFn2iDmwnedEUt7ugKDo8wMLwKXKlvqD9chibDn9nmEazXngiqsdm5HdQyzBClYVpeaOyisguXIzam8v19lyFGw7BxTtw8jnCliY0ClStduNwMTJKuhPOnY23yfY3iLOYEDJYvu3kWn2hRGGKO3OWn3zDM4lNhgKDmRdOc+KmvWyWldKkimZHitqE2NWsiatIIyzIj9DBTLEbrunw53utg7XJ8VOc5xlNz/OXjSkWmHCNGQOZwuBhIkAunJ6x1ANifZndbEOZV63b/QegEu2XcjriUthmLC0KjMn9H6gOre4ShRM22b5ePe6sr7Gg/kTUIkFhVSoe9evLRZ0j6ZWRv6CyLa4s8UT11REHqlvHcSXhsbtQTxnpSklx/Orq8/XCe7LRlp1DJE1miVULiZ+0q9EpTkI3Q+bbxCc+5i+5VKxDgbVoKybqRmNscCZ9ooRocTor2tVy/hYG3C7wl68fLNMzh801KISxI+jgVpCui2AquxRERmuNJ6WrtFL567mrsSHf7z9PT02Qh9j7M7JAsMJYC1tvq15pAqI4h7uaUA8UQOUIaFoNDzDFZQmuRobe2jKSG5eR8O+YVNLfynGqB/CngugvdPFmWNJpdvsViMZpzPCjLKeKohmF/G1j12NyvZ3jgLknGRy9bipXAfHx8fr0DYTt5ORJlgczm4FdbzyxU4iSrycVXUcszZytESyK4zSQvV0KRiWNY9JLfvT58hDQVxRkw2EjQujpe7c2ei3/uvL8DwPZhyPppgMZrxArPZiIvZ6EBrioPwi7b9RJCvzJJrP7AM2sbevj+11QGMU8IQKScEWn5nvHKJWRFAA0w/PVeqent0BN3jMllPp/QBKEjNLy7xb3r1+Ki+SwWqMbnYqFvSKmnJEBYCL93+N8lmOYWwTaxtQ7ifMiGugA9J2xHP15SeLDt2Va/JYWnuFB95jNUf5iZIXouMeN513Ze9QfcpZ3JkkX8yIi9M4muRt1LQtkWru0DwdUtCUlBFBMjV5ALbP3rkhSNmU3EBTNYaeZeiJCEXf+9Hv7nw0EpuByJS4sTPgSo2Z4z45CC4FbBWTWKZSrxEE4IynM1b+mlCplrq0DDHKqcywyLXmvQfRHAXCFMSzBrLCWYiEQXLuGpQjdJ7oHceWjbrOgtAk2BvqZoYfjPykQ2Bw8yXE6LSvVGRONrZXz00t/Fu0UOYfnW79Fs3jJLPLK+agHzv+DmBBfK3LZnNxK6m+HeSVg0BXmK1gfY8COzMTbyAZTfKsqLWKqqd7RubeO0Yiys4VZkQnIyUbhB/JRIzIOgLSM3Lm9Uk/L6S03fm/GI7rukF+sgt15D8O225hoA1W67z4Jfacg3ir2TLBQT9XlsuIOFr2XJPBkswF39Uo4VXatTtZtVhqTPNSva5JK8cPD9IA8+7jU7XnHWFLcyDZD24gtYsfXN20jMQ8qDGYtUx1dmDIiwnTcqcLSDSFoPNsL4/Pv3b2fVNz+DqvGoHzq4X4rZhMhffSvTx9ApVeFlwnCMNCB1SZg7snjU9M7U/Hdxh/Xh7e9W5xNJfbneLZaGi5DVWq25LqjWmxrinrpidkWzcOXNVSgVccLeDFlZsTNR0bBfL4Hpcqzw9AVaijNIPYUE69xRuqYcfr887qPSUKVvw1Akrl4ZoX4epgEI4/pY0bKDtLr4UR58ehovFYqhhDWtRmADa/FO6veCqlnt7KVHZnddjVOIqNK9gLLgysZBho2rpDapU/1Pz72cTw2+GYRoaVq7Ln7c1JgWBQ2D3WNM6LhWXakkAQ0KvQtSeyl9BglBa+jpEkmgGUN3zIQRGT1limV4BvabJ6V8X4tKujBRuls2aOab22oYdH1dttkT1ow7hgK3nhgCFUvf189c92UFzgTvB0yvxmDd6MV1yhaa8Zn3JKn+crdK9vjb/dtorHWjQQHO3vdKBOVl+0b3iQxqsdqVZGWrX85OLrnY1q6R/2q4HtYWNtgoV2cAca/UNBcJSzUMrLiWdFGRs9Et7675ufX6TkiBGtnQD4jZKyDxG87rEDGpegWIEbeet0365ZX5J5oCuS0D1eWpQgbUXdjL5dlPYRnz1ytvPNF290Tj+p0dOmKv93DdjFvojpywwtZttV5ISvK1g613Yrzrbz/2Qpy3cns0XYEBbbUC3kx6Vgtx19hwdHi6iWvmUhCmTs2S2NIQyZZhBHcsJZVgsDyJYUy6Q+X44wZLkA3SgReCBifYlD8p9zQU6sDV5zI9QOAw+RwC7hK3ZMgVle5gPgRdG4PuLai58DSH7g0QfLt//snL3wnN7XB1HkrkT9jm6jVazz0E97XSn5uCOFh1IokwV0hlRibtXs5LN1PMqg2pFWqGC12tqAUOwWhp364jNzNvq7buHOTsL6sJqaoDnmmH43e5DldsJ7Tb1PozWdyF7wcQjOu1MkY0k3VZf7M4ScKbicx1HYeyh27AfL/96+eHny4MBOnjPcX4Q58gf3CguiP7xlBREwV8nvGaKCP2ndrD1/28KPDlRogAo1x9PBF4U+ok2LKwkPF5nGZHw5ztM9VtQ9LZW84OtdcR/zklqDyrF0WDAkrIq+BJh5qxJSQbaKRcEyjvGHG75NgWnpKagaWRKWIkHoVuHksTAPrmJHvkFNK7Np0AheCyp6hT+PUhtGMc1Yh+5+i4EsVUqti0rvTQ4TM2sHnCaYLObjUjcndi2HGlqosDpH4MyzP3T9nuTEU6GMeS3ssG2JgRQrJ+Q35cUNyn4173TYIA6L9dIMJ9jAG0WikhVRQDBc24rwZVa+Q89BrMM95M6uyO7XmVaKLbzUHiiYEfXrcvRmUwjGnffq1pc1bhoIjqjaF8/N0257gjCYWc5eiVdRHe6INR2sxicr9l190FslMXUb0GmWec7suzOLdycb06fyz3VsKJFllr7a+UMlyGb2LN7J8fPVFMfqiEFHdKpO+paNUlwq28PXHZcy+Zy35chqlnXI2lalibyZGwYtBtEzomE205JWA72TI4VHphLRp/1X1Koyb/amfjyw4wl0mcepxVt6QE+ksu0AfTmta1vkrvhStP1yF5P+OzgdexmF+L3oNAJkM12BNxnruMRc8X4FrUfXqf4loqYDnVOOFuSjRsPnvSUCLEyteFrptBMYU6KRCLPdtssM74UoiwTcPp0lBP7FwL4a80tyqiiuPiMdFgMVnP569XtVdU9ERMuqVruapQAIb4PiRVFBx78gZM4K2gReDFudXLZwSwJzlrwoulm43XWgbYAJBqNRgdwx3xQiBpl2ks2363UrIZgEzQybgcaPcocMfEnrvaUFurfygJPkPacJZ2xbzeYwJxItRdqNCA4aOJsR5JwrXjJEwmfW62pocrBQiU0PbPp0ECS+8mThMhDJUxXCOjbaGp0t6/9EmcvUmGWT5YHh395/myADmTBFweHf3mh/4aORFLSe3Jw+JeXzwbuiE7zl82wnbYQeDEGh3Lm7HbFbKXrNG+3dp0DJwAamZDbqs69kuVc1xRZ2+lL8lApmihsuyWvY4U1t1CxdMF3PuYu1uedmY3pDAGfT+Ol/2+vnqMcL6VNSQqx2X52tlPLAeMLc/ZGCkkQjT1Om8c8kbyoFUEfGX3o0Hz46uVwQldOnCwIqcYJ/29LmaXBIElMF2LKUEkzwX3ZJbs7vi1EPc7M6aN5ZbXcCM21PWnQln8HlU3cbz7XfsV8Md6uJvyIZNQbE4GkBMgJZGG6HqKtrZmINe6cb7poYxc5sN5I/7WmidOHXUahVpxNUWkb+ChB4I4GBDHQsPKIwrqHWI5rRnc/8jk5vkGHGS8rLMgQs3woF7h6FtVz8Lt4pSP35Qgy0wbnUCB8To5vzJ0lqqscq1aFoU2lONg7+/J/NDAqFc2cle4zo9EZzuaIMCWWpvt0kB+QjJexMToHmlxrigHMlZcz3eCPx96yOqngpbsLGnEmg7+J52zG80l4Ea+/OZ30hMGYX7/viTbVyCVxg7f3HVnBJbGCxiSnm/AlK0otRLSgormMNtficzqbE2G7l/nrfoQOp2E+7CcIx/wEk/zJBT1/eoZwVZn+tw6D7biKJVqQougL22lmBG0VONDujbhiiawidXQ1zb9cW3hbM8wWpIoOLnwee5vj4suYUC+ECattqqd1UZzwojD5LJdb5d+bHtf+ZXuJsclTIEZNQGuGFWHRAau2XSBTHp70hkoLRHzlV9sukjlX6HD0zDNXhGBFTrV73uOecu6DdAPMEyyMtdM3KpeM3Zloc851y2/uEh0eNpe1N8QWFWnOzXKeWS9QccRLqtDQtIWH/j0uSNCUhHDPBtZpXcCDeuRaZQ8jdLYyrealIFWhLlSr9kXfaK/h1R11SxOO4UpI9wx+QoJaFmmSru3vezu1bAjwU1oViRlxK/JO8HIzPD/PifAeYVYLCTxKXYsZKj3MLjZYls3QHKN/u/lw2XAGpMv4qw+ZWmUUBcuDqWbFElQx0GKIC4KIiXOSA4QL6D5pMrTKWipUYpXNTXySRx3BN8vpU8wifjX96cOnr2yso8fp3kR/AiIH6E9c5ERM9F9zytQA/Yk8VAWmzBTN+JNkuJJzrrpzaVjqHYj9G6I3/KZyPjm1BS2pnVarCf3YrMj2LNWd8RQtjUpITz7kLvrZp3G9TGzVSqvJpqMllrJOINr6JdYFeZFg9i2n6fvuNMWlwQynaXYxoJ0wat7h1oxEUNGiIIp0yTJP7I0oi9BwakXElIuyXahFa5mgPDryVQChao+1fAdIElO78aMB+cH5b9ITgKN8U287XGBW46I7VCMvzrewGa2ECSz29t3hh6vx9dnV+19seA/sY9t20nCC7+nY3KU5ep1iteav31tV1mtoRfR+YNn11Ul/ADZaYZk90N5p0DB9sFqQbtbMQqrhES6KR2R+XZ3AmybVC8wad36b9AmqYvk4JEY9bISlc2PeOzkWKDyfALT1kVUIO+gDruH0gB9L1StPVkDTzJuIq+5Jrh9PC3zfL7c0Hl/AzO5IeCHFJYLko3rL0o+OSYj4Vpqe+zQf6CFk2ijV8rpW82HN6EMfxtkuGGH7PQblSh7y4M0pmvaZHSK5HSapcLmd9XwsJlQJjfL8NCyzDyShEmdzyggkG7uCmX247bPrktLD0FY/cPtu4HYv5a9F6HQvb3563+dy69+2S+904NF2ZUpl24fd/izNubaaZl/ZEIqTpd3ZJotRieT1I4bqWSQfC77Y5Ww3IsyddWvsJkR0Whe9branIQIYFF/gC590XWCpTNXdHpFLmSSi1ZJ3Pdnnlzdn17euMupmVNM8VamLkYV2HoAKkmvaE0RCr97mvGVTKm/O3p+drKcyWHPvSkXg+NSZxitKE2oaWzzxRSmEVV9B3xYumOmisDDb1tWslaCwGgsqME++lSvSp0yg7x6CyZr4tug0CXZQL95u7tNGWLSJnDy7ctis3GTTsKDz5btuQefLdzfo/vXRq+1y9QxctGOq3vpZ1tT5OwV3Jmv4KzGlJWVcjHfGA2DWY1N4Jbj71+jkw8XVh4+Xp0EtZ4VTdzOdqOkVTKBhN/DgaM/UmaGs831gKzhaIlha4SZbxK60c2MKWpau47xqFmvsKy7VTJB+td08sJ3udoi2Y8ZHSBtAtKu02YfN4ITz1WxfRkNSBnZMtWaFAlm3af2XjcRdEkv/NaGBKck9EXHw0nqg7qUtEoBNMd74u3fHt8fvW99dHV+en3wmG2H61dsIO1HYthGsLBEkp6Eeu9afe8QI/LadBHHg0VYSxJDZSezY6KYxjpQDkhsbmyGc8sCT5b+2v0SLkX3OKzSDyVd+tWup5oJOVbCYt/DF8PrqpGdFmwe2s1E8pu3WtVMJZ82KmrMUNee5Oa4KytysWErvqJzG4mNKC9Kqj2Mu0AKwtb3rk3DdpAWZF13xYeoHNSdiQaUFcX4aFMFwq+baPPf0DafZFswd+vLhqhk4Wmmabpv+hPT89L0ZcfJE7zH7qxvl0yJGr5E9kKXSh243KxVB3GADPmQEiNlOZgKnhBuvgdPvoDbPbE6v23VFKD9v33f9ALvX3m/Z3kUV20vOOWa5nOM7Ms54WRVE7dqy4GfbFQOW+v0NYmTGFTXmqe9806glfy8jiZT2mQhe0yLIVIEnLBPLCi5Ue0tZ1OWuo7CsoQcQEGaItwhsgXFUCXJPeS3dg30kAZ6xkU4d4raKkjmftmcsIswImJrlRBRwU2MlIkgW9IFpsRDBO6C5KbcQDvf8FPKNFc3uiGp+Np8ReVCE9YzWdKUYZ0TYNrtk7G/B98dbtmuHUZrujl1FHeaC026CqJKkiMftAi/sGwHB/aOak6LoFgbcpuBU1yVexQZrZgQ5URvX1dEr5V3oybLbo3VBoV1Doh2btkZqZuYsr4W5rKQp7g4HZZu8kHyc0WreV3iqHdq2weDe2/A2CzYcQ9ybr5aue2BIbBvcDSFQP1C+PTpaLBYjihkecTE7arqWySNVyGGj4lsfRw9zVRb/Jf5y2FP2K5gWXkL4eyMD9jZFYRRggMZu+2jKLD3ykROjoQ812CHNW5/MtKRnwQuL9JDbm6czZAin0/sugOQ6H3qzwjXOjOGkNiJqmVVEQBO5sesjmmgEvnp/dgh2TOubYX/TSwCuqsJiHRd4ScTYF/EJNOeuBHWZBgV7K6BhCDR42bF6v436h2U34Nioi89EvunFz2NtaDAOTAyxYRHXYZqUlVraKNIkRK0z8nutBiTxfatAqADQ5OmcYaA/oFqAgAfX43C95DxXLvwGIlulasJynZCL4zUhiVWbySRPzbcKyOvULLIcB7Pap3EglsYrnP3Om4GJZE0V8Zhaw/PeG8hex2sdcJPlowbVURd7HmBHT2w0zA4sL4U3H+MX0QbtkGHzb5022FEYp3R3h/xLC4jkIToUy93fV7B+tF1PMWO8ZpmNjcItEevTXHpZHxn2D9YDHRcLvJRtYbyRE7FWukYjO2le7DEVTABnFBAzSsjqrYX1uja7f//u+Z/tmUBTmLxHGgiKi3HieHaL/Q+7vZkMiGTRYLt3aSFqxtXY1KpP4m2FInaQnuppt7XuA98jWBNqihxAQ8IVNOBpX7f/jUiA13sogJw/0tdD2vQAG9+R5RgXMy6ompf7FcEebEsDx4tl6NBYuirZuPLo+uZ4gE5vjrWVc3ZyenO8fkit2Dy0MfPe0N/8qWJIWpp/Xd/JLzqFHXZ3VPRQiQtFBIN8z7Ex1lM0rvXLbmoooIyOG3DoEg6GUyvb18kbL3bZ51CgMtxkDF2dXXQPTKNFqlPVoDfUxW7QQf9II2Tbo41hrNPDkAoqUqp0K+12YsC0a962sXExw4z+thdH60MAy+YUbYQXF+Oa0Z31+UdGTXo0ZRH4FVSAcmRZt2L2lqivLBwthQSZ6fFbQuxqrqAh42XJWaqV+9ZkXMK1hwDX2yY2uXDoRv+v3YdUyrpH76zdE2dMUbV07pWstaHHcmTbnT9tjaet8YfZGn2nHZ/FKnf+5pNV/mSVP1nl0WierPInqxw9WeWPRvlkevzhTI8UQU9W+dPWeNoaGxjl42yOaTfpYmVloZM5JC1MkRK1VF5rW6t8o9iYz0PBRtE5uCDCNC/bsVhlqqOyuzoFJADU9FW+h+wD+FKQjND7ZOTmlLIZEZWgLFHsaaW39C54s7FXgiCtjT2j/8CvHic2/+34FSB0VyYNRT0SsnfLzLGc77pX0tdV2sbSdAbEAbY2B0m6UqrGKdqfgT5z12WMYmhnV2R1AUUY5gQIHn3z/wIAAP//XqLBAw=="
+	return "eJzsvWt3GzfSIPxdvwJHXyKfh6QvcTwzOu+8uxpJSXTGkhVJnklm5zk02A2SWHUDHQAtmtnd/76ngAIafePdjvOsRz4TieyuGwqFQlWhMCSPbHlKEpnnUhwRYrjJ2Ck593+nTCeKF4ZLcUr+/yNi//cwZ5qRKWdZqkkihaFckJQaSuhEloaYOSNMPHElRc6EIVyQxZwnc/gCQRhFhaYJwCVSkWkmF2RBNUloYUrF0tERQQSn9o0hETRnp0Qz9cQUAukkjpCHObNPEzkFjPgOMXNq3O+p/TgiYXRUQ5JknAkz3hcXF9xwataig3d4wrZDlMkZT2jmX96Nu8Ng3ZRPXqxHdnVLaJoqpnWXRPv4g7cJmUqVU3NKUmmAGCEN7Wd/f2JWsL0NPYrRbC01VzX0iJmLWRM14ZpQUij5cTkgZs61m0QBDk5Wbd+Tis+4oBmKJGLXc0DI91KRHx8ebgcgXcI+0rzIGICuSYd9NIomIIqpkjmhYBSmfFYqOsm8hhELh8wZTZkakMmSpGxKy8yQDz8Pv5dqQVXKUvjtA0oI/r0XGehCxQpwmHINgNMB4YbQbEGXmswpcP5Es5INCBUpfJVTk8yZDsCA6g9h/D9YloQUTl4oBd0cvYtNtGnGZPcQghr9wOTVLeHCQbQWzw2nw+gRmmXBTslMydJDig1gjDSTiYUTvggvMzkuJBcm+gbH7JT8rwzY+e7lgGRA2V/+T/RQj9r5ieA48Gg9+bEoveKQh9pI0SfKs5oSwD8psiXhU7KUJSgBF4zQ2gNzYwp9+vz5YrEYsYxqw5NRIp/PSp6y50w8x880oyqZPy+ycsaFfp5TbZh6XmouZkMuZkyboR2Y0dzk2f9wTNwqmTCtpfpPYjWm4AXLgAIuouXpAGS0Cbiyn6AwC08Hce/9JyyDlnTyVs60oXrerWqFVOZo5ajBiGV0yRR5TeBpP16I8qDWy764GUnhUZhvRiYyI6UGkyFViwZyNQV7SXTBEj7lLLUmRwR4JinAEFCty9w5CzVVL9OiTqZgZiHV4yiReV4KbpZj7o2imzmPbLmQKl3PxbmHQK4unNsyp3ruRdzh1ozsa/YhXpmiROZFGQxm3ZwyPbDjpq0d6xDbiZNYAHZ1fn1rLYB9IZEpe+ZsYEIFmTAnZiNJIpViGTUM3ibsCYz+gpvYLyPOj5NTIs2cKS84kkvBjVSgnkbKTLdEfvzydP7uP/TN6x//4/qH765/ef7n+ZX6+fbX5PW/fvrtxV+PG2qzLNh6WVuWOiR7UluLYFkZkOvl/U9vB+SOpVwPYC7dvb9+Bv89hkE6Bh80odo6MvBBYECxX0uuWHpKjCpZncoDTbVDeC0AcD0psau2EQk1HEmegiVcjwZmERXpEJ7eAx3as/04W2HHurAdjsdNESuWMao3wKjl1CyoYv4Nj9X72va/YtanRzA0XPspb5AFbghPYRpQollOheEJeWJKOzpx92cnxNjag2oLePw9GLdL+PC4eyO4yTYQQBNuNMumfVu6Y22oMmPDc3aMvMDEPwVQrHuW1mfOL7/88svw+np4cfHw44+n19en9/ejnGcZ/1fTSL168fK74YuXw1evH16+Pn3x5vTFd6MXf3r5r/WDY3iObu+UK21IQZNHZsLa5RcCMmFMEM1YUwuOwWP5w/CYS22IYgksEKj0LN2a5ylsLlajvRIpT6hhGtxBq4CwrIOs/F8CF9GrqYMH309pppFSr7RehH7dJFwYpnKWwhS1IIg28Cv4nk06M7mo/IFeSg1TgN/C4imZUFhVpQDNF8zOfJIzQ3EGiDT4xHVsTxkV61AJpuwQ/OPt2Y0H41ZxLsKS7IajCV6WhqnxeiT3LJGwS9oWVw2ZlqVKWO/+pQf1rZIFU4azaltt4ZC51GbN1ienfrFYgQD+3TuQ12fngSmqCUd9S2H/WZvJoL9BtZNSKdBFGOvRUYsIXmxGQzWQV7dPrz2XW5NTg7mWtPFuu8Pj1y9Gf3r53YAM//R69OLly+PNWFyxO+TF2HH8IfJtnTdc7Q+JNuBU1mDiUuLDGxk13JQpsx5tJsXM/aVZQZWXHUQc8px2CMTNh01HrDUrdhq4GsgNdcrT+cUMXyBo/SDWQLoBPewg8uLpzWYM1abcm8805Z7e7Dpqb8KovTnUpHt68yVNu6c3u0+87Ydvn4n3BQ1iRNIXMPmiXfC6QbTE2tgFEWU+YWrzGbdumMB70+2BibyNNcS9m/xPlmC8A/XKSBggw4UddYuZ5IzqUrE8DgV3OSQxbYKZMXpIYyNNcHr9j6MVBN/4YgW58O8BYHlJyimKTR/1EjFZGvZpSbAYjhpuIAjxqG9YNnYC46E4pCd4EcH9ktzBmN+taaoBXkvfF+NU8GIMbH8RS9NmzHR7hNuOXQ3kNrrlif1iRjAQtG4cP/m6tLNT+Bkn3pfmGX4xk28/v/CzT78v0zn83afg5q5hvAh/+f5hrF9G+m3IV/9wU/8wxsuTvFgfXYUMJk9D3NFmNF2ENRpvD7KKuK4FLO0nNCMP57dxpJanIfthcymt7MdDlWHZOwkSZWs6ciE11lKuHGerkgJrg+mLObPZ2xZy2I1NZClScsJybnDSQWaJqWcBkFRg+NrPVTUrz0bkH1BqE/JN3Oa7ZWlG5Eb6yp4wQQqpNZ9kbGzrc2rOPK8M6hCw1kcadn2lXs022Lw5n81Jxp5Yhq94cxlx76zjgi5dKtzm322NVIBkqSMpK5hINZHCJ/1sscKATHA4FdNQtuQqjnJGRbxicuHeB1MlpzUIo1VjukJE7/4e/XGplFTR3/d27Fofn9t0J35cE2nOzFymGydAnz8xNXnuXuoUalUhBrKE6FLsJeGLMJrk5IfLhwG5fXcP///+wZUoaEmkeDaw/vD9T29jIJConJCT+8u3l+cPgwDy/e3F2cPlgFxcvr18uIyhNMyEYrX8xApefVmjf8NleC0pEa9EsSlTmhjZwXWABwJ6f/eWFNTMSVmAssFHNqelMygDOXn+zAFAL2EAyS//Gtfkw/NSM6Wfv/xQMY16Z/mJnvngAIG9AWupB60HzbKAis1sWRsWAzVZVkwNnwGKbqY8y7Auh2ZZPNp2pWpmnIDRVZq9Qu7walOjVkrZi8lPJVegCHpTE0H1bMwoPPrIlkM3zbWRyj8doOFbj6yZI/y1ZGq5W8WQfRUSmZTMy5wKohhNLVkugR2zySFAlWXRqE2qQdMSZhM4cRl/ZOTDD5cPBFVl7Erh/hsQ+1cDbqGDilUxUAWge+G4CQZ1ArYYyUIkizlTjETwmoOuaO5BglacEsM+mvXSAOMHVXAWADNM6fowQ0kBFEHA4IGpgGUFGI2eD/DgvYe54lMzvLs9b75dvVEVWSH2xuAK6Z2WXtKvmdZ0xhDUrXW0Jowav57HdY+lLu3QoTegCQMrTPIAIrLUNk1dKGa8Q67owmaQEWJcNYpL7ZxlxbTM7Pw0SpaTjOm5lAChKulQdFE5M3f2jxpnnW6Lxx/PRktLT+UGSnNLLYBRA10J62JjyiJUCB3bSAmuwwuuqqlwQosi47gzcgVYkNhHuzrhgqplBT+Al2UlecUKxTQTpra96lYQxXQhhWYH59SB/b1ZrTnC8QYn8oevo4/JSeQd62fbeMYxdCh0svs+I5uLQF+tkJcYFNKslj1Y3gUsX0kmk0db2wIl4UbKR+//ZcywLsQVgEKxhOvgORNbVaRtRDCYoWjnVCM1KcpxH5kA+/z2/dZU9eGyu64xF1246iJp7NSaukBupIm9H81/Y03npq2PaNlIxsTMzAd2D+33Pu4zj+fqlkTGD/Zk7jxAlzTrBVCYeGhzDXuG3dl26vTH4jsVOlKs1psrxBD0jT4y8LDQNzG+wBLPl8DKQsmMPzFRWYkKDtd1xzJUcd+9vyYncJ5lCD7EsKoZfmb3TkmoSSKEZlqSOX1ixHpjdlF0ZaNqaOQQCcFybRT6Ys4Eubi5D0A4Fir5d6FKMuU6kU9MLdfN5ETJMJO7ogsHEbEPXjWiD0aSCSNMg3fK9dyxEMDAC074WximXnYySdOD8gKmHPaWjgkAz9JRWy0CpE3Vg1sNITl9hPij0PZIhq1CD6CggttGehcsy3aWSCrzHYVyJVYwAV4MIza41i25AObi3XVDeleCQLliMEz//Jbc0Cc+c4r/wHNwD89ur4L/EGABzpRPp0wxkTAyYWYBTtOHVObnbqDeWhyXIv0AG+7wYuuJeyjDhbNX3h8A/7byAP7m/uqQzLn3c2G6SvBRqfHrfjgABGMCy7GNs/l9ZLXI1wYIAIzAIK0ejWZdOFAImpOG4LaceRutR/4MnX3KvhZJUTMo6Pbq7Y6uAToy5XjcDTwzapy18obHwrR7Kyjbj4HZV2w9I2LC5x8kfFunYwDf2Y8+wJ8fAhwXu+yna9QWmse4XnCBNqqJYqZUoorxQXkmhf0E0UttWE5kdIDXER7JTpUCImAd1MAs+E1uUm3vn/yU1GDZ+3pi8EGvVsCKG/wZE0AKeKzgxVtVbtiW4/8OrGhD82K3Qu/ouZBEOitnpTbk1Rszh/LuNwPy8tXpt9+dfvft6NtvX61nKJDkltDq8E8mZ1DmLVVqD18H/hpMGTrTq7GcqQk3CnYi8KyTFm5XQd8LppzaQKwO/ogWtgAD5NRA7KwDPgHfnxJpy3jwI/fHeIuATLBV4KFUcwoMlEPWoIBFcdWNa1ts1LWx8wH9pWnKMR0B+/r4TJLFE7zBeOsTU4PGLHzesRVdQVZFGsIZtRDA2bHo+da6uBF0ANIGbZZFG3R9zDaCDnBGfolKMlmm1Rp1Dn/Cvv+Jp9Y/NxTiF93L1jV+60I6Se1VDSnVygTRNB3bB8YepD8WIVXvKgaPjuxbIw+2ObFZsmb23kTLW53CEbnFjIH3oCHuxZJXAzJLmD0Bl/IZNzSTCaNi1EsbF9pQkbD1OTp8EA4+IkmwiEAh1pwLtgGG9StTwBGv65thwQfGkZ4FOZtXIzgKUuarsV87ELVzh5shRzeHZ3C0NFryAgWlHjKqzfBlspqEswgQAUBx8wyurUsB7kRY5vooKpS0tpGnTVLwm+HH1ZTEqoevAC0/SDnLmJtp/dgVm61dau/sM+v4w4meyuSRqWqmX/i/O4C772wiEMxvlrGqNYH7Duasnktlxm4FOHVHio4IoSKZS+XxDcMsjyZ5zHIgq3t9iF+JX8M1gakRT/ezie8F/7VkFUDC09EqdDmd7WmFY72w4Lx3igSAIzEpeWaIFKtIiYzBjpTgWg6nlCGRtQJXRics0y1sNV9ijT+xhpYrKwmHJygtVrGiyv7o/uoAcgXOQKSoUnWYnko3AexazYwqaDfXy/3H5EfcVrRH40CaDnx1Kjnkv7hhCbQP2g8T8FADR07YaDYiH//8Zvzm9YBQlQ9IUSQDkvNCP2uTIvWoyKgBl34/St7dEw8IaYAjmVIPSDkphSkh1CpSueghor7j2Z0GhNOJY0pzni33RuHAIJOKpXNqBiRlE07FgEwVYxOdruH2kSnBsv0oeejYb36jiQPdL4daMbFDu2l98VuubaHI1e0Qq/iYbiOoF7vvwJhHM6cqhUPmFbJByFden53HNHgr9lhOgH0Ivwdb9vf4sw601ffBCa971BXQypNeuyhXL601f9WjWxvBQqYHWJwiCRRYAHPUiark6cEw3cqUvL+6aCOC/9cFTdjBUFUQ28hg/3dQCQqZsh4Rbrq0b4bIQSM5LdqYqPC9OQ6GLgLZjfOQ7lKEN4DtEepBHcZOvA4uWhia/1pUtuX47Pqn21ZOGT707YASDF/5iE23CUCo201+xYpsOdwzDGJptZAIQIJYPxWuCmRANM95RhV8CP2lujGGzdrrF6/bw+NeaQR9dhiZB6hNYB+LLCoIt1SO2jiTjGo95OkeYvme8gyWVawJtBA7MLmvD4rq6qIDD/uYzKk45HbIQ1yBbHiAMBiCsu+OupRmSkWopI2JKKjW/KmNfiJlxqjYDP3VFFIYA5JKSHqQRDFqKtaf/1qysksAaaMZ4F64MSlNqAe7Hj/7mGTl4bgPFIgKMunDTUsjhymDepPDYI8AOqQusF8Km2VqEyDkcEG5OQzyqBGlrVUELXCFFKkvdnXTrkMSiRTQsU0NDd1wJl+lTBhoAafCvtgDGUDkk6e27IKL2iERUEbBsg4KUpZxKA9oULCtgXkIQhjCpJpB9gySG4h4GFYqj48YOusgByPxw0SWwuxJT1XkglC1zx1aHRlgnM0O2oSR35iStboD+CfYIlsOU5ZkVLHUKZfuoDsM5GEJ92BtIpz2TiglS0hPDh/ZnjtPLOv0AKPC3xidkEOaPB589qTSurZ2CYboKk0ehVxkLJ1hfdw0qhrtJgtOh2UHJyxMa0imV8qEkzuuY5nTeOwJKUpf0GLmLO+gmU+HzkrtR/SFs32+kWiv4ePTIcsLszwoNguxA5nV1v30EevFS0yAcG/8dDWNY3P3BNGwDkoUQ7Ozr5yr/lxYG8e8OlSdvwrFnrgsdbYkAatbCKK0PB4rosIW9OApog7K8zIzvNjXTzirZlKAGPS4AytVs9IXvO6+sXvnz5pFed4A2TpfUDkAxwlzv0TqETl3aXI5rcF6ogpk6gtlWhTD0RxqpFq2KN5xfANAbws7kPIcG6rth/QOfacAzutNl+3FarMD+M3XV9eXHly/7wy7qud2R9RPCxOJTOvHpPelx4PskABWiq5Xzd2zF34ZdKjwGAMUJ3Ytvn6whnnXLnkrvDdSDAsIqmo7KCcvbcfW+JNXzzooKBSXipvlHm6H59iDGpAXMDX/0oHNd83lUnRtSrdi+CyqIY7gVoZejXq3+3JP1HhO3kgHEI6xtXGxjwVX3efcd9KoCl4I3sTtbWPUaJ8PKmOEuVq+oTxqP7ye5QCuC9X+VsxjgRoFoL8DC9Q27S3Gc9jYg08M0GzGrYWHFsXh0MTl9Tyt0g8J1ZqKVNEoQnjuP2uFCcM35On182+3CxjGmLqjhjVUV9HRrOqsd0VAFSJIq4MGa8OP8YmqbiII6eUZX48Xtiam1StLP8b1WD24GHsfBTEVmFVrfd9j1DuJ6Sjb9EeiRr2Ip1lV10jIei3uxPw9ALFnQ5agxej3kqmqJcGbqLVRjMbJ4Z1wkzOHB4+iO6AwVe12k6KXbUuiNanGyRtF3/odqYEDoD/jWVEyK6miwjCWVp5/9Vjj+AysnHZ/UEF2IYaf+yUgi325PxM+VYsHkx2lKYfeHbMStqGwbWGEJqakmWe7nyR3VGkvPTyzfYdnTFVn7kIxeu1A0ESmS/+7G8MTir9AA2SeczwY9+q7N9d/gziOoy5KffcdT95EmDWiYfKc//QWDwO5IFGkOjC6wTR2rAJeC47WmZBe81G3jZ/NaqHyIjyMgBhVupoPaKCjiQ4l8nbufKMR/Vcj99XIfTVyn87IHR11Ee86r+w28y+YoTzTkasWDpo4sNtO6YYvv9Pw1sxRmbXjEg3+5aJ/LndJYBMpRJehbMJ+TI8o83EPTWtVqkXaXVOZqrQA4CD4LayF1vp0j1qdQCg+6sG9Wmgt6s5lXkg4ySinfqx8ZVM3CaslGBP5yJbN2pxtlaqT5HcQHfdSo1MDNdHMkB8yOaHZ2IZ39Bh2SAPf9MSSgbtKD7KPatNI5/4eJEfdXdbS27cQ7kXvrTvYUe/TgV0c3AfWNKINVCzHSovo8fWSTmQ2bqbZtp5q20y3RGZlLqAnBVYkT5Y+/wCJTPCyCyXTMmHp+qkYc1I8suUYoX9aZm7/HriAjlwf8WasstG1r4dMOuNiNoYDBAfXcHDiYviw2aLYlcAe5MEblOayzNJweRcX5Kf3l3e/PL/8+fL8/cMlLJoQOuai9OAwzmAUZ08sUjc4CBX0D4YJ8+hcO4d/dNQnhhV2aR3rNZYxyRD0LKqYCTbHMh1dJmP6ydLJnOV03Cre2cywtwYDhQI1WnXQ/b7UZotjL4GbCLBFalvF/SklhwfaJj7J7Km6Da+bqhWDuhNd9ty/+2RiOw/C7jEMK4wo0jc62nU1OQxNFsPmBLWyK4ekKJ4GmvKU0OnUWVqHlpwwXjVwBMKhShv+XhZsQKalsEdn7Sk/OpspNgNLAhAb8YEmV4aqGTOdj+zClYUGZtWZquPv39+cP1y9uzkGwo7Pfvjh7vKHs4fL40GVhQ0J0dWENspd9yNzzoLIntfFtZoIqmb6UES8E8y34AX7y2gyD7Kw0MgJ1TYMA390DKMnqlBwA0ctsX8Ay3d7d3l7dne5r83zxFWHSfcWXMvueRzojkBtp3+xiyTFfh0fbhvQMZGriMPX7cDX7cDX7cDX7cB/re1ALAoIhn5aa+qtKJIVqOzcEnw1rF8N61fD+tWw/jEM61GXDHRZwH0YLX++p8Zvgzq/liiiKk+3FbYXLpcFNhhznWMCHV4JXZE6NvbDbQE0KGU2L0preTEqyLtb2PjdVxuITm5pCa3UDNb5HG26ePSxU2XtLLG+s5Zu4HE94h3v9W9IziA8wXUObJT1JHT/2uLZsUfYGt8RsmpgGrzErMAm1farpVrXgmRXZxXNUoGOlpr1ZMgWVIHh00ebk1QjCMKTUALrcXt4A1f9LpOkVO6w0T/dNzbBbLuH2RW6k6j6Jc1bDba9eoMUpZ63NfPM535tuYmlD+6s5k/Y3iw0XrQjoiHpC+Gfu8sfru4fLu/AqMrNxvuwSb+WEa16Io56Ea8Jd26IGoa3mssKj22BMYdf4VTHE7OloR0RRjKVWSYX1ThgrwCvKoItniuWS7j5X9RagDV5iXqT7MxJS4iAkvCiH2vjsqKNFsENUALYzxasRr1OMYsbNdB2iHCo2vT0a/ZGSrbJ8LQI/hqy/hqy/hqy/n8oZN3tksQtNNebvR73yPdP8N1iwKKEYi9wUuvVRs0aLSoIvm8bMsQrGcUv8BULSwzwFihAg9tM9jFhlqwByaWq2mDndIkr4+hoM4vrBdPo+bD9gvTg+zW4NhPIe7vEcXTUS0OuZ0fbq0oPFV7quxByCMeqosQvNFuTgSvr/iu1X6LlNG6r4R9fryQxUXBhEDRFcuekkmah76ay2mCBjpDgdWpy2gxJGMVnM3fIM54Wo6M1PLhLz3roWqn0GxBeBVXAKdPNzT2FI2vg1qKb22Z0DfkWwCenHQ5m8YQi+QumGIGDrP7CAEtE1cPZJ57mNPUncW23SpaSE82hUyuFnhDYmzSLxqo6vBsGMz5n1yUA3Fl9rvGb0yf4KjoSn8Y8ryF2Ane+NFsbfApiw4At5lKzmFy7SNoqRaf3MITQRZY9dcyyNewsFDe1xrb7z/wLdOxqbjn8bnHhROc5uHdlc1vfJA/C9WMUUQ/yvlPC/QReQUKVCp9itWLGaQHhJ/2IjTIBuZ0BuJettQHoovbQuwm7/oXdA0pxSrm9Qg9duNHR59tJHIAebfKocfD+FLUmUClgWot6X6YuSqDAGGRZKqY/HTlN42PVDJpyKG4v1aAEaYB9mbWjLCnD25uZJC/6g3HRvP1iuyGmamYNyuGkuuVuoZ9sf/AznSfF0+vo1OfFj+e3T69bRz7dx7UTnj0HPAPEbn+u6Yr516LLCuqzok9INfL+d/QFIfG1wlcXAziwQkUqc6+DCawjAiNstTddrNPWgYUIHMY/IbqNEXBYZbSWSaNLA/E7Io3pC7CVcJeOvy8mnKOBr+vZXQy3HrXkgrfrHK1ZWFdI4yZMPIRFWEYLqLJ3/gvSNGEzGl30nvxacm0vUogv1YIfxQRb0Mw7Qh00N7OTOwwhHoZSEIg2jZEwMlxnTuZyYQcJ9NMPj0s31cBxexEe9AocDgnGUOydV9CwVZGJkjRNqDYdzDik4626zz5EXbKqm+P9bOzty+KbXG2HrC44QB2hDMmbWECQpbOowjmziigPJ9xCVt10D7eJuSWFanK8lKU6jlB18OPG44DcyGmLl8AgKgduR+C2H0xM1AAKSLtowwqC3X0mUhptFC1W6DNEgJf7smGBxMx02BhMhNIkTrjVIJ3wERsR6kTgQOLF4v2qu0Nf44dA0zeaXJ+dB6JP3KV8ZiGf9Q94I0i3w/Rvrrs42PG9U2hrR6Ej0Ii8B4lGd53DPxDUu++/v7yDeQ5/nJ3/PdjpDhZkMW6EjPrID91sQIXAeV5uzhsSQGThokonDob1cYDQALJLynNZbLce1Nu/wevVNPKULMBWmbmS5WzehRNbWjc3SDuOrd8MebDVFYNAmG24RgQzC6keycklmGvBTHVRN/y8hYceaPY4IMwkXXJymfejddGlpkcSg0DpdG0L+zivqUa9o90awXjhhH4aXkq1gZowmAHgWWMjd38ZY1088CPhxrrQRhPCrUnGBRvAFnpABH2E7zJGNRtgEU8sxlgO4ZrnMQIbZ1ybLSSylm2ucbz8PW5gGtHOVdYRsUf3WbZAofRY2rjMrbqpuodHhG2X3zHauwb0Dqu/I3M88gY9UyfA7MXV/fm7f1zePQN2KUTQW/DqC4Z/2y6ElBRUGZ6U0Pw4WmsmLDgXPdz7tTr08DkI6+21Gzy3J57CMep4GXd1I3Mq0gzrsFqwcAL00B9cuE82dF6xYOvKKpcxMOhKRjCT0YIUVlNdTkRvEUdOP45hAzVGZsdwN3ADVqe934uXnH7keZn7g+U1c+P9qxY41ECu7Rl99CRpAvmbHuZsIc86DTuk+YiMh22sKdFTgA6Tjor2DhB+nphIfX6DiqYhkdO6XRqRf9jnNclpO2uQzCUELY0kKZtyEVl3xGKlEvXOspQmUjzV2q5i589qcjdoUsT1fPRwfI+nqjSzBcyd0fdWaETsVee4crmK1IooYM21z2MrlOEbXS3oNfp6FCKVUNMX31h2YF2oq7lDZ3Hj5YnNLUMLmE0DKKZlZiPl/j5PTZ44BUGQCwfTNii/t/d49vEq9NjZ2ENZpjpDaEdbjFOS4a0kEaktaI50BBJfKNvYHXay5hTZT2xoXjLGosfNyy3XcHsWTzirjLDBM9FQQ2WhoPmEz0rXJnWjGQ5akFNRTqntRwMrD6t0uHYHarB3LWB4jRr2tpFTY192y4ErxRAQsCi1UUtwSrRUhpe2GNIuey2AAAcpnDAw9HrU2Iv7khHY7pC778/Jt3959V3P8LgFZ5xT/XgwzXMwCcDENMactaeTz+pDKER0lJegh99Dd2kSe7f/WE6nmpmxZkkn/bushHjttoOMYq0bC/wKvRZv31qgUBBc+ECduwHvXEqVcmGvsX0voO2qphl5gAurT94/nPe52dAV9kCeF/DowK2yCZV/hjdou1fafEpRkwMqQA8bINuDGzvDN7JyMBn+/ObP8eNtbrazb8IUB+aG61Us1AYFI52w+tw83LZg7Wax/Tr2OVbdOIyzkqhq1zW2e9IxqtHhZn3bSVy7DWsGv31I6e7yp/eX9w/VLq1nV0aJ5cWpY1dAsr5Lgn5bQBLquY0qkZMQw3o28K4nPlDqjpRdY1l0w7HE1lGBGN703W20oGdscDfQQNV5L/Leu31kzUh/DW+418dvSloAjay75LAqOmg3ZyHa5yO+Loclp1XGsV0ydLbK1QjALy7P317dhPPcpNY9GBMPPlEBmBfzWrQXwzGpX29suc8GYQqbfvmUs6M+gQGR7bionmjmljfUVowpQPKxBa8Uhme1SQFJOZtQCpcc3F3eXP7z6uYHe4ss6+V3AjZQzP5rcPy3q5uLdSxD9Hc85VntMucDG2k/74ysPGUKLeYNIK7qn76BP79xLlILIM4omGjYsrEqegoRXfstbgiqq4ZFdNHh8cXNfTvjfHM/3KqzcCr01lnnjkxzQ5FWdFUGF+vi5p4UNHlkJt4t+1ibT+8USs4UzZ2rPGMC7r60Ya764NpeC+Dq17beHO5XLri/76Fqljg6avHTzl5sQH/V4BX399Q0JsQjF7YnmyXQ21G0eh0pQxudBcse5W6l4jNwiKUKt86oJUZXLHNcOKtQA1ex2hFct82a2jKw2ecRHEGD/vXU7H0d1ZmVEoBFscAWt9r2ROd7cOmCZKkgnoJlK0zdDEZ4VwRrQHUjZFJnTLGktO1Jx8Hn+xTsLebMBpQQ3ZOvTsUjjDC6AT/SXoMaBSU2YCVlulG3evhxQquccsUSo+O0IrgapdIlaxRlOHUPEsiWI3LXLw4fXexlN5yKHEM93SflNdDsWYSwA4fG4ZHKNuJdgbxeBpI5Sx4hvJNyDdV0n2m8LK54wGpQwNJSCN5AUoinIUYbCqp72TGqFHAuNB13yOOw/Nhzk0DRlCttyHcvX+EpaSTUOfpQi1yD6JundrDgSV5p772FB2ejhGUk7bakN+8u7+7e3bWxBGvUcERWSKEZmHT5ShgJztIRucJzjPCVXcX9faVwS5cYFoqLdqVmMqeKJuAUkxOIiC3It69sYG0inxh5+erNMxt8AysEwfbocYjEhQa6NYUlcMKa6YQWsE7DtujlC99zV5OTf19cXDwbkb/R5JHojNoWwLBa/VpKOEkMcPHlWKKEPNCJHpCEKsVhS+BGULvD0ZB8JVPGUve+DfIrPFr4bzMg/1b2uRq8fwtfTu8sUNfwLRaL0UzKWcZGicxHK4axkcduKYvPOCuWSJXqxuB14T47OztbgbB5eLuF0T4AKLfCenWzAiczWTouslKPpVjJLbMN4cBKGlkMbZG4V90T9vD24hkBKEQK5k4j2YuLY3o6cibw3n+8hCWfHE+lHE2oGs1kRsVsJNVsdAwrxXH8QR2enT2+M0vKDFN5dG3sw9sL7A7gNiWCsHzC7JXfiSz8wawaQFhq3KYNLsI9ff7c3h6X6HI65R8tBV3ypTn9DUZPjsrHDn2iQi/q0bCe0P4KO3EmCFWKLv38ByYpSbkt26TgG9r8lOvhZvFBiBW+xEkF07aeIqtWiH6aW81HdvH6q2IayA2VKmFBd5GbyqH7kAo9QuQf3EZkdNRLXvMK6hohTdPqEwihb0lMCimYsna1c4Dxlx574YnZ1FxYJWtw3qaok5Drn/vRb248YJHbg4irm34ijMn6SGgrRj1yEGUF0Ktp02OTWRNGEprMG+vThE3B6vCQUpkw8IYSqlJYSf8FV4tiIQyc4qg8JyuJjipYuEQ2oBp1z4FeOTR81jWCgKdRWBNvvjznIyyBoyK0E4JT0O4NOBCqjzpSD1U23g96DDOMbpt+3IZx9ontVVWQHzZ+3mBZ+9u0zE7BVlP8O1mrioBgsZpAex606gynVOnSqxsXSVbCEtU87VsjtFHPMCW3NqoyYdSsFtEXYjEjgj6D1by5X03C72s5w82cn23GVXeB7jjlKpJ/pylXEbBmyrUe/FxTrkL8hUy5iKDfa8pFJHwpU+6rwxLJ4o/qtMjCjNq3WdXIB3IuQZXwuU5dOX5x3A08ldvGuuIrzKPDepBF0hD4ur8872GEfTRjtSpMdfnRMAHmyge1bKSqbQYrtv52dvGPy7v7HubKtGgWzq434nhhslTfaPL+4pYUdJlJCofkfmPkhMNxQcP0s+rOTNhPRzmsHx8ebltJLPhwuywWQu1OY21wNSZgPNCtmC1OOp5p09iFI8ZjE9z1ybJyYvrJCUGoZXUQQcOSB8WtaFFG3Q9BnK2Zp/BDPXx/d9VCBXE63/DUGysAAqksfN2K2DbCCVlS7FNjL9D2iS8jyYePw8ViMQRYw1JlroA2/TDqFMyqK/cO0qKyLdczktPCL0Pe4iW0gHB6igThYAaHyitBnQn4+aeNRSAbsO4jJBBI8DXgumsIAvvHqqvj6j6F+0ESQEA2ZIqB3EYK0hqlZehDpKF1PTXt+BD8JDLPqe4eARjTnUpcmp2R4snSYRT9lDzqAYev9+QktplsDavbSbjF1pMhqFnd1y9eH3ViKeaK6q3wuDd6Md1IaOxainTUjRCV5w8wVdrp6wPMlRY0e4HmfnOlBXOy/KxzxQvKr648yePV9er8ur26ulGCr8hWayzC7p5SzenkX9rAHcNHPGeWsK7LQwupNZ9kbOzWl+bUfd34+81RixhvW3BQoxf6VLxG7BmZlzkVtucVaBnspHNP9iq75b5p7LbW+1YPFVjswNoLu2GxtoMNL3fCRu36NOIKqtuLd0eB+d7Py2Il9B1FFrna1bTLWW43dtHUu8aPWtPPf5F2e7g9ky/CsN0E9DNppyPIdYMOOuPpCHAJh6gDFDPaGKRzKO0MJQkVsOU9nnCIOx3XYE3hlIb9fDihmqUDcgwVsccwp6wx9B9DRhB78rgvbeMw+3cNYJuwNVMG0sr7y0PRhTP4IVEtlafPf6HJu5u3v6wgBZ/bn5ogBISIOWHE4yMm0XMg6U5jW8vRkmPNjLs4a8ZMR+7VjXAlelnA7HJbALvrdb2AbbFaN+4aSKRed4osTN8DyOwy6gsL1Fidq9gIsx0tYLsTpFStan1fshcJnvApKnsFGytJV2jFgScsCszFVMJZRzwiVZ+w72/+fvPunzfHA3L8VtL0uH5G/vjeSMXgywuWMWN/O4foL1Pw65WYSvjvfUYn50Zl8Pvbu/fnii4yptqwqNHwyH2ZQB8R+PV7yuEtUDdoL3+8Sg2+CikIqclUl0bbzR7Li0xCFan3JuHA3WLOFLPtHWN5Eq+3XXByDp1DY++nsni2dOtEszqwD17QozCAbgv1IVoQApZnqwbeHm0Y13vE7jj6vgSxflyiZSs9anLSJVlguJtgawJGziTuT2xDRoFaBx/bMHdQgWL7vcmIheEc+a18sK0JsSjWC+T3JcULhf56cBocUNwLowULZwzsNQtZbamqAbRRJi8pz87KVfkPzYN9efQ0KSGftycPCAVvHoojCsgdUrJKmM407j9XwVxB55BQ0RlSl/FODK1fwzwQctIajl5LV6O7WWK7ixSj+BqOeyhi46JO/RZkunF+ZMu2bG2tyub0+bOnAKs2yBpWf3CYbTLEr4qrhHZwcoKk8PxkjRRywqc+1LVKSDarjwGXPceySu5jfsCGKjtWWX9lacc5GSyD9kykkkH9swHRp9afgcwixOriU/85tz35Vwn/92AT9fbz8ImmrZvBHbUMXMI3r7G/SerZBY2q0hN+NNeqGw7E70GhNyCbzQibz1ynIxDpouaUNB9eQzc8Dvd/iNBBAUl223i7k54ypVYebfiSKXQiTFlm6DoC1xBiaYBDYyJRNvr0PGX4G7Hw17pbXHDDafYJ6UAMuHKF9Or2S9UTUxOpuVnuSawjRE7rpug4gD/2FmcFLYouxo2bXPZwSyoZQCDN92Subkc7Bg9Ak9FodGzT8seZKkkCoQT32cqV1QnPleGMm4VGu8gP60987ylYu77RGZ1AiNueXf1mAwGm0AfwENQAIBtokmJPkmhpJFyOtt+YOqo8LJKD04bLnpOS/yqQRNhHWBTAlYfG+dije3TUcf66HnvRhop0sjw++euLZwNyrDO5OD7560v43d5IpOGs4fHJX189G/gQHegXnrCdNhAEMwarKMZuV0iru0/zdmMXJp+XhAVacyG3XToPShZ6JZ1kbbdeso8FVMvtSRj4O6AtHG56cacFQs1dfT1vSbZOZ4eXFYb+//v2BUnpUuORpBgb3meHN7UcC7lwAUqWaajcq4HFc8wTLbPSMPJe8I8tmk++fTWc8JWC0xljxbjUe0rOgoEaNLvL54LkPFHS0+Ht7DeZKsfWrkK/YHhltd1AnTtE1MSvoI393QQaK/jvwln7FfISstlNeIfDqPeuAskoaycIwvR3iDamZl2bgJd2fNNXG/vKgfVO+q8lZ+agXERRh7Dc+gnONV7gYxSzORpriC0N1Rasl9aE6nEp+P4hn/Oze3KSyLygig2pSId68X/Zu4LeNnYjfNevINIAeQFspUXSQ3vTk23AD46tWg5ee1JoiZLYrHbV5a4d9dcXMxxyySUpaSUl6AOUnLzanfk4JGeG5HCGr997+RzsLN42IH8iIC023EvD5c5wMNZnlqxez7jvVbO9tTj6O6da/wAxqSo5NV66mV19dg0XAEQOufWk8u8HGFXqkaUYnTcAl1wxpLn1cCYM/jjQVbRawWp3EzRiXAZ7El/ki2L27B7Ew5Or50QYjP7110S0KTBXdONamfOOaVYoQYqmWtpfjSoliuxVls1hNByzCbaUCzhu1Fdm7XE/Y7/M3fuwXzEc8ysK+asJev76nvE1qKKqsBwQKhQlYK8iy1JhO41EugUOtGsjbumi27nXctIOmNRG1xunnGGUkMrbuKDYsPDgyT+MsSPAL7QcoIY61cMiy/R9llbWzi1NeLI1ru3HdIixz1vgIFBAK6RGIKecFlrgu4BiQHqNo9Ii4R/51Yqu7RQV+6X/3tppj0HyvP7Cvm95zwsohT5rc37m5YVT39uHBK0yl7EDQevtvKdi/E2uj9C1Y0FJRZp9s1kxpVUgpFdbyYpdQgBkiQ6MDRLUKSHMu453CqVR8wVejIR44steJDMtjCUTjUgV8f3cF6nWPuIwPKK9TtyVmNkU0onGPzfjPgXpkX4/ElIMgBXpOtuE7E2P3JTFaj8+v8MJs6EKiVZwjEpTYkYqSzPkhipxPzYD9tv44d62Q1+XsUcfKtbLRgr4WJ9tk1rCLAZwJgzb+ULHOUFaCijqPqcbWqtaVWzFq+kSpx23rD36VeFdMbPCxcGH9enNm/B/RLGOlqf5kr1FkBfsbVHORPm8uWBvlxKKL70V39cZlzkmw2BvVc7XallUoSz1kLoB7avGAiZ8UR4h2kyuZKVcS2jbRirbvK/6CcPuYzGyVwnh421hK32pvAgcTmalVWTTYLnoxW4tUP4SWoL8JRSZ6iimX0Mx+anBsBNxuGjSRhk13xTkRsIqc4ZxNiEs/cbJQBFDPVLXooQ9Yv8CmbYyTnp0ZrMAYtYe8nwvmBI6d+MXfMAezPpNWQDcu29qfYfPPK95FjZV64vbDj4jaRjHY7cDkgbow2jyeD26+xeF9+A8prKTeiTYmo72S4vXGFZyfw3Mcj1NOloe3od8+jgasrjbucMz+y6TYgCaxmVyr5s1Uoj461OeZQfc/BoN8Ut91QvdGrN/G+GB6+jDmOCn+3EJTsyTwiGi+H6EUOctK5c2lcSCCQF0EuQnqioPoAYGNBJXHWECN9sn84y/pPUWoLYJzGhG4gcRclM41Kk7pn40g0TA/cBaYakFqJrDpuAQg76uq+VlncvvKY6LYzji9DuEpdqLvN5FgzWzYaS6cVIVX607NW9QPsuqBJa3V2QBQc9qGbMVlPGFvEt8YxNmpnjTu7supRvD2nB5pwwfZ9m9Uf/J3EX3ZvyPu9SSG35LLLhTa1giH1esKT0pVXsN230vzSxtATNZbW0MVHw529xihD3EiOw5Zs8Ss0lZvKpOXR9fcyMws9cN3HUc7bzOkstsi8Ej2CwBAJnp8owrqPzHK5FQuTJXomyV5N0N+/Z+fP34RALdE7WcESiPYC5eYfGAKOAudPEaAYm1epv9ln1Rjq/vroe7UTp9bpdSHrliTmPU+sQJjK0x8VMRAu9t+DoswUBvlMWrnrYEDr0o7nhQjnvyTtkd5ZAvzuFTBJM18W22mZBpGGdQki9Z4a5cwEW2FjzGjfRmPncTOt/fhAmd72/G7OXTh4+sk8LUdLupy9Ru7TYpAzp7pmD2ZLUmiYh0JfOinBzNB8ns5lbxreRePrHhw+fRw5f7q+Z8iVU8djYTRE1vGQQAtaEH3+NJIeafaz93fAWDxaMFBld19nN9BC1P14y89cK32KNCVYtSpM1280I3220YdRuMB2ib9eIE2uYUPoNRzqPFqZyGqA70AIDqaXrI0XX9+DxLsNih7qJc6JskbCVeROkHL+0maj7qcAFYJ+M1f8P/S3YzeBrctd4bDe5vhz/IR5j/3/sI81P6CKRLSjGTrh17hL8TagR/66ZBDPluGkTDDC527HXSSDuB5AMjZBtPgnVMIivwaPqvtjLZ3Vs+sx95hKY5mYM0YxeqZSnnldOZT/jg8nE0TPRo80I3H8Vy6tavQSacLTIFfQVbECtRLYsZLtzdNDdbutJ0x+2Vrz6grkcrPw70hRPeifsc2P0Kj5tAkVnV5aozxh7g9umrVJQE+fbKSYJhes2UeY4MLug6Oe0wuN21vNtrmg4cJOtqm3aH9PbqTre43zvR/AqjfFpgoI9oQ1YqG7rd9JRHcY8J+H0qgqqKu2choPAmXkMnOfUcXs7I2oHXzLrM1Z9Pd+PUXLvrWN6lyrqXd4F6wmrJv4kJBMBkwt8pOMAf+p2qYoCQnu7GLBeLopLaPbWVbxqzZM9lFOyi6nc8ek2JIJ0FXuTTcrOGnlolU1nUq2NbQUMDGuAA02V7iAFVPYJInxdZ1Mq8mIKE0ppo7XRclAyBSwGDQStYnc9EmeFJDWlE1CzsAQ45/ajYN3Km0y24zb29gpXMm0rCBbnmZ/03E5CPLtFaXZViMhUlnEJAjIQpiX7KsUVVO5bcevhi5pWWKtzdbgjcVCKbRwMv6AsHcLpVS5FlYWJAd6bFZptLKFwSbxsGOyRiVS0tkI3Cv3OW0M8bd/MYm8FeJZZriJRjmxarVZ2jFNishoArrQNpXPR70UZRkRcxm0zleplKPNUObdujcXcU3kZk3Tb4tfnAEM+LsgXWkDH/xkJg/kD19w8foCaE5DnvF+XiQ1O1TH2oMnXZmPjWn/3vy2qV/cl/ePlpp1iKFUQlqEYHnExEbhSgw4YcIeUVntV41IGCAeqXQPZSzlp/abHEpWCVRbzJ7ckTNBkaiPPOoWQqH1q3whTONBTSE9HrINQiWERuYuqIBnNz1/wMAJtBa4qIql4SAAT6EddJxjeinJh5O3Es57GAwkHjzi0HwyVisLpj+3zrp5tFE3CizcUPgq9r8ZPKI45koC4gotsMEVNhWqzWkHEeo0ijFKFI5uwFzIASZq6g5dNEo7tzegD9Ac0CBjyYGoe7NedtZQIfMbJVVZY4CaqZimSa4bIuuMliFpN35cBrDLjM3RGHUu33ovLSsTTW4JxWbtraMFXLSlBAUNA8u3pD3WvGWkDueXNQowJzceIGBnZir2YGtEyzO7Txp1iDdsjwftbgSGUcs90B/HsiJGYuO+br3V4S4s9QrF+o6inP86LOpxQbxVsq1l5zMbKP0vL7gw2yV75RbWXsjJSWu+345L1dI8Vr2bD5MBgcNEhB5/kBMf1eOFZ6sV4gxdWLib51tBUg++df//w32hMwCjAxU5QoJc8mke3Z7d3ssYPp4kjxHXjyQJZ2fROs86Ka6OI6LdqabysUMWB6BasHynXvrD2cPpE6mQMWJNyCgc9T1f73goCfJxDgnT+hEsx1DbDJN7GZ8GwBRUOWqyiOg1WwJduywH5naRwwn9vKjNFSnj2OBxfsajwAL+d6eDUe7G5SKzZv/8E7hsg88iZcaFGGtu7kTxWh18vvVIMigZJnlSjhFseLLiatevvqdl8yNWZVZoOGHIMrKSraswksJX89VEI2QWXDBIzy6PpzswEZY6nqWDboPW2xabQNuTdKtt3abnYYr4J6R8L7iCNAhzn4yo3J32reaXMrygXP5X9PstB6cGi1E6Nt48szuFt5tD3/kssKbx3J3CO/BQUax9w7WTiI9YjogBYqxQLaT0CoN7dgmBarVZHHSrl3hgFTDiDA0psuNplw6Mb+uyOzFwMklapFedicuM4rWcEcwOWVqsHRy6FIBpY7P0+N89T4w0yNXhuNXla5W/u9XfNjb6/crDfPXvnZKz975Wev/OyVn73ys1d+9srPXvnZKz975Y5X3gYTOuWT6ZLLvLfLWno4hvAJbCdWJdyNNlabvPK9YmN+DALard+OgGdwMQ2YqN7ujtjixMQqKpujU2SCgwy9Bwj23NDDUkyFfIlGbs5lvhDlupR5JNlTW295yG6cL0kcUrlBWv1eqKKcRw2Gf/OP3vMU+wDCb4OPyNAcmTSIEhqy9biBsORqGfyY7qIomtDfND4n4HTAQRThsj2ClNyqVf0r2j8An05fpZ1iLGeXTWuolcOqpWBLrpb93v8GAGqQQKM="
 }
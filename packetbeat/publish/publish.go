@@ -19,9 +19,11 @@ package publish
 
 import (
 	"errors"
+	"net"
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/flowhash"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/processors"
 )
@@ -37,6 +39,7 @@ type transProcessor struct {
 	ignoreOutgoing bool
 	localIPs       []string
 	name           string
+	communityID    flowhash.CommunityID
 }
 
 var debugf = logp.MakeDebug("publish")
@@ -46,6 +49,7 @@ func NewTransactionPublisher(
 	pipeline beat.Pipeline,
 	ignoreOutgoing bool,
 	canDrop bool,
+	communityID flowhash.CommunityID,
 ) (*TransactionPublisher, error) {
 	localIPs, err := common.LocalIPAddrsAsStrings(false)
 	if err != nil {
@@ -60,6 +64,7 @@ func NewTransactionPublisher(
 			localIPs:       localIPs,
 			name:           name,
 			ignoreOutgoing: ignoreOutgoing,
+			communityID:    communityID,
 		},
 	}
 	return p, nil
@@ -137,6 +142,10 @@ func (p *transProcessor) Run(event *beat.Event) (*beat.Event, error) {
 		return nil, nil
 	}
 
+	if id := p.transCommunityID(event.Fields); id != "" {
+		event.Fields.Put("network.community_id", id)
+	}
+
 	return event, nil
 }
 
@@ -224,6 +233,69 @@ func (p *transProcessor) normalizeTransAddr(event common.MapStr) bool {
 	return true
 }
 
+// transCommunityID returns the Community ID of the transaction, computed from
+// the normalized address fields. ICMP transactions are hashed using the ICMP
+// type and code of the request, or of the response if there is no request.
+func (p *transProcessor) transCommunityID(event common.MapStr) string {
+	var flow flowhash.Flow
+
+	flow.SourceIP, flow.DestinationIP = toIP(event["client_ip"]), toIP(event["ip"])
+
+	if transport, ok := event["transport"].(string); ok {
+		proto, found := flowhash.ProtocolNumber(transport)
+		if !found {
+			return ""
+		}
+		srcPort, ok1 := event["client_port"].(uint16)
+		dstPort, ok2 := event["port"].(uint16)
+		if !ok1 || !ok2 {
+			return ""
+		}
+		flow.Protocol = proto
+		flow.SourcePort, flow.DestinationPort = srcPort, dstPort
+		return p.communityID.Hash(flow)
+	}
+
+	icmp, ok := event["icmp"].(common.MapStr)
+	if !ok {
+		return ""
+	}
+	switch icmp["version"] {
+	case uint8(4):
+		flow.Protocol = flowhash.ICMP
+	case uint8(6):
+		flow.Protocol = flowhash.ICMPv6
+	default:
+		return ""
+	}
+
+	msg, ok := icmp["request"].(common.MapStr)
+	if !ok {
+		if msg, ok = icmp["response"].(common.MapStr); !ok {
+			return ""
+		}
+	}
+	typ, ok1 := msg["type"].(uint8)
+	code, ok2 := msg["code"].(uint8)
+	if !ok1 || !ok2 {
+		return ""
+	}
+	flow.ICMP.Type, flow.ICMP.Code = typ, code
+	return p.communityID.Hash(flow)
+}
+
+// toIP returns the IP address of an address field, which is either a string or
+// a net.IP.
+func toIP(v interface{}) net.IP {
+	switch ip := v.(type) {
+	case string:
+		return net.ParseIP(ip)
+	case net.IP:
+		return ip
+	}
+	return nil
+}
+
 func (p *transProcessor) IsPublisherIP(ip string) bool {
 	for _, myip := range p.localIPs {
 		if myip == ip {
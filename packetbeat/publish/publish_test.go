@@ -20,6 +20,7 @@
 package publish
 
 import (
+	"net"
 	"testing"
 	"time"
 
@@ -193,3 +194,68 @@ func TestNoDirection(t *testing.T) {
 	_, ok := event.Fields["direction"]
 	assert.False(t, ok)
 }
+
+func TestCommunityID(t *testing.T) {
+	processor := transProcessor{name: "test"}
+
+	var tests = []struct {
+		fields   common.MapStr
+		expected interface{}
+	}{
+		{
+			fields: common.MapStr{
+				"transport": "tcp",
+				"src":       &common.Endpoint{IP: "192.145.2.4", Port: 3267},
+				"dst":       &common.Endpoint{IP: "192.145.2.5", Port: 32232},
+			},
+			expected: "1:ZI7cAIw5crkwsM6u6CCrjrUqB9c=",
+		},
+		{
+			fields: common.MapStr{
+				"transport": "udp",
+				"src":       &common.Endpoint{IP: "192.145.2.5", Port: 32232},
+				"dst":       &common.Endpoint{IP: "192.145.2.4", Port: 3267},
+			},
+			expected: "1:kegyjFFILRhuXxHGkeB0tivO9jQ=",
+		},
+		{
+			fields: common.MapStr{
+				"client_ip": net.ParseIP("10.0.0.1"),
+				"ip":        net.ParseIP("10.0.0.2"),
+				"icmp": common.MapStr{
+					"version": uint8(4),
+					"request": common.MapStr{"type": uint8(8), "code": uint8(0)},
+				},
+			},
+			expected: "1:YcMyyWJfhc95EW1GfXt6jlZ3DiQ=",
+		},
+		{
+			fields: common.MapStr{
+				"client_ip": net.ParseIP("fe80::1"),
+				"ip":        net.ParseIP("fe80::2"),
+				"icmp": common.MapStr{
+					"version":  uint8(6),
+					"response": common.MapStr{"type": uint8(129), "code": uint8(0)},
+				},
+			},
+			expected: "1:hcQR4+TVVdHthPBS7U0F6CmEKfY=",
+		},
+		{
+			fields: common.MapStr{
+				"src": &common.Endpoint{IP: "192.145.2.4", Port: 3267},
+				"dst": &common.Endpoint{IP: "192.145.2.5", Port: 32232},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.fields["type"] = "test"
+		event := beat.Event{Timestamp: time.Now(), Fields: test.fields}
+		if res, _ := processor.Run(&event); res == nil {
+			t.Fatalf("event has been filtered out")
+		}
+
+		id, _ := event.GetValue("network.community_id")
+		assert.Equal(t, test.expected, id)
+	}
+}